@@ -1,14 +1,93 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	logGo "log"
 	"strconv"
 	"ticket-service/configs"
+	accessHandler "ticket-service/internal/modules/access/handlers"
+	accessRepoCommand "ticket-service/internal/modules/access/repositories/commands"
+	accessRepoQuery "ticket-service/internal/modules/access/repositories/queries"
+	accessUsecase "ticket-service/internal/modules/access/usecases"
+	ballotHandler "ticket-service/internal/modules/ballot/handlers"
+	ballotRepoCommand "ticket-service/internal/modules/ballot/repositories/commands"
+	ballotRepoQuery "ticket-service/internal/modules/ballot/repositories/queries"
+	ballotUsecase "ticket-service/internal/modules/ballot/usecases"
+	checkinHandler "ticket-service/internal/modules/checkin/handlers"
+	checkinRepoCommand "ticket-service/internal/modules/checkin/repositories/commands"
+	checkinUsecase "ticket-service/internal/modules/checkin/usecases"
+	eticketHandler "ticket-service/internal/modules/eticket/handlers"
+	eticketRepoCommand "ticket-service/internal/modules/eticket/repositories/commands"
+	eticketRepoQuery "ticket-service/internal/modules/eticket/repositories/queries"
+	eticketUsecase "ticket-service/internal/modules/eticket/usecases"
+	feeHandler "ticket-service/internal/modules/fee/handlers"
+	feeRepoCommand "ticket-service/internal/modules/fee/repositories/commands"
+	feeRepoQuery "ticket-service/internal/modules/fee/repositories/queries"
+	feeUsecase "ticket-service/internal/modules/fee/usecases"
+	fxHandler "ticket-service/internal/modules/fx/handlers"
+	fxRepoCommand "ticket-service/internal/modules/fx/repositories/commands"
+	fxRepoQuery "ticket-service/internal/modules/fx/repositories/queries"
+	fxUsecase "ticket-service/internal/modules/fx/usecases"
+	orderHandler "ticket-service/internal/modules/order/handlers"
+	orderRepoCommand "ticket-service/internal/modules/order/repositories/commands"
+	orderRepoQuery "ticket-service/internal/modules/order/repositories/queries"
+	orderUsecase "ticket-service/internal/modules/order/usecases"
+	paymentHandler "ticket-service/internal/modules/payment/handlers"
+	paymentProviders "ticket-service/internal/modules/payment/providers"
+	paymentRepoCommand "ticket-service/internal/modules/payment/repositories/commands"
+	paymentRepoQuery "ticket-service/internal/modules/payment/repositories/queries"
+	paymentUsecase "ticket-service/internal/modules/payment/usecases"
+	presaleHandler "ticket-service/internal/modules/presale/handlers"
+	presaleRepoCommand "ticket-service/internal/modules/presale/repositories/commands"
+	presaleRepoQuery "ticket-service/internal/modules/presale/repositories/queries"
+	presaleUsecase "ticket-service/internal/modules/presale/usecases"
+	pricingHandler "ticket-service/internal/modules/pricing/handlers"
+	pricingRepoCommand "ticket-service/internal/modules/pricing/repositories/commands"
+	pricingRepoQuery "ticket-service/internal/modules/pricing/repositories/queries"
+	pricingUsecase "ticket-service/internal/modules/pricing/usecases"
+	purchaseHandler "ticket-service/internal/modules/purchase/handlers"
+	purchaseRepoCommand "ticket-service/internal/modules/purchase/repositories/commands"
+	purchaseRepoQuery "ticket-service/internal/modules/purchase/repositories/queries"
+	purchaseUsecase "ticket-service/internal/modules/purchase/usecases"
+	refundHandler "ticket-service/internal/modules/refund/handlers"
+	refundRepoCommand "ticket-service/internal/modules/refund/repositories/commands"
+	refundRepoQuery "ticket-service/internal/modules/refund/repositories/queries"
+	refundUsecase "ticket-service/internal/modules/refund/usecases"
+	resaleHandler "ticket-service/internal/modules/resale/handlers"
+	resaleRepoCommand "ticket-service/internal/modules/resale/repositories/commands"
+	resaleRepoQuery "ticket-service/internal/modules/resale/repositories/queries"
+	resaleUsecase "ticket-service/internal/modules/resale/usecases"
+	seatHandler "ticket-service/internal/modules/seat/handlers"
+	seatRepoCommand "ticket-service/internal/modules/seat/repositories/commands"
+	seatRepoQuery "ticket-service/internal/modules/seat/repositories/queries"
+	seatUsecase "ticket-service/internal/modules/seat/usecases"
 	ticketHandler "ticket-service/internal/modules/ticket/handlers"
+	ticketRepoCommand "ticket-service/internal/modules/ticket/repositories/commands"
 	ticketRepoQuery "ticket-service/internal/modules/ticket/repositories/queries"
 	ticketUsecase "ticket-service/internal/modules/ticket/usecases"
+	tourHandler "ticket-service/internal/modules/tour/handlers"
+	tourRepoQuery "ticket-service/internal/modules/tour/repositories/queries"
+	tourUsecase "ticket-service/internal/modules/tour/usecases"
+	transferHandler "ticket-service/internal/modules/transfer/handlers"
+	transferRepoCommand "ticket-service/internal/modules/transfer/repositories/commands"
+	transferRepoQuery "ticket-service/internal/modules/transfer/repositories/queries"
+	transferUsecase "ticket-service/internal/modules/transfer/usecases"
+	userRepoQuery "ticket-service/internal/modules/user/repositories/queries"
+	venueHandler "ticket-service/internal/modules/venue/handlers"
+	venueRepoCommand "ticket-service/internal/modules/venue/repositories/commands"
+	venueRepoQuery "ticket-service/internal/modules/venue/repositories/queries"
+	venueUsecase "ticket-service/internal/modules/venue/usecases"
+	voucherHandler "ticket-service/internal/modules/voucher/handlers"
+	voucherRepoCommand "ticket-service/internal/modules/voucher/repositories/commands"
+	voucherRepoQuery "ticket-service/internal/modules/voucher/repositories/queries"
+	voucherUsecase "ticket-service/internal/modules/voucher/usecases"
+	waitlistHandler "ticket-service/internal/modules/waitlist/handlers"
+	waitlistRepoCommand "ticket-service/internal/modules/waitlist/repositories/commands"
+	waitlistRepoQuery "ticket-service/internal/modules/waitlist/repositories/queries"
+	waitlistUsecase "ticket-service/internal/modules/waitlist/usecases"
 	"ticket-service/internal/pkg/apm"
+	"ticket-service/internal/pkg/constants"
 	"ticket-service/internal/pkg/databases/mongodb"
 	graceful "ticket-service/internal/pkg/gs"
 	"ticket-service/internal/pkg/helpers"
@@ -106,6 +185,9 @@ func setHttp(app *fiber.App, gs *graceful.GracefulShutdown) {
 	helperImpl := &helpers.JwtImpl{}
 	helperImpl.InitConfig(configs.GetConfig().Jwt.JwtPrivateKey, configs.GetConfig().Jwt.JwtPublicKey,
 		configs.GetConfig().Jwt.JwtRefreshPrivateKey, configs.GetConfig().Jwt.JwtRefreshPublicKey)
+	// Init Ticket Sign
+	ticketSignImpl := &helpers.TicketSignImpl{}
+	ticketSignImpl.InitConfig(configs.GetConfig().TicketSign.TicketSignPrivateKey, configs.GetConfig().TicketSign.TicketSignPublicKey)
 
 	logger := log.GetLogger()
 	mongoMasterClient := mongodb.NewMongoDBLogger(mongodb.GetMasterConn(), mongodb.GetMasterDBName(), logger)
@@ -121,10 +203,232 @@ func setHttp(app *fiber.App, gs *graceful.GracefulShutdown) {
 		kafkaProducer,
 	)
 
+	presaleQueryMongodbRepo := presaleRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	presaleCommandMongodbRepo := presaleRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	if resp := <-presaleCommandMongodbRepo.CreateUniqueIndexes(context.Background()); resp.Error != nil {
+		logger.Error(context.Background(), "Error create presale unique index", fmt.Sprintf("%+v", resp.Error))
+	}
+	presaleUsecaseCommand := presaleUsecase.NewCommandUsecase(presaleQueryMongodbRepo, presaleCommandMongodbRepo, logger)
+	presaleUsecaseQuery := presaleUsecase.NewQueryUsecase(presaleQueryMongodbRepo, logger)
+
+	feeQueryMongodbRepo := feeRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	feeCommandMongodbRepo := feeRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	feeUsecaseCommand := feeUsecase.NewCommandUsecase(feeCommandMongodbRepo, logger)
+	feeUsecaseQuery := feeUsecase.NewQueryUsecase(feeQueryMongodbRepo, logger)
+
+	userQueryMongodbRepo := userRepoQuery.NewQueryMongodbRepository(mongoSlaveClient, logger)
+	fxQueryMongodbRepo := fxRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	fxCommandMongodbRepo := fxRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	fxUsecaseCommand := fxUsecase.NewCommandUsecase(fxCommandMongodbRepo, logger)
+	fxUsecaseQuery := fxUsecase.NewQueryUsecase(fxQueryMongodbRepo, userQueryMongodbRepo, logger)
+	// the snapshot id is derived from its content, so every pod importing the same file stores it once
+	if ratesFile := configs.GetConfig().Fx.FxRatesFile; ratesFile != "" {
+		if _, err := fxUsecaseCommand.LoadRatesFile(context.Background(), ratesFile); err != nil {
+			logger.Error(context.Background(), "Error load exchange rate file", fmt.Sprintf("%+v", err))
+		}
+	}
+
+	seatQueryMongodbRepo := seatRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	seatCommandMongodbRepo := seatRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	if resp := <-seatCommandMongodbRepo.CreateUniqueIndexes(context.Background()); resp.Error != nil {
+		logger.Error(context.Background(), "Error create seat unique index", fmt.Sprintf("%+v", resp.Error))
+	}
+	seatUsecaseCommand := seatUsecase.NewCommandUsecase(seatQueryMongodbRepo, seatCommandMongodbRepo, logger)
+	seatUsecaseQuery := seatUsecase.NewQueryUsecase(seatQueryMongodbRepo, logger)
+
 	ticketQueryMongodbRepo := ticketRepoQuery.NewQueryMongodbRepository(mongoSlaveClient, logger)
-	ticketUsecaseQuery := ticketUsecase.NewQueryUsecase(ticketQueryMongodbRepo, kafkaProducer, logger)
+	// every inventory change is published to the availability stream of every pod, whichever module made it
+	ticketUsecaseStream := ticketUsecase.NewStreamUsecase(redisClient, logger)
+	ticketCommandMongodbRepo := ticketUsecase.NewStreamRepositoryCommand(ticketRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger),
+		ticketUsecaseStream)
+	// without the text index only the search fails, the rest of the service keeps working
+	if resp := <-ticketCommandMongodbRepo.CreateSearchIndex(context.Background()); resp.Error != nil {
+		logger.Error(context.Background(), "Error create ticket search index", fmt.Sprintf("%+v", resp.Error))
+	}
+
+	venueQueryMongodbRepo := venueRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	venueCommandMongodbRepo := venueRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	venueUsecaseCommand := venueUsecase.NewCommandUsecase(venueQueryMongodbRepo, venueCommandMongodbRepo, ticketQueryMongodbRepo,
+		ticketCommandMongodbRepo, redisClient, logger)
+	venueUsecaseQuery := venueUsecase.NewQueryUsecase(venueQueryMongodbRepo, redisClient, logger)
+
+	tourQueryMongodbRepo := tourRepoQuery.NewQueryMongodbRepository(mongoSlaveClient, logger)
+	tourUsecaseQuery := tourUsecase.NewQueryUsecase(tourQueryMongodbRepo, venueUsecaseQuery, redisClient, logger)
+
+	voucherQueryMongodbRepo := voucherRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	voucherCommandMongodbRepo := voucherRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	if resp := <-voucherCommandMongodbRepo.CreateUniqueIndexes(context.Background()); resp.Error != nil {
+		logger.Error(context.Background(), "Error create voucher unique index", fmt.Sprintf("%+v", resp.Error))
+	}
+	voucherUsecaseCommand := voucherUsecase.NewCommandUsecase(voucherQueryMongodbRepo, voucherCommandMongodbRepo, logger)
+	voucherUsecaseQuery := voucherUsecase.NewQueryUsecase(voucherQueryMongodbRepo, ticketQueryMongodbRepo, logger)
+
+	// quotes preview vouchers, fees and display currencies and suggestions rank by venue, so the ticket usecase is built after them
+	ticketUsecaseQuery := ticketUsecase.NewQueryUsecase(ticketQueryMongodbRepo, userQueryMongodbRepo, presaleUsecaseQuery, voucherUsecaseQuery,
+		feeUsecaseQuery, fxUsecaseQuery, venueUsecaseQuery, kafkaProducer, logger)
+
+	ballotQueryMongodbRepo := ballotRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	ballotCommandMongodbRepo := ballotRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	if resp := <-ballotCommandMongodbRepo.CreateUniqueIndexes(context.Background()); resp.Error != nil {
+		logger.Error(context.Background(), "Error create ballot unique index", fmt.Sprintf("%+v", resp.Error))
+	}
+	ballotUsecaseQuery := ballotUsecase.NewQueryUsecase(ballotQueryMongodbRepo, logger)
+
+	waitlistQueryMongodbRepo := waitlistRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	waitlistCommandMongodbRepo := waitlistRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	waitlistUsecaseQuery := waitlistUsecase.NewQueryUsecase(waitlistQueryMongodbRepo, logger)
+
+	orderQueryMongodbRepo := orderRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	orderCommandMongodbRepo := orderRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	if resp := <-orderCommandMongodbRepo.CreateUniqueIndexes(context.Background()); resp.Error != nil {
+		logger.Error(context.Background(), "Error create order unique index", fmt.Sprintf("%+v", resp.Error))
+	}
+	eticketQueryMongodbRepo := eticketRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	eticketCommandMongodbRepo := eticketRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	if resp := <-eticketCommandMongodbRepo.CreateUniqueIndexes(context.Background()); resp.Error != nil {
+		logger.Error(context.Background(), "Error create eticket unique index", fmt.Sprintf("%+v", resp.Error))
+	}
+
+	// access passes are signed when an online ticket is issued or changes hands, so access is built before those usecases
+	accessQueryMongodbRepo := accessRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	accessCommandMongodbRepo := accessRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	if resp := <-accessCommandMongodbRepo.CreateUniqueIndexes(context.Background()); resp.Error != nil {
+		logger.Error(context.Background(), "Error create access unique index", fmt.Sprintf("%+v", resp.Error))
+	}
+	accessUsecaseCommand := accessUsecase.NewCommandUsecase(accessCommandMongodbRepo, eticketQueryMongodbRepo, ticketQueryMongodbRepo,
+		ticketSignImpl, redisClient, kafkaProducer, logger)
+	accessUsecaseQuery := accessUsecase.NewQueryUsecase(accessQueryMongodbRepo, eticketQueryMongodbRepo, logger)
+
+	// resale orders are released and completed through the resale usecase, so it is built before the order and payment usecases
+	resaleQueryMongodbRepo := resaleRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	resaleCommandMongodbRepo := resaleRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	resaleUsecaseCommand := resaleUsecase.NewCommandUsecase(resaleQueryMongodbRepo, resaleCommandMongodbRepo, eticketQueryMongodbRepo,
+		eticketCommandMongodbRepo, orderQueryMongodbRepo, orderCommandMongodbRepo, accessUsecaseCommand, kafkaProducer, logger)
+	resaleUsecaseQuery := resaleUsecase.NewQueryUsecase(resaleQueryMongodbRepo, logger)
+
+	orderUsecaseCommand := orderUsecase.NewCommandUsecase(orderQueryMongodbRepo, orderCommandMongodbRepo, ticketQueryMongodbRepo,
+		ticketCommandMongodbRepo, voucherUsecaseCommand, presaleUsecaseCommand, ballotUsecaseQuery, waitlistUsecaseQuery, resaleUsecaseCommand,
+		feeUsecaseQuery, seatUsecaseCommand, logger)
+	orderUsecaseQuery := orderUsecase.NewQueryUsecase(orderQueryMongodbRepo, logger)
+
+	// the ballot and the waitlist reserve tickets for their users through the order usecase, so they are built after it
+	ballotUsecaseCommand := ballotUsecase.NewCommandUsecase(ballotQueryMongodbRepo, ballotCommandMongodbRepo, ticketQueryMongodbRepo,
+		orderUsecaseCommand, kafkaProducer, logger)
+	waitlistUsecaseCommand := waitlistUsecase.NewCommandUsecase(waitlistQueryMongodbRepo, waitlistCommandMongodbRepo, ticketQueryMongodbRepo,
+		orderQueryMongodbRepo, orderUsecaseCommand, kafkaProducer, logger)
+
+	// tickets released by refunds, expired holds or a bigger quota are offered to the waitlist on a schedule
+	go func() {
+		ticker := time.NewTicker(constants.WaitlistOfferInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := waitlistUsecaseCommand.OfferReleasedTickets(context.Background()); err != nil {
+				logger.Error(context.Background(), "Error offer waitlist", fmt.Sprintf("%+v", err))
+			}
+		}
+	}()
+
+	eticketUsecaseCommand := eticketUsecase.NewCommandUsecase(eticketQueryMongodbRepo, eticketCommandMongodbRepo, orderQueryMongodbRepo,
+		accessUsecaseCommand, kafkaProducer, logger)
+	eticketUsecaseQuery := eticketUsecase.NewQueryUsecase(eticketQueryMongodbRepo, ticketSignImpl, logger)
+
+	checkinCommandMongodbRepo := checkinRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	checkinUsecaseCommand := checkinUsecase.NewCommandUsecase(checkinCommandMongodbRepo, eticketQueryMongodbRepo, eticketCommandMongodbRepo,
+		userQueryMongodbRepo, ticketSignImpl, kafkaProducer, logger)
+
+	transferQueryMongodbRepo := transferRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	transferCommandMongodbRepo := transferRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	transferUsecaseCommand := transferUsecase.NewCommandUsecase(transferQueryMongodbRepo, transferCommandMongodbRepo, eticketQueryMongodbRepo,
+		eticketCommandMongodbRepo, userQueryMongodbRepo, accessUsecaseCommand, kafkaProducer, logger)
+	transferUsecaseQuery := transferUsecase.NewQueryUsecase(transferQueryMongodbRepo, eticketQueryMongodbRepo, logger)
+
+	refundQueryMongodbRepo := refundRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	refundCommandMongodbRepo := refundRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	refundUsecaseCommand := refundUsecase.NewCommandUsecase(refundQueryMongodbRepo, refundCommandMongodbRepo, orderQueryMongodbRepo,
+		orderCommandMongodbRepo, orderUsecaseCommand, eticketQueryMongodbRepo, eticketCommandMongodbRepo, ticketCommandMongodbRepo,
+		accessUsecaseCommand, kafkaProducer, logger)
+	refundUsecaseQuery := refundUsecase.NewQueryUsecase(refundQueryMongodbRepo, logger)
+
+	paymentProvider, err := paymentProviders.NewProvider(configs.GetConfig().Payment.PaymentProvider,
+		configs.GetConfig().Payment.PaymentWebhookSecret, configs.GetConfig().ServiceEnv)
+	if err != nil {
+		panic(err)
+	}
+	paymentQueryMongodbRepo := paymentRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	paymentCommandMongodbRepo := paymentRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	if resp := <-paymentCommandMongodbRepo.CreateUniqueIndexes(context.Background()); resp.Error != nil {
+		logger.Error(context.Background(), "Error create payment unique index", fmt.Sprintf("%+v", resp.Error))
+	}
+	paymentUsecaseCommand := paymentUsecase.NewCommandUsecase(paymentQueryMongodbRepo, paymentCommandMongodbRepo, orderQueryMongodbRepo,
+		orderCommandMongodbRepo, orderUsecaseCommand, resaleUsecaseCommand, seatUsecaseCommand, paymentProvider, kafkaProducer, logger)
+	paymentUsecaseQuery := paymentUsecase.NewQueryUsecase(paymentQueryMongodbRepo, logger)
+
+	// unpaid reservations give their inventory back and have their open charges voided on a schedule
+	go func() {
+		ticker := time.NewTicker(constants.HoldExpireInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := paymentUsecaseCommand.ExpireHolds(context.Background()); err != nil {
+				logger.Error(context.Background(), "Error expire holds", fmt.Sprintf("%+v", err))
+			}
+		}
+	}()
+
+	purchaseQueryMongodbRepo := purchaseRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	purchaseCommandMongodbRepo := purchaseRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	purchaseUsecaseCommand := purchaseUsecase.NewCommandUsecase(purchaseQueryMongodbRepo, purchaseCommandMongodbRepo, orderUsecaseCommand,
+		orderQueryMongodbRepo, paymentUsecaseCommand, eticketUsecaseCommand, eticketQueryMongodbRepo, eticketCommandMongodbRepo,
+		kafkaProducer, logger)
+	purchaseUsecaseQuery := purchaseUsecase.NewQueryUsecase(purchaseQueryMongodbRepo, logger)
+
+	// every pod keeps resuming due sagas, this is how a saga interrupted by a restart gets finished
+	go func() {
+		ticker := time.NewTicker(constants.SagaResumeInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := purchaseUsecaseCommand.ResumeSagas(context.Background()); err != nil {
+				logger.Error(context.Background(), "Error resume saga", fmt.Sprintf("%+v", err))
+			}
+		}
+	}()
+
+	pricingQueryMongodbRepo := pricingRepoQuery.NewQueryMongodbRepository(mongoMasterClient, logger)
+	pricingCommandMongodbRepo := pricingRepoCommand.NewCommandMongodbRepository(mongoMasterClient, logger)
+	pricingUsecaseCommand := pricingUsecase.NewCommandUsecase(pricingQueryMongodbRepo, pricingCommandMongodbRepo, ticketQueryMongodbRepo,
+		ticketCommandMongodbRepo, waitlistQueryMongodbRepo, kafkaProducer, logger)
+	pricingUsecaseQuery := pricingUsecase.NewQueryUsecase(pricingQueryMongodbRepo, logger)
+
+	// dynamic prices follow demand on a schedule, only the pod that moves a price logs the change
+	go func() {
+		ticker := time.NewTicker(constants.PricingRecalculateInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := pricingUsecaseCommand.RecalculatePrices(context.Background()); err != nil {
+				logger.Error(context.Background(), "Error recalculate prices", fmt.Sprintf("%+v", err))
+			}
+		}
+	}()
 
 	// set module
-	ticketHandler.InitTicketHttpHandler(app, ticketUsecaseQuery, logger, redisClient)
+	ticketHandler.InitTicketHttpHandler(app, ticketUsecaseQuery, ticketUsecaseStream, logger, redisClient)
+	orderHandler.InitOrderHttpHandler(app, orderUsecaseCommand, orderUsecaseQuery, logger, redisClient)
+	eticketHandler.InitEticketHttpHandler(app, eticketUsecaseCommand, eticketUsecaseQuery, logger, redisClient)
+	checkinHandler.InitCheckinHttpHandler(app, checkinUsecaseCommand, logger, redisClient)
+	accessHandler.InitAccessHttpHandler(app, accessUsecaseCommand, accessUsecaseQuery, logger, redisClient)
+	transferHandler.InitTransferHttpHandler(app, transferUsecaseCommand, transferUsecaseQuery, logger, redisClient)
+	refundHandler.InitRefundHttpHandler(app, refundUsecaseCommand, refundUsecaseQuery, logger, redisClient)
+	paymentHandler.InitPaymentHttpHandler(app, paymentUsecaseCommand, paymentUsecaseQuery, logger, redisClient)
+	purchaseHandler.InitPurchaseHttpHandler(app, purchaseUsecaseCommand, purchaseUsecaseQuery, logger, redisClient)
+	voucherHandler.InitVoucherHttpHandler(app, voucherUsecaseCommand, voucherUsecaseQuery, logger, redisClient)
+	presaleHandler.InitPresaleHttpHandler(app, presaleUsecaseCommand, presaleUsecaseQuery, logger, redisClient)
+	ballotHandler.InitBallotHttpHandler(app, ballotUsecaseCommand, ballotUsecaseQuery, logger, redisClient)
+	waitlistHandler.InitWaitlistHttpHandler(app, waitlistUsecaseCommand, waitlistUsecaseQuery, logger, redisClient)
+	resaleHandler.InitResaleHttpHandler(app, resaleUsecaseCommand, resaleUsecaseQuery, logger, redisClient)
+	pricingHandler.InitPricingHttpHandler(app, pricingUsecaseCommand, pricingUsecaseQuery, logger, redisClient)
+	feeHandler.InitFeeHttpHandler(app, feeUsecaseCommand, logger, redisClient)
+	fxHandler.InitFxHttpHandler(app, fxUsecaseCommand, fxUsecaseQuery, logger, redisClient)
+	seatHandler.InitSeatHttpHandler(app, seatUsecaseCommand, seatUsecaseQuery, logger, redisClient)
+	venueHandler.InitVenueHttpHandler(app, venueUsecaseCommand, venueUsecaseQuery, logger, redisClient)
+	tourHandler.InitTourHttpHandler(app, tourUsecaseQuery, logger, redisClient)
 
 }
@@ -23,6 +23,9 @@ type Config struct {
 	Datadog           DatadogConfig    `envconfig:"datadog"`
 	Kafka             KafkaConfig      `envconfig:"kafka"`
 	Jwt               JwtConfig        `envconfig:"jwt"`
+	TicketSign        TicketSignConfig `envconfig:"ticket_sign"`
+	Payment           PaymentConfig    `envconfig:"payment"`
+	Fx                FxConfig         `envconfig:"fx"`
 	UsernameBasicAuth string           `envconfig:"username_basic_auth"`
 	PasswordBasicAuth string           `envconfig:"password_basic_auth"`
 	ShutDownDelay     string           `envconfig:"shutdown_delay"`
@@ -94,6 +97,20 @@ type JwtConfig struct {
 	JwtRefreshPublicKey  string `envconfig:"public_key_refresh"`
 }
 
+type TicketSignConfig struct {
+	TicketSignPrivateKey string `envconfig:"ticket_sign_private_key"`
+	TicketSignPublicKey  string `envconfig:"ticket_sign_public_key"`
+}
+
+type PaymentConfig struct {
+	PaymentProvider      string `envconfig:"payment_provider"`
+	PaymentWebhookSecret string `envconfig:"payment_webhook_secret"`
+}
+
+type FxConfig struct {
+	FxRatesFile string `envconfig:"fx_rates_file"`
+}
+
 func InitConfig() *Config {
 	err := godotenv.Load()
 	if err != nil {
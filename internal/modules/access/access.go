@@ -0,0 +1,31 @@
+package access
+
+import (
+	"context"
+	"ticket-service/internal/modules/access/models/entity"
+	"ticket-service/internal/modules/access/models/request"
+	"ticket-service/internal/modules/access/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+// UsecaseCommand hands out the streaming access passes of Online tickets. A pass is signed with the ticket keys when
+// the ticket is issued or changes hands, the issued ticket behind it and the redis device and session slots decide
+// if it still works
+type UsecaseCommand interface {
+	IssuePass(origCtx context.Context, issuedTicketId string) (*response.Pass, error)
+	ValidatePass(origCtx context.Context, payload request.ValidateReq) (*response.Validation, error)
+	RevokePass(origCtx context.Context, issuedTicketId string) error
+}
+
+type UsecaseQuery interface {
+	FindPass(origCtx context.Context, payload request.PassReq) (*response.Pass, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindPassByIssuedTicketId(ctx context.Context, issuedTicketId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertPass(ctx context.Context, pass entity.Pass) <-chan wrapper.Result
+	CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result
+}
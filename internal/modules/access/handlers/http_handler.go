@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/access"
+	"ticket-service/internal/modules/access/models/request"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AccessHttpHandler struct {
+	AccessUsecaseCommand access.UsecaseCommand
+	AccessUsecaseQuery   access.UsecaseQuery
+	Logger               log.Logger
+	Validator            *validator.Validate
+}
+
+func InitAccessHttpHandler(app *fiber.App, auc access.UsecaseCommand, auq access.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &AccessHttpHandler{
+		AccessUsecaseCommand: auc,
+		AccessUsecaseQuery:   auq,
+		Logger:               log,
+		Validator:            validator.New(),
+	}
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/access")
+
+	route.Get("/v1/passes/:id", middlewares.VerifyBearer(), handler.GetPass)
+	route.Post("/v1/validate", middlewares.VerifyBasicAuth(), handler.ValidatePass)
+}
+
+func (h AccessHttpHandler) GetPass(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, h.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.PassReq{
+		UserId:         userId,
+		IssuedTicketId: c.Params("id"),
+	}
+	resp, err := h.AccessUsecaseQuery.FindPass(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, h.Logger, err)
+	}
+	return helpers.RespSuccess(c, h.Logger, resp, "Get access pass success")
+}
+
+func (h AccessHttpHandler) ValidatePass(c *fiber.Ctx) error {
+	req := new(request.ValidateReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, h.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, h.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := h.AccessUsecaseCommand.ValidatePass(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, h.Logger, err)
+	}
+	return helpers.RespSuccess(c, h.Logger, resp, "Validate access pass success")
+}
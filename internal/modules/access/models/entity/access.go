@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// Pass is the signed access pass of the current owner of an Online ticket, it is signed again when the ticket changes
+// hands so QrVersion always follows the issued ticket
+type Pass struct {
+	IssuedTicketId string    `json:"issuedTicketId" bson:"issuedTicketId"`
+	UserId         string    `json:"userId" bson:"userId"`
+	EventId        string    `json:"eventId" bson:"eventId"`
+	QrVersion      int       `json:"qrVersion" bson:"qrVersion"`
+	Token          string    `json:"token" bson:"token"`
+	ValidFrom      time.Time `json:"validFrom" bson:"validFrom"`
+	ValidUntil     time.Time `json:"validUntil" bson:"validUntil"`
+	IssuedAt       time.Time `json:"issuedAt" bson:"issuedAt"`
+}
@@ -0,0 +1,12 @@
+package request
+
+type PassReq struct {
+	UserId         string `json:"-"`
+	IssuedTicketId string `json:"-"`
+}
+
+type ValidateReq struct {
+	Token     string `json:"token" validate:"required"`
+	DeviceId  string `json:"deviceId" validate:"required,max=128"`
+	SessionId string `json:"sessionId" validate:"required,max=128"`
+}
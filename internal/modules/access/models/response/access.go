@@ -0,0 +1,23 @@
+package response
+
+import "time"
+
+type Pass struct {
+	IssuedTicketId string    `json:"issuedTicketId"`
+	EventId        string    `json:"eventId"`
+	Token          string    `json:"token"`
+	ValidFrom      time.Time `json:"validFrom"`
+	ValidUntil     time.Time `json:"validUntil"`
+	MaxDevices     int       `json:"maxDevices"`
+	MaxSessions    int       `json:"maxSessions"`
+}
+
+type Validation struct {
+	IssuedTicketId   string    `json:"issuedTicketId"`
+	EventId          string    `json:"eventId"`
+	UserId           string    `json:"userId"`
+	DeviceId         string    `json:"deviceId"`
+	SessionId        string    `json:"sessionId"`
+	ValidUntil       time.Time `json:"validUntil"`
+	SessionExpiresAt time.Time `json:"sessionExpiresAt"`
+}
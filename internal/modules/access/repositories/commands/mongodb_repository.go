@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/access"
+	"ticket-service/internal/modules/access/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) access.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// UpsertPass replaces the pass of the issued ticket, an earlier owner's pass is overwritten
+func (c commandMongodbRepository) UpsertPass(ctx context.Context, pass entity.Pass) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "access-passes",
+			Filter: bson.M{
+				"issuedTicketId": pass.IssuedTicketId,
+			},
+			Document: pass,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CreateUniqueIndexes keeps one pass per issued ticket, two UpsertPass calls racing would insert it twice without it
+func (c commandMongodbRepository) CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.CreateIndex(mongodb.CreateIndex{
+			CollectionName: "access-passes",
+			Keys:           bson.D{{Key: "issuedTicketId", Value: 1}},
+			Options:        options.Index().SetUnique(true),
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/access"
+	"ticket-service/internal/modules/access/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) access.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindPassByIssuedTicketId(ctx context.Context, issuedTicketId string) <-chan wrapper.Result {
+	var pass entity.Pass
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &pass,
+			CollectionName: "access-passes",
+			Filter: bson.M{
+				"issuedTicketId": issuedTicketId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
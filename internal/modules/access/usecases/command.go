@@ -0,0 +1,333 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"ticket-service/internal/modules/access"
+	"ticket-service/internal/modules/access/models/entity"
+	"ticket-service/internal/modules/access/models/request"
+	"ticket-service/internal/modules/access/models/response"
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/ticket"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	accessRepositoryCommand access.MongodbRepositoryCommand
+	eticketRepositoryQuery  eticket.MongodbRepositoryQuery
+	ticketRepositoryQuery   ticket.MongodbRepositoryQuery
+	ticketSigner            helpers.TicketSigner
+	redisClient             redis.Collections
+	kafkaProducer           kafkaConfluent.Producer
+	logger                  log.Logger
+}
+
+func NewCommandUsecase(amc access.MongodbRepositoryCommand, emq eticket.MongodbRepositoryQuery, tmq ticket.MongodbRepositoryQuery,
+	ts helpers.TicketSigner, redisClient redis.Collections, kp kafkaConfluent.Producer, log log.Logger) access.UsecaseCommand {
+	return commandUsecase{
+		accessRepositoryCommand: amc,
+		eticketRepositoryQuery:  emq,
+		ticketRepositoryQuery:   tmq,
+		ticketSigner:            ts,
+		redisClient:             redisClient,
+		kafkaProducer:           kp,
+		logger:                  log,
+	}
+}
+
+// IssuePass signs and stores the pass of the current owner of an Online ticket. It is called when the ticket is issued
+// and again when it changes hands, the pass is bound to the qr version so the previous owner's pass stops working
+func (c commandUsecase) IssuePass(origCtx context.Context, issuedTicketId string) (*response.Pass, error) {
+	domain := "accessUsecase-IssuePass"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	issuedTicket, err := c.findIssuedTicket(ctx, issuedTicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuedTicket.TicketType != constants.TicketTypeOnline {
+		return nil, errors.UnprocessableEntity("only online tickets have an access pass")
+	}
+
+	if issuedTicket.Status != constants.IssuedTicketStatusActive {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("ticket with status %s has no access pass", issuedTicket.Status))
+	}
+
+	eventDate, err := c.findEventDate(ctx, *issuedTicket)
+	if err != nil {
+		return nil, err
+	}
+	validFrom := eventDate.Add(-constants.AccessPassOpenBefore)
+	validUntil := eventDate.Add(constants.AccessPassCloseAfter)
+	if time.Now().After(validUntil) {
+		return nil, errors.UnprocessableEntity("event stream has ended")
+	}
+
+	signed, err := c.ticketSigner.SignTicket(helpers.TicketPayload{
+		TicketId:   issuedTicket.IssuedTicketId,
+		EventId:    issuedTicket.EventId,
+		TicketType: issuedTicket.TicketType,
+		UserId:     issuedTicket.UserId,
+		Version:    issuedTicket.QrVersion,
+		Purpose:    constants.AccessPassPurposeStream,
+		NotBefore:  validFrom.Unix(),
+		ExpiresAt:  validUntil.Unix(),
+	})
+	if err != nil {
+		msg := "Error sign access pass"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	pass := entity.Pass{
+		IssuedTicketId: issuedTicket.IssuedTicketId,
+		UserId:         issuedTicket.UserId,
+		EventId:        issuedTicket.EventId,
+		QrVersion:      issuedTicket.QrVersion,
+		Token:          signed,
+		ValidFrom:      validFrom,
+		ValidUntil:     validUntil,
+		IssuedAt:       time.Now(),
+	}
+	resp := <-c.accessRepositoryCommand.UpsertPass(ctx, pass)
+	if resp.Error != nil {
+		msg := "Error upsert access pass"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapPass(pass), nil
+}
+
+// ValidatePass is called by the streaming platform when a viewer starts watching and then as a heartbeat. A device
+// keeps its slot until the pass expires, a session gives its slot back when the heartbeats stop
+func (c commandUsecase) ValidatePass(origCtx context.Context, payload request.ValidateReq) (*response.Validation, error) {
+	domain := "accessUsecase-ValidatePass"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	pass, err := c.ticketSigner.VerifyTicket(payload.Token)
+	if err != nil {
+		return nil, errors.ForbiddenError("invalid access pass")
+	}
+
+	if pass.Purpose != constants.AccessPassPurposeStream {
+		return nil, errors.ForbiddenError("invalid access pass")
+	}
+
+	now := time.Now()
+	validUntil := time.Unix(pass.ExpiresAt, 0)
+	if now.Before(time.Unix(pass.NotBefore, 0)) {
+		return nil, errors.ForbiddenError("access pass is not valid yet")
+	}
+	if !now.Before(validUntil) {
+		return nil, errors.ForbiddenError("access pass has expired")
+	}
+
+	issuedTicket, err := c.findIssuedTicket(ctx, pass.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuedTicket.Status == constants.IssuedTicketStatusRevoked {
+		return nil, errors.ForbiddenError("access pass has been revoked")
+	}
+
+	// a transfer bumps the qr version, so the pass of the previous owner is refused here
+	if issuedTicket.Status != constants.IssuedTicketStatusActive || issuedTicket.QrVersion != pass.Version ||
+		issuedTicket.UserId != pass.UserId {
+		return nil, errors.ForbiddenError("access pass is no longer valid")
+	}
+
+	deviceKey, sessionKey := slotKeys(issuedTicket.IssuedTicketId)
+	claimed, err := c.claimSlots(ctx, []string{deviceKey, sessionKey}, now.UnixMilli(),
+		payload.DeviceId, validUntil.UnixMilli(), constants.AccessPassMaxDevices,
+		payload.SessionId, now.Add(constants.AccessSessionTTL).UnixMilli(), constants.AccessPassMaxSessions)
+	if err != nil {
+		return nil, err
+	}
+	switch claimed {
+	case deviceSlotsFull:
+		return nil, errors.TooManyRequest(fmt.Sprintf("access pass is already used on %d devices", constants.AccessPassMaxDevices))
+	case sessionSlotsFull:
+		return nil, errors.TooManyRequest(fmt.Sprintf("at most %d sessions can watch with this access pass at once",
+			constants.AccessPassMaxSessions))
+	}
+
+	return &response.Validation{
+		IssuedTicketId:   issuedTicket.IssuedTicketId,
+		EventId:          issuedTicket.EventId,
+		UserId:           issuedTicket.UserId,
+		DeviceId:         payload.DeviceId,
+		SessionId:        payload.SessionId,
+		ValidUntil:       validUntil,
+		SessionExpiresAt: now.Add(constants.AccessSessionTTL),
+	}, nil
+}
+
+// RevokePass frees the device and session slots and tells the streaming platform to cut the running sessions.
+// The issued ticket itself is revoked by the caller, which is what refuses the next validation
+func (c commandUsecase) RevokePass(origCtx context.Context, issuedTicketId string) error {
+	domain := "accessUsecase-RevokePass"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	deviceKey, sessionKey := slotKeys(issuedTicketId)
+	if err := c.redisClient.Del(ctx, deviceKey, sessionKey).Err(); err != nil {
+		msg := "Error delete access pass slot"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return errors.InternalServerError("cannot revoke access pass")
+	}
+
+	revokedEvent := map[string]interface{}{
+		"issuedTicketId": issuedTicketId,
+		"revokedAt":      time.Now(),
+	}
+	marshaledKafkaData, _ := json.Marshal(revokedEvent)
+	topic := "concert-access-revoked"
+	c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka access revoked, issued ticket : %s", issuedTicketId), fmt.Sprintf("%+v", revokedEvent))
+	return nil
+}
+
+// claimSlotsScript takes a device slot and a session slot of a pass in one step, so a viewer refused by either limit
+// holds neither. Each key is a sorted set of holders scored by the unix millisecond their slot ends, a holder already
+// in the set only has its end moved
+const claimSlotsScript = `
+local now = tonumber(ARGV[1])
+local function full(key, holder, limit)
+	redis.call('ZREMRANGEBYSCORE', key, '-inf', now)
+	return not redis.call('ZSCORE', key, holder) and redis.call('ZCARD', key) >= tonumber(limit)
+end
+local function claim(key, holder, ends)
+	redis.call('ZADD', key, ends, holder)
+	local last = redis.call('ZRANGE', key, -1, -1, 'WITHSCORES')
+	redis.call('PEXPIREAT', key, last[2])
+end
+if full(KEYS[1], ARGV[2], ARGV[4]) then
+	return 1
+end
+if full(KEYS[2], ARGV[5], ARGV[7]) then
+	return 2
+end
+claim(KEYS[1], ARGV[2], ARGV[3])
+claim(KEYS[2], ARGV[5], ARGV[6])
+return 0
+`
+
+var claimSlotsSha = fmt.Sprintf("%x", sha1.Sum([]byte(claimSlotsScript)))
+
+// results of claimSlotsScript
+const (
+	slotsClaimed int64 = iota
+	deviceSlotsFull
+	sessionSlotsFull
+)
+
+func (c commandUsecase) claimSlots(ctx context.Context, keys []string, args ...interface{}) (int64, error) {
+	claimed, err := c.redisClient.EvalSha(ctx, claimSlotsSha, keys, args...).Int64()
+	// redis forgets loaded scripts on a restart, load it again and retry once
+	if err != nil && strings.HasPrefix(err.Error(), "NOSCRIPT") {
+		if err = c.redisClient.ScriptLoad(ctx, claimSlotsScript).Err(); err == nil {
+			claimed, err = c.redisClient.EvalSha(ctx, claimSlotsSha, keys, args...).Int64()
+		}
+	}
+	if err != nil {
+		msg := "Error claim access pass slot"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return slotsClaimed, errors.InternalServerError("cannot validate access pass, please retry")
+	}
+	return claimed, nil
+}
+
+// slotKeys tags both keys with the ticket id so they hash to the same cluster slot, which the script needs
+func slotKeys(issuedTicketId string) (string, string) {
+	return fmt.Sprintf("%s:{%s}", constants.RedisKeyAccessDevice, issuedTicketId),
+		fmt.Sprintf("%s:{%s}", constants.RedisKeyAccessSession, issuedTicketId)
+}
+
+func mapPass(pass entity.Pass) *response.Pass {
+	return &response.Pass{
+		IssuedTicketId: pass.IssuedTicketId,
+		EventId:        pass.EventId,
+		Token:          pass.Token,
+		ValidFrom:      pass.ValidFrom,
+		ValidUntil:     pass.ValidUntil,
+		MaxDevices:     constants.AccessPassMaxDevices,
+		MaxSessions:    constants.AccessPassMaxSessions,
+	}
+}
+
+func (c commandUsecase) findIssuedTicket(ctx context.Context, issuedTicketId string) (*eticketEntity.IssuedTicket, error) {
+	resp := <-c.eticketRepositoryQuery.FindIssuedTicketById(ctx, issuedTicketId)
+	if resp.Error != nil {
+		msg := "Error query issued ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	issuedTicket, ok := resp.Data.(*eticketEntity.IssuedTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return issuedTicket, nil
+}
+
+// findEventDate reads the schedule from the Online tier the ticket was bought from
+func (c commandUsecase) findEventDate(ctx context.Context, issuedTicket eticketEntity.IssuedTicket) (time.Time, error) {
+	resp := <-c.ticketRepositoryQuery.FindTicketsByEventCountry(ctx, issuedTicket.EventId, issuedTicket.CountryCode)
+	if resp.Error != nil {
+		msg := "Error query ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return time.Time{}, resp.Error
+	}
+
+	if resp.Data == nil {
+		return time.Time{}, errors.NotFound("ticket not found")
+	}
+
+	tickets, ok := resp.Data.(*[]ticketEntity.Ticket)
+	if !ok {
+		return time.Time{}, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *tickets {
+		if value.TicketId != issuedTicket.TicketId {
+			continue
+		}
+		if value.EventDate.IsZero() {
+			return time.Time{}, errors.UnprocessableEntity("event schedule is not set yet")
+		}
+		return value.EventDate, nil
+	}
+	return time.Time{}, errors.NotFound("ticket not found")
+}
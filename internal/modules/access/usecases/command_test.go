@@ -0,0 +1,291 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ticket-service/internal/modules/access"
+	accessEntity "ticket-service/internal/modules/access/models/entity"
+	"ticket-service/internal/modules/access/models/request"
+	uc "ticket-service/internal/modules/access/usecases"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockaccess "ticket-service/mocks/modules/access"
+	mocketicket "ticket-service/mocks/modules/eticket"
+	mockticket "ticket-service/mocks/modules/ticket"
+	mockhelpers "ticket-service/mocks/pkg/helpers"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+	mockredis "ticket-service/mocks/pkg/redis"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockAccessRepositoryCommand *mockaccess.MongodbRepositoryCommand
+	mockEticketRepositoryQuery  *mocketicket.MongodbRepositoryQuery
+	mockTicketRepositoryQuery   *mockticket.MongodbRepositoryQuery
+	mockTicketSigner            *mockhelpers.TicketSigner
+	mockRedis                   *mockredis.Collections
+	mockKafkaProducer           *mockkafka.Producer
+	mockLogger                  *mocklog.Logger
+	usecase                     access.UsecaseCommand
+	ctx                         context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockAccessRepositoryCommand = &mockaccess.MongodbRepositoryCommand{}
+	suite.mockEticketRepositoryQuery = &mocketicket.MongodbRepositoryQuery{}
+	suite.mockTicketRepositoryQuery = &mockticket.MongodbRepositoryQuery{}
+	suite.mockTicketSigner = &mockhelpers.TicketSigner{}
+	suite.mockRedis = &mockredis.Collections{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockAccessRepositoryCommand,
+		suite.mockEticketRepositoryQuery,
+		suite.mockTicketRepositoryQuery,
+		suite.mockTicketSigner,
+		suite.mockRedis,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestIssuePass() {
+	// Arrange
+	eventDate := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive)}))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventCountry", mock.Anything, "event-id", "ID").
+		Return(mockChannel(helpers.Result{Data: &[]ticketEntity.Ticket{
+			{TicketId: "offline-ticket-id", TicketType: "Gold", EventDate: eventDate},
+			{TicketId: "ticket-id", TicketType: constants.TicketTypeOnline, EventDate: eventDate},
+		}}))
+	suite.mockTicketSigner.On("SignTicket", helpers.TicketPayload{
+		TicketId:   "issued-id",
+		EventId:    "event-id",
+		TicketType: constants.TicketTypeOnline,
+		UserId:     "user-id",
+		Version:    2,
+		Purpose:    constants.AccessPassPurposeStream,
+		NotBefore:  eventDate.Add(-constants.AccessPassOpenBefore).Unix(),
+		ExpiresAt:  eventDate.Add(constants.AccessPassCloseAfter).Unix(),
+	}).Return("signed-pass", nil)
+	suite.mockAccessRepositoryCommand.On("UpsertPass", mock.Anything, mock.MatchedBy(func(pass accessEntity.Pass) bool {
+		return pass.UserId == "user-id" && pass.QrVersion == 2 && pass.Token == "signed-pass"
+	})).Return(mockChannel(helpers.Result{Data: "Success upsert data"}))
+
+	// Act
+	result, err := suite.usecase.IssuePass(suite.ctx, "issued-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "signed-pass", result.Token)
+	assert.Equal(suite.T(), eventDate.Add(constants.AccessPassCloseAfter), result.ValidUntil)
+	assert.Equal(suite.T(), constants.AccessPassMaxDevices, result.MaxDevices)
+}
+
+func (suite *CommandUsecaseTestSuite) TestIssuePassErrNotOnline() {
+	// Arrange
+	issuedTicket := getMockIssuedTicket(constants.IssuedTicketStatusActive)
+	issuedTicket.TicketType = "Gold"
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: issuedTicket}))
+
+	// Act
+	result, err := suite.usecase.IssuePass(suite.ctx, "issued-id")
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.UnprocessableEntity("only online tickets have an access pass"), err)
+	suite.mockTicketSigner.AssertNotCalled(suite.T(), "SignTicket", mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestValidatePass() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "pass").Return(getMockPass(time.Now()), nil)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive)}))
+	suite.onClaimSlots().Return(redis.NewCmdResult(int64(0), nil))
+
+	// Act
+	result, err := suite.usecase.ValidatePass(suite.ctx, getMockValidateReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "user-id", result.UserId)
+	assert.Equal(suite.T(), "session-id", result.SessionId)
+}
+
+func (suite *CommandUsecaseTestSuite) TestValidatePassReloadsScript() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "pass").Return(getMockPass(time.Now()), nil)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive)}))
+	suite.onClaimSlots().Return(redis.NewCmdResult(nil, fmt.Errorf("NOSCRIPT No matching script. Please use EVAL."))).Once()
+	suite.mockRedis.On("ScriptLoad", mock.Anything, mock.Anything).Return(redis.NewStringResult("sha", nil))
+	suite.onClaimSlots().Return(redis.NewCmdResult(int64(0), nil))
+
+	// Act
+	_, err := suite.usecase.ValidatePass(suite.ctx, getMockValidateReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockRedis.AssertNumberOfCalls(suite.T(), "ScriptLoad", 1)
+	suite.mockRedis.AssertNumberOfCalls(suite.T(), "EvalSha", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestValidatePassErrTooManyDevices() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "pass").Return(getMockPass(time.Now()), nil)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive)}))
+	suite.onClaimSlots().Return(redis.NewCmdResult(int64(1), nil))
+
+	// Act
+	result, err := suite.usecase.ValidatePass(suite.ctx, getMockValidateReq())
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.TooManyRequest("access pass is already used on 3 devices"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestValidatePassErrTooManySessions() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "pass").Return(getMockPass(time.Now()), nil)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive)}))
+	suite.onClaimSlots().Return(redis.NewCmdResult(int64(2), nil))
+
+	// Act
+	result, err := suite.usecase.ValidatePass(suite.ctx, getMockValidateReq())
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.TooManyRequest("at most 1 sessions can watch with this access pass at once"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestValidatePassErrRevoked() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "pass").Return(getMockPass(time.Now()), nil)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusRevoked)}))
+
+	// Act
+	result, err := suite.usecase.ValidatePass(suite.ctx, getMockValidateReq())
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.ForbiddenError("access pass has been revoked"), err)
+	suite.mockRedis.AssertNumberOfCalls(suite.T(), "EvalSha", 0)
+}
+
+func (suite *CommandUsecaseTestSuite) TestValidatePassErrNotValidYet() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "pass").Return(getMockPass(time.Now().Add(24*time.Hour)), nil)
+
+	// Act
+	result, err := suite.usecase.ValidatePass(suite.ctx, getMockValidateReq())
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.ForbiddenError("access pass is not valid yet"), err)
+	suite.mockEticketRepositoryQuery.AssertNotCalled(suite.T(), "FindIssuedTicketById", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestValidatePassErrGateQrCode() {
+	// Arrange
+	gateQr := getMockPass(time.Now())
+	gateQr.Purpose = ""
+	suite.mockTicketSigner.On("VerifyTicket", "pass").Return(gateQr, nil)
+
+	// Act
+	result, err := suite.usecase.ValidatePass(suite.ctx, getMockValidateReq())
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.ForbiddenError("invalid access pass"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRevokePass() {
+	// Arrange
+	suite.mockRedis.On("Del", mock.Anything, "ACCESS-DEVICE:{issued-id}", "ACCESS-SESSION:{issued-id}").
+		Return(redis.NewIntResult(2, nil))
+
+	// Act
+	err := suite.usecase.RevokePass(suite.ctx, "issued-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-access-revoked", mock.Anything, mock.Anything)
+}
+
+// onClaimSlots expects the device and the session slot of the pass to be claimed together
+func (suite *CommandUsecaseTestSuite) onClaimSlots() *mock.Call {
+	return suite.mockRedis.On("EvalSha", mock.Anything, mock.Anything, []string{"ACCESS-DEVICE:{issued-id}", "ACCESS-SESSION:{issued-id}"},
+		mock.Anything, "device-id", mock.Anything, constants.AccessPassMaxDevices,
+		"session-id", mock.Anything, constants.AccessPassMaxSessions)
+}
+
+func getMockIssuedTicket(status string) *eticketEntity.IssuedTicket {
+	return &eticketEntity.IssuedTicket{
+		IssuedTicketId: "issued-id",
+		OrderId:        "order-id",
+		UserId:         "user-id",
+		TicketId:       "ticket-id",
+		EventId:        "event-id",
+		TicketType:     constants.TicketTypeOnline,
+		CountryCode:    "ID",
+		Status:         status,
+		QrVersion:      2,
+	}
+}
+
+func getMockPass(eventDate time.Time) *helpers.TicketPayload {
+	return &helpers.TicketPayload{
+		TicketId:   "issued-id",
+		EventId:    "event-id",
+		TicketType: constants.TicketTypeOnline,
+		UserId:     "user-id",
+		Version:    2,
+		Purpose:    constants.AccessPassPurposeStream,
+		NotBefore:  eventDate.Add(-constants.AccessPassOpenBefore).Unix(),
+		ExpiresAt:  eventDate.Add(constants.AccessPassCloseAfter).Unix(),
+	}
+}
+
+func getMockValidateReq() request.ValidateReq {
+	return request.ValidateReq{
+		Token:     "pass",
+		DeviceId:  "device-id",
+		SessionId: "session-id",
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/access"
+	"ticket-service/internal/modules/access/models/entity"
+	"ticket-service/internal/modules/access/models/request"
+	"ticket-service/internal/modules/access/models/response"
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	accessRepositoryQuery  access.MongodbRepositoryQuery
+	eticketRepositoryQuery eticket.MongodbRepositoryQuery
+	logger                 log.Logger
+}
+
+func NewQueryUsecase(amq access.MongodbRepositoryQuery, emq eticket.MongodbRepositoryQuery, log log.Logger) access.UsecaseQuery {
+	return queryUsecase{
+		accessRepositoryQuery:  amq,
+		eticketRepositoryQuery: emq,
+		logger:                 log,
+	}
+}
+
+// FindPass returns the pass signed for the owner when the ticket was issued or last changed hands
+func (q queryUsecase) FindPass(origCtx context.Context, payload request.PassReq) (*response.Pass, error) {
+	domain := "accessUsecase-FindPass"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	ticketData := <-q.eticketRepositoryQuery.FindIssuedTicketById(ctx, payload.IssuedTicketId)
+	if ticketData.Error != nil {
+		msg := "Error query issued ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", ticketData.Error))
+		return nil, ticketData.Error
+	}
+
+	if ticketData.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	issuedTicket, ok := ticketData.Data.(*eticketEntity.IssuedTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	// other users' tickets look exactly like missing ones
+	if issuedTicket.UserId != payload.UserId {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	if issuedTicket.TicketType != constants.TicketTypeOnline {
+		return nil, errors.UnprocessableEntity("only online tickets have an access pass")
+	}
+
+	if issuedTicket.Status != constants.IssuedTicketStatusActive {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("ticket with status %s has no access pass", issuedTicket.Status))
+	}
+
+	passData := <-q.accessRepositoryQuery.FindPassByIssuedTicketId(ctx, issuedTicket.IssuedTicketId)
+	if passData.Error != nil {
+		msg := "Error query access pass"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", passData.Error))
+		return nil, passData.Error
+	}
+
+	if passData.Data == nil {
+		return nil, errors.NotFound("access pass not found")
+	}
+
+	pass, ok := passData.Data.(*entity.Pass)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	// a pass left from before the ticket changed hands is not handed to the new owner
+	if pass.QrVersion != issuedTicket.QrVersion {
+		return nil, errors.NotFound("access pass not found")
+	}
+
+	return mapPass(*pass), nil
+}
@@ -0,0 +1,116 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"ticket-service/internal/modules/access"
+	accessEntity "ticket-service/internal/modules/access/models/entity"
+	"ticket-service/internal/modules/access/models/request"
+	uc "ticket-service/internal/modules/access/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockaccess "ticket-service/mocks/modules/access"
+	mocketicket "ticket-service/mocks/modules/eticket"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryUsecaseTestSuite struct {
+	suite.Suite
+	mockAccessRepositoryQuery  *mockaccess.MongodbRepositoryQuery
+	mockEticketRepositoryQuery *mocketicket.MongodbRepositoryQuery
+	mockLogger                 *mocklog.Logger
+	usecase                    access.UsecaseQuery
+	ctx                        context.Context
+}
+
+func (suite *QueryUsecaseTestSuite) SetupTest() {
+	suite.mockAccessRepositoryQuery = &mockaccess.MongodbRepositoryQuery{}
+	suite.mockEticketRepositoryQuery = &mocketicket.MongodbRepositoryQuery{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewQueryUsecase(
+		suite.mockAccessRepositoryQuery,
+		suite.mockEticketRepositoryQuery,
+		suite.mockLogger,
+	)
+}
+
+func TestQueryUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryUsecaseTestSuite))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindPass() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive)}))
+	suite.mockAccessRepositoryQuery.On("FindPassByIssuedTicketId", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockStoredPass(2)}))
+
+	// Act
+	result, err := suite.usecase.FindPass(suite.ctx, request.PassReq{UserId: "user-id", IssuedTicketId: "issued-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "signed-pass", result.Token)
+	assert.Equal(suite.T(), constants.AccessPassMaxSessions, result.MaxSessions)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindPassErrOtherUser() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive)}))
+
+	// Act
+	result, err := suite.usecase.FindPass(suite.ctx, request.PassReq{UserId: "other-user-id", IssuedTicketId: "issued-id"})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.NotFound("ticket not found"), err)
+	suite.mockAccessRepositoryQuery.AssertNotCalled(suite.T(), "FindPassByIssuedTicketId", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindPassErrPreviousOwner() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive)}))
+	suite.mockAccessRepositoryQuery.On("FindPassByIssuedTicketId", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockStoredPass(1)}))
+
+	// Act
+	result, err := suite.usecase.FindPass(suite.ctx, request.PassReq{UserId: "user-id", IssuedTicketId: "issued-id"})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.NotFound("access pass not found"), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindPassErrNotIssued() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive)}))
+	suite.mockAccessRepositoryQuery.On("FindPassByIssuedTicketId", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	result, err := suite.usecase.FindPass(suite.ctx, request.PassReq{UserId: "user-id", IssuedTicketId: "issued-id"})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.NotFound("access pass not found"), err)
+}
+
+func getMockStoredPass(qrVersion int) *accessEntity.Pass {
+	return &accessEntity.Pass{
+		IssuedTicketId: "issued-id",
+		UserId:         "user-id",
+		EventId:        "event-id",
+		QrVersion:      qrVersion,
+		Token:          "signed-pass",
+	}
+}
@@ -0,0 +1,45 @@
+package ballot
+
+import (
+	"context"
+	"ticket-service/internal/modules/ballot/models/entity"
+	"ticket-service/internal/modules/ballot/models/request"
+	"ticket-service/internal/modules/ballot/models/response"
+	orderResponse "ticket-service/internal/modules/order/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"time"
+)
+
+type UsecaseCommand interface {
+	UpsertBallot(origCtx context.Context, payload request.BallotReq) (*response.Ballot, error)
+	EnterBallot(origCtx context.Context, payload request.EntryReq) (*response.Entry, error)
+	DrawBallot(origCtx context.Context, payload request.DrawReq) (*response.Draw, error)
+	ClaimEntry(origCtx context.Context, payload request.MyEntryReq) (*orderResponse.Reservation, error)
+	CloseBallot(origCtx context.Context, ballotId string) (*response.Ballot, error)
+}
+
+type UsecaseQuery interface {
+	FindMyEntry(origCtx context.Context, payload request.MyEntryReq) (*response.Entry, error)
+	FindDraw(origCtx context.Context, ballotId string, round int) (*response.Draw, error)
+	CheckDirectSale(origCtx context.Context, eventId string) error
+}
+
+type MongodbRepositoryQuery interface {
+	FindBallotById(ctx context.Context, ballotId string) <-chan wrapper.Result
+	FindActiveBallotByEventId(ctx context.Context, eventId string, now time.Time) <-chan wrapper.Result
+	FindEntry(ctx context.Context, ballotId string, userId string) <-chan wrapper.Result
+	FindEntriesByStatus(ctx context.Context, ballotId string, status string) <-chan wrapper.Result
+	FindDraw(ctx context.Context, ballotId string, round int) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertBallot(ctx context.Context, ballot entity.Ballot) <-chan wrapper.Result
+	IncreaseBallotRound(ctx context.Context, ballotId string, round int) <-chan wrapper.Result
+	UpdateBallotClosed(ctx context.Context, ballotId string) <-chan wrapper.Result
+	InsertOneEntry(ctx context.Context, entry entity.BallotEntry) <-chan wrapper.Result
+	UpdateEntryStatus(ctx context.Context, entryId string, from string, to string) <-chan wrapper.Result
+	UpdateEntryWon(ctx context.Context, entryId string, round int, claimBy time.Time) <-chan wrapper.Result
+	UpdateEntryClaimed(ctx context.Context, entryId string, orderId string, now time.Time) <-chan wrapper.Result
+	InsertOneDraw(ctx context.Context, draw entity.BallotDraw) <-chan wrapper.Result
+	CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/ballot"
+	"ticket-service/internal/modules/ballot/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type BallotHttpHandler struct {
+	BallotUsecaseCommand ballot.UsecaseCommand
+	BallotUsecaseQuery   ballot.UsecaseQuery
+	Logger               log.Logger
+	Validator            *validator.Validate
+}
+
+func InitBallotHttpHandler(app *fiber.App, buc ballot.UsecaseCommand, buq ballot.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &BallotHttpHandler{
+		BallotUsecaseCommand: buc,
+		BallotUsecaseQuery:   buq,
+		Logger:               log,
+		Validator:            validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/ballots")
+
+	route.Post("/v1/:id/entries", middlewares.VerifyBearer(), handler.EnterBallot)
+	route.Get("/v1/:id/entries/me", middlewares.VerifyBearer(), handler.GetMyEntry)
+	route.Post("/v1/:id/entries/me/claim", middlewares.VerifyBearer(), handler.ClaimEntry)
+	route.Put("/v1", middlewares.VerifyBearer(), adminRole, handler.UpsertBallot)
+	route.Post("/v1/:id/draw", middlewares.VerifyBearer(), adminRole, handler.DrawBallot)
+	route.Get("/v1/:id/draws/:round", middlewares.VerifyBearer(), adminRole, handler.GetDraw)
+	route.Post("/v1/:id/close", middlewares.VerifyBearer(), adminRole, handler.CloseBallot)
+}
+
+func (b BallotHttpHandler) EnterBallot(c *fiber.Ctx) error {
+	req := new(request.EntryReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, b.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := b.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, b.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, b.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	req.BallotId = c.Params("id")
+	resp, err := b.BallotUsecaseCommand.EnterBallot(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, b.Logger, err)
+	}
+	return helpers.RespSuccess(c, b.Logger, resp, "Enter ballot success")
+}
+
+func (b BallotHttpHandler) GetMyEntry(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, b.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.MyEntryReq{
+		UserId:   userId,
+		BallotId: c.Params("id"),
+	}
+	resp, err := b.BallotUsecaseQuery.FindMyEntry(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, b.Logger, err)
+	}
+	return helpers.RespSuccess(c, b.Logger, resp, "Get ballot entry success")
+}
+
+func (b BallotHttpHandler) ClaimEntry(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, b.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.MyEntryReq{
+		UserId:   userId,
+		BallotId: c.Params("id"),
+	}
+	resp, err := b.BallotUsecaseCommand.ClaimEntry(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, b.Logger, err)
+	}
+	return helpers.RespSuccess(c, b.Logger, resp, "Claim ballot entry success")
+}
+
+func (b BallotHttpHandler) UpsertBallot(c *fiber.Ctx) error {
+	req := new(request.BallotReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, b.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := b.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, b.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := b.BallotUsecaseCommand.UpsertBallot(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, b.Logger, err)
+	}
+	return helpers.RespSuccess(c, b.Logger, resp, "Update ballot success")
+}
+
+func (b BallotHttpHandler) DrawBallot(c *fiber.Ctx) error {
+	req := new(request.DrawReq)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(req); err != nil {
+			return helpers.RespError(c, b.Logger, errors.BadRequest("bad request"))
+		}
+	}
+
+	if err := b.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, b.Logger, errors.BadRequest(err.Error()))
+	}
+	req.BallotId = c.Params("id")
+	resp, err := b.BallotUsecaseCommand.DrawBallot(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, b.Logger, err)
+	}
+	return helpers.RespSuccess(c, b.Logger, resp, "Draw ballot success")
+}
+
+func (b BallotHttpHandler) GetDraw(c *fiber.Ctx) error {
+	round, err := c.ParamsInt("round")
+	if err != nil || round < 1 {
+		return helpers.RespError(c, b.Logger, errors.BadRequest("invalid round"))
+	}
+	resp, err := b.BallotUsecaseQuery.FindDraw(c.Context(), c.Params("id"), round)
+	if err != nil {
+		return helpers.RespCustomError(c, b.Logger, err)
+	}
+	return helpers.RespSuccess(c, b.Logger, resp, "Get ballot draw success")
+}
+
+func (b BallotHttpHandler) CloseBallot(c *fiber.Ctx) error {
+	resp, err := b.BallotUsecaseCommand.CloseBallot(c.Context(), c.Params("id"))
+	if err != nil {
+		return helpers.RespCustomError(c, b.Logger, err)
+	}
+	return helpers.RespSuccess(c, b.Logger, resp, "Close ballot success")
+}
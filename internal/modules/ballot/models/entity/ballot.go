@@ -0,0 +1,53 @@
+package entity
+
+import "time"
+
+// Ballot replaces the race for tickets of an event, from EntryStartAt until it is closed tickets
+// of the event are only sold to entries that won a draw
+type Ballot struct {
+	BallotId     string    `json:"ballotId" bson:"ballotId"`
+	EventId      string    `json:"eventId" bson:"eventId"`
+	Name         string    `json:"name" bson:"name"`
+	EntryStartAt time.Time `json:"entryStartAt" bson:"entryStartAt"`
+	EntryEndAt   time.Time `json:"entryEndAt" bson:"entryEndAt"`
+	MaxPerUser   int       `json:"maxPerUser" bson:"maxPerUser"`
+	ClaimMinutes int       `json:"claimMinutes" bson:"claimMinutes"`
+	Round        int       `json:"round" bson:"round"`
+	Status       string    `json:"status" bson:"status"`
+	CreatedAt    time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+type BallotEntry struct {
+	EntryId     string    `json:"entryId" bson:"entryId"`
+	BallotId    string    `json:"ballotId" bson:"ballotId"`
+	EventId     string    `json:"eventId" bson:"eventId"`
+	UserId      string    `json:"userId" bson:"userId"`
+	TicketId    string    `json:"ticketId" bson:"ticketId"`
+	TicketType  string    `json:"ticketType" bson:"ticketType"`
+	CountryCode string    `json:"countryCode" bson:"countryCode"`
+	Quantity    int       `json:"quantity" bson:"quantity"`
+	Status      string    `json:"status" bson:"status"`
+	Round       int       `json:"round,omitempty" bson:"round,omitempty"`
+	ClaimBy     time.Time `json:"claimBy,omitempty" bson:"claimBy,omitempty"`
+	OrderId     string    `json:"orderId,omitempty" bson:"orderId,omitempty"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// BallotDraw is the audit record of one round, it keeps every input of the draw so the result can be recomputed
+type BallotDraw struct {
+	BallotId  string         `json:"ballotId" bson:"ballotId"`
+	Round     int            `json:"round" bson:"round"`
+	Seed      string         `json:"seed" bson:"seed"`
+	Available map[string]int `json:"available" bson:"available"`
+	Entries   []DrawEntry    `json:"entries" bson:"entries"`
+	Winners   []string       `json:"winners" bson:"winners"`
+	DrawnAt   time.Time      `json:"drawnAt" bson:"drawnAt"`
+}
+
+type DrawEntry struct {
+	EntryId  string `json:"entryId" bson:"entryId"`
+	TicketId string `json:"ticketId" bson:"ticketId"`
+	Quantity int    `json:"quantity" bson:"quantity"`
+}
@@ -0,0 +1,31 @@
+package request
+
+import "time"
+
+type BallotReq struct {
+	BallotId     string    `json:"ballotId"`
+	EventId      string    `json:"eventId" validate:"required"`
+	Name         string    `json:"name" validate:"required"`
+	EntryStartAt time.Time `json:"entryStartAt" validate:"required"`
+	EntryEndAt   time.Time `json:"entryEndAt" validate:"required,gtfield=EntryStartAt"`
+	MaxPerUser   int       `json:"maxPerUser" validate:"required,min=1"`
+	ClaimMinutes int       `json:"claimMinutes" validate:"required,min=1"`
+}
+
+type EntryReq struct {
+	UserId      string `json:"-"`
+	BallotId    string `json:"-"`
+	CountryCode string `json:"countryCode" validate:"required"`
+	TicketType  string `json:"ticketType" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required,min=1"`
+}
+
+type MyEntryReq struct {
+	UserId   string `json:"-"`
+	BallotId string `json:"-"`
+}
+
+type DrawReq struct {
+	BallotId string `json:"-"`
+	Seed     string `json:"seed" validate:"max=128"`
+}
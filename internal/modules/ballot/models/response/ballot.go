@@ -0,0 +1,38 @@
+package response
+
+import "time"
+
+type Ballot struct {
+	BallotId     string    `json:"ballotId"`
+	EventId      string    `json:"eventId"`
+	Name         string    `json:"name"`
+	EntryStartAt time.Time `json:"entryStartAt"`
+	EntryEndAt   time.Time `json:"entryEndAt"`
+	MaxPerUser   int       `json:"maxPerUser"`
+	ClaimMinutes int       `json:"claimMinutes"`
+	Round        int       `json:"round"`
+	Status       string    `json:"status"`
+}
+
+type Entry struct {
+	EntryId     string    `json:"entryId"`
+	BallotId    string    `json:"ballotId"`
+	TicketType  string    `json:"ticketType"`
+	CountryCode string    `json:"countryCode"`
+	Quantity    int       `json:"quantity"`
+	Status      string    `json:"status"`
+	Round       int       `json:"round,omitempty"`
+	ClaimBy     time.Time `json:"claimBy,omitempty"`
+	OrderId     string    `json:"orderId,omitempty"`
+}
+
+type Draw struct {
+	BallotId     string         `json:"ballotId"`
+	Round        int            `json:"round"`
+	Seed         string         `json:"seed"`
+	Available    map[string]int `json:"available"`
+	TotalEntries int            `json:"totalEntries"`
+	Winners      []string       `json:"winners"`
+	DrawnAt      time.Time      `json:"drawnAt"`
+	Reproducible bool           `json:"reproducible"`
+}
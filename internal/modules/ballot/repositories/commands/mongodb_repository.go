@@ -0,0 +1,269 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/ballot"
+	"ticket-service/internal/modules/ballot/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) ballot.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// UpsertBallot writes the configuration of a ballot and keeps its round and status
+func (c commandMongodbRepository) UpsertBallot(ctx context.Context, ballot entity.Ballot) <-chan wrapper.Result {
+	var updated entity.Ballot
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &updated,
+			CollectionName: "ballots",
+			Filter: bson.M{
+				"ballotId": ballot.BallotId,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"eventId":      ballot.EventId,
+					"name":         ballot.Name,
+					"entryStartAt": ballot.EntryStartAt,
+					"entryEndAt":   ballot.EntryEndAt,
+					"maxPerUser":   ballot.MaxPerUser,
+					"claimMinutes": ballot.ClaimMinutes,
+					"updatedAt":    ballot.UpdatedAt,
+				},
+				"$setOnInsert": bson.M{
+					"round":     0,
+					"status":    constants.BallotStatusOpen,
+					"createdAt": ballot.UpdatedAt,
+				},
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// IncreaseBallotRound starts the round after round, Data is nil when another draw started it first or the ballot is closed
+func (c commandMongodbRepository) IncreaseBallotRound(ctx context.Context, ballotId string, round int) <-chan wrapper.Result {
+	var ballot entity.Ballot
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &ballot,
+			CollectionName: "ballots",
+			Filter: bson.M{
+				"ballotId": ballotId,
+				"round":    round,
+				"status":   constants.BallotStatusOpen,
+			},
+			Update: bson.M{
+				"$inc": bson.M{"round": 1},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpdateBallotClosed(ctx context.Context, ballotId string) <-chan wrapper.Result {
+	var ballot entity.Ballot
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &ballot,
+			CollectionName: "ballots",
+			Filter: bson.M{
+				"ballotId": ballotId,
+				"status":   constants.BallotStatusOpen,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.BallotStatusClosed,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// InsertOneEntry stores an entry, a second entry of the same user fails on the unique index
+func (c commandMongodbRepository) InsertOneEntry(ctx context.Context, entry entity.BallotEntry) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "ballot-entries",
+			Document:       entry,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateEntryStatus moves an entry from one status to another, Data is nil when it is not in from anymore
+func (c commandMongodbRepository) UpdateEntryStatus(ctx context.Context, entryId string, from string, to string) <-chan wrapper.Result {
+	var entry entity.BallotEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &entry,
+			CollectionName: "ballot-entries",
+			Filter: bson.M{
+				"entryId": entryId,
+				"status":  from,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    to,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpdateEntryWon(ctx context.Context, entryId string, round int, claimBy time.Time) <-chan wrapper.Result {
+	var entry entity.BallotEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &entry,
+			CollectionName: "ballot-entries",
+			Filter: bson.M{
+				"entryId": entryId,
+				"status":  constants.BallotEntryStatusEntered,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.BallotEntryStatusWon,
+					"round":     round,
+					"claimBy":   claimBy,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateEntryClaimed spends the purchase right of a winning entry, Data is nil when it was claimed already or has expired
+func (c commandMongodbRepository) UpdateEntryClaimed(ctx context.Context, entryId string, orderId string, now time.Time) <-chan wrapper.Result {
+	var entry entity.BallotEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &entry,
+			CollectionName: "ballot-entries",
+			Filter: bson.M{
+				"entryId": entryId,
+				"status":  constants.BallotEntryStatusWon,
+				"claimBy": bson.M{"$gt": now},
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.BallotEntryStatusClaimed,
+					"orderId":   orderId,
+					"updatedAt": now,
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// InsertOneDraw stores the audit record of a round, a replayed round fails on the unique index
+func (c commandMongodbRepository) InsertOneDraw(ctx context.Context, draw entity.BallotDraw) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "ballot-draws",
+			Document:       draw,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CreateUniqueIndexes keeps one entry per user and one draw record per round of a ballot,
+// a second entry or a replayed round fails on them instead of being stored twice
+func (c commandMongodbRepository) CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		for _, index := range []mongodb.CreateIndex{
+			{
+				CollectionName: "ballots",
+				Keys:           bson.D{{Key: "ballotId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+			{
+				CollectionName: "ballot-entries",
+				Keys:           bson.D{{Key: "ballotId", Value: 1}, {Key: "userId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+			{
+				CollectionName: "ballot-draws",
+				Keys:           bson.D{{Key: "ballotId", Value: 1}, {Key: "round", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+		} {
+			resp := <-c.mongoDb.CreateIndex(index, ctx)
+			if resp.Error != nil {
+				output <- resp
+				return
+			}
+		}
+		output <- wrapper.Result{Data: "Success create index"}
+	}()
+
+	return output
+}
@@ -0,0 +1,131 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/ballot"
+	"ticket-service/internal/modules/ballot/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) ballot.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindBallotById(ctx context.Context, ballotId string) <-chan wrapper.Result {
+	var ballot entity.Ballot
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &ballot,
+			CollectionName: "ballots",
+			Filter: bson.M{
+				"ballotId": ballotId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindActiveBallotByEventId finds the ballot that holds back the sale of an event, one that has opened and is not closed yet
+func (q queryMongodbRepository) FindActiveBallotByEventId(ctx context.Context, eventId string, now time.Time) <-chan wrapper.Result {
+	var ballot entity.Ballot
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &ballot,
+			CollectionName: "ballots",
+			Filter: bson.M{
+				"eventId":      eventId,
+				"status":       constants.BallotStatusOpen,
+				"entryStartAt": bson.M{"$lte": now},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindEntry(ctx context.Context, ballotId string, userId string) <-chan wrapper.Result {
+	var entry entity.BallotEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &entry,
+			CollectionName: "ballot-entries",
+			Filter: bson.M{
+				"ballotId": ballotId,
+				"userId":   userId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindEntriesByStatus(ctx context.Context, ballotId string, status string) <-chan wrapper.Result {
+	var entries []entity.BallotEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &entries,
+			CollectionName: "ballot-entries",
+			Filter: bson.M{
+				"ballotId": ballotId,
+				"status":   status,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "entryId",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindDraw(ctx context.Context, ballotId string, round int) <-chan wrapper.Result {
+	var draw entity.BallotDraw
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &draw,
+			CollectionName: "ballot-draws",
+			Filter: bson.M{
+				"ballotId": ballotId,
+				"round":    round,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,501 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ticket-service/internal/modules/ballot"
+	"ticket-service/internal/modules/ballot/models/entity"
+	"ticket-service/internal/modules/ballot/models/request"
+	"ticket-service/internal/modules/ballot/models/response"
+	"ticket-service/internal/modules/order"
+	orderDto "ticket-service/internal/modules/order/models/dto"
+	orderResponse "ticket-service/internal/modules/order/models/response"
+	"ticket-service/internal/modules/ticket"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	ticketRequest "ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	ballotRepositoryQuery   ballot.MongodbRepositoryQuery
+	ballotRepositoryCommand ballot.MongodbRepositoryCommand
+	ticketRepositoryQuery   ticket.MongodbRepositoryQuery
+	orderUsecaseCommand     order.UsecaseCommand
+	kafkaProducer           kafkaConfluent.Producer
+	logger                  log.Logger
+}
+
+func NewCommandUsecase(bmq ballot.MongodbRepositoryQuery, bmc ballot.MongodbRepositoryCommand, tmq ticket.MongodbRepositoryQuery,
+	ouc order.UsecaseCommand, kp kafkaConfluent.Producer, log log.Logger) ballot.UsecaseCommand {
+	return commandUsecase{
+		ballotRepositoryQuery:   bmq,
+		ballotRepositoryCommand: bmc,
+		ticketRepositoryQuery:   tmq,
+		orderUsecaseCommand:     ouc,
+		kafkaProducer:           kp,
+		logger:                  log,
+	}
+}
+
+func (c commandUsecase) UpsertBallot(origCtx context.Context, payload request.BallotReq) (*response.Ballot, error) {
+	domain := "ballotUsecase-UpsertBallot"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	ballotId := payload.BallotId
+	if ballotId == "" {
+		ballotId = uuid.NewString()
+	}
+
+	ballotData := entity.Ballot{
+		BallotId:     ballotId,
+		EventId:      payload.EventId,
+		Name:         payload.Name,
+		EntryStartAt: payload.EntryStartAt,
+		EntryEndAt:   payload.EntryEndAt,
+		MaxPerUser:   payload.MaxPerUser,
+		ClaimMinutes: payload.ClaimMinutes,
+		Status:       constants.BallotStatusOpen,
+		UpdatedAt:    time.Now(),
+	}
+	resp := <-c.ballotRepositoryCommand.UpsertBallot(ctx, ballotData)
+	if resp.Error != nil {
+		msg := "Error upsert ballot"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if updated, ok := resp.Data.(*entity.Ballot); ok {
+		ballotData = *updated
+	}
+
+	return mapBallot(ballotData), nil
+}
+
+func (c commandUsecase) EnterBallot(origCtx context.Context, payload request.EntryReq) (*response.Entry, error) {
+	domain := "ballotUsecase-EnterBallot"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	ballotData, err := c.findBallot(ctx, payload.BallotId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if ballotData.Status != constants.BallotStatusOpen || now.Before(ballotData.EntryStartAt) || now.After(ballotData.EntryEndAt) {
+		return nil, errors.UnprocessableEntity("ballot entry window is closed")
+	}
+
+	if payload.Quantity > ballotData.MaxPerUser {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("maximum %d tickets per entry", ballotData.MaxPerUser))
+	}
+
+	existing := <-c.ballotRepositoryQuery.FindEntry(ctx, payload.BallotId, payload.UserId)
+	if existing.Error != nil {
+		msg := "Error query ballot entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", existing.Error))
+		return nil, existing.Error
+	}
+
+	if existing.Data != nil {
+		return nil, errors.Conflict("you have already entered this ballot")
+	}
+
+	ticketDetail, err := c.findTicket(ctx, ballotData.EventId, payload.CountryCode, payload.TicketType)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := entity.BallotEntry{
+		EntryId:     uuid.NewString(),
+		BallotId:    ballotData.BallotId,
+		EventId:     ballotData.EventId,
+		UserId:      payload.UserId,
+		TicketId:    ticketDetail.TicketId,
+		TicketType:  payload.TicketType,
+		CountryCode: payload.CountryCode,
+		Quantity:    payload.Quantity,
+		Status:      constants.BallotEntryStatusEntered,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	insert := <-c.ballotRepositoryCommand.InsertOneEntry(ctx, entry)
+	if insert.Error != nil {
+		msg := "Error insert ballot entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		return nil, insert.Error
+	}
+
+	return mapEntry(entry), nil
+}
+
+// DrawBallot runs the next round: winners of earlier rounds that did not claim in time give their tickets back,
+// then every entry still in is ranked by the seeded draw against what is left of each ticket
+func (c commandUsecase) DrawBallot(origCtx context.Context, payload request.DrawReq) (*response.Draw, error) {
+	domain := "ballotUsecase-DrawBallot"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	ballotData, err := c.findBallot(ctx, payload.BallotId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if ballotData.Status != constants.BallotStatusOpen {
+		return nil, errors.UnprocessableEntity("ballot is closed")
+	}
+
+	if now.Before(ballotData.EntryEndAt) {
+		return nil, errors.UnprocessableEntity("ballot entry window is still open")
+	}
+
+	started := <-c.ballotRepositoryCommand.IncreaseBallotRound(ctx, ballotData.BallotId, ballotData.Round)
+	if started.Error != nil {
+		msg := "Error increase ballot round"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", started.Error))
+		return nil, started.Error
+	}
+
+	if started.Data == nil {
+		return nil, errors.Conflict("another draw is running for this ballot")
+	}
+	round := ballotData.Round + 1
+
+	outstanding, err := c.expireWinners(ctx, ballotData.BallotId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := c.findEntries(ctx, ballotData.BallotId, constants.BallotEntryStatusEntered)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]int)
+	drawEntries := make([]entity.DrawEntry, 0)
+	for _, value := range entries {
+		if _, ok := available[value.TicketId]; !ok {
+			ticketDetail, err := c.findTicket(ctx, value.EventId, value.CountryCode, value.TicketType)
+			if err != nil {
+				return nil, err
+			}
+			available[value.TicketId] = ticketDetail.TotalRemaining - outstanding[value.TicketId]
+			if available[value.TicketId] < 0 {
+				available[value.TicketId] = 0
+			}
+		}
+		drawEntries = append(drawEntries, entity.DrawEntry{
+			EntryId:  value.EntryId,
+			TicketId: value.TicketId,
+			Quantity: value.Quantity,
+		})
+	}
+
+	seed := payload.Seed
+	if seed == "" {
+		seed = uuid.NewString()
+	}
+	draw := entity.BallotDraw{
+		BallotId:  ballotData.BallotId,
+		Round:     round,
+		Seed:      seed,
+		Available: available,
+		Entries:   drawEntries,
+		Winners:   drawWinners(seed, round, drawEntries, available),
+		DrawnAt:   now,
+	}
+	insert := <-c.ballotRepositoryCommand.InsertOneDraw(ctx, draw)
+	if insert.Error != nil {
+		msg := "Error insert ballot draw"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		return nil, insert.Error
+	}
+
+	// an entry that could not be marked stays in and is drawn again next round, its tickets are not held for it
+	claimBy := now.Add(time.Duration(ballotData.ClaimMinutes) * time.Minute)
+	for _, entryId := range draw.Winners {
+		won := <-c.ballotRepositoryCommand.UpdateEntryWon(ctx, entryId, round, claimBy)
+		if won.Error != nil {
+			msg := "Error update ballot winner"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", won.Error))
+		}
+	}
+
+	drawEvent := map[string]interface{}{
+		"ballotId": draw.BallotId,
+		"eventId":  ballotData.EventId,
+		"round":    draw.Round,
+		"winners":  draw.Winners,
+		"claimBy":  claimBy,
+	}
+	marshaledKafkaData, _ := json.Marshal(drawEvent)
+	topic := "concert-ballot-drawn"
+	c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka ballot drawn, ballot : %s", draw.BallotId), fmt.Sprintf("%+v", drawEvent))
+
+	return mapDraw(draw, true), nil
+}
+
+// ClaimEntry uses the purchase right of a winning entry to reserve its tickets, the order is then paid like any other
+func (c commandUsecase) ClaimEntry(origCtx context.Context, payload request.MyEntryReq) (*orderResponse.Reservation, error) {
+	domain := "ballotUsecase-ClaimEntry"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	entry, err := findEntry(ctx, c.ballotRepositoryQuery, c.logger, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch {
+	case entry.Status == constants.BallotEntryStatusClaimed:
+		return nil, errors.Conflict(fmt.Sprintf("ballot allocation has been claimed with order %s", entry.OrderId))
+	case entry.Status != constants.BallotEntryStatusWon:
+		return nil, errors.UnprocessableEntity("your entry has no purchase right")
+	case !now.Before(entry.ClaimBy):
+		return nil, errors.UnprocessableEntity("your purchase right has expired")
+	}
+
+	orderId := uuid.NewString()
+	claimed := <-c.ballotRepositoryCommand.UpdateEntryClaimed(ctx, entry.EntryId, orderId, now)
+	if claimed.Error != nil {
+		msg := "Error claim ballot entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", claimed.Error))
+		return nil, claimed.Error
+	}
+
+	if claimed.Data == nil {
+		return nil, errors.UnprocessableEntity("your purchase right has expired")
+	}
+
+	reservation, err := c.orderUsecaseCommand.CreateReservation(ctx, orderDto.ReservationReq{
+		UserId:        entry.UserId,
+		OrderId:       orderId,
+		BallotEntryId: entry.EntryId,
+		EventId:       entry.EventId,
+		CountryCode:   entry.CountryCode,
+		TicketType:    entry.TicketType,
+		Quantity:      entry.Quantity,
+	})
+	if err != nil {
+		// the right is given back so the user can try again before it expires
+		reverted := <-c.ballotRepositoryCommand.UpdateEntryStatus(ctx, entry.EntryId, constants.BallotEntryStatusClaimed, constants.BallotEntryStatusWon)
+		if reverted.Error != nil {
+			msg := "Error revert ballot claim"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", reverted.Error))
+		}
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// CloseBallot ends the ballot, entries that never won lose and the event goes back to normal sale
+func (c commandUsecase) CloseBallot(origCtx context.Context, ballotId string) (*response.Ballot, error) {
+	domain := "ballotUsecase-CloseBallot"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.ballotRepositoryCommand.UpdateBallotClosed(ctx, ballotId)
+	if resp.Error != nil {
+		msg := "Error close ballot"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("open ballot not found")
+	}
+
+	ballotData, ok := resp.Data.(*entity.Ballot)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	entries, err := c.findEntries(ctx, ballotId, constants.BallotEntryStatusEntered)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range entries {
+		lost := <-c.ballotRepositoryCommand.UpdateEntryStatus(ctx, value.EntryId, constants.BallotEntryStatusEntered, constants.BallotEntryStatusLost)
+		if lost.Error != nil {
+			msg := "Error update ballot entry lost"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", lost.Error))
+		}
+	}
+
+	return mapBallot(*ballotData), nil
+}
+
+// expireWinners ends the purchase right of winners past their claim time and returns,
+// per ticket, how many tickets are still held for winners that can claim
+func (c commandUsecase) expireWinners(ctx context.Context, ballotId string, now time.Time) (map[string]int, error) {
+	winners, err := c.findEntries(ctx, ballotId, constants.BallotEntryStatusWon)
+	if err != nil {
+		return nil, err
+	}
+
+	outstanding := make(map[string]int)
+	for _, value := range winners {
+		if now.Before(value.ClaimBy) {
+			outstanding[value.TicketId] += value.Quantity
+			continue
+		}
+		expired := <-c.ballotRepositoryCommand.UpdateEntryStatus(ctx, value.EntryId, constants.BallotEntryStatusWon, constants.BallotEntryStatusExpired)
+		if expired.Error != nil {
+			msg := "Error expire ballot winner"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", expired.Error))
+			return nil, expired.Error
+		}
+	}
+	return outstanding, nil
+}
+
+func (c commandUsecase) findBallot(ctx context.Context, ballotId string) (*entity.Ballot, error) {
+	resp := <-c.ballotRepositoryQuery.FindBallotById(ctx, ballotId)
+	if resp.Error != nil {
+		msg := "Error query ballot"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ballot not found")
+	}
+
+	ballotData, ok := resp.Data.(*entity.Ballot)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return ballotData, nil
+}
+
+func (c commandUsecase) findEntries(ctx context.Context, ballotId string, status string) ([]entity.BallotEntry, error) {
+	resp := <-c.ballotRepositoryQuery.FindEntriesByStatus(ctx, ballotId, status)
+	if resp.Error != nil {
+		msg := "Error query ballot entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return []entity.BallotEntry{}, nil
+	}
+
+	entries, ok := resp.Data.(*[]entity.BallotEntry)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return *entries, nil
+}
+
+func (c commandUsecase) findTicket(ctx context.Context, eventId string, countryCode string, ticketType string) (*ticketEntity.Ticket, error) {
+	resp := <-c.ticketRepositoryQuery.FindTicketByType(ctx, ticketRequest.TicketTypeReq{
+		EventId:     eventId,
+		CountryCode: countryCode,
+		TicketType:  ticketType,
+	})
+	if resp.Error != nil {
+		msg := "Error query ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	ticketDetail, ok := resp.Data.(*ticketEntity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return ticketDetail, nil
+}
+
+func findEntry(ctx context.Context, repository ballot.MongodbRepositoryQuery, logger log.Logger, payload request.MyEntryReq) (*entity.BallotEntry, error) {
+	resp := <-repository.FindEntry(ctx, payload.BallotId, payload.UserId)
+	if resp.Error != nil {
+		msg := "Error query ballot entry"
+		logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ballot entry not found")
+	}
+
+	entry, ok := resp.Data.(*entity.BallotEntry)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return entry, nil
+}
+
+func mapBallot(ballotData entity.Ballot) *response.Ballot {
+	return &response.Ballot{
+		BallotId:     ballotData.BallotId,
+		EventId:      ballotData.EventId,
+		Name:         ballotData.Name,
+		EntryStartAt: ballotData.EntryStartAt,
+		EntryEndAt:   ballotData.EntryEndAt,
+		MaxPerUser:   ballotData.MaxPerUser,
+		ClaimMinutes: ballotData.ClaimMinutes,
+		Round:        ballotData.Round,
+		Status:       ballotData.Status,
+	}
+}
+
+func mapEntry(entry entity.BallotEntry) *response.Entry {
+	return &response.Entry{
+		EntryId:     entry.EntryId,
+		BallotId:    entry.BallotId,
+		TicketType:  entry.TicketType,
+		CountryCode: entry.CountryCode,
+		Quantity:    entry.Quantity,
+		Status:      entry.Status,
+		Round:       entry.Round,
+		ClaimBy:     entry.ClaimBy,
+		OrderId:     entry.OrderId,
+	}
+}
+
+func mapDraw(draw entity.BallotDraw, reproducible bool) *response.Draw {
+	return &response.Draw{
+		BallotId:     draw.BallotId,
+		Round:        draw.Round,
+		Seed:         draw.Seed,
+		Available:    draw.Available,
+		TotalEntries: len(draw.Entries),
+		Winners:      draw.Winners,
+		DrawnAt:      draw.DrawnAt,
+		Reproducible: reproducible,
+	}
+}
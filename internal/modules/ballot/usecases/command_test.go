@@ -0,0 +1,384 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ticket-service/internal/modules/ballot"
+	"ticket-service/internal/modules/ballot/models/entity"
+	"ticket-service/internal/modules/ballot/models/request"
+	uc "ticket-service/internal/modules/ballot/usecases"
+	orderDto "ticket-service/internal/modules/order/models/dto"
+	orderResponse "ticket-service/internal/modules/order/models/response"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	ticketRequest "ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockballot "ticket-service/mocks/modules/ballot"
+	mockorder "ticket-service/mocks/modules/order"
+	mockticket "ticket-service/mocks/modules/ticket"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockBallotRepositoryQuery   *mockballot.MongodbRepositoryQuery
+	mockBallotRepositoryCommand *mockballot.MongodbRepositoryCommand
+	mockTicketRepositoryQuery   *mockticket.MongodbRepositoryQuery
+	mockOrderUsecaseCommand     *mockorder.UsecaseCommand
+	mockKafkaProducer           *mockkafka.Producer
+	mockLogger                  *mocklog.Logger
+	usecase                     ballot.UsecaseCommand
+	queryUsecase                ballot.UsecaseQuery
+	ctx                         context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockBallotRepositoryQuery = &mockballot.MongodbRepositoryQuery{}
+	suite.mockBallotRepositoryCommand = &mockballot.MongodbRepositoryCommand{}
+	suite.mockTicketRepositoryQuery = &mockticket.MongodbRepositoryQuery{}
+	suite.mockOrderUsecaseCommand = &mockorder.UsecaseCommand{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockBallotRepositoryQuery,
+		suite.mockBallotRepositoryCommand,
+		suite.mockTicketRepositoryQuery,
+		suite.mockOrderUsecaseCommand,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+	suite.queryUsecase = uc.NewQueryUsecase(suite.mockBallotRepositoryQuery, suite.mockLogger)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestEnterBallotSuccess() {
+	// Arrange
+	payload := getEntryReq(2)
+	ballotData := getMockBallot(time.Now().Add(time.Hour))
+	suite.mockBallotRepositoryQuery.On("FindBallotById", mock.Anything, "ballot-id").Return(mockChannel(helpers.Result{Data: &ballotData}))
+	suite.mockBallotRepositoryQuery.On("FindEntry", mock.Anything, "ballot-id", "user-id").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(10)))
+	suite.mockBallotRepositoryCommand.On("InsertOneEntry", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.EnterBallot(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.BallotEntryStatusEntered, result.Status)
+	assert.Equal(suite.T(), 2, result.Quantity)
+}
+
+func (suite *CommandUsecaseTestSuite) TestEnterBallotErrMaxPerUser() {
+	// Arrange
+	payload := getEntryReq(5)
+	ballotData := getMockBallot(time.Now().Add(time.Hour))
+	suite.mockBallotRepositoryQuery.On("FindBallotById", mock.Anything, "ballot-id").Return(mockChannel(helpers.Result{Data: &ballotData}))
+
+	// Act
+	_, err := suite.usecase.EnterBallot(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("maximum 4 tickets per entry"), err)
+	suite.mockBallotRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneEntry", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestEnterBallotErrDuplicate() {
+	// Arrange
+	payload := getEntryReq(2)
+	ballotData := getMockBallot(time.Now().Add(time.Hour))
+	suite.mockBallotRepositoryQuery.On("FindBallotById", mock.Anything, "ballot-id").Return(mockChannel(helpers.Result{Data: &ballotData}))
+	suite.mockBallotRepositoryQuery.On("FindEntry", mock.Anything, "ballot-id", "user-id").Return(mockChannel(helpers.Result{Data: &entity.BallotEntry{EntryId: "entry-id"}}))
+
+	// Act
+	_, err := suite.usecase.EnterBallot(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("you have already entered this ballot"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestEnterBallotErrWindowClosed() {
+	// Arrange
+	payload := getEntryReq(2)
+	ballotData := getMockBallot(time.Now().Add(-time.Minute))
+	suite.mockBallotRepositoryQuery.On("FindBallotById", mock.Anything, "ballot-id").Return(mockChannel(helpers.Result{Data: &ballotData}))
+
+	// Act
+	_, err := suite.usecase.EnterBallot(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("ballot entry window is closed"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDrawBallotDeterministic() {
+	// Arrange
+	entries := getMockEntries(8, 2)
+	var recorded []entity.BallotDraw
+	suite.arrangeDraw(entries, []entity.BallotEntry{}, 6, &recorded)
+	payload := request.DrawReq{BallotId: "ballot-id", Seed: "seed-2026"}
+
+	// Act
+	first, err := suite.usecase.DrawBallot(suite.ctx, payload)
+	assert.NoError(suite.T(), err)
+	second, err := suite.usecase.DrawBallot(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), first.Winners, 3)
+	assert.Equal(suite.T(), first.Winners, second.Winners)
+	assert.Equal(suite.T(), 6, first.Available["ticket-id"])
+	assert.True(suite.T(), first.Reproducible)
+	suite.mockBallotRepositoryCommand.AssertNumberOfCalls(suite.T(), "UpdateEntryWon", 6)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-ballot-drawn", mock.Anything, mock.Anything)
+
+	other, err := suite.usecase.DrawBallot(suite.ctx, request.DrawReq{BallotId: "ballot-id", Seed: "another-seed"})
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), other.Winners, 3)
+	assert.NotEqual(suite.T(), first.Winners, other.Winners)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDrawBallotReproducible() {
+	// Arrange
+	var recorded []entity.BallotDraw
+	suite.arrangeDraw(getMockEntries(8, 2), []entity.BallotEntry{}, 6, &recorded)
+	drawn, err := suite.usecase.DrawBallot(suite.ctx, request.DrawReq{BallotId: "ballot-id", Seed: "seed-2026"})
+	assert.NoError(suite.T(), err)
+	tampered := recorded[0]
+	tampered.Winners = []string{"entry-0", "entry-1", "entry-2"}
+	suite.mockBallotRepositoryQuery.On("FindDraw", mock.Anything, "ballot-id", 1).Return(mockChannel(helpers.Result{Data: &recorded[0]}))
+	suite.mockBallotRepositoryQuery.On("FindDraw", mock.Anything, "ballot-id", 2).Return(mockChannel(helpers.Result{Data: &tampered}))
+
+	// Act
+	result, err := suite.queryUsecase.FindDraw(suite.ctx, "ballot-id", 1)
+	tamperedResult, tamperedErr := suite.queryUsecase.FindDraw(suite.ctx, "ballot-id", 2)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), tamperedErr)
+	assert.Equal(suite.T(), drawn.Winners, result.Winners)
+	assert.True(suite.T(), result.Reproducible)
+	assert.False(suite.T(), tamperedResult.Reproducible)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDrawBallotHoldsUnexpiredWinners() {
+	// Arrange
+	now := time.Now()
+	winners := []entity.BallotEntry{
+		{EntryId: "winner-valid", TicketId: "ticket-id", Quantity: 2, Status: constants.BallotEntryStatusWon, ClaimBy: now.Add(time.Hour)},
+		{EntryId: "winner-late", TicketId: "ticket-id", Quantity: 2, Status: constants.BallotEntryStatusWon, ClaimBy: now.Add(-time.Minute)},
+	}
+	var recorded []entity.BallotDraw
+	suite.arrangeDraw(getMockEntries(4, 2), winners, 6, &recorded)
+
+	// Act
+	result, err := suite.usecase.DrawBallot(suite.ctx, request.DrawReq{BallotId: "ballot-id", Seed: "seed-2026"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, result.Available["ticket-id"])
+	assert.Len(suite.T(), result.Winners, 2)
+	suite.mockBallotRepositoryCommand.AssertCalled(suite.T(), "UpdateEntryStatus", mock.Anything, "winner-late",
+		constants.BallotEntryStatusWon, constants.BallotEntryStatusExpired)
+	suite.mockBallotRepositoryCommand.AssertNotCalled(suite.T(), "UpdateEntryStatus", mock.Anything, "winner-valid",
+		mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDrawBallotErrEntryOpen() {
+	// Arrange
+	ballotData := getMockBallot(time.Now().Add(time.Hour))
+	suite.mockBallotRepositoryQuery.On("FindBallotById", mock.Anything, "ballot-id").Return(mockChannel(helpers.Result{Data: &ballotData}))
+
+	// Act
+	_, err := suite.usecase.DrawBallot(suite.ctx, request.DrawReq{BallotId: "ballot-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("ballot entry window is still open"), err)
+	suite.mockBallotRepositoryCommand.AssertNotCalled(suite.T(), "IncreaseBallotRound", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestDrawBallotErrConcurrentDraw() {
+	// Arrange
+	ballotData := getMockBallot(time.Now().Add(-time.Minute))
+	suite.mockBallotRepositoryQuery.On("FindBallotById", mock.Anything, "ballot-id").Return(mockChannel(helpers.Result{Data: &ballotData}))
+	suite.mockBallotRepositoryCommand.On("IncreaseBallotRound", mock.Anything, "ballot-id", 0).Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.DrawBallot(suite.ctx, request.DrawReq{BallotId: "ballot-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("another draw is running for this ballot"), err)
+	suite.mockBallotRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneDraw", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestClaimEntrySuccess() {
+	// Arrange
+	entry := getMockWinner(time.Now().Add(time.Hour))
+	suite.mockBallotRepositoryQuery.On("FindEntry", mock.Anything, "ballot-id", "user-id").Return(mockChannel(helpers.Result{Data: &entry}))
+	suite.mockBallotRepositoryCommand.On("UpdateEntryClaimed", mock.Anything, "entry-id", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &entry}))
+	suite.mockOrderUsecaseCommand.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req orderDto.ReservationReq) bool {
+		return req.BallotEntryId == "entry-id" && req.OrderId != "" && req.Quantity == 2
+	})).Return(&orderResponse.Reservation{OrderId: "order-id"}, nil)
+
+	// Act
+	result, err := suite.usecase.ClaimEntry(suite.ctx, request.MyEntryReq{UserId: "user-id", BallotId: "ballot-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "order-id", result.OrderId)
+}
+
+func (suite *CommandUsecaseTestSuite) TestClaimEntryErrExpired() {
+	// Arrange
+	entry := getMockWinner(time.Now().Add(-time.Minute))
+	suite.mockBallotRepositoryQuery.On("FindEntry", mock.Anything, "ballot-id", "user-id").Return(mockChannel(helpers.Result{Data: &entry}))
+
+	// Act
+	_, err := suite.usecase.ClaimEntry(suite.ctx, request.MyEntryReq{UserId: "user-id", BallotId: "ballot-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("your purchase right has expired"), err)
+	suite.mockOrderUsecaseCommand.AssertNotCalled(suite.T(), "CreateReservation", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestClaimEntryErrReservationRevertsClaim() {
+	// Arrange
+	entry := getMockWinner(time.Now().Add(time.Hour))
+	suite.mockBallotRepositoryQuery.On("FindEntry", mock.Anything, "ballot-id", "user-id").Return(mockChannel(helpers.Result{Data: &entry}))
+	suite.mockBallotRepositoryCommand.On("UpdateEntryClaimed", mock.Anything, "entry-id", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &entry}))
+	suite.mockOrderUsecaseCommand.On("CreateReservation", mock.Anything, mock.Anything).Return(nil, errors.UnprocessableEntity("ticket quota is not enough"))
+	suite.mockBallotRepositoryCommand.On("UpdateEntryStatus", mock.Anything, "entry-id", constants.BallotEntryStatusClaimed,
+		constants.BallotEntryStatusWon).Return(mockChannel(helpers.Result{Data: &entry}))
+
+	// Act
+	_, err := suite.usecase.ClaimEntry(suite.ctx, request.MyEntryReq{UserId: "user-id", BallotId: "ballot-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("ticket quota is not enough"), err)
+	suite.mockBallotRepositoryCommand.AssertCalled(suite.T(), "UpdateEntryStatus", mock.Anything, "entry-id",
+		constants.BallotEntryStatusClaimed, constants.BallotEntryStatusWon)
+}
+
+func (suite *CommandUsecaseTestSuite) arrangeDraw(entries []entity.BallotEntry, winners []entity.BallotEntry, remaining int, recorded *[]entity.BallotDraw) {
+	ballotData := getMockBallot(time.Now().Add(-time.Minute))
+	suite.mockBallotRepositoryQuery.On("FindBallotById", mock.Anything, "ballot-id").Return(func(context.Context, string) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &ballotData})
+	})
+	suite.mockBallotRepositoryCommand.On("IncreaseBallotRound", mock.Anything, "ballot-id", 0).Return(func(context.Context, string, int) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &ballotData})
+	})
+	suite.mockBallotRepositoryQuery.On("FindEntriesByStatus", mock.Anything, "ballot-id", constants.BallotEntryStatusWon).Return(func(context.Context, string, string) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &winners})
+	})
+	suite.mockBallotRepositoryQuery.On("FindEntriesByStatus", mock.Anything, "ballot-id", constants.BallotEntryStatusEntered).Return(func(context.Context, string, string) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &entries})
+	})
+	suite.mockBallotRepositoryCommand.On("UpdateEntryStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(func(context.Context, string, string, string) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &entity.BallotEntry{}})
+	})
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(func(context.Context, ticketRequest.TicketTypeReq) <-chan helpers.Result {
+		return mockChannel(getMockTicket(remaining))
+	})
+	suite.mockBallotRepositoryCommand.On("InsertOneDraw", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*recorded = append(*recorded, args.Get(1).(entity.BallotDraw))
+	}).Return(func(context.Context, entity.BallotDraw) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: "Success insert data"})
+	})
+	suite.mockBallotRepositoryCommand.On("UpdateEntryWon", mock.Anything, mock.Anything, 1, mock.Anything).Return(func(context.Context, string, int, time.Time) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &entity.BallotEntry{}})
+	})
+	suite.mockKafkaProducer.On("Publish", "concert-ballot-drawn", mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func getEntryReq(quantity int) request.EntryReq {
+	return request.EntryReq{
+		UserId:      "user-id",
+		BallotId:    "ballot-id",
+		CountryCode: "ID",
+		TicketType:  "Gold",
+		Quantity:    quantity,
+	}
+}
+
+func getMockBallot(entryEndAt time.Time) entity.Ballot {
+	return entity.Ballot{
+		BallotId:     "ballot-id",
+		EventId:      "event-id",
+		Name:         "Main Ballot",
+		EntryStartAt: entryEndAt.Add(-24 * time.Hour),
+		EntryEndAt:   entryEndAt,
+		MaxPerUser:   4,
+		ClaimMinutes: 60,
+		Status:       constants.BallotStatusOpen,
+	}
+}
+
+func getMockEntries(total int, quantity int) []entity.BallotEntry {
+	entries := make([]entity.BallotEntry, 0)
+	for i := 0; i < total; i++ {
+		entries = append(entries, entity.BallotEntry{
+			EntryId:     fmt.Sprintf("entry-%d", i),
+			BallotId:    "ballot-id",
+			EventId:     "event-id",
+			UserId:      fmt.Sprintf("user-%d", i),
+			TicketId:    "ticket-id",
+			TicketType:  "Gold",
+			CountryCode: "ID",
+			Quantity:    quantity,
+			Status:      constants.BallotEntryStatusEntered,
+		})
+	}
+	return entries
+}
+
+func getMockWinner(claimBy time.Time) entity.BallotEntry {
+	return entity.BallotEntry{
+		EntryId:     "entry-id",
+		BallotId:    "ballot-id",
+		EventId:     "event-id",
+		UserId:      "user-id",
+		TicketId:    "ticket-id",
+		TicketType:  "Gold",
+		CountryCode: "ID",
+		Quantity:    2,
+		Status:      constants.BallotEntryStatusWon,
+		Round:       1,
+		ClaimBy:     claimBy,
+	}
+}
+
+func getMockTicket(remaining int) helpers.Result {
+	return helpers.Result{
+		Data: &ticketEntity.Ticket{
+			TicketId:       "ticket-id",
+			EventId:        "event-id",
+			TicketType:     "Gold",
+			TotalQuota:     100,
+			TotalRemaining: remaining,
+		},
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"ticket-service/internal/modules/ballot/models/entity"
+)
+
+// drawWinners ranks the entries by sha256(seed:round:entryId) and lets them take what is left of their ticket
+// in that order, an entry gets its whole quantity or stays in for the next round. It depends on nothing
+// but its arguments, which is what makes a recorded draw reproducible
+func drawWinners(seed string, round int, entries []entity.DrawEntry, available map[string]int) []string {
+	ranked := make([]entity.DrawEntry, len(entries))
+	copy(ranked, entries)
+	keys := make(map[string]string)
+	for _, value := range ranked {
+		keys[value.EntryId] = drawKey(seed, round, value.EntryId)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if keys[ranked[i].EntryId] != keys[ranked[j].EntryId] {
+			return keys[ranked[i].EntryId] < keys[ranked[j].EntryId]
+		}
+		return ranked[i].EntryId < ranked[j].EntryId
+	})
+
+	remaining := make(map[string]int)
+	for ticketId, total := range available {
+		remaining[ticketId] = total
+	}
+
+	winners := make([]string, 0)
+	for _, value := range ranked {
+		if remaining[value.TicketId] < value.Quantity {
+			continue
+		}
+		remaining[value.TicketId] -= value.Quantity
+		winners = append(winners, value.EntryId)
+	}
+	return winners
+}
+
+func drawKey(seed string, round int, entryId string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%s", seed, round, entryId)))
+	return hex.EncodeToString(sum[:])
+}
+
+func sameWinners(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
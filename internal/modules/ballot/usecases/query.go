@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/ballot"
+	"ticket-service/internal/modules/ballot/models/entity"
+	"ticket-service/internal/modules/ballot/models/request"
+	"ticket-service/internal/modules/ballot/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	ballotRepositoryQuery ballot.MongodbRepositoryQuery
+	logger                log.Logger
+}
+
+func NewQueryUsecase(bmq ballot.MongodbRepositoryQuery, log log.Logger) ballot.UsecaseQuery {
+	return queryUsecase{
+		ballotRepositoryQuery: bmq,
+		logger:                log,
+	}
+}
+
+func (q queryUsecase) FindMyEntry(origCtx context.Context, payload request.MyEntryReq) (*response.Entry, error) {
+	domain := "ballotUsecase-FindMyEntry"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	entry, err := findEntry(ctx, q.ballotRepositoryQuery, q.logger, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapEntry(*entry), nil
+}
+
+// FindDraw returns a recorded round and runs it again from its own seed and inputs,
+// Reproducible is false when the stored winners do not match that rerun
+func (q queryUsecase) FindDraw(origCtx context.Context, ballotId string, round int) (*response.Draw, error) {
+	domain := "ballotUsecase-FindDraw"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.ballotRepositoryQuery.FindDraw(ctx, ballotId, round)
+	if resp.Error != nil {
+		msg := "Error query ballot draw"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ballot draw not found")
+	}
+
+	draw, ok := resp.Data.(*entity.BallotDraw)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	rerun := drawWinners(draw.Seed, draw.Round, draw.Entries, draw.Available)
+	return mapDraw(*draw, sameWinners(rerun, draw.Winners)), nil
+}
+
+// CheckDirectSale refuses a normal purchase while the event is sold by an open ballot
+func (q queryUsecase) CheckDirectSale(origCtx context.Context, eventId string) error {
+	domain := "ballotUsecase-CheckDirectSale"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.ballotRepositoryQuery.FindActiveBallotByEventId(ctx, eventId, time.Now())
+	if resp.Error != nil {
+		msg := "Error query active ballot"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil
+	}
+
+	ballotData, ok := resp.Data.(*entity.Ballot)
+	if !ok {
+		return errors.InternalServerError("cannot parsing data")
+	}
+
+	return errors.ForbiddenError(fmt.Sprintf("tickets of this event are sold by ballot %s", ballotData.Name))
+}
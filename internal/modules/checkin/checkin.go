@@ -0,0 +1,18 @@
+package checkin
+
+import (
+	"context"
+	"ticket-service/internal/modules/checkin/models/entity"
+	"ticket-service/internal/modules/checkin/models/request"
+	"ticket-service/internal/modules/checkin/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseCommand interface {
+	ScanTicket(origCtx context.Context, payload request.ScanReq) (*response.Scan, error)
+	SyncScans(origCtx context.Context, payload request.SyncReq) (*response.Sync, error)
+}
+
+type MongodbRepositoryCommand interface {
+	InsertOneScan(ctx context.Context, scan entity.Scan) <-chan wrapper.Result
+}
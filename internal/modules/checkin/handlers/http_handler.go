@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/checkin"
+	"ticket-service/internal/modules/checkin/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CheckinHttpHandler struct {
+	CheckinUsecaseCommand checkin.UsecaseCommand
+	Logger                log.Logger
+	Validator             *validator.Validate
+}
+
+func InitCheckinHttpHandler(app *fiber.App, cuc checkin.UsecaseCommand, log log.Logger, redisClient redis.Collections) {
+	handler := &CheckinHttpHandler{
+		CheckinUsecaseCommand: cuc,
+		Logger:                log,
+		Validator:             validator.New(),
+	}
+	gateRole := middlewares.AllowedRoles(constants.RoleGate)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/checkin")
+
+	route.Post("/v1/scan", middlewares.VerifyBearer(), gateRole, handler.ScanTicket)
+	route.Post("/v1/sync", middlewares.VerifyBearer(), gateRole, handler.SyncScans)
+}
+
+func (h CheckinHttpHandler) ScanTicket(c *fiber.Ctx) error {
+	req := new(request.ScanReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, h.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, h.Logger, errors.BadRequest(err.Error()))
+	}
+	staffId, _ := c.Locals("userId").(string)
+	req.StaffId = staffId
+	resp, err := h.CheckinUsecaseCommand.ScanTicket(c.Context(), *req)
+	if err != nil {
+		return helpers.RespErrorWithData(c, h.Logger, resp, err)
+	}
+	return helpers.RespSuccess(c, h.Logger, resp, "Check in success")
+}
+
+func (h CheckinHttpHandler) SyncScans(c *fiber.Ctx) error {
+	req := new(request.SyncReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, h.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, h.Logger, errors.BadRequest(err.Error()))
+	}
+	staffId, _ := c.Locals("userId").(string)
+	req.StaffId = staffId
+	resp, err := h.CheckinUsecaseCommand.SyncScans(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, h.Logger, err)
+	}
+	return helpers.RespSuccess(c, h.Logger, resp, "Sync scan success")
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// Scan is the audit trail of every gate scan, including the rejected and conflicting ones
+type Scan struct {
+	ScanId         string    `json:"scanId" bson:"scanId"`
+	IssuedTicketId string    `json:"issuedTicketId" bson:"issuedTicketId"`
+	EventId        string    `json:"eventId" bson:"eventId"`
+	GateId         string    `json:"gateId" bson:"gateId"`
+	ScannedBy      string    `json:"scannedBy" bson:"scannedBy"`
+	Result         string    `json:"result" bson:"result"`
+	Reason         string    `json:"reason" bson:"reason"`
+	ConflictGateId string    `json:"conflictGateId,omitempty" bson:"conflictGateId,omitempty"`
+	Offline        bool      `json:"offline" bson:"offline"`
+	ScannedAt      time.Time `json:"scannedAt" bson:"scannedAt"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
+}
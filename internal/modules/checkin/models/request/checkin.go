@@ -0,0 +1,22 @@
+package request
+
+import "time"
+
+type ScanReq struct {
+	StaffId string `json:"-"`
+	EventId string `json:"eventId" validate:"required"`
+	GateId  string `json:"gateId" validate:"required"`
+	QrCode  string `json:"qrCode" validate:"required"`
+}
+
+type OfflineScan struct {
+	QrCode    string    `json:"qrCode" validate:"required"`
+	ScannedAt time.Time `json:"scannedAt" validate:"required"`
+}
+
+type SyncReq struct {
+	StaffId string        `json:"-"`
+	EventId string        `json:"eventId" validate:"required"`
+	GateId  string        `json:"gateId" validate:"required"`
+	Scans   []OfflineScan `json:"scans" validate:"required,min=1,max=1000,dive"`
+}
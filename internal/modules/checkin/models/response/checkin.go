@@ -0,0 +1,24 @@
+package response
+
+import "time"
+
+type Scan struct {
+	IssuedTicketId string    `json:"issuedTicketId"`
+	EventId        string    `json:"eventId"`
+	TicketType     string    `json:"ticketType"`
+	HolderName     string    `json:"holderName"`
+	HolderEmail    string    `json:"holderEmail"`
+	GateId         string    `json:"gateId"`
+	Result         string    `json:"result"`
+	Reason         string    `json:"reason"`
+	ConflictGateId string    `json:"conflictGateId,omitempty"`
+	ScannedAt      time.Time `json:"scannedAt"`
+}
+
+type Sync struct {
+	Accepted  int    `json:"accepted"`
+	Duplicate int    `json:"duplicate"`
+	Conflict  int    `json:"conflict"`
+	Rejected  int    `json:"rejected"`
+	Scans     []Scan `json:"scans"`
+}
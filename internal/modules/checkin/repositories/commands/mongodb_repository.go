@@ -0,0 +1,37 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/checkin"
+	"ticket-service/internal/modules/checkin/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) checkin.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) InsertOneScan(ctx context.Context, scan entity.Scan) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "checkin-scans",
+			Document:       scan,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,278 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"ticket-service/internal/modules/checkin"
+	"ticket-service/internal/modules/checkin/models/entity"
+	"ticket-service/internal/modules/checkin/models/request"
+	"ticket-service/internal/modules/checkin/models/response"
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/user"
+	userEntity "ticket-service/internal/modules/user/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	checkinRepositoryCommand checkin.MongodbRepositoryCommand
+	eticketRepositoryQuery   eticket.MongodbRepositoryQuery
+	eticketRepositoryCommand eticket.MongodbRepositoryCommand
+	userRepositoryQuery      user.MongodbRepositoryQuery
+	ticketSigner             helpers.TicketSigner
+	kafkaProducer            kafkaConfluent.Producer
+	logger                   log.Logger
+}
+
+func NewCommandUsecase(cmc checkin.MongodbRepositoryCommand, emq eticket.MongodbRepositoryQuery, emc eticket.MongodbRepositoryCommand,
+	umq user.MongodbRepositoryQuery, ts helpers.TicketSigner, kp kafkaConfluent.Producer, log log.Logger) checkin.UsecaseCommand {
+	return commandUsecase{
+		checkinRepositoryCommand: cmc,
+		eticketRepositoryQuery:   emq,
+		eticketRepositoryCommand: emc,
+		userRepositoryQuery:      umq,
+		ticketSigner:             ts,
+		kafkaProducer:            kp,
+		logger:                   log,
+	}
+}
+
+// ScanTicket still returns the scan detail alongside the error so the gate can show who is holding the ticket
+func (c commandUsecase) ScanTicket(origCtx context.Context, payload request.ScanReq) (*response.Scan, error) {
+	domain := "checkinUsecase-ScanTicket"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	result := c.checkIn(ctx, scanAttempt{
+		qrCode:    payload.QrCode,
+		eventId:   payload.EventId,
+		gateId:    payload.GateId,
+		staffId:   payload.StaffId,
+		scannedAt: time.Now(),
+	})
+
+	switch result.Result {
+	case constants.ScanResultAccepted:
+		return &result, nil
+	case constants.ScanResultRejected:
+		return &result, errors.ForbiddenError(result.Reason)
+	default:
+		return &result, errors.Conflict(result.Reason)
+	}
+}
+
+// SyncScans replays scans collected while a gate was offline, oldest first, the same way an online scan is checked
+func (c commandUsecase) SyncScans(origCtx context.Context, payload request.SyncReq) (*response.Sync, error) {
+	domain := "checkinUsecase-SyncScans"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	scans := make([]request.OfflineScan, len(payload.Scans))
+	copy(scans, payload.Scans)
+	sort.SliceStable(scans, func(i, j int) bool {
+		return scans[i].ScannedAt.Before(scans[j].ScannedAt)
+	})
+
+	result := response.Sync{
+		Scans: make([]response.Scan, 0),
+	}
+	for _, scan := range scans {
+		scanResult := c.checkIn(ctx, scanAttempt{
+			qrCode:    scan.QrCode,
+			eventId:   payload.EventId,
+			gateId:    payload.GateId,
+			staffId:   payload.StaffId,
+			scannedAt: scan.ScannedAt,
+			offline:   true,
+		})
+		switch scanResult.Result {
+		case constants.ScanResultAccepted:
+			result.Accepted = result.Accepted + 1
+		case constants.ScanResultDuplicate:
+			result.Duplicate = result.Duplicate + 1
+		case constants.ScanResultConflict:
+			result.Conflict = result.Conflict + 1
+		default:
+			result.Rejected = result.Rejected + 1
+		}
+		result.Scans = append(result.Scans, scanResult)
+	}
+
+	return &result, nil
+}
+
+type scanAttempt struct {
+	qrCode    string
+	eventId   string
+	gateId    string
+	staffId   string
+	scannedAt time.Time
+	offline   bool
+}
+
+func (c commandUsecase) checkIn(ctx context.Context, attempt scanAttempt) response.Scan {
+	result := response.Scan{
+		EventId:   attempt.eventId,
+		GateId:    attempt.gateId,
+		ScannedAt: attempt.scannedAt,
+	}
+
+	payload, err := c.ticketSigner.VerifyTicket(attempt.qrCode)
+	if err != nil {
+		return c.recordScan(ctx, attempt, reject(result, "invalid ticket signature"))
+	}
+	result.IssuedTicketId = payload.TicketId
+	result.TicketType = payload.TicketType
+
+	if payload.Purpose != "" {
+		return c.recordScan(ctx, attempt, reject(result, "access pass is not valid for admission"))
+	}
+
+	if payload.EventId != attempt.eventId {
+		return c.recordScan(ctx, attempt, reject(result, "ticket is for another event"))
+	}
+
+	issuedTicket, err := c.findIssuedTicket(ctx, payload.TicketId)
+	if err != nil {
+		return c.recordScan(ctx, attempt, reject(result, err.Error()))
+	}
+
+	// a transfer bumps the qr version, so an older screenshot of the code is refused here
+	if issuedTicket.QrVersion != payload.Version || issuedTicket.UserId != payload.UserId {
+		return c.recordScan(ctx, attempt, reject(result, "qr code is no longer valid"))
+	}
+	c.fillHolder(ctx, &result, issuedTicket.UserId)
+
+	switch issuedTicket.Status {
+	case constants.IssuedTicketStatusRevoked:
+		return c.recordScan(ctx, attempt, reject(result, "ticket has been revoked"))
+	case constants.IssuedTicketStatusUsed:
+		return c.recordScan(ctx, attempt, alreadyUsed(result, *issuedTicket))
+	case constants.IssuedTicketStatusListed:
+		return c.recordScan(ctx, attempt, reject(result, "ticket is listed for resale"))
+	}
+
+	used := <-c.eticketRepositoryCommand.UpdateIssuedTicketUsed(ctx, eticketEntity.IssuedTicket{
+		IssuedTicketId: issuedTicket.IssuedTicketId,
+		QrVersion:      issuedTicket.QrVersion,
+		UsedGateId:     attempt.gateId,
+		UsedAt:         attempt.scannedAt,
+	})
+	if used.Error != nil {
+		msg := "Error update issued ticket used"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", used.Error))
+		return c.recordScan(ctx, attempt, reject(result, "cannot check in ticket, please retry"))
+	}
+
+	// another gate won the race, read it back to report where the ticket was used
+	if used.Data == nil {
+		latest, err := c.findIssuedTicket(ctx, issuedTicket.IssuedTicketId)
+		if err != nil {
+			return c.recordScan(ctx, attempt, reject(result, err.Error()))
+		}
+		return c.recordScan(ctx, attempt, alreadyUsed(result, *latest))
+	}
+
+	result.Result = constants.ScanResultAccepted
+	result.Reason = "welcome"
+	return c.recordScan(ctx, attempt, result)
+}
+
+func (c commandUsecase) findIssuedTicket(ctx context.Context, issuedTicketId string) (*eticketEntity.IssuedTicket, error) {
+	resp := <-c.eticketRepositoryQuery.FindIssuedTicketById(ctx, issuedTicketId)
+	if resp.Error != nil {
+		msg := "Error query issued ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, errors.InternalServerError("cannot check in ticket, please retry")
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	issuedTicket, ok := resp.Data.(*eticketEntity.IssuedTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return issuedTicket, nil
+}
+
+func (c commandUsecase) fillHolder(ctx context.Context, result *response.Scan, userId string) {
+	resp := <-c.userRepositoryQuery.FindOneUserId(ctx, userId)
+	if resp.Error != nil || resp.Data == nil {
+		return
+	}
+
+	holder, ok := resp.Data.(*userEntity.User)
+	if !ok {
+		return
+	}
+	result.HolderName = holder.FullName
+	result.HolderEmail = helpers.MaskEmail(holder.Email)
+}
+
+func (c commandUsecase) recordScan(ctx context.Context, attempt scanAttempt, result response.Scan) response.Scan {
+	scan := entity.Scan{
+		ScanId:         uuid.NewString(),
+		IssuedTicketId: result.IssuedTicketId,
+		EventId:        attempt.eventId,
+		GateId:         attempt.gateId,
+		ScannedBy:      attempt.staffId,
+		Result:         result.Result,
+		Reason:         result.Reason,
+		ConflictGateId: result.ConflictGateId,
+		Offline:        attempt.offline,
+		ScannedAt:      attempt.scannedAt,
+		CreatedAt:      time.Now(),
+	}
+	resp := <-c.checkinRepositoryCommand.InsertOneScan(ctx, scan)
+	if resp.Error != nil {
+		msg := "Error insert scan"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", scan))
+	}
+
+	if result.Result == constants.ScanResultConflict {
+		marshaledKafkaData, _ := json.Marshal(scan)
+		topic := "concert-checkin-conflict"
+		c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+		c.logger.Info(ctx, fmt.Sprintf("Send kafka checkin conflict, ticket : %s", scan.IssuedTicketId), fmt.Sprintf("%+v", scan))
+	}
+
+	return result
+}
+
+func reject(result response.Scan, reason string) response.Scan {
+	result.Result = constants.ScanResultRejected
+	result.Reason = reason
+	return result
+}
+
+// alreadyUsed tells a repeated scan at the same gate apart from the same ticket showing up at two gates
+func alreadyUsed(result response.Scan, issuedTicket eticketEntity.IssuedTicket) response.Scan {
+	if issuedTicket.UsedGateId == result.GateId {
+		result.Result = constants.ScanResultDuplicate
+		result.Reason = fmt.Sprintf("ticket already checked in at this gate at %s", issuedTicket.UsedAt.Format(time.RFC3339))
+		return result
+	}
+	result.Result = constants.ScanResultConflict
+	result.ConflictGateId = issuedTicket.UsedGateId
+	result.Reason = fmt.Sprintf("ticket already checked in at gate %s at %s", issuedTicket.UsedGateId, issuedTicket.UsedAt.Format(time.RFC3339))
+	return result
+}
@@ -0,0 +1,236 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticket-service/internal/modules/checkin"
+	checkinRequest "ticket-service/internal/modules/checkin/models/request"
+	uc "ticket-service/internal/modules/checkin/usecases"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	userEntity "ticket-service/internal/modules/user/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockcheckin "ticket-service/mocks/modules/checkin"
+	mocketicket "ticket-service/mocks/modules/eticket"
+	mockuser "ticket-service/mocks/modules/user"
+	mockhelpers "ticket-service/mocks/pkg/helpers"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockCheckinRepositoryCommand *mockcheckin.MongodbRepositoryCommand
+	mockEticketRepositoryQuery   *mocketicket.MongodbRepositoryQuery
+	mockEticketRepositoryCommand *mocketicket.MongodbRepositoryCommand
+	mockUserRepositoryQuery      *mockuser.MongodbRepositoryQuery
+	mockTicketSigner             *mockhelpers.TicketSigner
+	mockKafkaProducer            *mockkafka.Producer
+	mockLogger                   *mocklog.Logger
+	usecase                      checkin.UsecaseCommand
+	ctx                          context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockCheckinRepositoryCommand = &mockcheckin.MongodbRepositoryCommand{}
+	suite.mockEticketRepositoryQuery = &mocketicket.MongodbRepositoryQuery{}
+	suite.mockEticketRepositoryCommand = &mocketicket.MongodbRepositoryCommand{}
+	suite.mockUserRepositoryQuery = &mockuser.MongodbRepositoryQuery{}
+	suite.mockTicketSigner = &mockhelpers.TicketSigner{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockCheckinRepositoryCommand,
+		suite.mockEticketRepositoryQuery,
+		suite.mockEticketRepositoryCommand,
+		suite.mockUserRepositoryQuery,
+		suite.mockTicketSigner,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+	suite.mockCheckinRepositoryCommand.On("InsertOneScan", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+	suite.mockUserRepositoryQuery.On("FindOneUserId", mock.Anything, "user-id").Return(mockChannel(helpers.Result{
+		Data: &userEntity.User{UserId: "user-id", FullName: "Holder", Email: "holder@mail.com"},
+	}))
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestScanTicketAccepted() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "qr").Return(getMockPayload(1), nil)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive, "")}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketUsed", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusUsed, "gate-a")}))
+
+	// Act
+	result, err := suite.usecase.ScanTicket(suite.ctx, getScanReq("gate-a"))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.ScanResultAccepted, result.Result)
+	assert.Equal(suite.T(), "Holder", result.HolderName)
+}
+
+func (suite *CommandUsecaseTestSuite) TestScanTicketErrInvalidSignature() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "qr").Return(nil, errors.ForbiddenError("Invalid ticket signature"))
+
+	// Act
+	result, err := suite.usecase.ScanTicket(suite.ctx, getScanReq("gate-a"))
+
+	// Assert
+	assert.Equal(suite.T(), errors.ForbiddenError("invalid ticket signature"), err)
+	assert.Equal(suite.T(), constants.ScanResultRejected, result.Result)
+}
+
+func (suite *CommandUsecaseTestSuite) TestScanTicketErrAccessPass() {
+	// Arrange
+	accessPass := getMockPayload(1)
+	accessPass.Purpose = constants.AccessPassPurposeStream
+	suite.mockTicketSigner.On("VerifyTicket", "qr").Return(accessPass, nil)
+
+	// Act
+	result, err := suite.usecase.ScanTicket(suite.ctx, getScanReq("gate-a"))
+
+	// Assert
+	assert.Equal(suite.T(), errors.ForbiddenError("access pass is not valid for admission"), err)
+	assert.Equal(suite.T(), constants.ScanResultRejected, result.Result)
+	suite.mockEticketRepositoryQuery.AssertNotCalled(suite.T(), "FindIssuedTicketById", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestScanTicketErrRotatedQr() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "qr").Return(getMockPayload(1), nil)
+	rotated := getMockIssuedTicket(constants.IssuedTicketStatusActive, "")
+	rotated.QrVersion = 2
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").Return(mockChannel(helpers.Result{Data: rotated}))
+
+	// Act
+	_, err := suite.usecase.ScanTicket(suite.ctx, getScanReq("gate-a"))
+
+	// Assert
+	assert.Equal(suite.T(), errors.ForbiddenError("qr code is no longer valid"), err)
+	suite.mockEticketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateIssuedTicketUsed", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestScanTicketConflictOtherGate() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "qr").Return(getMockPayload(1), nil)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusUsed, "gate-a")}))
+	suite.mockKafkaProducer.On("Publish", "concert-checkin-conflict", mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	result, err := suite.usecase.ScanTicket(suite.ctx, getScanReq("gate-b"))
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), constants.ScanResultConflict, result.Result)
+	assert.Equal(suite.T(), "gate-a", result.ConflictGateId)
+}
+
+func (suite *CommandUsecaseTestSuite) TestScanTicketLostRace() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "qr").Return(getMockPayload(1), nil)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive, "")})).Once()
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketUsed", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusUsed, "gate-a")})).Once()
+
+	// Act
+	result, err := suite.usecase.ScanTicket(suite.ctx, getScanReq("gate-a"))
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), constants.ScanResultDuplicate, result.Result)
+}
+
+func (suite *CommandUsecaseTestSuite) TestSyncScans() {
+	// Arrange
+	suite.mockTicketSigner.On("VerifyTicket", "qr").Return(getMockPayload(1), nil)
+	suite.mockTicketSigner.On("VerifyTicket", "bad").Return(nil, errors.ForbiddenError("Invalid ticket signature"))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusActive, "")})).Once()
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketUsed", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusUsed, "gate-b")}))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket(constants.IssuedTicketStatusUsed, "gate-b")}))
+
+	now := time.Now()
+	payload := checkinRequest.SyncReq{
+		StaffId: "staff-id",
+		EventId: "event-id",
+		GateId:  "gate-b",
+		Scans: []checkinRequest.OfflineScan{
+			{QrCode: "qr", ScannedAt: now.Add(-time.Minute)},
+			{QrCode: "bad", ScannedAt: now},
+			{QrCode: "qr", ScannedAt: now.Add(-2 * time.Minute)},
+		},
+	}
+
+	// Act
+	result, err := suite.usecase.SyncScans(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Accepted)
+	assert.Equal(suite.T(), 1, result.Duplicate)
+	assert.Equal(suite.T(), 1, result.Rejected)
+	assert.Equal(suite.T(), now.Add(-2*time.Minute), result.Scans[0].ScannedAt)
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
+
+func getScanReq(gateId string) checkinRequest.ScanReq {
+	return checkinRequest.ScanReq{
+		StaffId: "staff-id",
+		EventId: "event-id",
+		GateId:  gateId,
+		QrCode:  "qr",
+	}
+}
+
+func getMockPayload(version int) *helpers.TicketPayload {
+	return &helpers.TicketPayload{
+		TicketId:   "issued-id",
+		EventId:    "event-id",
+		TicketType: "Gold",
+		UserId:     "user-id",
+		Version:    version,
+	}
+}
+
+func getMockIssuedTicket(status string, gateId string) *eticketEntity.IssuedTicket {
+	return &eticketEntity.IssuedTicket{
+		IssuedTicketId: "issued-id",
+		UserId:         "user-id",
+		EventId:        "event-id",
+		TicketType:     "Gold",
+		Status:         status,
+		QrVersion:      1,
+		UsedGateId:     gateId,
+	}
+}
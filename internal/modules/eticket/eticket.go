@@ -0,0 +1,34 @@
+package eticket
+
+import (
+	"context"
+	"ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/eticket/models/request"
+	"ticket-service/internal/modules/eticket/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseCommand interface {
+	IssueTickets(origCtx context.Context, payload request.IssueTicketReq) ([]response.IssuedTicket, error)
+}
+
+type UsecaseQuery interface {
+	FindMyTickets(origCtx context.Context, userId string) ([]response.IssuedTicket, error)
+	FindMyTicketQr(origCtx context.Context, payload request.TicketQrReq) (*response.TicketQr, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindIssuedTicketsByUserId(ctx context.Context, userId string) <-chan wrapper.Result
+	FindIssuedTicketsByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result
+	FindIssuedTicketById(ctx context.Context, issuedTicketId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	InitIssuedTicket(ctx context.Context, issuedTicket entity.IssuedTicket) <-chan wrapper.Result
+	UpdateIssuedTicketUsed(ctx context.Context, payload entity.IssuedTicket) <-chan wrapper.Result
+	UpdateIssuedTicketOwner(ctx context.Context, payload entity.IssuedTicket, ownership entity.Ownership) <-chan wrapper.Result
+	UpdateIssuedTicketStatus(ctx context.Context, payload entity.IssuedTicket, fromStatus string) <-chan wrapper.Result
+	UpdateIssuedTicketResold(ctx context.Context, payload entity.IssuedTicket, ownership entity.Ownership) <-chan wrapper.Result
+	UpdateIssuedTicketRevoked(ctx context.Context, issuedTicketId string) <-chan wrapper.Result
+	CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result
+}
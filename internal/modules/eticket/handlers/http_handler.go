@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/eticket"
+	"ticket-service/internal/modules/eticket/models/request"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type EticketHttpHandler struct {
+	EticketUsecaseCommand eticket.UsecaseCommand
+	EticketUsecaseQuery   eticket.UsecaseQuery
+	Logger                log.Logger
+	Validator             *validator.Validate
+}
+
+func InitEticketHttpHandler(app *fiber.App, euc eticket.UsecaseCommand, euq eticket.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &EticketHttpHandler{
+		EticketUsecaseCommand: euc,
+		EticketUsecaseQuery:   euq,
+		Logger:                log,
+		Validator:             validator.New(),
+	}
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/tickets")
+
+	route.Get("/v1/mine", middlewares.VerifyBearer(), handler.GetMyTickets)
+	route.Get("/v1/mine/:id/qr", middlewares.VerifyBearer(), handler.GetMyTicketQr)
+	route.Post("/v1/issue", middlewares.VerifyBasicAuth(), handler.IssueTickets)
+}
+
+func (e EticketHttpHandler) IssueTickets(c *fiber.Ctx) error {
+	req := new(request.IssueTicketReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := e.EticketUsecaseCommand.IssueTickets(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Issue ticket success")
+}
+
+func (e EticketHttpHandler) GetMyTickets(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, e.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	resp, err := e.EticketUsecaseQuery.FindMyTickets(c.Context(), userId)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	return helpers.RespSuccess(c, e.Logger, resp, "Get my ticket success")
+}
+
+func (e EticketHttpHandler) GetMyTicketQr(c *fiber.Ctx) error {
+	req := new(request.TicketQrReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := e.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, e.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, e.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	req.IssuedTicketId = c.Params("id")
+	resp, err := e.EticketUsecaseQuery.FindMyTicketQr(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, e.Logger, err)
+	}
+	c.Set(fiber.HeaderContentType, resp.ContentType)
+	return c.Send(resp.Content)
+}
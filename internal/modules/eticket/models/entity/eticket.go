@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// IssuedTicket is one admission of an order, QrVersion is part of the signed payload so rotating it voids older QR codes.
+// AdmissionIndex is the position of the admission in its order, an order never has two tickets at the same index
+type IssuedTicket struct {
+	IssuedTicketId   string      `json:"issuedTicketId" bson:"issuedTicketId"`
+	OrderId          string      `json:"orderId" bson:"orderId"`
+	AdmissionIndex   int         `json:"admissionIndex" bson:"admissionIndex"`
+	UserId           string      `json:"userId" bson:"userId"`
+	TicketId         string      `json:"ticketId" bson:"ticketId"`
+	EventId          string      `json:"eventId" bson:"eventId"`
+	TicketType       string      `json:"ticketType" bson:"ticketType"`
+	CountryCode      string      `json:"countryCode" bson:"countryCode"`
+	Seat             *Seat       `json:"seat,omitempty" bson:"seat,omitempty"`
+	Status           string      `json:"status" bson:"status"`
+	QrVersion        int         `json:"qrVersion" bson:"qrVersion"`
+	UsedGateId       string      `json:"usedGateId,omitempty" bson:"usedGateId,omitempty"`
+	UsedAt           time.Time   `json:"usedAt,omitempty" bson:"usedAt,omitempty"`
+	OwnershipHistory []Ownership `json:"ownershipHistory" bson:"ownershipHistory"`
+	IssuedAt         time.Time   `json:"issuedAt" bson:"issuedAt"`
+	CreatedAt        time.Time   `json:"createdAt" bson:"createdAt"`
+	UpdatedAt        time.Time   `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Seat is the reserved seat of the admission, tickets of general admission tiers have none
+type Seat struct {
+	SeatId  string `json:"seatId" bson:"seatId"`
+	Section string `json:"section" bson:"section"`
+	Row     string `json:"row" bson:"row"`
+	Number  int    `json:"number" bson:"number"`
+}
+
+type Ownership struct {
+	UserId      string    `json:"userId" bson:"userId"`
+	Via         string    `json:"via" bson:"via"`
+	ReferenceId string    `json:"referenceId" bson:"referenceId"`
+	AcquiredAt  time.Time `json:"acquiredAt" bson:"acquiredAt"`
+}
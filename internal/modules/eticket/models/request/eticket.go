@@ -0,0 +1,12 @@
+package request
+
+type IssueTicketReq struct {
+	OrderId string `json:"orderId" validate:"required"`
+}
+
+type TicketQrReq struct {
+	UserId         string `json:"-"`
+	IssuedTicketId string `json:"-"`
+	Format         string `json:"format" validate:"omitempty,oneof=png svg"`
+	Size           int    `json:"size" validate:"omitempty,min=128,max=1024"`
+}
@@ -0,0 +1,25 @@
+package response
+
+import "time"
+
+type IssuedTicket struct {
+	IssuedTicketId string    `json:"issuedTicketId"`
+	OrderId        string    `json:"orderId"`
+	EventId        string    `json:"eventId"`
+	TicketType     string    `json:"ticketType"`
+	CountryCode    string    `json:"countryCode"`
+	Seat           *Seat     `json:"seat,omitempty"`
+	Status         string    `json:"status"`
+	IssuedAt       time.Time `json:"issuedAt"`
+}
+
+type Seat struct {
+	Section string `json:"section"`
+	Row     string `json:"row"`
+	Number  int    `json:"number"`
+}
+
+type TicketQr struct {
+	ContentType string
+	Content     []byte
+}
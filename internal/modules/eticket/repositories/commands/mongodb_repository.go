@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/eticket"
+	"ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) eticket.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// InitIssuedTicket stores the admission unless its order already has a ticket at that index and returns the previous
+// document, Data is nil when the admission was inserted
+func (c commandMongodbRepository) InitIssuedTicket(ctx context.Context, issuedTicket entity.IssuedTicket) <-chan wrapper.Result {
+	var existing entity.IssuedTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &existing,
+			CollectionName: "issued-tickets",
+			Filter: bson.M{
+				"orderId":        issuedTicket.OrderId,
+				"admissionIndex": issuedTicket.AdmissionIndex,
+			},
+			Update: bson.M{
+				"$setOnInsert": issuedTicket,
+			},
+			Upsert: true,
+		}, options.Before, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateIssuedTicketUsed flips an ACTIVE ticket with the scanned qr version to USED in one write,
+// so two gates scanning at the same time can't both let the holder in. Data is nil for the losing scan
+func (c commandMongodbRepository) UpdateIssuedTicketUsed(ctx context.Context, payload entity.IssuedTicket) <-chan wrapper.Result {
+	var issuedTicket entity.IssuedTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &issuedTicket,
+			CollectionName: "issued-tickets",
+			Filter: bson.M{
+				"issuedTicketId": payload.IssuedTicketId,
+				"qrVersion":      payload.QrVersion,
+				"status":         constants.IssuedTicketStatusActive,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":     constants.IssuedTicketStatusUsed,
+					"usedGateId": payload.UsedGateId,
+					"usedAt":     payload.UsedAt,
+					"updatedAt":  time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateIssuedTicketOwner hands an ACTIVE ticket to a new owner and bumps the qr version in the same write,
+// so every QR code rendered for the previous owner stops being accepted at the gate
+func (c commandMongodbRepository) UpdateIssuedTicketOwner(ctx context.Context, payload entity.IssuedTicket, ownership entity.Ownership) <-chan wrapper.Result {
+	var issuedTicket entity.IssuedTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &issuedTicket,
+			CollectionName: "issued-tickets",
+			Filter: bson.M{
+				"issuedTicketId": payload.IssuedTicketId,
+				"userId":         payload.UserId,
+				"qrVersion":      payload.QrVersion,
+				"status":         constants.IssuedTicketStatusActive,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"userId":    ownership.UserId,
+					"updatedAt": time.Now(),
+				},
+				"$inc":  bson.M{"qrVersion": 1},
+				"$push": bson.M{"ownershipHistory": ownership},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateIssuedTicketStatus moves a ticket of the owner in payload out of fromStatus, Data is nil when the ticket changed meanwhile
+func (c commandMongodbRepository) UpdateIssuedTicketStatus(ctx context.Context, payload entity.IssuedTicket, fromStatus string) <-chan wrapper.Result {
+	var issuedTicket entity.IssuedTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &issuedTicket,
+			CollectionName: "issued-tickets",
+			Filter: bson.M{
+				"issuedTicketId": payload.IssuedTicketId,
+				"userId":         payload.UserId,
+				"qrVersion":      payload.QrVersion,
+				"status":         fromStatus,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    payload.Status,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateIssuedTicketResold hands a LISTED ticket to its buyer, it becomes ACTIVE again under a new qr version
+func (c commandMongodbRepository) UpdateIssuedTicketResold(ctx context.Context, payload entity.IssuedTicket, ownership entity.Ownership) <-chan wrapper.Result {
+	var issuedTicket entity.IssuedTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &issuedTicket,
+			CollectionName: "issued-tickets",
+			Filter: bson.M{
+				"issuedTicketId": payload.IssuedTicketId,
+				"userId":         payload.UserId,
+				"qrVersion":      payload.QrVersion,
+				"status":         constants.IssuedTicketStatusListed,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"userId":    ownership.UserId,
+					"status":    constants.IssuedTicketStatusActive,
+					"updatedAt": time.Now(),
+				},
+				"$inc":  bson.M{"qrVersion": 1},
+				"$push": bson.M{"ownershipHistory": ownership},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateIssuedTicketRevoked voids an ACTIVE ticket, a ticket that was already scanned is left untouched
+func (c commandMongodbRepository) UpdateIssuedTicketRevoked(ctx context.Context, issuedTicketId string) <-chan wrapper.Result {
+	var issuedTicket entity.IssuedTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &issuedTicket,
+			CollectionName: "issued-tickets",
+			Filter: bson.M{
+				"issuedTicketId": issuedTicketId,
+				"status":         constants.IssuedTicketStatusActive,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.IssuedTicketStatusRevoked,
+					"updatedAt": time.Now(),
+				},
+				"$inc": bson.M{"qrVersion": 1},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CreateUniqueIndexes keeps one ticket per admission of an order, two IssueTickets calls racing would both insert
+// the missing admissions without it. Tickets issued before admissionIndex existed are left out of the index
+func (c commandMongodbRepository) CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		for _, index := range []mongodb.CreateIndex{
+			{
+				CollectionName: "issued-tickets",
+				Keys:           bson.D{{Key: "issuedTicketId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+			{
+				CollectionName: "issued-tickets",
+				Keys:           bson.D{{Key: "orderId", Value: 1}, {Key: "admissionIndex", Value: 1}},
+				Options: options.Index().SetUnique(true).
+					SetPartialFilterExpression(bson.M{"admissionIndex": bson.M{"$exists": true}}),
+			},
+		} {
+			resp := <-c.mongoDb.CreateIndex(index, ctx)
+			if resp.Error != nil {
+				output <- resp
+				return
+			}
+		}
+		output <- wrapper.Result{Data: "Success create index"}
+	}()
+
+	return output
+}
@@ -0,0 +1,85 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/eticket"
+	"ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) eticket.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindIssuedTicketsByUserId(ctx context.Context, userId string) <-chan wrapper.Result {
+	var issuedTickets []entity.IssuedTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &issuedTickets,
+			CollectionName: "issued-tickets",
+			Filter: bson.M{
+				"userId": userId,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "issuedAt",
+				By:        mongodb.SortDescending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindIssuedTicketsByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result {
+	var issuedTickets []entity.IssuedTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &issuedTickets,
+			CollectionName: "issued-tickets",
+			Filter: bson.M{
+				"orderId": orderId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindIssuedTicketById(ctx context.Context, issuedTicketId string) <-chan wrapper.Result {
+	var issuedTicket entity.IssuedTicket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &issuedTicket,
+			CollectionName: "issued-tickets",
+			Filter: bson.M{
+				"issuedTicketId": issuedTicketId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,221 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ticket-service/internal/modules/access"
+	"ticket-service/internal/modules/eticket"
+	"ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/eticket/models/request"
+	"ticket-service/internal/modules/eticket/models/response"
+	"ticket-service/internal/modules/order"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	eticketRepositoryQuery   eticket.MongodbRepositoryQuery
+	eticketRepositoryCommand eticket.MongodbRepositoryCommand
+	orderRepositoryQuery     order.MongodbRepositoryQuery
+	accessUsecaseCommand     access.UsecaseCommand
+	kafkaProducer            kafkaConfluent.Producer
+	logger                   log.Logger
+}
+
+func NewCommandUsecase(emq eticket.MongodbRepositoryQuery, emc eticket.MongodbRepositoryCommand, omq order.MongodbRepositoryQuery,
+	auc access.UsecaseCommand, kp kafkaConfluent.Producer, log log.Logger) eticket.UsecaseCommand {
+	return commandUsecase{
+		eticketRepositoryQuery:   emq,
+		eticketRepositoryCommand: emc,
+		orderRepositoryQuery:     omq,
+		accessUsecaseCommand:     auc,
+		kafkaProducer:            kp,
+		logger:                   log,
+	}
+}
+
+// IssueTickets is safe to call again for the same order, it only issues the admissions that are still missing
+func (c commandUsecase) IssueTickets(origCtx context.Context, payload request.IssueTicketReq) ([]response.IssuedTicket, error) {
+	domain := "eticketUsecase-IssueTickets"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	orderData := <-c.orderRepositoryQuery.FindOrderById(ctx, payload.OrderId)
+	if orderData.Error != nil {
+		msg := "Error query order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", orderData.Error))
+		return nil, orderData.Error
+	}
+
+	if orderData.Data == nil {
+		msg := "Order Not Found"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+		return nil, errors.NotFound("order not found")
+	}
+
+	orderDetail, ok := orderData.Data.(*orderEntity.Order)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if orderDetail.ResaleListingId != "" {
+		return nil, errors.UnprocessableEntity("resale orders take over the ticket of their listing, nothing is issued")
+	}
+
+	// tickets are only issued for an order the payment has already settled
+	if orderDetail.Status != constants.OrderStatusPaid {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("order with status %s cannot be issued", orderDetail.Status))
+	}
+
+	existingData := <-c.eticketRepositoryQuery.FindIssuedTicketsByOrderId(ctx, orderDetail.OrderId)
+	if existingData.Error != nil {
+		msg := "Error query issued ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", existingData.Error))
+		return nil, existingData.Error
+	}
+
+	issuedTickets := make([]entity.IssuedTicket, 0)
+	if existingData.Data != nil {
+		existing, ok := existingData.Data.(*[]entity.IssuedTicket)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		issuedTickets = append(issuedTickets, *existing...)
+	}
+
+	// every admission has a fixed index in its order, a call racing this one for the same index stores one ticket
+	// and the loser gets that ticket back instead of inserting a second one
+	newTicketIds := make([]string, 0)
+	if len(issuedTickets) < orderDetail.Quantity {
+		issuedIndexes := make(map[int]bool)
+		for _, value := range issuedTickets {
+			issuedIndexes[value.AdmissionIndex] = true
+		}
+
+		for i := 0; i < orderDetail.Quantity; i++ {
+			if issuedIndexes[i] {
+				continue
+			}
+
+			now := time.Now()
+			issuedTicket := entity.IssuedTicket{
+				IssuedTicketId: uuid.NewString(),
+				OrderId:        orderDetail.OrderId,
+				AdmissionIndex: i,
+				UserId:         orderDetail.UserId,
+				TicketId:       orderDetail.TicketId,
+				EventId:        orderDetail.EventId,
+				TicketType:     orderDetail.TicketType,
+				CountryCode:    orderDetail.CountryCode,
+				Seat:           assignSeat(*orderDetail, i),
+				Status:         constants.IssuedTicketStatusActive,
+				QrVersion:      1,
+				OwnershipHistory: []entity.Ownership{
+					{
+						UserId:      orderDetail.UserId,
+						Via:         constants.OwnershipViaPurchase,
+						ReferenceId: orderDetail.OrderId,
+						AcquiredAt:  now,
+					},
+				},
+				IssuedAt:  now,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			resp := <-c.eticketRepositoryCommand.InitIssuedTicket(ctx, issuedTicket)
+			if resp.Error != nil {
+				msg := "Error insert issued ticket"
+				c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+				return nil, resp.Error
+			}
+
+			if resp.Data != nil {
+				existing, ok := resp.Data.(*entity.IssuedTicket)
+				if !ok {
+					return nil, errors.InternalServerError("cannot parsing data")
+				}
+				issuedTickets = append(issuedTickets, *existing)
+				continue
+			}
+			issuedTickets = append(issuedTickets, issuedTicket)
+			newTicketIds = append(newTicketIds, issuedTicket.IssuedTicketId)
+		}
+	}
+
+	if len(newTicketIds) > 0 {
+		issuedEvent := map[string]interface{}{
+			"orderId":         orderDetail.OrderId,
+			"userId":          orderDetail.UserId,
+			"eventId":         orderDetail.EventId,
+			"issuedTicketIds": newTicketIds,
+		}
+		marshaledKafkaData, _ := json.Marshal(issuedEvent)
+		topic := "concert-ticket-issued"
+		c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+		c.logger.Info(ctx, fmt.Sprintf("Send kafka ticket issued, order : %s", orderDetail.OrderId), fmt.Sprintf("%+v", issuedEvent))
+	}
+
+	// an earlier attempt may have stopped before signing, so every online ticket of the order gets its pass here.
+	// A missing pass does not fail the purchase, the ticket itself is already issued
+	for _, value := range issuedTickets {
+		if value.TicketType != constants.TicketTypeOnline || value.Status != constants.IssuedTicketStatusActive {
+			continue
+		}
+		if _, err := c.accessUsecaseCommand.IssuePass(ctx, value.IssuedTicketId); err != nil {
+			msg := "Error issue access pass"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		}
+	}
+
+	return mapIssuedTickets(issuedTickets), nil
+}
+
+// assignSeat gives the i-th admission of the order the i-th seat it held, so a retried issue assigns the same seats
+func assignSeat(orderDetail orderEntity.Order, i int) *entity.Seat {
+	if i >= len(orderDetail.Seats) {
+		return nil
+	}
+	return &entity.Seat{
+		SeatId:  orderDetail.Seats[i].SeatId,
+		Section: orderDetail.Seats[i].Section,
+		Row:     orderDetail.Seats[i].Row,
+		Number:  orderDetail.Seats[i].Number,
+	}
+}
+
+func mapIssuedTickets(issuedTickets []entity.IssuedTicket) []response.IssuedTicket {
+	var collectionData = make([]response.IssuedTicket, 0)
+	for _, value := range issuedTickets {
+		var seat *response.Seat
+		if value.Seat != nil {
+			seat = &response.Seat{
+				Section: value.Seat.Section,
+				Row:     value.Seat.Row,
+				Number:  value.Seat.Number,
+			}
+		}
+		collectionData = append(collectionData, response.IssuedTicket{
+			IssuedTicketId: value.IssuedTicketId,
+			OrderId:        value.OrderId,
+			EventId:        value.EventId,
+			TicketType:     value.TicketType,
+			CountryCode:    value.CountryCode,
+			Seat:           seat,
+			Status:         value.Status,
+			IssuedAt:       value.IssuedAt,
+		})
+	}
+	return collectionData
+}
@@ -0,0 +1,208 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	eticketRequest "ticket-service/internal/modules/eticket/models/request"
+	uc "ticket-service/internal/modules/eticket/usecases"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockaccess "ticket-service/mocks/modules/access"
+	mocketicket "ticket-service/mocks/modules/eticket"
+	mockorder "ticket-service/mocks/modules/order"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockEticketRepositoryQuery   *mocketicket.MongodbRepositoryQuery
+	mockEticketRepositoryCommand *mocketicket.MongodbRepositoryCommand
+	mockOrderRepositoryQuery     *mockorder.MongodbRepositoryQuery
+	mockAccessUsecaseCommand     *mockaccess.UsecaseCommand
+	mockKafkaProducer            *mockkafka.Producer
+	mockLogger                   *mocklog.Logger
+	usecase                      eticket.UsecaseCommand
+	ctx                          context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockEticketRepositoryQuery = &mocketicket.MongodbRepositoryQuery{}
+	suite.mockEticketRepositoryCommand = &mocketicket.MongodbRepositoryCommand{}
+	suite.mockOrderRepositoryQuery = &mockorder.MongodbRepositoryQuery{}
+	suite.mockAccessUsecaseCommand = &mockaccess.UsecaseCommand{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockEticketRepositoryQuery,
+		suite.mockEticketRepositoryCommand,
+		suite.mockOrderRepositoryQuery,
+		suite.mockAccessUsecaseCommand,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestIssueTicketsPaidOrder() {
+	// Arrange
+	payload := eticketRequest.IssueTicketReq{OrderId: "order-id"}
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, payload.OrderId).Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketsByOrderId", mock.Anything, payload.OrderId).Return(mockChannel(helpers.Result{Data: &[]eticketEntity.IssuedTicket{}}))
+	suite.mockEticketRepositoryCommand.On("InitIssuedTicket", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockKafkaProducer.On("Publish", "concert-ticket-issued", mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	result, err := suite.usecase.IssueTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	suite.mockEticketRepositoryCommand.AssertNumberOfCalls(suite.T(), "InitIssuedTicket", 2)
+	suite.mockAccessUsecaseCommand.AssertNotCalled(suite.T(), "IssuePass", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestIssueTicketsOnlineSignsPasses() {
+	// Arrange
+	payload := eticketRequest.IssueTicketReq{OrderId: "order-id"}
+	orderData := getMockOrder(constants.OrderStatusPaid)
+	orderData.Data.(*orderEntity.Order).TicketType = constants.TicketTypeOnline
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, payload.OrderId).Return(mockChannel(orderData))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketsByOrderId", mock.Anything, payload.OrderId).Return(mockChannel(helpers.Result{
+		Data: &[]eticketEntity.IssuedTicket{
+			{IssuedTicketId: "1", OrderId: payload.OrderId, TicketType: constants.TicketTypeOnline, Status: constants.IssuedTicketStatusActive},
+		},
+	}))
+	suite.mockEticketRepositoryCommand.On("InitIssuedTicket", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockKafkaProducer.On("Publish", "concert-ticket-issued", mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockAccessUsecaseCommand.On("IssuePass", mock.Anything, "1").Return(nil, errors.UnprocessableEntity("event schedule is not set yet"))
+	suite.mockAccessUsecaseCommand.On("IssuePass", mock.Anything, mock.Anything).Return(nil, nil)
+
+	// Act
+	result, err := suite.usecase.IssueTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	suite.mockAccessUsecaseCommand.AssertNumberOfCalls(suite.T(), "IssuePass", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestIssueTicketsAlreadyIssued() {
+	// Arrange
+	payload := eticketRequest.IssueTicketReq{OrderId: "order-id"}
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, payload.OrderId).Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketsByOrderId", mock.Anything, payload.OrderId).Return(mockChannel(helpers.Result{
+		Data: &[]eticketEntity.IssuedTicket{
+			{IssuedTicketId: "1", OrderId: payload.OrderId},
+			{IssuedTicketId: "2", OrderId: payload.OrderId},
+		},
+	}))
+
+	// Act
+	result, err := suite.usecase.IssueTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	suite.mockEticketRepositoryCommand.AssertNotCalled(suite.T(), "InitIssuedTicket", mock.Anything, mock.Anything)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestIssueTicketsIssuedConcurrently() {
+	// Arrange
+	payload := eticketRequest.IssueTicketReq{OrderId: "order-id"}
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, payload.OrderId).Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketsByOrderId", mock.Anything, payload.OrderId).Return(mockChannel(helpers.Result{Data: &[]eticketEntity.IssuedTicket{}}))
+	suite.mockEticketRepositoryCommand.On("InitIssuedTicket", mock.Anything, mock.MatchedBy(func(t eticketEntity.IssuedTicket) bool {
+		return t.AdmissionIndex == 0
+	})).Return(mockChannel(helpers.Result{Data: &eticketEntity.IssuedTicket{IssuedTicketId: "1", OrderId: payload.OrderId}}))
+	suite.mockEticketRepositoryCommand.On("InitIssuedTicket", mock.Anything, mock.MatchedBy(func(t eticketEntity.IssuedTicket) bool {
+		return t.AdmissionIndex == 1
+	})).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockKafkaProducer.On("Publish", "concert-ticket-issued", mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	result, err := suite.usecase.IssueTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), "1", result[0].IssuedTicketId)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-ticket-issued", mock.MatchedBy(func(data []byte) bool {
+		var event map[string]interface{}
+		_ = json.Unmarshal(data, &event)
+		return len(event["issuedTicketIds"].([]interface{})) == 1
+	}), mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestIssueTicketsErrNotPaid() {
+	// Arrange
+	payload := eticketRequest.IssueTicketReq{OrderId: "order-id"}
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, payload.OrderId).Return(mockChannel(getMockOrder(constants.OrderStatusPending)))
+
+	// Act
+	_, err := suite.usecase.IssueTickets(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("order with status PENDING cannot be issued"), err)
+	suite.mockEticketRepositoryCommand.AssertNotCalled(suite.T(), "InitIssuedTicket", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestIssueTicketsErrNotFound() {
+	// Arrange
+	payload := eticketRequest.IssueTicketReq{OrderId: "order-id"}
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, payload.OrderId).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	_, err := suite.usecase.IssueTickets(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.NotFound("order not found"), err)
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
+
+func getMockOrder(status string) helpers.Result {
+	return helpers.Result{
+		Data: &orderEntity.Order{
+			OrderId:     "order-id",
+			UserId:      "user-id",
+			TicketId:    "ticket-id",
+			EventId:     "event-id",
+			TicketType:  "Gold",
+			CountryCode: "ID",
+			Quantity:    2,
+			Status:      status,
+			ExpiredAt:   time.Now().Add(constants.OrderHoldDuration),
+		},
+	}
+}
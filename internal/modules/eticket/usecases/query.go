@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/eticket"
+	"ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/eticket/models/request"
+	"ticket-service/internal/modules/eticket/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+const defaultQrSize = 256
+
+type queryUsecase struct {
+	eticketRepositoryQuery eticket.MongodbRepositoryQuery
+	ticketSigner           helpers.TicketSigner
+	logger                 log.Logger
+}
+
+func NewQueryUsecase(emq eticket.MongodbRepositoryQuery, ts helpers.TicketSigner, log log.Logger) eticket.UsecaseQuery {
+	return queryUsecase{
+		eticketRepositoryQuery: emq,
+		ticketSigner:           ts,
+		logger:                 log,
+	}
+}
+
+func (q queryUsecase) FindMyTickets(origCtx context.Context, userId string) ([]response.IssuedTicket, error) {
+	domain := "eticketUsecase-FindMyTickets"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.eticketRepositoryQuery.FindIssuedTicketsByUserId(ctx, userId)
+	if resp.Error != nil {
+		msg := "Error query issued ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return make([]response.IssuedTicket, 0), nil
+	}
+
+	issuedTickets, ok := resp.Data.(*[]entity.IssuedTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	return mapIssuedTickets(*issuedTickets), nil
+}
+
+func (q queryUsecase) FindMyTicketQr(origCtx context.Context, payload request.TicketQrReq) (*response.TicketQr, error) {
+	domain := "eticketUsecase-FindMyTicketQr"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.eticketRepositoryQuery.FindIssuedTicketById(ctx, payload.IssuedTicketId)
+	if resp.Error != nil {
+		msg := "Error query issued ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	issuedTicket, ok := resp.Data.(*entity.IssuedTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	// other users' tickets look exactly like missing ones
+	if issuedTicket.UserId != payload.UserId {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	if issuedTicket.Status == constants.IssuedTicketStatusRevoked {
+		return nil, errors.UnprocessableEntity("ticket has been revoked")
+	}
+
+	if issuedTicket.Status == constants.IssuedTicketStatusListed {
+		return nil, errors.UnprocessableEntity("ticket is listed for resale, cancel the listing to show its qr code")
+	}
+
+	signed, err := q.ticketSigner.SignTicket(helpers.TicketPayload{
+		TicketId:   issuedTicket.IssuedTicketId,
+		EventId:    issuedTicket.EventId,
+		TicketType: issuedTicket.TicketType,
+		UserId:     issuedTicket.UserId,
+		Version:    issuedTicket.QrVersion,
+	})
+	if err != nil {
+		msg := "Error sign ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	if payload.Format == helpers.QrFormatSvg {
+		content, err := helpers.GenerateQrSvg(signed)
+		if err != nil {
+			return nil, errors.InternalServerError("cannot generate qr code")
+		}
+		return &response.TicketQr{ContentType: "image/svg+xml", Content: content}, nil
+	}
+
+	size := payload.Size
+	if size == 0 {
+		size = defaultQrSize
+	}
+	content, err := helpers.GenerateQrPng(signed, size)
+	if err != nil {
+		return nil, errors.InternalServerError("cannot generate qr code")
+	}
+	return &response.TicketQr{ContentType: "image/png", Content: content}, nil
+}
@@ -0,0 +1,126 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	eticketRequest "ticket-service/internal/modules/eticket/models/request"
+	uc "ticket-service/internal/modules/eticket/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mocketicket "ticket-service/mocks/modules/eticket"
+	mockhelpers "ticket-service/mocks/pkg/helpers"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryUsecaseTestSuite struct {
+	suite.Suite
+	mockEticketRepositoryQuery *mocketicket.MongodbRepositoryQuery
+	mockTicketSigner           *mockhelpers.TicketSigner
+	mockLogger                 *mocklog.Logger
+	usecase                    eticket.UsecaseQuery
+	ctx                        context.Context
+}
+
+func (suite *QueryUsecaseTestSuite) SetupTest() {
+	suite.mockEticketRepositoryQuery = &mocketicket.MongodbRepositoryQuery{}
+	suite.mockTicketSigner = &mockhelpers.TicketSigner{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewQueryUsecase(
+		suite.mockEticketRepositoryQuery,
+		suite.mockTicketSigner,
+		suite.mockLogger,
+	)
+}
+
+func TestQueryUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryUsecaseTestSuite))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindMyTickets() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketsByUserId", mock.Anything, "user-id").Return(mockChannel(helpers.Result{
+		Data: &[]eticketEntity.IssuedTicket{getMockIssuedTicket(constants.IssuedTicketStatusActive)},
+	}))
+
+	// Act
+	result, err := suite.usecase.FindMyTickets(suite.ctx, "user-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindMyTicketQrPng() {
+	// Arrange
+	issuedTicket := getMockIssuedTicket(constants.IssuedTicketStatusActive)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").Return(mockChannel(helpers.Result{Data: &issuedTicket}))
+	suite.mockTicketSigner.On("SignTicket", mock.Anything).Return("payload.signature", nil)
+
+	// Act
+	result, err := suite.usecase.FindMyTicketQr(suite.ctx, eticketRequest.TicketQrReq{UserId: "user-id", IssuedTicketId: "issued-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "image/png", result.ContentType)
+	assert.NotEmpty(suite.T(), result.Content)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindMyTicketQrSvg() {
+	// Arrange
+	issuedTicket := getMockIssuedTicket(constants.IssuedTicketStatusActive)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").Return(mockChannel(helpers.Result{Data: &issuedTicket}))
+	suite.mockTicketSigner.On("SignTicket", mock.Anything).Return("payload.signature", nil)
+
+	// Act
+	result, err := suite.usecase.FindMyTicketQr(suite.ctx, eticketRequest.TicketQrReq{UserId: "user-id", IssuedTicketId: "issued-id", Format: "svg"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "image/svg+xml", result.ContentType)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindMyTicketQrErrOtherUser() {
+	// Arrange
+	issuedTicket := getMockIssuedTicket(constants.IssuedTicketStatusActive)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").Return(mockChannel(helpers.Result{Data: &issuedTicket}))
+
+	// Act
+	_, err := suite.usecase.FindMyTicketQr(suite.ctx, eticketRequest.TicketQrReq{UserId: "other-user", IssuedTicketId: "issued-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.NotFound("ticket not found"), err)
+	suite.mockTicketSigner.AssertNotCalled(suite.T(), "SignTicket", mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindMyTicketQrErrRevoked() {
+	// Arrange
+	issuedTicket := getMockIssuedTicket(constants.IssuedTicketStatusRevoked)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").Return(mockChannel(helpers.Result{Data: &issuedTicket}))
+
+	// Act
+	_, err := suite.usecase.FindMyTicketQr(suite.ctx, eticketRequest.TicketQrReq{UserId: "user-id", IssuedTicketId: "issued-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("ticket has been revoked"), err)
+}
+
+func getMockIssuedTicket(status string) eticketEntity.IssuedTicket {
+	return eticketEntity.IssuedTicket{
+		IssuedTicketId: "issued-id",
+		OrderId:        "order-id",
+		UserId:         "user-id",
+		EventId:        "event-id",
+		TicketType:     "Gold",
+		Status:         status,
+		QrVersion:      1,
+	}
+}
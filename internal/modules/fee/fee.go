@@ -0,0 +1,26 @@
+package fee
+
+import (
+	"context"
+	"ticket-service/internal/modules/fee/models/dto"
+	"ticket-service/internal/modules/fee/models/entity"
+	"ticket-service/internal/modules/fee/models/request"
+	"ticket-service/internal/modules/fee/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseCommand interface {
+	UpsertFeeRule(origCtx context.Context, payload request.FeeRuleReq) (*response.FeeRule, error)
+}
+
+type UsecaseQuery interface {
+	CalculateBreakdown(origCtx context.Context, payload dto.BreakdownReq) (*dto.Breakdown, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindFeeRules(ctx context.Context, countryCode string, eventId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertFeeRule(ctx context.Context, rule entity.FeeRule) <-chan wrapper.Result
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/fee"
+	"ticket-service/internal/modules/fee/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FeeHttpHandler struct {
+	FeeUsecaseCommand fee.UsecaseCommand
+	Logger            log.Logger
+	Validator         *validator.Validate
+}
+
+func InitFeeHttpHandler(app *fiber.App, fuc fee.UsecaseCommand, log log.Logger, redisClient redis.Collections) {
+	handler := &FeeHttpHandler{
+		FeeUsecaseCommand: fuc,
+		Logger:            log,
+		Validator:         validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/fees")
+
+	route.Put("/v1/rules", middlewares.VerifyBearer(), adminRole, handler.UpsertFeeRule)
+}
+
+func (f FeeHttpHandler) UpsertFeeRule(c *fiber.Ctx) error {
+	req := new(request.FeeRuleReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, f.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := f.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, f.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := f.FeeUsecaseCommand.UpsertFeeRule(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, f.Logger, err)
+	}
+	return helpers.RespSuccess(c, f.Logger, resp, "Update fee rule success")
+}
@@ -0,0 +1,27 @@
+package dto
+
+type BreakdownReq struct {
+	EventId     string
+	CountryCode string
+	TicketPrice int
+	Quantity    int
+	Discount    int
+}
+
+// Breakdown is what an order is charged, Total is FaceValue less Discount with the fees and taxes added
+type Breakdown struct {
+	FaceValue   int
+	Discount    int
+	ServiceFee  int
+	FacilityFee int
+	Taxes       []TaxLine
+	TotalTax    int
+	Total       int
+}
+
+type TaxLine struct {
+	Name         string
+	Jurisdiction string
+	Percent      int
+	Amount       int
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// FeeRule is configured per country, a rule with an EventId replaces the country rule for that event
+type FeeRule struct {
+	CountryCode       string    `json:"countryCode" bson:"countryCode"`
+	EventId           string    `json:"eventId" bson:"eventId"`
+	ServiceFeePercent int       `json:"serviceFeePercent" bson:"serviceFeePercent"`
+	ServiceFeeFlat    int       `json:"serviceFeeFlat" bson:"serviceFeeFlat"`
+	FacilityFee       int       `json:"facilityFee" bson:"facilityFee"`
+	Taxes             []Tax     `json:"taxes" bson:"taxes"`
+	CreatedAt         time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Tax is charged by one jurisdiction on the ticket price after discounts, and on the fees too when IncludeFees is set
+type Tax struct {
+	Name         string `json:"name" bson:"name"`
+	Jurisdiction string `json:"jurisdiction" bson:"jurisdiction"`
+	Percent      int    `json:"percent" bson:"percent"`
+	IncludeFees  bool   `json:"includeFees" bson:"includeFees"`
+}
@@ -0,0 +1,17 @@
+package request
+
+type FeeRuleReq struct {
+	CountryCode       string   `json:"countryCode" validate:"required"`
+	EventId           string   `json:"eventId"`
+	ServiceFeePercent int      `json:"serviceFeePercent" validate:"min=0,max=100"`
+	ServiceFeeFlat    int      `json:"serviceFeeFlat" validate:"min=0"`
+	FacilityFee       int      `json:"facilityFee" validate:"min=0"`
+	Taxes             []TaxReq `json:"taxes" validate:"max=5,dive"`
+}
+
+type TaxReq struct {
+	Name         string `json:"name" validate:"required"`
+	Jurisdiction string `json:"jurisdiction" validate:"required"`
+	Percent      int    `json:"percent" validate:"required,min=1,max=100"`
+	IncludeFees  bool   `json:"includeFees"`
+}
@@ -0,0 +1,17 @@
+package response
+
+type FeeRule struct {
+	CountryCode       string `json:"countryCode"`
+	EventId           string `json:"eventId,omitempty"`
+	ServiceFeePercent int    `json:"serviceFeePercent"`
+	ServiceFeeFlat    string `json:"serviceFeeFlat"`
+	FacilityFee       string `json:"facilityFee"`
+	Taxes             []Tax  `json:"taxes"`
+}
+
+type Tax struct {
+	Name         string `json:"name"`
+	Jurisdiction string `json:"jurisdiction"`
+	Percent      int    `json:"percent"`
+	IncludeFees  bool   `json:"includeFees"`
+}
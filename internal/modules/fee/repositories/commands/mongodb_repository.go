@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/fee"
+	"ticket-service/internal/modules/fee/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) fee.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) UpsertFeeRule(ctx context.Context, rule entity.FeeRule) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "fee-rules",
+			Filter: bson.M{
+				"countryCode": rule.CountryCode,
+				"eventId":     rule.EventId,
+			},
+			Document: rule,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,45 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/fee"
+	"ticket-service/internal/modules/fee/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) fee.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// FindFeeRules finds the rule of the event and the rule of its country, either may be missing
+func (q queryMongodbRepository) FindFeeRules(ctx context.Context, countryCode string, eventId string) <-chan wrapper.Result {
+	var rules []entity.FeeRule
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &rules,
+			CollectionName: "fee-rules",
+			Filter: bson.M{
+				"countryCode": countryCode,
+				"eventId":     bson.M{"$in": bson.A{eventId, ""}},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/fee"
+	"ticket-service/internal/modules/fee/models/entity"
+	"ticket-service/internal/modules/fee/models/request"
+	"ticket-service/internal/modules/fee/models/response"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	feeRepositoryCommand fee.MongodbRepositoryCommand
+	logger               log.Logger
+}
+
+func NewCommandUsecase(fmc fee.MongodbRepositoryCommand, log log.Logger) fee.UsecaseCommand {
+	return commandUsecase{
+		feeRepositoryCommand: fmc,
+		logger:               log,
+	}
+}
+
+func (c commandUsecase) UpsertFeeRule(origCtx context.Context, payload request.FeeRuleReq) (*response.FeeRule, error) {
+	domain := "feeUsecase-UpsertFeeRule"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	now := time.Now()
+	rule := entity.FeeRule{
+		CountryCode:       payload.CountryCode,
+		EventId:           payload.EventId,
+		ServiceFeePercent: payload.ServiceFeePercent,
+		ServiceFeeFlat:    payload.ServiceFeeFlat,
+		FacilityFee:       payload.FacilityFee,
+		Taxes:             make([]entity.Tax, 0),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	for _, value := range payload.Taxes {
+		rule.Taxes = append(rule.Taxes, entity.Tax{
+			Name:         value.Name,
+			Jurisdiction: value.Jurisdiction,
+			Percent:      value.Percent,
+			IncludeFees:  value.IncludeFees,
+		})
+	}
+
+	resp := <-c.feeRepositoryCommand.UpsertFeeRule(ctx, rule)
+	if resp.Error != nil {
+		msg := "Error upsert fee rule"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapFeeRule(rule), nil
+}
+
+func mapFeeRule(rule entity.FeeRule) *response.FeeRule {
+	taxes := make([]response.Tax, 0)
+	for _, value := range rule.Taxes {
+		taxes = append(taxes, response.Tax{
+			Name:         value.Name,
+			Jurisdiction: value.Jurisdiction,
+			Percent:      value.Percent,
+			IncludeFees:  value.IncludeFees,
+		})
+	}
+	return &response.FeeRule{
+		CountryCode:       rule.CountryCode,
+		EventId:           rule.EventId,
+		ServiceFeePercent: rule.ServiceFeePercent,
+		ServiceFeeFlat:    fmt.Sprintf("$%d", rule.ServiceFeeFlat),
+		FacilityFee:       fmt.Sprintf("$%d", rule.FacilityFee),
+		Taxes:             taxes,
+	}
+}
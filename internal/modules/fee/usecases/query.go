@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/fee"
+	"ticket-service/internal/modules/fee/models/dto"
+	"ticket-service/internal/modules/fee/models/entity"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	feeRepositoryQuery fee.MongodbRepositoryQuery
+	logger             log.Logger
+}
+
+func NewQueryUsecase(fmq fee.MongodbRepositoryQuery, log log.Logger) fee.UsecaseQuery {
+	return queryUsecase{
+		feeRepositoryQuery: fmq,
+		logger:             log,
+	}
+}
+
+// CalculateBreakdown prices an order with the fees and taxes of its event, or of its country when the event has none.
+// The quote and the order both come from here, so a user is charged the total they were shown
+func (q queryUsecase) CalculateBreakdown(origCtx context.Context, payload dto.BreakdownReq) (*dto.Breakdown, error) {
+	domain := "feeUsecase-CalculateBreakdown"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.feeRepositoryQuery.FindFeeRules(ctx, payload.CountryCode, payload.EventId)
+	if resp.Error != nil {
+		msg := "Error query fee rule"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	var rules []entity.FeeRule
+	if resp.Data != nil {
+		found, ok := resp.Data.(*[]entity.FeeRule)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		rules = *found
+	}
+
+	return calculateBreakdown(selectFeeRule(rules, payload.EventId), payload), nil
+}
+
+// selectFeeRule picks the rule of the event first, then the rule of the country, and falls back to a rule
+// without fees or taxes when neither is configured
+func selectFeeRule(rules []entity.FeeRule, eventId string) entity.FeeRule {
+	for _, value := range rules {
+		if eventId != "" && value.EventId == eventId {
+			return value
+		}
+	}
+	for _, value := range rules {
+		if value.EventId == "" {
+			return value
+		}
+	}
+	return entity.FeeRule{}
+}
+
+// calculateBreakdown applies the percentages to the price after discounts, rounding each line half up
+func calculateBreakdown(rule entity.FeeRule, payload dto.BreakdownReq) *dto.Breakdown {
+	breakdown := &dto.Breakdown{
+		FaceValue: payload.TicketPrice * payload.Quantity,
+		Discount:  payload.Discount,
+		Taxes:     make([]dto.TaxLine, 0),
+	}
+	net := breakdown.FaceValue - breakdown.Discount
+	breakdown.ServiceFee = percentOf(net, rule.ServiceFeePercent) + rule.ServiceFeeFlat*payload.Quantity
+	breakdown.FacilityFee = rule.FacilityFee * payload.Quantity
+
+	fees := breakdown.ServiceFee + breakdown.FacilityFee
+	for _, value := range rule.Taxes {
+		base := net
+		if value.IncludeFees {
+			base += fees
+		}
+		line := dto.TaxLine{
+			Name:         value.Name,
+			Jurisdiction: value.Jurisdiction,
+			Percent:      value.Percent,
+			Amount:       percentOf(base, value.Percent),
+		}
+		breakdown.Taxes = append(breakdown.Taxes, line)
+		breakdown.TotalTax += line.Amount
+	}
+
+	breakdown.Total = net + fees + breakdown.TotalTax
+	return breakdown
+}
+
+func percentOf(amount int, percent int) int {
+	return (amount*percent + 50) / 100
+}
@@ -0,0 +1,157 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"ticket-service/internal/modules/fee"
+	"ticket-service/internal/modules/fee/models/dto"
+	"ticket-service/internal/modules/fee/models/entity"
+	uc "ticket-service/internal/modules/fee/usecases"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockfee "ticket-service/mocks/modules/fee"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryUsecaseTestSuite struct {
+	suite.Suite
+	mockFeeRepositoryQuery *mockfee.MongodbRepositoryQuery
+	mockLogger             *mocklog.Logger
+	usecase                fee.UsecaseQuery
+	ctx                    context.Context
+}
+
+func (suite *QueryUsecaseTestSuite) SetupTest() {
+	suite.mockFeeRepositoryQuery = &mockfee.MongodbRepositoryQuery{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewQueryUsecase(
+		suite.mockFeeRepositoryQuery,
+		suite.mockLogger,
+	)
+}
+
+func TestQueryUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryUsecaseTestSuite))
+}
+
+func (suite *QueryUsecaseTestSuite) TestCalculateBreakdownCountryRule() {
+	// Arrange
+	suite.mockFeeRepositoryQuery.On("FindFeeRules", mock.Anything, "ID", "event-id").
+		Return(mockChannel(helpers.Result{Data: &[]entity.FeeRule{getMockFeeRule("")}}))
+
+	// Act
+	result, err := suite.usecase.CalculateBreakdown(suite.ctx, getBreakdownReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 200, result.FaceValue)
+	// 5% of 180 and $2 for each ticket
+	assert.Equal(suite.T(), 13, result.ServiceFee)
+	assert.Equal(suite.T(), 6, result.FacilityFee)
+	// PPN on 180 + 19 of fees rounds 21.89 up, the entertainment tax is on the ticket price only
+	assert.Equal(suite.T(), []dto.TaxLine{
+		{Name: "PPN", Jurisdiction: "ID", Percent: 11, Amount: 22},
+		{Name: "Pajak Hiburan", Jurisdiction: "ID-JK", Percent: 10, Amount: 18},
+	}, result.Taxes)
+	assert.Equal(suite.T(), 40, result.TotalTax)
+	assert.Equal(suite.T(), 180+13+6+40, result.Total)
+}
+
+func (suite *QueryUsecaseTestSuite) TestCalculateBreakdownEventRule() {
+	// Arrange
+	eventRule := getMockFeeRule("event-id")
+	eventRule.ServiceFeePercent = 0
+	eventRule.ServiceFeeFlat = 0
+	eventRule.Taxes = nil
+	suite.mockFeeRepositoryQuery.On("FindFeeRules", mock.Anything, "ID", "event-id").
+		Return(mockChannel(helpers.Result{Data: &[]entity.FeeRule{getMockFeeRule(""), eventRule}}))
+
+	// Act
+	result, err := suite.usecase.CalculateBreakdown(suite.ctx, getBreakdownReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.ServiceFee)
+	assert.Equal(suite.T(), 0, result.TotalTax)
+	assert.Equal(suite.T(), 186, result.Total)
+}
+
+func (suite *QueryUsecaseTestSuite) TestCalculateBreakdownDefaultRule() {
+	// Arrange
+	suite.mockFeeRepositoryQuery.On("FindFeeRules", mock.Anything, "ID", "event-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	result, err := suite.usecase.CalculateBreakdown(suite.ctx, getBreakdownReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 180, result.Total)
+	assert.Empty(suite.T(), result.Taxes)
+}
+
+func (suite *QueryUsecaseTestSuite) TestCalculateBreakdownErr() {
+	// Arrange
+	suite.mockFeeRepositoryQuery.On("FindFeeRules", mock.Anything, "ID", "event-id").
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	_, err := suite.usecase.CalculateBreakdown(suite.ctx, getBreakdownReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.InternalServerError("error"), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestCalculateBreakdownErrParse() {
+	// Arrange
+	suite.mockFeeRepositoryQuery.On("FindFeeRules", mock.Anything, "ID", "event-id").
+		Return(mockChannel(helpers.Result{Data: "invalid"}))
+
+	// Act
+	_, err := suite.usecase.CalculateBreakdown(suite.ctx, getBreakdownReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.InternalServerError("cannot parsing data"), err)
+}
+
+func getBreakdownReq() dto.BreakdownReq {
+	return dto.BreakdownReq{
+		EventId:     "event-id",
+		CountryCode: "ID",
+		TicketPrice: 100,
+		Quantity:    2,
+		Discount:    20,
+	}
+}
+
+func getMockFeeRule(eventId string) entity.FeeRule {
+	return entity.FeeRule{
+		CountryCode:       "ID",
+		EventId:           eventId,
+		ServiceFeePercent: 5,
+		ServiceFeeFlat:    2,
+		FacilityFee:       3,
+		Taxes: []entity.Tax{
+			{Name: "PPN", Jurisdiction: "ID", Percent: 11, IncludeFees: true},
+			{Name: "Pajak Hiburan", Jurisdiction: "ID-JK", Percent: 10},
+		},
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
@@ -0,0 +1,28 @@
+package fx
+
+import (
+	"context"
+	"ticket-service/internal/modules/fx/models/dto"
+	"ticket-service/internal/modules/fx/models/entity"
+	"ticket-service/internal/modules/fx/models/request"
+	"ticket-service/internal/modules/fx/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseCommand interface {
+	ImportRates(origCtx context.Context, payload request.RatesReq) (*response.Snapshot, error)
+	LoadRatesFile(origCtx context.Context, path string) (*response.Snapshot, error)
+}
+
+type UsecaseQuery interface {
+	FindLatestRates(origCtx context.Context) (*response.Snapshot, error)
+	FindDisplayRate(origCtx context.Context, payload dto.DisplayReq) (*dto.Rate, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindLatestSnapshot(ctx context.Context) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertSnapshot(ctx context.Context, snapshot entity.RateSnapshot) <-chan wrapper.Result
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/fx"
+	"ticket-service/internal/modules/fx/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FxHttpHandler struct {
+	FxUsecaseCommand fx.UsecaseCommand
+	FxUsecaseQuery   fx.UsecaseQuery
+	Logger           log.Logger
+	Validator        *validator.Validate
+}
+
+func InitFxHttpHandler(app *fiber.App, xuc fx.UsecaseCommand, xuq fx.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &FxHttpHandler{
+		FxUsecaseCommand: xuc,
+		FxUsecaseQuery:   xuq,
+		Logger:           log,
+		Validator:        validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/fx")
+
+	route.Get("/v1/rates", middlewares.VerifyBearer(), handler.GetRates)
+	route.Post("/v1/rates", middlewares.VerifyBearer(), adminRole, handler.ImportRates)
+}
+
+func (x FxHttpHandler) GetRates(c *fiber.Ctx) error {
+	resp, err := x.FxUsecaseQuery.FindLatestRates(c.Context())
+	if err != nil {
+		return helpers.RespCustomError(c, x.Logger, err)
+	}
+	return helpers.RespSuccess(c, x.Logger, resp, "Get exchange rate success")
+}
+
+func (x FxHttpHandler) ImportRates(c *fiber.Ctx) error {
+	req := new(request.RatesReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, x.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := x.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, x.Logger, errors.BadRequest(err.Error()))
+	}
+	req.Source = constants.RateSourceAdmin
+	resp, err := x.FxUsecaseCommand.ImportRates(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, x.Logger, err)
+	}
+	return helpers.RespSuccess(c, x.Logger, resp, "Import exchange rate success")
+}
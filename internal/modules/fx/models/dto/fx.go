@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DisplayReq asks for the currency a user wants to see, Currency wins over the country of the user
+type DisplayReq struct {
+	Currency string
+	UserId   string
+}
+
+// Rate converts native prices for display, converted amounts are indicative and never charged
+type Rate struct {
+	Currency string
+	Rate     float64
+	Decimals int
+	AsOf     time.Time
+}
+
+// Format shows amount, given in the native currency, in the currency of the rate
+func (r Rate) Format(amount int) string {
+	scale := math.Pow(10, float64(r.Decimals))
+	converted := math.Round(float64(amount)*r.Rate*scale) / scale
+	return fmt.Sprintf("%s %.*f", r.Currency, r.Decimals, converted)
+}
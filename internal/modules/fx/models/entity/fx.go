@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// RateSnapshot holds how much of each currency one unit of Base buys as of AsOf, the latest AsOf is used
+type RateSnapshot struct {
+	SnapshotId string             `json:"snapshotId" bson:"snapshotId"`
+	Base       string             `json:"base" bson:"base"`
+	Rates      map[string]float64 `json:"rates" bson:"rates"`
+	Source     string             `json:"source" bson:"source"`
+	AsOf       time.Time          `json:"asOf" bson:"asOf"`
+	CreatedAt  time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// CrossRate is how much of currency one unit of from buys, ok is false when the snapshot misses either of them
+func (s RateSnapshot) CrossRate(from string, currency string) (float64, bool) {
+	fromRate, ok := s.rate(from)
+	if !ok || fromRate == 0 {
+		return 0, false
+	}
+	toRate, ok := s.rate(currency)
+	if !ok {
+		return 0, false
+	}
+	return toRate / fromRate, true
+}
+
+func (s RateSnapshot) rate(currency string) (float64, bool) {
+	if currency == s.Base {
+		return 1, true
+	}
+	rate, ok := s.Rates[currency]
+	return rate, ok
+}
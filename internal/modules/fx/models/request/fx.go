@@ -0,0 +1,10 @@
+package request
+
+import "time"
+
+type RatesReq struct {
+	Base   string             `json:"base" validate:"required,len=3"`
+	AsOf   time.Time          `json:"asOf" validate:"required"`
+	Rates  map[string]float64 `json:"rates" validate:"required,min=1,dive,keys,len=3,endkeys,gt=0"`
+	Source string             `json:"-"`
+}
@@ -0,0 +1,11 @@
+package response
+
+import "time"
+
+type Snapshot struct {
+	SnapshotId string             `json:"snapshotId"`
+	Base       string             `json:"base"`
+	Rates      map[string]float64 `json:"rates"`
+	Source     string             `json:"source"`
+	AsOf       time.Time          `json:"asOf"`
+}
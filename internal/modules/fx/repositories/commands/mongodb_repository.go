@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/fx"
+	"ticket-service/internal/modules/fx/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) fx.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// UpsertSnapshot is keyed by snapshotId, so every pod loading the same file at startup writes one snapshot
+func (c commandMongodbRepository) UpsertSnapshot(ctx context.Context, snapshot entity.RateSnapshot) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "fx-rates",
+			Filter: bson.M{
+				"snapshotId": snapshot.SnapshotId,
+			},
+			Document: snapshot,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
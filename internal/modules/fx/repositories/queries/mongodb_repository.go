@@ -0,0 +1,48 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/fx"
+	"ticket-service/internal/modules/fx/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) fx.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// FindLatestSnapshot returns a list holding the snapshot with the latest asOf, it is empty before any rates are loaded
+func (q queryMongodbRepository) FindLatestSnapshot(ctx context.Context) <-chan wrapper.Result {
+	var snapshots []entity.RateSnapshot
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindAllData(mongodb.FindAllData{
+			Result:         &snapshots,
+			CollectionName: "fx-rates",
+			Filter:         bson.M{},
+			Sort: &mongodb.Sort{
+				FieldName: "asOf",
+				By:        mongodb.SortDescending,
+			},
+			Page: 1,
+			Size: 1,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"ticket-service/internal/modules/fx"
+	"ticket-service/internal/modules/fx/models/entity"
+	"ticket-service/internal/modules/fx/models/request"
+	"ticket-service/internal/modules/fx/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	fxRepositoryCommand fx.MongodbRepositoryCommand
+	logger              log.Logger
+}
+
+func NewCommandUsecase(xmc fx.MongodbRepositoryCommand, log log.Logger) fx.UsecaseCommand {
+	return commandUsecase{
+		fxRepositoryCommand: xmc,
+		logger:              log,
+	}
+}
+
+// ImportRates stores a snapshot, loading the same base and asOf again replaces it instead of adding another
+func (c commandUsecase) ImportRates(origCtx context.Context, payload request.RatesReq) (*response.Snapshot, error) {
+	domain := "fxUsecase-ImportRates"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	base := strings.ToUpper(payload.Base)
+	snapshot := entity.RateSnapshot{
+		SnapshotId: fmt.Sprintf("%s-%s", base, payload.AsOf.UTC().Format(time.RFC3339)),
+		Base:       base,
+		Rates:      make(map[string]float64),
+		Source:     payload.Source,
+		AsOf:       payload.AsOf,
+		CreatedAt:  time.Now(),
+	}
+	for currency, rate := range payload.Rates {
+		snapshot.Rates[strings.ToUpper(currency)] = rate
+	}
+
+	// a snapshot that cannot price the native currency could never convert anything
+	if _, ok := snapshot.CrossRate(constants.NativeCurrency, base); !ok {
+		return nil, errors.BadRequest(fmt.Sprintf("rates must include %s", constants.NativeCurrency))
+	}
+
+	resp := <-c.fxRepositoryCommand.UpsertSnapshot(ctx, snapshot)
+	if resp.Error != nil {
+		msg := "Error upsert exchange rate"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapSnapshot(snapshot), nil
+}
+
+// LoadRatesFile imports the snapshot kept in a json file with the same shape as the admin request
+func (c commandUsecase) LoadRatesFile(origCtx context.Context, path string) (*response.Snapshot, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.BadRequest(fmt.Sprintf("cannot read exchange rate file: %s", err.Error()))
+	}
+
+	var payload request.RatesReq
+	if err := json.Unmarshal(content, &payload); err != nil {
+		return nil, errors.BadRequest(fmt.Sprintf("cannot parse exchange rate file: %s", err.Error()))
+	}
+
+	if err := validator.New().Struct(payload); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	payload.Source = constants.RateSourceFile
+	return c.ImportRates(origCtx, payload)
+}
+
+func mapSnapshot(snapshot entity.RateSnapshot) *response.Snapshot {
+	return &response.Snapshot{
+		SnapshotId: snapshot.SnapshotId,
+		Base:       snapshot.Base,
+		Rates:      snapshot.Rates,
+		Source:     snapshot.Source,
+		AsOf:       snapshot.AsOf,
+	}
+}
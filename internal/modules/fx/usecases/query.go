@@ -0,0 +1,130 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"ticket-service/internal/modules/fx"
+	"ticket-service/internal/modules/fx/models/dto"
+	"ticket-service/internal/modules/fx/models/entity"
+	"ticket-service/internal/modules/fx/models/response"
+	"ticket-service/internal/modules/user"
+	userEntity "ticket-service/internal/modules/user/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	fxRepositoryQuery   fx.MongodbRepositoryQuery
+	userRepositoryQuery user.MongodbRepositoryQuery
+	logger              log.Logger
+}
+
+func NewQueryUsecase(xmq fx.MongodbRepositoryQuery, umq user.MongodbRepositoryQuery, log log.Logger) fx.UsecaseQuery {
+	return queryUsecase{
+		fxRepositoryQuery:   xmq,
+		userRepositoryQuery: umq,
+		logger:              log,
+	}
+}
+
+func (q queryUsecase) FindLatestRates(origCtx context.Context) (*response.Snapshot, error) {
+	domain := "fxUsecase-FindLatestRates"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	snapshot, err := q.findLatestSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot == nil {
+		return nil, errors.NotFound("exchange rates not found")
+	}
+	return mapSnapshot(*snapshot), nil
+}
+
+// FindDisplayRate picks the currency to show and its rate from the native currency. It returns nil when prices
+// are to be shown as they are: the user wants the native currency, or no loaded snapshot has their currency
+func (q queryUsecase) FindDisplayRate(origCtx context.Context, payload dto.DisplayReq) (*dto.Rate, error) {
+	domain := "fxUsecase-FindDisplayRate"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	currency := strings.ToUpper(payload.Currency)
+	if currency == "" && payload.UserId != "" {
+		currency = q.findUserCurrency(ctx, payload.UserId)
+	}
+
+	if currency == "" || currency == constants.NativeCurrency {
+		return nil, nil
+	}
+
+	snapshot, err := q.findLatestSnapshot(ctx)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+
+	rate, ok := snapshot.CrossRate(constants.NativeCurrency, currency)
+	if !ok {
+		return nil, nil
+	}
+
+	decimals := 2
+	if constants.ZeroDecimalCurrencies[currency] {
+		decimals = 0
+	}
+	return &dto.Rate{
+		Currency: currency,
+		Rate:     rate,
+		Decimals: decimals,
+		AsOf:     snapshot.AsOf,
+	}, nil
+}
+
+// findUserCurrency is best effort, a user that cannot be found sees the native currency
+func (q queryUsecase) findUserCurrency(ctx context.Context, userId string) string {
+	resp := <-q.userRepositoryQuery.FindOneUserId(ctx, userId)
+	if resp.Error != nil || resp.Data == nil {
+		return ""
+	}
+
+	userDetail, ok := resp.Data.(*userEntity.User)
+	if !ok {
+		return ""
+	}
+	return constants.CountryCurrencies[strings.ToUpper(userDetail.Country.Code)]
+}
+
+func (q queryUsecase) findLatestSnapshot(ctx context.Context) (*entity.RateSnapshot, error) {
+	resp := <-q.fxRepositoryQuery.FindLatestSnapshot(ctx)
+	if resp.Error != nil {
+		msg := "Error query exchange rate"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	snapshots, ok := resp.Data.(*[]entity.RateSnapshot)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if len(*snapshots) == 0 {
+		return nil, nil
+	}
+	return &(*snapshots)[0], nil
+}
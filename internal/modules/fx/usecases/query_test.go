@@ -0,0 +1,154 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticket-service/internal/modules/fx"
+	"ticket-service/internal/modules/fx/models/dto"
+	"ticket-service/internal/modules/fx/models/entity"
+	uc "ticket-service/internal/modules/fx/usecases"
+	userEntity "ticket-service/internal/modules/user/models/entity"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockfx "ticket-service/mocks/modules/fx"
+	mockuser "ticket-service/mocks/modules/user"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryUsecaseTestSuite struct {
+	suite.Suite
+	mockFxRepositoryQuery   *mockfx.MongodbRepositoryQuery
+	mockUserRepositoryQuery *mockuser.MongodbRepositoryQuery
+	mockLogger              *mocklog.Logger
+	usecase                 fx.UsecaseQuery
+	ctx                     context.Context
+}
+
+func (suite *QueryUsecaseTestSuite) SetupTest() {
+	suite.mockFxRepositoryQuery = &mockfx.MongodbRepositoryQuery{}
+	suite.mockUserRepositoryQuery = &mockuser.MongodbRepositoryQuery{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewQueryUsecase(
+		suite.mockFxRepositoryQuery,
+		suite.mockUserRepositoryQuery,
+		suite.mockLogger,
+	)
+}
+
+func TestQueryUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryUsecaseTestSuite))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindDisplayRate() {
+	// Arrange
+	suite.mockFxRepositoryQuery.On("FindLatestSnapshot", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.RateSnapshot{getMockSnapshot()}}))
+
+	// Act
+	result, err := suite.usecase.FindDisplayRate(suite.ctx, dto.DisplayReq{Currency: "idr"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "IDR", result.Currency)
+	assert.Equal(suite.T(), 0, result.Decimals)
+	assert.Equal(suite.T(), "IDR 1550000", result.Format(100))
+	suite.mockUserRepositoryQuery.AssertNotCalled(suite.T(), "FindOneUserId", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindDisplayRateCrossBase() {
+	// Arrange
+	snapshot := getMockSnapshot()
+	snapshot.Base = "EUR"
+	snapshot.Rates = map[string]float64{"USD": 1.25, "GBP": 0.85}
+	suite.mockFxRepositoryQuery.On("FindLatestSnapshot", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.RateSnapshot{snapshot}}))
+
+	// Act
+	result, err := suite.usecase.FindDisplayRate(suite.ctx, dto.DisplayReq{Currency: "GBP"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "GBP 68.00", result.Format(100))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindDisplayRateUserCountry() {
+	// Arrange
+	suite.mockUserRepositoryQuery.On("FindOneUserId", mock.Anything, "user-id").
+		Return(mockChannel(helpers.Result{Data: &userEntity.User{Country: userEntity.Country{Code: "DE"}}}))
+	suite.mockFxRepositoryQuery.On("FindLatestSnapshot", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.RateSnapshot{getMockSnapshot()}}))
+
+	// Act
+	result, err := suite.usecase.FindDisplayRate(suite.ctx, dto.DisplayReq{UserId: "user-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "EUR", result.Currency)
+	assert.Equal(suite.T(), "EUR 92.50", result.Format(100))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindDisplayRateNativeCurrency() {
+	// Arrange
+	suite.mockUserRepositoryQuery.On("FindOneUserId", mock.Anything, "user-id").
+		Return(mockChannel(helpers.Result{Data: &userEntity.User{Country: userEntity.Country{Code: "US"}}}))
+
+	// Act
+	result, err := suite.usecase.FindDisplayRate(suite.ctx, dto.DisplayReq{UserId: "user-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	suite.mockFxRepositoryQuery.AssertNotCalled(suite.T(), "FindLatestSnapshot", mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindDisplayRateMissingRate() {
+	// Arrange
+	suite.mockFxRepositoryQuery.On("FindLatestSnapshot", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.RateSnapshot{getMockSnapshot()}}))
+
+	// Act
+	result, err := suite.usecase.FindDisplayRate(suite.ctx, dto.DisplayReq{Currency: "JPY"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindLatestRatesErrNotFound() {
+	// Arrange
+	suite.mockFxRepositoryQuery.On("FindLatestSnapshot", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.RateSnapshot{}}))
+
+	// Act
+	_, err := suite.usecase.FindLatestRates(suite.ctx)
+
+	// Assert
+	assert.Equal(suite.T(), errors.NotFound("exchange rates not found"), err)
+}
+
+func getMockSnapshot() entity.RateSnapshot {
+	return entity.RateSnapshot{
+		SnapshotId: "USD-2026-10-01T00:00:00Z",
+		Base:       "USD",
+		Rates:      map[string]float64{"IDR": 15500, "EUR": 0.925},
+		AsOf:       time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
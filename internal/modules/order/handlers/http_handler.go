@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/order"
+	"ticket-service/internal/modules/order/models/dto"
+	"ticket-service/internal/modules/order/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type OrderHttpHandler struct {
+	OrderUsecaseCommand order.UsecaseCommand
+	OrderUsecaseQuery   order.UsecaseQuery
+	Logger              log.Logger
+	Validator           *validator.Validate
+}
+
+func InitOrderHttpHandler(app *fiber.App, ouc order.UsecaseCommand, ouq order.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &OrderHttpHandler{
+		OrderUsecaseCommand: ouc,
+		OrderUsecaseQuery:   ouq,
+		Logger:              log,
+		Validator:           validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/orders")
+
+	route.Post("/v1/reserve", middlewares.VerifyBearer(), handler.CreateReservation)
+	route.Post("/v1/:id/cancel", middlewares.VerifyBearer(), handler.CancelReservation)
+	route.Get("/v1/limits/:eventId", middlewares.VerifyBearer(), handler.GetPurchaseLimit)
+	route.Put("/v1/limits", middlewares.VerifyBearer(), adminRole, handler.UpsertPurchaseLimit)
+}
+
+func (o OrderHttpHandler) CreateReservation(c *fiber.Ctx) error {
+	req := new(request.ReservationReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := o.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, o.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	userRole, _ := c.Locals("userRole").(string)
+	resp, err := o.OrderUsecaseCommand.CreateReservation(c.Context(), dto.ReservationReq{
+		UserId:       userId,
+		UserRole:     userRole,
+		EventId:      req.EventId,
+		CountryCode:  req.CountryCode,
+		TicketType:   req.TicketType,
+		Quantity:     req.Quantity,
+		VoucherCodes: req.VoucherCodes,
+		SeatIds:      req.SeatIds,
+	})
+	if err != nil {
+		return helpers.RespCustomError(c, o.Logger, err)
+	}
+	return helpers.RespSuccess(c, o.Logger, resp, "Create reservation success")
+}
+
+func (o OrderHttpHandler) CancelReservation(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, o.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.CancelReservationReq{
+		UserId:  userId,
+		OrderId: c.Params("id"),
+	}
+	resp, err := o.OrderUsecaseCommand.CancelReservation(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, o.Logger, err)
+	}
+	return helpers.RespSuccess(c, o.Logger, resp, "Cancel reservation success")
+}
+
+func (o OrderHttpHandler) GetPurchaseLimit(c *fiber.Ctx) error {
+	eventId := c.Params("eventId")
+	if eventId == "" {
+		return helpers.RespError(c, o.Logger, errors.BadRequest("eventId is required"))
+	}
+	resp, err := o.OrderUsecaseQuery.FindPurchaseLimit(c.Context(), eventId)
+	if err != nil {
+		return helpers.RespCustomError(c, o.Logger, err)
+	}
+	return helpers.RespSuccess(c, o.Logger, resp, "Get purchase limit success")
+}
+
+func (o OrderHttpHandler) UpsertPurchaseLimit(c *fiber.Ctx) error {
+	req := new(request.PurchaseLimitReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := o.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, o.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := o.OrderUsecaseCommand.UpsertPurchaseLimit(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, o.Logger, err)
+	}
+	return helpers.RespSuccess(c, o.Logger, resp, "Update purchase limit success")
+}
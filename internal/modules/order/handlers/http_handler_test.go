@@ -0,0 +1,163 @@
+package handlers_test
+
+import (
+	"testing"
+	"ticket-service/internal/modules/order/handlers"
+	"ticket-service/internal/modules/order/models/dto"
+	"ticket-service/internal/modules/order/models/response"
+	"ticket-service/internal/pkg/errors"
+	mockorder "ticket-service/mocks/modules/order"
+	mocklog "ticket-service/mocks/pkg/log"
+	mockredis "ticket-service/mocks/pkg/redis"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+)
+
+type orderHttpHandlerTestSuite struct {
+	suite.Suite
+
+	cUC       *mockorder.UsecaseCommand
+	cUQ       *mockorder.UsecaseQuery
+	cLog      *mocklog.Logger
+	validator *validator.Validate
+	handler   *handlers.OrderHttpHandler
+	cRedis    *mockredis.Collections
+	app       *fiber.App
+}
+
+func (suite *orderHttpHandlerTestSuite) SetupTest() {
+	suite.cUC = new(mockorder.UsecaseCommand)
+	suite.cUQ = new(mockorder.UsecaseQuery)
+	suite.cLog = new(mocklog.Logger)
+	suite.validator = validator.New()
+	suite.cRedis = new(mockredis.Collections)
+	suite.handler = &handlers.OrderHttpHandler{
+		OrderUsecaseCommand: suite.cUC,
+		OrderUsecaseQuery:   suite.cUQ,
+		Logger:              suite.cLog,
+		Validator:           suite.validator,
+	}
+	suite.app = fiber.New()
+	handlers.InitOrderHttpHandler(suite.app, suite.cUC, suite.cUQ, suite.cLog, suite.cRedis)
+}
+
+func TestOrderHttpHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(orderHttpHandlerTestSuite))
+}
+
+func (suite *orderHttpHandlerTestSuite) TestCreateReservation() {
+	suite.cUC.On("CreateReservation", mock.Anything, mock.Anything).Return(&response.Reservation{OrderId: "id"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().Header.SetMethod(fiber.MethodPost)
+	ctx.Request().Header.SetContentType("application/json")
+	ctx.Request().SetBody([]byte(`{"eventId":"id","countryCode":"ID","ticketType":"Gold","quantity":2}`))
+	ctx.Locals("userId", "user-id")
+
+	err := suite.handler.CreateReservation(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, ctx.Response().StatusCode())
+}
+
+func (suite *orderHttpHandlerTestSuite) TestCreateReservationIgnoresBallotEntry() {
+	suite.cUC.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req dto.ReservationReq) bool {
+		return req.BallotEntryId == "" && req.UserId == "user-id" && req.Quantity == 2
+	})).Return(&response.Reservation{OrderId: "id"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().Header.SetMethod(fiber.MethodPost)
+	ctx.Request().Header.SetContentType(fiber.MIMEApplicationForm)
+	ctx.Request().SetBody([]byte("eventId=id&countryCode=ID&ticketType=Gold&quantity=2&BallotEntryId=entry-id&UserId=other-user-id"))
+	ctx.Locals("userId", "user-id")
+
+	err := suite.handler.CreateReservation(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, ctx.Response().StatusCode())
+	suite.cUC.AssertExpectations(suite.T())
+}
+
+func (suite *orderHttpHandlerTestSuite) TestCreateReservationIgnoresWaitlistHold() {
+	suite.cUC.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req dto.ReservationReq) bool {
+		return req.WaitlistEntryId == "" && req.HoldDuration == 0
+	})).Return(&response.Reservation{OrderId: "id"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().Header.SetMethod(fiber.MethodPost)
+	ctx.Request().Header.SetContentType(fiber.MIMEApplicationForm)
+	ctx.Request().SetBody([]byte("eventId=id&countryCode=ID&ticketType=Gold&quantity=2&WaitlistEntryId=entry-id&HoldDuration=8760h"))
+	ctx.Locals("userId", "user-id")
+
+	err := suite.handler.CreateReservation(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, ctx.Response().StatusCode())
+	suite.cUC.AssertExpectations(suite.T())
+}
+
+func (suite *orderHttpHandlerTestSuite) TestCreateReservationIgnoresOrderId() {
+	suite.cUC.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req dto.ReservationReq) bool {
+		return req.OrderId == ""
+	})).Return(&response.Reservation{OrderId: "id"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().Header.SetMethod(fiber.MethodPost)
+	ctx.Request().Header.SetContentType(fiber.MIMEApplicationForm)
+	ctx.Request().SetBody([]byte("eventId=id&countryCode=ID&ticketType=Gold&quantity=2&OrderId=existing-order-id"))
+	ctx.Locals("userId", "user-id")
+
+	err := suite.handler.CreateReservation(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, ctx.Response().StatusCode())
+	suite.cUC.AssertExpectations(suite.T())
+}
+
+func (suite *orderHttpHandlerTestSuite) TestCreateReservationErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().Header.SetMethod(fiber.MethodPost)
+	ctx.Request().Header.SetContentType("application/json")
+	ctx.Request().SetBody([]byte(`{"eventId":"id","countryCode":"ID","ticketType":"Gold","quantity":0}`))
+	ctx.Locals("userId", "user-id")
+
+	err := suite.handler.CreateReservation(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, ctx.Response().StatusCode())
+}
+
+func (suite *orderHttpHandlerTestSuite) TestCreateReservationErrLimit() {
+	suite.cUC.On("CreateReservation", mock.Anything, mock.Anything).Return(nil, errors.UnprocessableEntity("maximum 4 tickets per order"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().Header.SetMethod(fiber.MethodPost)
+	ctx.Request().Header.SetContentType("application/json")
+	ctx.Request().SetBody([]byte(`{"eventId":"id","countryCode":"ID","ticketType":"Gold","quantity":5}`))
+	ctx.Locals("userId", "user-id")
+
+	err := suite.handler.CreateReservation(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusUnprocessableEntity, ctx.Response().StatusCode())
+}
+
+func (suite *orderHttpHandlerTestSuite) TestUpsertPurchaseLimit() {
+	suite.cUC.On("UpsertPurchaseLimit", mock.Anything, mock.Anything).Return(&response.PurchaseLimit{EventId: "id"}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().Header.SetMethod(fiber.MethodPut)
+	ctx.Request().Header.SetContentType("application/json")
+	ctx.Request().SetBody([]byte(`{"eventId":"id","maxPerUser":6,"maxPerOrder":4}`))
+
+	err := suite.handler.UpsertPurchaseLimit(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, ctx.Response().StatusCode())
+}
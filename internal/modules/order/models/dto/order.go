@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// ReservationReq is what CreateReservation takes, the handler only copies the request body into it so the order id,
+// the ballot and waitlist entries and the hold duration only ever come from the modules reserving on a user's behalf
+type ReservationReq struct {
+	UserId          string
+	UserRole        string
+	OrderId         string
+	BallotEntryId   string
+	WaitlistEntryId string
+	HoldDuration    time.Duration
+	EventId         string
+	CountryCode     string
+	TicketType      string
+	Quantity        int
+	VoucherCodes    []string
+	SeatIds         []string
+}
+
+type PurchaseCounter struct {
+	UserId      string
+	EventId     string
+	TicketType  string
+	CountryCode string
+	Quantity    int
+}
@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+// Order keeps TotalPrice as the amount to pay, SubtotalPrice is the price before the vouchers took DiscountPrice off.
+// The fees and taxes are added to TotalPrice, Taxes keeps the amount charged by each jurisdiction
+type Order struct {
+	OrderId         string    `json:"orderId" bson:"orderId"`
+	UserId          string    `json:"userId" bson:"userId"`
+	TicketId        string    `json:"ticketId" bson:"ticketId"`
+	EventId         string    `json:"eventId" bson:"eventId"`
+	TicketType      string    `json:"ticketType" bson:"ticketType"`
+	CountryCode     string    `json:"countryCode" bson:"countryCode"`
+	Quantity        int       `json:"quantity" bson:"quantity"`
+	TicketPrice     int       `json:"ticketPrice" bson:"ticketPrice"`
+	PricePhase      string    `json:"pricePhase,omitempty" bson:"pricePhase,omitempty"`
+	SubtotalPrice   int       `json:"subtotalPrice" bson:"subtotalPrice"`
+	DiscountPrice   int       `json:"discountPrice" bson:"discountPrice"`
+	ServiceFee      int       `json:"serviceFee" bson:"serviceFee"`
+	FacilityFee     int       `json:"facilityFee" bson:"facilityFee"`
+	Taxes           []Tax     `json:"taxes,omitempty" bson:"taxes,omitempty"`
+	TaxPrice        int       `json:"taxPrice" bson:"taxPrice"`
+	TotalPrice      int       `json:"totalPrice" bson:"totalPrice"`
+	VoucherCodes    []string  `json:"voucherCodes,omitempty" bson:"voucherCodes,omitempty"`
+	PresaleId       string    `json:"presaleId,omitempty" bson:"presaleId,omitempty"`
+	BallotEntryId   string    `json:"ballotEntryId,omitempty" bson:"ballotEntryId,omitempty"`
+	WaitlistEntryId string    `json:"waitlistEntryId,omitempty" bson:"waitlistEntryId,omitempty"`
+	ResaleListingId string    `json:"resaleListingId,omitempty" bson:"resaleListingId,omitempty"`
+	Seats           []Seat    `json:"seats,omitempty" bson:"seats,omitempty"`
+	Status          string    `json:"status" bson:"status"`
+	ExpiredAt       time.Time `json:"expiredAt" bson:"expiredAt"`
+	CreatedAt       time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+type Tax struct {
+	Name         string `json:"name" bson:"name"`
+	Jurisdiction string `json:"jurisdiction" bson:"jurisdiction"`
+	Percent      int    `json:"percent" bson:"percent"`
+	Amount       int    `json:"amount" bson:"amount"`
+}
+
+// Seat is held for the order in a seated tier, the issued tickets are assigned these seats in order
+type Seat struct {
+	SeatId  string `json:"seatId" bson:"seatId"`
+	Section string `json:"section" bson:"section"`
+	Row     string `json:"row" bson:"row"`
+	Number  int    `json:"number" bson:"number"`
+}
+
+// PurchaseLimit is configured per event, a zero value means the limit is not enforced
+type PurchaseLimit struct {
+	EventId       string    `json:"eventId" bson:"eventId"`
+	MaxPerUser    int       `json:"maxPerUser" bson:"maxPerUser"`
+	MaxPerTier    int       `json:"maxPerTier" bson:"maxPerTier"`
+	MaxPerCountry int       `json:"maxPerCountry" bson:"maxPerCountry"`
+	MaxPerOrder   int       `json:"maxPerOrder" bson:"maxPerOrder"`
+	CreatedAt     time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// PurchaseCounter keeps how many tickets a user already holds for one event
+type PurchaseCounter struct {
+	UserId    string         `json:"userId" bson:"userId"`
+	EventId   string         `json:"eventId" bson:"eventId"`
+	Total     int            `json:"total" bson:"total"`
+	Tiers     map[string]int `json:"tiers" bson:"tiers"`
+	Countries map[string]int `json:"countries" bson:"countries"`
+	CreatedAt time.Time      `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt" bson:"updatedAt"`
+}
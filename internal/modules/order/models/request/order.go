@@ -0,0 +1,23 @@
+package request
+
+type ReservationReq struct {
+	EventId      string   `json:"eventId" validate:"required"`
+	CountryCode  string   `json:"countryCode" validate:"required"`
+	TicketType   string   `json:"ticketType" validate:"required"`
+	Quantity     int      `json:"quantity" validate:"required,min=1"`
+	VoucherCodes []string `json:"voucherCodes" validate:"omitempty,max=3,dive,required"`
+	SeatIds      []string `json:"seatIds" validate:"omitempty,unique,dive,required"`
+}
+
+type PurchaseLimitReq struct {
+	EventId       string `json:"eventId" validate:"required"`
+	MaxPerUser    int    `json:"maxPerUser" validate:"min=0"`
+	MaxPerTier    int    `json:"maxPerTier" validate:"min=0"`
+	MaxPerCountry int    `json:"maxPerCountry" validate:"min=0"`
+	MaxPerOrder   int    `json:"maxPerOrder" validate:"min=0"`
+}
+
+type CancelReservationReq struct {
+	UserId  string `json:"-"`
+	OrderId string `json:"-"`
+}
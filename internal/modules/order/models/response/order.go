@@ -0,0 +1,46 @@
+package response
+
+import "time"
+
+type Reservation struct {
+	OrderId       string    `json:"orderId"`
+	EventId       string    `json:"eventId"`
+	TicketType    string    `json:"ticketType"`
+	CountryCode   string    `json:"countryCode"`
+	Quantity      int       `json:"quantity"`
+	TicketPrice   string    `json:"ticketPrice"`
+	PricePhase    string    `json:"pricePhase,omitempty"`
+	SubtotalPrice string    `json:"subtotalPrice"`
+	DiscountPrice string    `json:"discountPrice"`
+	ServiceFee    string    `json:"serviceFee"`
+	FacilityFee   string    `json:"facilityFee"`
+	Taxes         []Tax     `json:"taxes"`
+	TaxPrice      string    `json:"taxPrice"`
+	TotalPrice    string    `json:"totalPrice"`
+	VoucherCodes  []string  `json:"voucherCodes"`
+	Seats         []Seat    `json:"seats,omitempty"`
+	Status        string    `json:"status"`
+	ExpiredAt     time.Time `json:"expiredAt"`
+}
+
+type Tax struct {
+	Name         string `json:"name"`
+	Jurisdiction string `json:"jurisdiction"`
+	Percent      int    `json:"percent"`
+	Amount       string `json:"amount"`
+}
+
+type Seat struct {
+	SeatId  string `json:"seatId"`
+	Section string `json:"section"`
+	Row     string `json:"row"`
+	Number  int    `json:"number"`
+}
+
+type PurchaseLimit struct {
+	EventId       string `json:"eventId"`
+	MaxPerUser    int    `json:"maxPerUser"`
+	MaxPerTier    int    `json:"maxPerTier"`
+	MaxPerCountry int    `json:"maxPerCountry"`
+	MaxPerOrder   int    `json:"maxPerOrder"`
+}
@@ -0,0 +1,41 @@
+package order
+
+import (
+	"context"
+	"ticket-service/internal/modules/order/models/dto"
+	"ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/order/models/request"
+	"ticket-service/internal/modules/order/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"time"
+)
+
+type UsecaseCommand interface {
+	CreateReservation(origCtx context.Context, payload dto.ReservationReq) (*response.Reservation, error)
+	UpsertPurchaseLimit(origCtx context.Context, payload request.PurchaseLimitReq) (*response.PurchaseLimit, error)
+	CancelReservation(origCtx context.Context, payload request.CancelReservationReq) (*response.Reservation, error)
+	ReleaseOrder(origCtx context.Context, orderId string) (*response.Reservation, error)
+	ReleaseHold(origCtx context.Context, orderDetail entity.Order)
+}
+
+type UsecaseQuery interface {
+	FindPurchaseLimit(origCtx context.Context, eventId string) (*response.PurchaseLimit, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindPurchaseLimitByEventId(ctx context.Context, eventId string) <-chan wrapper.Result
+	FindPurchaseCounter(ctx context.Context, userId string, eventId string) <-chan wrapper.Result
+	FindOrderById(ctx context.Context, orderId string) <-chan wrapper.Result
+	FindOrdersByEventId(ctx context.Context, eventId string, status string) <-chan wrapper.Result
+	FindExpiredPendingOrders(ctx context.Context, expiredBefore time.Time) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	InsertOneOrder(ctx context.Context, order entity.Order) <-chan wrapper.Result
+	UpdateOrderStatus(ctx context.Context, orderId string, fromStatus string, toStatus string) <-chan wrapper.Result
+	UpsertPurchaseLimit(ctx context.Context, limit entity.PurchaseLimit) <-chan wrapper.Result
+	InitPurchaseCounter(ctx context.Context, userId string, eventId string) <-chan wrapper.Result
+	IncreasePurchaseCounter(ctx context.Context, payload dto.PurchaseCounter, limit entity.PurchaseLimit) <-chan wrapper.Result
+	DecreasePurchaseCounter(ctx context.Context, payload dto.PurchaseCounter) <-chan wrapper.Result
+	CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result
+}
@@ -0,0 +1,230 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"ticket-service/internal/modules/order"
+	"ticket-service/internal/modules/order/models/dto"
+	"ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	"ticket-service/internal/pkg/errors"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) order.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) InsertOneOrder(ctx context.Context, order entity.Order) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "orders",
+			Document:       order,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateOrderStatus moves the order only when it is still in fromStatus, Data is nil when it is not
+func (c commandMongodbRepository) UpdateOrderStatus(ctx context.Context, orderId string, fromStatus string, toStatus string) <-chan wrapper.Result {
+	var order entity.Order
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &order,
+			CollectionName: "orders",
+			Filter: bson.M{
+				"orderId": orderId,
+				"status":  fromStatus,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    toStatus,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpsertPurchaseLimit(ctx context.Context, limit entity.PurchaseLimit) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "purchase-limits",
+			Filter: bson.M{
+				"eventId": limit.EventId,
+			},
+			Document: limit,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// InitPurchaseCounter makes sure the counter document exists before it is conditionally incremented
+func (c commandMongodbRepository) InitPurchaseCounter(ctx context.Context, userId string, eventId string) <-chan wrapper.Result {
+	var counter entity.PurchaseCounter
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &counter,
+			CollectionName: "purchase-counters",
+			Filter: bson.M{
+				"userId":  userId,
+				"eventId": eventId,
+			},
+			Update: bson.M{
+				"$setOnInsert": bson.M{
+					"total":     0,
+					"tiers":     bson.M{},
+					"countries": bson.M{},
+					"createdAt": time.Now(),
+					"updatedAt": time.Now(),
+				},
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// IncreasePurchaseCounter adds the quantity only when every configured limit still has room for it,
+// Data is nil when one of the limits would be exceeded
+func (c commandMongodbRepository) IncreasePurchaseCounter(ctx context.Context, payload dto.PurchaseCounter, limit entity.PurchaseLimit) <-chan wrapper.Result {
+	var counter entity.PurchaseCounter
+	output := make(chan wrapper.Result)
+
+	go func() {
+		tierField, countryField, err := counterFields(payload)
+		if err != nil {
+			output <- wrapper.Result{Error: err}
+			close(output)
+			return
+		}
+		filter := bson.M{
+			"userId":  payload.UserId,
+			"eventId": payload.EventId,
+		}
+		if limit.MaxPerUser > 0 {
+			filter["total"] = bson.M{"$not": bson.M{"$gt": limit.MaxPerUser - payload.Quantity}}
+		}
+		if limit.MaxPerTier > 0 {
+			filter[tierField] = bson.M{"$not": bson.M{"$gt": limit.MaxPerTier - payload.Quantity}}
+		}
+		if limit.MaxPerCountry > 0 {
+			filter[countryField] = bson.M{"$not": bson.M{"$gt": limit.MaxPerCountry - payload.Quantity}}
+		}
+
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &counter,
+			CollectionName: "purchase-counters",
+			Filter:         filter,
+			Update: bson.M{
+				"$inc": bson.M{
+					"total":      payload.Quantity,
+					tierField:    payload.Quantity,
+					countryField: payload.Quantity,
+				},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) DecreasePurchaseCounter(ctx context.Context, payload dto.PurchaseCounter) <-chan wrapper.Result {
+	var counter entity.PurchaseCounter
+	output := make(chan wrapper.Result)
+
+	go func() {
+		tierField, countryField, err := counterFields(payload)
+		if err != nil {
+			output <- wrapper.Result{Error: err}
+			close(output)
+			return
+		}
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &counter,
+			CollectionName: "purchase-counters",
+			Filter: bson.M{
+				"userId":  payload.UserId,
+				"eventId": payload.EventId,
+			},
+			Update: bson.M{
+				"$inc": bson.M{
+					"total":      -payload.Quantity,
+					tierField:    -payload.Quantity,
+					countryField: -payload.Quantity,
+				},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// counterFields builds the field paths of the tier and country counters, a key holding a dot or a dollar would
+// write another field than its own so it is refused
+func counterFields(payload dto.PurchaseCounter) (string, string, error) {
+	for _, key := range []string{payload.TicketType, payload.CountryCode} {
+		if key == "" || strings.ContainsAny(key, ".$") {
+			return "", "", errors.BadRequest(fmt.Sprintf("invalid purchase counter key %q", key))
+		}
+	}
+	return fmt.Sprintf("tiers.%s", payload.TicketType), fmt.Sprintf("countries.%s", payload.CountryCode), nil
+}
+
+// CreateUniqueIndexes keeps a single purchase counter per user and event, InitPurchaseCounter upserts race without it
+func (c commandMongodbRepository) CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.CreateIndex(mongodb.CreateIndex{
+			CollectionName: "purchase-counters",
+			Keys:           bson.D{{Key: "userId", Value: 1}, {Key: "eventId", Value: 1}},
+			Options:        options.Index().SetUnique(true),
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
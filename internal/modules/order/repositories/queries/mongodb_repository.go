@@ -0,0 +1,124 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/order"
+	"ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) order.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindPurchaseLimitByEventId(ctx context.Context, eventId string) <-chan wrapper.Result {
+	var limit entity.PurchaseLimit
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &limit,
+			CollectionName: "purchase-limits",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindPurchaseCounter(ctx context.Context, userId string, eventId string) <-chan wrapper.Result {
+	var counter entity.PurchaseCounter
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &counter,
+			CollectionName: "purchase-counters",
+			Filter: bson.M{
+				"userId":  userId,
+				"eventId": eventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindOrderById(ctx context.Context, orderId string) <-chan wrapper.Result {
+	var order entity.Order
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &order,
+			CollectionName: "orders",
+			Filter: bson.M{
+				"orderId": orderId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindOrdersByEventId(ctx context.Context, eventId string, status string) <-chan wrapper.Result {
+	var orders []entity.Order
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &orders,
+			CollectionName: "orders",
+			Filter: bson.M{
+				"eventId": eventId,
+				"status":  status,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindExpiredPendingOrders(ctx context.Context, expiredBefore time.Time) <-chan wrapper.Result {
+	var orders []entity.Order
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &orders,
+			CollectionName: "orders",
+			Filter: bson.M{
+				"status":    constants.OrderStatusPending,
+				"expiredAt": bson.M{"$lt": expiredBefore},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,627 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/ballot"
+	"ticket-service/internal/modules/fee"
+	feeDto "ticket-service/internal/modules/fee/models/dto"
+	"ticket-service/internal/modules/order"
+	"ticket-service/internal/modules/order/models/dto"
+	"ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/order/models/request"
+	"ticket-service/internal/modules/order/models/response"
+	"ticket-service/internal/modules/presale"
+	presaleDto "ticket-service/internal/modules/presale/models/dto"
+	"ticket-service/internal/modules/resale"
+	"ticket-service/internal/modules/seat"
+	seatDto "ticket-service/internal/modules/seat/models/dto"
+	"ticket-service/internal/modules/ticket"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	ticketRequest "ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/modules/voucher"
+	voucherDto "ticket-service/internal/modules/voucher/models/dto"
+	voucherRequest "ticket-service/internal/modules/voucher/models/request"
+	"ticket-service/internal/modules/waitlist"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	orderRepositoryQuery    order.MongodbRepositoryQuery
+	orderRepositoryCommand  order.MongodbRepositoryCommand
+	ticketRepositoryQuery   ticket.MongodbRepositoryQuery
+	ticketRepositoryCommand ticket.MongodbRepositoryCommand
+	voucherUsecaseCommand   voucher.UsecaseCommand
+	presaleUsecaseCommand   presale.UsecaseCommand
+	ballotUsecaseQuery      ballot.UsecaseQuery
+	waitlistUsecaseQuery    waitlist.UsecaseQuery
+	resaleUsecaseCommand    resale.UsecaseCommand
+	feeUsecaseQuery         fee.UsecaseQuery
+	seatUsecaseCommand      seat.UsecaseCommand
+	logger                  log.Logger
+}
+
+func NewCommandUsecase(omq order.MongodbRepositoryQuery, omc order.MongodbRepositoryCommand, tmq ticket.MongodbRepositoryQuery,
+	tmc ticket.MongodbRepositoryCommand, vuc voucher.UsecaseCommand, puc presale.UsecaseCommand, buq ballot.UsecaseQuery, wuq waitlist.UsecaseQuery,
+	ruc resale.UsecaseCommand, fuq fee.UsecaseQuery, suc seat.UsecaseCommand, log log.Logger) order.UsecaseCommand {
+	return commandUsecase{
+		orderRepositoryQuery:    omq,
+		orderRepositoryCommand:  omc,
+		ticketRepositoryQuery:   tmq,
+		ticketRepositoryCommand: tmc,
+		voucherUsecaseCommand:   vuc,
+		presaleUsecaseCommand:   puc,
+		ballotUsecaseQuery:      buq,
+		waitlistUsecaseQuery:    wuq,
+		resaleUsecaseCommand:    ruc,
+		feeUsecaseQuery:         fuq,
+		seatUsecaseCommand:      suc,
+		logger:                  log,
+	}
+}
+
+func (c commandUsecase) CreateReservation(origCtx context.Context, payload dto.ReservationReq) (*response.Reservation, error) {
+	domain := "orderUsecase-CreateReservation"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	ticketData := <-c.ticketRepositoryQuery.FindTicketByType(ctx, ticketRequest.TicketTypeReq{
+		EventId:     payload.EventId,
+		CountryCode: payload.CountryCode,
+		TicketType:  payload.TicketType,
+	})
+	if ticketData.Error != nil {
+		msg := "Error query ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", ticketData.Error))
+		return nil, ticketData.Error
+	}
+
+	if ticketData.Data == nil {
+		msg := "Ticket Not Found"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	ticketDetail, ok := ticketData.Data.(*ticketEntity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	if ticketDetail.Cancelled {
+		return nil, errors.UnprocessableEntity("event is cancelled")
+	}
+
+	// while a ballot runs tickets only go to winners claiming their entry
+	if payload.BallotEntryId == "" {
+		if err := c.ballotUsecaseQuery.CheckDirectSale(ctx, payload.EventId); err != nil {
+			return nil, err
+		}
+	}
+
+	// released tickets go to the waitlist first, only the holds it offers may take them
+	if payload.WaitlistEntryId == "" {
+		if err := c.waitlistUsecaseQuery.CheckPublicSale(ctx, ticketDetail.TicketId, ticketDetail.TotalRemaining); err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := c.findPurchaseLimit(ctx, payload.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit.MaxPerOrder > 0 && payload.Quantity > limit.MaxPerOrder {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("maximum %d tickets per order", limit.MaxPerOrder))
+	}
+
+	// outside a presale presaleId stays empty and the whole TotalRemaining is on sale
+	presaleId, err := c.presaleUsecaseCommand.HoldAllocation(ctx, presaleDto.AllocationReq{
+		EventId:    payload.EventId,
+		UserId:     payload.UserId,
+		UserRole:   payload.UserRole,
+		TicketId:   ticketDetail.TicketId,
+		TotalQuota: ticketDetail.TotalQuota,
+		Quantity:   payload.Quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the counter keys come from the stored ticket, never straight from the request
+	counter := dto.PurchaseCounter{
+		UserId:      payload.UserId,
+		EventId:     payload.EventId,
+		TicketType:  ticketDetail.TicketType,
+		CountryCode: ticketDetail.Country.Code,
+		Quantity:    payload.Quantity,
+	}
+	if err := c.increasePurchaseCounter(ctx, counter, *limit); err != nil {
+		c.releasePresaleAllocation(ctx, presaleId, ticketDetail.TicketId, payload.Quantity)
+		return nil, err
+	}
+
+	remaining := <-c.ticketRepositoryCommand.DecreaseTotalRemaining(ctx, ticketDetail.TicketId, payload.Quantity)
+	if remaining.Error != nil || remaining.Data == nil {
+		c.rollbackPurchaseCounter(ctx, counter)
+		c.releasePresaleAllocation(ctx, presaleId, ticketDetail.TicketId, payload.Quantity)
+		if remaining.Error != nil {
+			msg := "Error hold ticket"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", remaining.Error))
+			return nil, remaining.Error
+		}
+		return nil, errors.UnprocessableEntity("ticket quota is not enough")
+	}
+
+	// the phase is picked from the count the hold was taken at, so concurrent orders never both get the last early-bird
+	// tickets. An order crossing a quantity trigger keeps the price it started at
+	now := time.Now()
+	pricing := ticketDetail.PriceAt(now, ticketDetail.Sold())
+	if heldTicket, ok := remaining.Data.(*ticketEntity.Ticket); ok {
+		pricing = heldTicket.PriceAt(now, heldTicket.Sold()-payload.Quantity)
+	}
+
+	// callers that need to find the order again after a crash choose the id up front
+	orderId := payload.OrderId
+	if orderId == "" {
+		orderId = uuid.NewString()
+	}
+
+	holdDuration := payload.HoldDuration
+	if holdDuration == 0 {
+		holdDuration = constants.OrderHoldDuration
+	}
+
+	// seats are held as long as the reservation, seated tiers without picked seats get the best adjacent ones
+	seats, err := c.seatUsecaseCommand.HoldSeats(ctx, seatDto.HoldReq{
+		EventId:    payload.EventId,
+		TicketType: payload.TicketType,
+		OrderId:    orderId,
+		SeatIds:    payload.SeatIds,
+		Quantity:   payload.Quantity,
+		HeldUntil:  now.Add(holdDuration),
+	})
+	if err != nil {
+		c.rollbackPurchaseCounter(ctx, counter)
+		c.releasePresaleAllocation(ctx, presaleId, ticketDetail.TicketId, payload.Quantity)
+		<-c.ticketRepositoryCommand.IncreaseTotalRemaining(ctx, ticketDetail.TicketId, payload.Quantity)
+		return nil, err
+	}
+
+	subtotal := pricing.Price * payload.Quantity
+	quote := &voucherDto.Quote{Subtotal: subtotal, Total: subtotal}
+	if len(payload.VoucherCodes) > 0 {
+		quote, err = c.voucherUsecaseCommand.RedeemVouchers(ctx, voucherRequest.RedeemReq{
+			OrderId: orderId,
+			Codes:   payload.VoucherCodes,
+			Target: voucherDto.Target{
+				UserId:      payload.UserId,
+				EventId:     ticketDetail.EventId,
+				Tag:         ticketDetail.Tag,
+				TicketType:  ticketDetail.TicketType,
+				CountryCode: payload.CountryCode,
+				TicketPrice: pricing.Price,
+				Quantity:    payload.Quantity,
+			},
+		})
+		if err != nil {
+			c.rollbackPurchaseCounter(ctx, counter)
+			c.releasePresaleAllocation(ctx, presaleId, ticketDetail.TicketId, payload.Quantity)
+			c.releaseSeats(ctx, orderId, len(seats))
+			<-c.ticketRepositoryCommand.IncreaseTotalRemaining(ctx, ticketDetail.TicketId, payload.Quantity)
+			return nil, err
+		}
+	}
+
+	orderData := entity.Order{
+		OrderId:         orderId,
+		UserId:          payload.UserId,
+		TicketId:        ticketDetail.TicketId,
+		EventId:         payload.EventId,
+		TicketType:      payload.TicketType,
+		CountryCode:     payload.CountryCode,
+		Quantity:        payload.Quantity,
+		TicketPrice:     pricing.Price,
+		PricePhase:      pricing.Phase,
+		SubtotalPrice:   quote.Subtotal,
+		DiscountPrice:   quote.TotalDiscount,
+		TotalPrice:      quote.Total,
+		VoucherCodes:    appliedCodes(*quote),
+		PresaleId:       presaleId,
+		BallotEntryId:   payload.BallotEntryId,
+		WaitlistEntryId: payload.WaitlistEntryId,
+		Seats:           mapOrderSeats(seats),
+		Status:          constants.OrderStatusPending,
+		ExpiredAt:       now.Add(holdDuration),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	// the fees come from the same calculation as the quote, so the total charged is the total the user was shown
+	breakdown, err := c.feeUsecaseQuery.CalculateBreakdown(ctx, feeDto.BreakdownReq{
+		EventId:     payload.EventId,
+		CountryCode: payload.CountryCode,
+		TicketPrice: pricing.Price,
+		Quantity:    payload.Quantity,
+		Discount:    quote.TotalDiscount,
+	})
+	if err != nil {
+		c.releaseHold(ctx, orderData)
+		return nil, err
+	}
+	orderData.ServiceFee = breakdown.ServiceFee
+	orderData.FacilityFee = breakdown.FacilityFee
+	orderData.TaxPrice = breakdown.TotalTax
+	orderData.TotalPrice = breakdown.Total
+	for _, value := range breakdown.Taxes {
+		orderData.Taxes = append(orderData.Taxes, entity.Tax{
+			Name:         value.Name,
+			Jurisdiction: value.Jurisdiction,
+			Percent:      value.Percent,
+			Amount:       value.Amount,
+		})
+	}
+
+	insert := <-c.orderRepositoryCommand.InsertOneOrder(ctx, orderData)
+	if insert.Error != nil {
+		msg := "Error insert order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		c.releaseHold(ctx, orderData)
+		return nil, insert.Error
+	}
+
+	return mapReservation(orderData, orderData.Status), nil
+}
+
+func (c commandUsecase) UpsertPurchaseLimit(origCtx context.Context, payload request.PurchaseLimitReq) (*response.PurchaseLimit, error) {
+	domain := "orderUsecase-UpsertPurchaseLimit"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	limit := entity.PurchaseLimit{
+		EventId:       payload.EventId,
+		MaxPerUser:    payload.MaxPerUser,
+		MaxPerTier:    payload.MaxPerTier,
+		MaxPerCountry: payload.MaxPerCountry,
+		MaxPerOrder:   payload.MaxPerOrder,
+		UpdatedAt:     time.Now(),
+	}
+	resp := <-c.orderRepositoryCommand.UpsertPurchaseLimit(ctx, limit)
+	if resp.Error != nil {
+		msg := "Error upsert purchase limit"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return &response.PurchaseLimit{
+		EventId:       limit.EventId,
+		MaxPerUser:    limit.MaxPerUser,
+		MaxPerTier:    limit.MaxPerTier,
+		MaxPerCountry: limit.MaxPerCountry,
+		MaxPerOrder:   limit.MaxPerOrder,
+	}, nil
+}
+
+// CancelReservation releases the hold of an unpaid order, paid orders go through the refund flow instead
+func (c commandUsecase) CancelReservation(origCtx context.Context, payload request.CancelReservationReq) (*response.Reservation, error) {
+	domain := "orderUsecase-CancelReservation"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	orderData := <-c.orderRepositoryQuery.FindOrderById(ctx, payload.OrderId)
+	if orderData.Error != nil {
+		msg := "Error query order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", orderData.Error))
+		return nil, orderData.Error
+	}
+
+	if orderData.Data == nil {
+		return nil, errors.NotFound("order not found")
+	}
+
+	orderDetail, ok := orderData.Data.(*entity.Order)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if orderDetail.UserId != payload.UserId {
+		return nil, errors.NotFound("order not found")
+	}
+
+	if orderDetail.Status != constants.OrderStatusPending {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("order is %s and cannot be cancelled", orderDetail.Status))
+	}
+
+	cancelled := <-c.orderRepositoryCommand.UpdateOrderStatus(ctx, orderDetail.OrderId, constants.OrderStatusPending,
+		constants.OrderStatusCancelled)
+	if cancelled.Error != nil {
+		msg := "Error cancel order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", cancelled.Error))
+		return nil, cancelled.Error
+	}
+
+	if cancelled.Data == nil {
+		return nil, errors.Conflict("order is no longer pending")
+	}
+
+	c.releaseHold(ctx, *orderDetail)
+
+	return mapReservation(*orderDetail, constants.OrderStatusCancelled), nil
+}
+
+// ReleaseOrder gives the inventory of an order back, pending orders are cancelled and paid orders are marked refunded
+// so it must only be called once the payment was voided or refunded. Orders released earlier are returned as they are
+func (c commandUsecase) ReleaseOrder(origCtx context.Context, orderId string) (*response.Reservation, error) {
+	domain := "orderUsecase-ReleaseOrder"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	orderData := <-c.orderRepositoryQuery.FindOrderById(ctx, orderId)
+	if orderData.Error != nil {
+		msg := "Error query order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", orderData.Error))
+		return nil, orderData.Error
+	}
+
+	if orderData.Data == nil {
+		return nil, errors.NotFound("order not found")
+	}
+
+	orderDetail, ok := orderData.Data.(*entity.Order)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	var toStatus string
+	switch orderDetail.Status {
+	case constants.OrderStatusPending:
+		toStatus = constants.OrderStatusCancelled
+	case constants.OrderStatusPaid:
+		toStatus = constants.OrderStatusRefunded
+	default:
+		return mapReservation(*orderDetail, orderDetail.Status), nil
+	}
+
+	released := <-c.orderRepositoryCommand.UpdateOrderStatus(ctx, orderDetail.OrderId, orderDetail.Status, toStatus)
+	if released.Error != nil {
+		msg := "Error release order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", released.Error))
+		return nil, released.Error
+	}
+
+	if released.Data == nil {
+		return nil, errors.Conflict("order status has changed")
+	}
+
+	c.releaseHold(ctx, *orderDetail)
+
+	return mapReservation(*orderDetail, toStatus), nil
+}
+
+// ReleaseHold gives back everything the order took when it was reserved. The caller moves the order out of PENDING
+// or PAID first and only releases when that move went through, so the hold is never released twice
+func (c commandUsecase) ReleaseHold(origCtx context.Context, orderDetail entity.Order) {
+	domain := "orderUsecase-ReleaseHold"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	c.releaseHold(ctx, orderDetail)
+}
+
+func (c commandUsecase) findPurchaseLimit(ctx context.Context, eventId string) (*entity.PurchaseLimit, error) {
+	resp := <-c.orderRepositoryQuery.FindPurchaseLimitByEventId(ctx, eventId)
+	if resp.Error != nil {
+		msg := "Error query purchase limit"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	// events without configuration are not limited
+	if resp.Data == nil {
+		return &entity.PurchaseLimit{EventId: eventId}, nil
+	}
+
+	limit, ok := resp.Data.(*entity.PurchaseLimit)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return limit, nil
+}
+
+// increasePurchaseCounter always records the purchase so limits configured later still see earlier orders,
+// when a limit is hit it reads the counter back to tell the user which one
+func (c commandUsecase) increasePurchaseCounter(ctx context.Context, payload dto.PurchaseCounter, limit entity.PurchaseLimit) error {
+	initCounter := <-c.orderRepositoryCommand.InitPurchaseCounter(ctx, payload.UserId, payload.EventId)
+	if initCounter.Error != nil {
+		msg := "Error init purchase counter"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", initCounter.Error))
+		return initCounter.Error
+	}
+
+	resp := <-c.orderRepositoryCommand.IncreasePurchaseCounter(ctx, payload, limit)
+	if resp.Error != nil {
+		msg := "Error increase purchase counter"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return resp.Error
+	}
+
+	if resp.Data != nil {
+		return nil
+	}
+
+	counterData := <-c.orderRepositoryQuery.FindPurchaseCounter(ctx, payload.UserId, payload.EventId)
+	if counterData.Error != nil {
+		msg := "Error query purchase counter"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", counterData.Error))
+		return counterData.Error
+	}
+
+	counter, ok := counterData.Data.(*entity.PurchaseCounter)
+	if !ok {
+		return errors.UnprocessableEntity("purchase limit reached")
+	}
+
+	return purchaseLimitError(*counter, payload, limit)
+}
+
+// releaseHold gives back what the order held, a resale order only held its listing
+func (c commandUsecase) releaseHold(ctx context.Context, orderDetail entity.Order) {
+	// a resale order never took inventory of the event, it only held the listing
+	if orderDetail.ResaleListingId != "" {
+		if err := c.resaleUsecaseCommand.ReleaseListing(ctx, orderDetail.OrderId); err != nil {
+			msg := "Error release resale listing"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", orderDetail))
+		}
+		return
+	}
+
+	// the tiers of a cancelled event are not restocked, IncreaseTotalRemaining leaves them alone
+	restock := <-c.ticketRepositoryCommand.IncreaseTotalRemaining(ctx, orderDetail.TicketId, orderDetail.Quantity)
+	if restock.Error != nil {
+		msg := "Error restock ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", orderDetail))
+	}
+	c.rollbackPurchaseCounter(ctx, dto.PurchaseCounter{
+		UserId:      orderDetail.UserId,
+		EventId:     orderDetail.EventId,
+		TicketType:  orderDetail.TicketType,
+		CountryCode: orderDetail.CountryCode,
+		Quantity:    orderDetail.Quantity,
+	})
+	if len(orderDetail.VoucherCodes) > 0 {
+		if err := c.voucherUsecaseCommand.ReleaseVouchers(ctx, orderDetail.OrderId); err != nil {
+			msg := "Error release voucher"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", orderDetail))
+		}
+	}
+	c.releasePresaleAllocation(ctx, orderDetail.PresaleId, orderDetail.TicketId, orderDetail.Quantity)
+	c.releaseSeats(ctx, orderDetail.OrderId, len(orderDetail.Seats))
+}
+
+func (c commandUsecase) releaseSeats(ctx context.Context, orderId string, seats int) {
+	if seats == 0 {
+		return
+	}
+	if err := c.seatUsecaseCommand.ReleaseSeats(ctx, orderId); err != nil {
+		msg := "Error release seat"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+	}
+}
+
+func (c commandUsecase) releasePresaleAllocation(ctx context.Context, presaleId string, ticketId string, quantity int) {
+	if presaleId == "" {
+		return
+	}
+	if err := c.presaleUsecaseCommand.ReleaseAllocation(ctx, presaleId, ticketId, quantity); err != nil {
+		msg := "Error release presale allocation"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+	}
+}
+
+func (c commandUsecase) rollbackPurchaseCounter(ctx context.Context, payload dto.PurchaseCounter) {
+	resp := <-c.orderRepositoryCommand.DecreasePurchaseCounter(ctx, payload)
+	if resp.Error != nil {
+		msg := "Error rollback purchase counter"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+	}
+}
+
+func purchaseLimitError(counter entity.PurchaseCounter, payload dto.PurchaseCounter, limit entity.PurchaseLimit) error {
+	if limit.MaxPerUser > 0 && counter.Total+payload.Quantity > limit.MaxPerUser {
+		return errors.UnprocessableEntity(fmt.Sprintf("maximum %d tickets per user for this event, you already have %d",
+			limit.MaxPerUser, counter.Total))
+	}
+	if limit.MaxPerTier > 0 && counter.Tiers[payload.TicketType]+payload.Quantity > limit.MaxPerTier {
+		return errors.UnprocessableEntity(fmt.Sprintf("maximum %d %s tickets per user, you already have %d",
+			limit.MaxPerTier, payload.TicketType, counter.Tiers[payload.TicketType]))
+	}
+	if limit.MaxPerCountry > 0 && counter.Countries[payload.CountryCode]+payload.Quantity > limit.MaxPerCountry {
+		return errors.UnprocessableEntity(fmt.Sprintf("maximum %d tickets per user in %s, you already have %d",
+			limit.MaxPerCountry, payload.CountryCode, counter.Countries[payload.CountryCode]))
+	}
+	return errors.UnprocessableEntity("purchase limit reached")
+}
+
+func appliedCodes(quote voucherDto.Quote) []string {
+	codes := make([]string, 0)
+	for _, value := range quote.Vouchers {
+		codes = append(codes, value.Code)
+	}
+	return codes
+}
+
+func mapOrderSeats(seats []seatDto.Seat) []entity.Seat {
+	if len(seats) == 0 {
+		return nil
+	}
+	result := make([]entity.Seat, 0)
+	for _, value := range seats {
+		result = append(result, entity.Seat{
+			SeatId:  value.SeatId,
+			Section: value.Section,
+			Row:     value.Row,
+			Number:  value.Number,
+		})
+	}
+	return result
+}
+
+func mapReservation(orderDetail entity.Order, status string) *response.Reservation {
+	var seats []response.Seat
+	for _, value := range orderDetail.Seats {
+		seats = append(seats, response.Seat{
+			SeatId:  value.SeatId,
+			Section: value.Section,
+			Row:     value.Row,
+			Number:  value.Number,
+		})
+	}
+	taxes := make([]response.Tax, 0)
+	for _, value := range orderDetail.Taxes {
+		taxes = append(taxes, response.Tax{
+			Name:         value.Name,
+			Jurisdiction: value.Jurisdiction,
+			Percent:      value.Percent,
+			Amount:       fmt.Sprintf("$%d", value.Amount),
+		})
+	}
+	return &response.Reservation{
+		OrderId:       orderDetail.OrderId,
+		EventId:       orderDetail.EventId,
+		TicketType:    orderDetail.TicketType,
+		CountryCode:   orderDetail.CountryCode,
+		Quantity:      orderDetail.Quantity,
+		TicketPrice:   fmt.Sprintf("$%d", orderDetail.TicketPrice),
+		PricePhase:    orderDetail.PricePhase,
+		SubtotalPrice: fmt.Sprintf("$%d", orderDetail.SubtotalPrice),
+		DiscountPrice: fmt.Sprintf("$%d", orderDetail.DiscountPrice),
+		ServiceFee:    fmt.Sprintf("$%d", orderDetail.ServiceFee),
+		FacilityFee:   fmt.Sprintf("$%d", orderDetail.FacilityFee),
+		Taxes:         taxes,
+		TaxPrice:      fmt.Sprintf("$%d", orderDetail.TaxPrice),
+		TotalPrice:    fmt.Sprintf("$%d", orderDetail.TotalPrice),
+		VoucherCodes:  orderDetail.VoucherCodes,
+		Seats:         seats,
+		Status:        status,
+		ExpiredAt:     orderDetail.ExpiredAt,
+	}
+}
@@ -0,0 +1,793 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	feeDto "ticket-service/internal/modules/fee/models/dto"
+	"ticket-service/internal/modules/order"
+	orderDto "ticket-service/internal/modules/order/models/dto"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	orderRequest "ticket-service/internal/modules/order/models/request"
+	uc "ticket-service/internal/modules/order/usecases"
+	presaleDto "ticket-service/internal/modules/presale/models/dto"
+	seatDto "ticket-service/internal/modules/seat/models/dto"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	voucherDto "ticket-service/internal/modules/voucher/models/dto"
+	voucherRequest "ticket-service/internal/modules/voucher/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockballot "ticket-service/mocks/modules/ballot"
+	mockfee "ticket-service/mocks/modules/fee"
+	mockorder "ticket-service/mocks/modules/order"
+	mockpresale "ticket-service/mocks/modules/presale"
+	mockresale "ticket-service/mocks/modules/resale"
+	mockseat "ticket-service/mocks/modules/seat"
+	mockticket "ticket-service/mocks/modules/ticket"
+	mockvoucher "ticket-service/mocks/modules/voucher"
+	mockwaitlist "ticket-service/mocks/modules/waitlist"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockOrderRepositoryQuery    *mockorder.MongodbRepositoryQuery
+	mockOrderRepositoryCommand  *mockorder.MongodbRepositoryCommand
+	mockTicketRepositoryQuery   *mockticket.MongodbRepositoryQuery
+	mockTicketRepositoryCommand *mockticket.MongodbRepositoryCommand
+	mockVoucherUsecaseCommand   *mockvoucher.UsecaseCommand
+	mockPresaleUsecaseCommand   *mockpresale.UsecaseCommand
+	mockBallotUsecaseQuery      *mockballot.UsecaseQuery
+	mockWaitlistUsecaseQuery    *mockwaitlist.UsecaseQuery
+	mockResaleUsecaseCommand    *mockresale.UsecaseCommand
+	mockFeeUsecaseQuery         *mockfee.UsecaseQuery
+	mockSeatUsecaseCommand      *mockseat.UsecaseCommand
+	mockLogger                  *mocklog.Logger
+	usecase                     order.UsecaseCommand
+	ctx                         context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockOrderRepositoryQuery = &mockorder.MongodbRepositoryQuery{}
+	suite.mockOrderRepositoryCommand = &mockorder.MongodbRepositoryCommand{}
+	suite.mockTicketRepositoryQuery = &mockticket.MongodbRepositoryQuery{}
+	suite.mockTicketRepositoryCommand = &mockticket.MongodbRepositoryCommand{}
+	suite.mockVoucherUsecaseCommand = &mockvoucher.UsecaseCommand{}
+	suite.mockPresaleUsecaseCommand = &mockpresale.UsecaseCommand{}
+	suite.mockBallotUsecaseQuery = &mockballot.UsecaseQuery{}
+	suite.mockWaitlistUsecaseQuery = &mockwaitlist.UsecaseQuery{}
+	suite.mockResaleUsecaseCommand = &mockresale.UsecaseCommand{}
+	suite.mockFeeUsecaseQuery = &mockfee.UsecaseQuery{}
+	suite.mockSeatUsecaseCommand = &mockseat.UsecaseCommand{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockOrderRepositoryQuery,
+		suite.mockOrderRepositoryCommand,
+		suite.mockTicketRepositoryQuery,
+		suite.mockTicketRepositoryCommand,
+		suite.mockVoucherUsecaseCommand,
+		suite.mockPresaleUsecaseCommand,
+		suite.mockBallotUsecaseQuery,
+		suite.mockWaitlistUsecaseQuery,
+		suite.mockResaleUsecaseCommand,
+		suite.mockFeeUsecaseQuery,
+		suite.mockSeatUsecaseCommand,
+		suite.mockLogger,
+	)
+	suite.mockPresaleUsecaseCommand.On("HoldAllocation", mock.Anything, mock.Anything).Return("", nil)
+	suite.mockBallotUsecaseQuery.On("CheckDirectSale", mock.Anything, mock.Anything).Return(nil)
+	suite.mockWaitlistUsecaseQuery.On("CheckPublicSale", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.mockSeatUsecaseCommand.On("HoldSeats", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.mockFeeUsecaseQuery.On("CalculateBreakdown", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, payload feeDto.BreakdownReq) (*feeDto.Breakdown, error) {
+			faceValue := payload.TicketPrice * payload.Quantity
+			return &feeDto.Breakdown{FaceValue: faceValue, Discount: payload.Discount, Total: faceValue - payload.Discount}, nil
+		})
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationSuccess() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$200", result.TotalPrice)
+	suite.mockOrderRepositoryCommand.AssertCalled(suite.T(), "IncreasePurchaseCounter", mock.Anything, orderDto.PurchaseCounter{
+		UserId:      payload.UserId,
+		EventId:     payload.EventId,
+		TicketType:  "Gold",
+		CountryCode: "ID",
+		Quantity:    2,
+	}, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationLocksPricePhase() {
+	// Arrange
+	payload := getReservationReq(2)
+	held := getMockEarlyBirdTicket(6)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockEarlyBirdTicket(8)))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(held))
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.MatchedBy(func(o orderEntity.Order) bool {
+		return o.TicketPrice == 80 && o.PricePhase == "Early Bird" && o.TotalPrice == 160
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$80", result.TicketPrice)
+	assert.Equal(suite.T(), "Early Bird", result.PricePhase)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationPricePhaseTakenConcurrently() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockEarlyBirdTicket(8)))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	// another order took the last early-bird tickets between the read and the hold
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockEarlyBirdTicket(4)))
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.MatchedBy(func(o orderEntity.Order) bool {
+		return o.TicketPrice == 100 && o.PricePhase == ""
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$200", result.TotalPrice)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationLocksDynamicPrice() {
+	// Arrange
+	payload := getReservationReq(2)
+	held := getMockEarlyBirdTicket(6)
+	held.Data.(*ticketEntity.Ticket).DynamicPrice = 130
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockEarlyBirdTicket(8)))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	// the price moved between the read and the hold, the order keeps the price of the hold
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(held))
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.MatchedBy(func(o orderEntity.Order) bool {
+		return o.TicketPrice == 130 && o.PricePhase == constants.PricePhaseDynamic && o.TotalPrice == 260
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$130", result.TicketPrice)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationWithVoucher() {
+	// Arrange
+	payload := getReservationReq(2)
+	payload.VoucherCodes = []string{"PROMO10"}
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockVoucherUsecaseCommand.On("RedeemVouchers", mock.Anything, mock.MatchedBy(func(r voucherRequest.RedeemReq) bool {
+		return r.OrderId != "" && r.Target.TicketPrice == 100 && r.Target.Quantity == 2
+	})).Return(&voucherDto.Quote{
+		Subtotal:      200,
+		TotalDiscount: 20,
+		Total:         180,
+		Vouchers:      []voucherDto.AppliedVoucher{{Code: "PROMO10", Discount: 20}},
+	}, nil)
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.MatchedBy(func(o orderEntity.Order) bool {
+		return o.TotalPrice == 180 && o.SubtotalPrice == 200
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$180", result.TotalPrice)
+	assert.Equal(suite.T(), "$20", result.DiscountPrice)
+	assert.Equal(suite.T(), []string{"PROMO10"}, result.VoucherCodes)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationWithFees() {
+	// Arrange
+	payload := getReservationReq(2)
+	payload.VoucherCodes = []string{"PROMO10"}
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockVoucherUsecaseCommand.On("RedeemVouchers", mock.Anything, mock.Anything).Return(&voucherDto.Quote{
+		Subtotal:      200,
+		TotalDiscount: 20,
+		Total:         180,
+		Vouchers:      []voucherDto.AppliedVoucher{{Code: "PROMO10", Discount: 20}},
+	}, nil)
+	suite.mockFeeUsecaseQuery.ExpectedCalls = nil
+	suite.mockFeeUsecaseQuery.On("CalculateBreakdown", mock.Anything, feeDto.BreakdownReq{
+		EventId:     payload.EventId,
+		CountryCode: payload.CountryCode,
+		TicketPrice: 100,
+		Quantity:    2,
+		Discount:    20,
+	}).Return(&feeDto.Breakdown{
+		FaceValue:   200,
+		Discount:    20,
+		ServiceFee:  18,
+		FacilityFee: 4,
+		Taxes:       []feeDto.TaxLine{{Name: "PPN", Jurisdiction: "ID", Percent: 11, Amount: 22}},
+		TotalTax:    22,
+		Total:       224,
+	}, nil)
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.MatchedBy(func(o orderEntity.Order) bool {
+		return o.SubtotalPrice == 200 && o.DiscountPrice == 20 && o.ServiceFee == 18 && o.FacilityFee == 4 &&
+			o.TaxPrice == 22 && len(o.Taxes) == 1 && o.TotalPrice == 224
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$224", result.TotalPrice)
+	assert.Equal(suite.T(), "$18", result.ServiceFee)
+	assert.Equal(suite.T(), "$22", result.Taxes[0].Amount)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrFees() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockFeeUsecaseQuery.ExpectedCalls = nil
+	suite.mockFeeUsecaseQuery.On("CalculateBreakdown", mock.Anything, mock.Anything).Return(nil, errors.InternalServerError("error"))
+	suite.mockTicketRepositoryCommand.On("IncreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryCommand.On("DecreasePurchaseCounter", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Error(suite.T(), err)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "IncreaseTotalRemaining", mock.Anything, "ticket-id", 2)
+	suite.mockOrderRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationWithSeats() {
+	// Arrange
+	payload := getReservationReq(2)
+	payload.SeatIds = []string{"event-id:A:1:5", "event-id:A:1:6"}
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockSeatUsecaseCommand.ExpectedCalls = nil
+	suite.mockSeatUsecaseCommand.On("HoldSeats", mock.Anything, mock.MatchedBy(func(req seatDto.HoldReq) bool {
+		return req.TicketType == payload.TicketType && len(req.SeatIds) == 2 && req.OrderId != "" && req.HeldUntil.After(time.Now())
+	})).Return([]seatDto.Seat{
+		{SeatId: "event-id:A:1:5", Section: "A", Row: "1", Number: 5},
+		{SeatId: "event-id:A:1:6", Section: "A", Row: "1", Number: 6},
+	}, nil)
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.MatchedBy(func(order orderEntity.Order) bool {
+		return len(order.Seats) == 2 && order.Seats[1].Number == 6
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5, result.Seats[0].Number)
+	assert.Equal(suite.T(), "A", result.Seats[0].Section)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrSeatTaken() {
+	// Arrange
+	payload := getReservationReq(2)
+	payload.SeatIds = []string{"event-id:A:1:5", "event-id:A:1:6"}
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockSeatUsecaseCommand.ExpectedCalls = nil
+	suite.mockSeatUsecaseCommand.On("HoldSeats", mock.Anything, mock.Anything).Return(nil, errors.Conflict("seat event-id:A:1:5 is not available"))
+	suite.mockTicketRepositoryCommand.On("IncreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryCommand.On("DecreasePurchaseCounter", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("seat event-id:A:1:5 is not available"), err)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "IncreaseTotalRemaining", mock.Anything, "ticket-id", 2)
+	suite.mockOrderRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrVoucher() {
+	// Arrange
+	payload := getReservationReq(2)
+	payload.VoucherCodes = []string{"PROMO10"}
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockVoucherUsecaseCommand.On("RedeemVouchers", mock.Anything, mock.Anything).
+		Return(nil, errors.UnprocessableEntity("voucher PROMO10 has been fully redeemed"))
+	suite.mockOrderRepositoryCommand.On("DecreasePurchaseCounter", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockTicketRepositoryCommand.On("IncreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("voucher PROMO10 has been fully redeemed"), err)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "IncreaseTotalRemaining", mock.Anything, "ticket-id", 2)
+	suite.mockOrderRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrMaxPerOrder() {
+	// Arrange
+	payload := getReservationReq(5)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("maximum 4 tickets per order"), err)
+	suite.mockOrderRepositoryCommand.AssertNotCalled(suite.T(), "IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrMaxPerTier() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(getMockLimit()))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{
+		Data: &orderEntity.PurchaseCounter{
+			Total:     3,
+			Tiers:     map[string]int{"Gold": 3},
+			Countries: map[string]int{"ID": 3},
+		},
+	}))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("maximum 4 Gold tickets per user, you already have 3"), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "DecreaseTotalRemaining", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrSoldOut() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderRepositoryCommand.On("DecreasePurchaseCounter", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("ticket quota is not enough"), err)
+	suite.mockOrderRepositoryCommand.AssertCalled(suite.T(), "DecreasePurchaseCounter", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationPresale() {
+	// Arrange
+	payload := getReservationReq(2)
+	payload.UserRole = "fanclub"
+	suite.mockPresaleUsecaseCommand.ExpectedCalls = nil
+	suite.mockPresaleUsecaseCommand.On("HoldAllocation", mock.Anything, presaleDto.AllocationReq{
+		EventId:    "event-id",
+		UserId:     "user-id",
+		UserRole:   "fanclub",
+		TicketId:   "ticket-id",
+		TotalQuota: 10,
+		Quantity:   2,
+	}).Return("presale-id", nil)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.MatchedBy(func(o orderEntity.Order) bool {
+		return o.PresaleId == "presale-id"
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.OrderStatusPending, result.Status)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrPresaleOnly() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockPresaleUsecaseCommand.ExpectedCalls = nil
+	suite.mockPresaleUsecaseCommand.On("HoldAllocation", mock.Anything, mock.Anything).
+		Return("", errors.ForbiddenError("tickets are only available to Fan Club members until 2026-01-01T00:00:00Z"))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.ForbiddenError("tickets are only available to Fan Club members until 2026-01-01T00:00:00Z"), err)
+	suite.mockOrderRepositoryCommand.AssertNotCalled(suite.T(), "InitPurchaseCounter", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationPresaleErrSoldOut() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockPresaleUsecaseCommand.ExpectedCalls = nil
+	suite.mockPresaleUsecaseCommand.On("HoldAllocation", mock.Anything, mock.Anything).Return("presale-id", nil)
+	suite.mockPresaleUsecaseCommand.On("ReleaseAllocation", mock.Anything, "presale-id", "ticket-id", 2).Return(nil)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderRepositoryCommand.On("DecreasePurchaseCounter", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("ticket quota is not enough"), err)
+	suite.mockPresaleUsecaseCommand.AssertCalled(suite.T(), "ReleaseAllocation", mock.Anything, "presale-id", "ticket-id", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrBallotOnly() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockBallotUsecaseQuery.ExpectedCalls = nil
+	suite.mockBallotUsecaseQuery.On("CheckDirectSale", mock.Anything, payload.EventId).
+		Return(errors.ForbiddenError("tickets of this event are sold by ballot Main Ballot"))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.ForbiddenError("tickets of this event are sold by ballot Main Ballot"), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "DecreaseTotalRemaining", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrEventCancelled() {
+	// Arrange
+	payload := getReservationReq(2)
+	ticket := getMockTicket()
+	ticket.Data.(*ticketEntity.Ticket).Cancelled = true
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(ticket))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("event is cancelled"), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "DecreaseTotalRemaining", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationBallotClaim() {
+	// Arrange
+	payload := getReservationReq(2)
+	payload.OrderId = "order-id"
+	payload.BallotEntryId = "entry-id"
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.MatchedBy(func(o orderEntity.Order) bool {
+		return o.OrderId == "order-id" && o.BallotEntryId == "entry-id"
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "order-id", result.OrderId)
+	suite.mockBallotUsecaseQuery.AssertNotCalled(suite.T(), "CheckDirectSale", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrHeldForWaitlist() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockWaitlistUsecaseQuery.ExpectedCalls = nil
+	suite.mockWaitlistUsecaseQuery.On("CheckPublicSale", mock.Anything, "ticket-id", mock.Anything).
+		Return(errors.UnprocessableEntity("released tickets of this tier are held for the waitlist"))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("released tickets of this tier are held for the waitlist"), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "DecreaseTotalRemaining", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationWaitlistOffer() {
+	// Arrange
+	payload := getReservationReq(2)
+	payload.WaitlistEntryId = "entry-id"
+	payload.HoldDuration = 2 * time.Hour
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryQuery.On("FindPurchaseLimitByEventId", mock.Anything, payload.EventId).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderRepositoryCommand.On("InitPurchaseCounter", mock.Anything, payload.UserId, payload.EventId).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockOrderRepositoryCommand.On("IncreasePurchaseCounter", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{Total: 2}}))
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.ExpiredAt.After(time.Now().Add(time.Hour)))
+	suite.mockWaitlistUsecaseQuery.AssertNotCalled(suite.T(), "CheckPublicSale", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateReservationErrTicketNotFound() {
+	// Arrange
+	payload := getReservationReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	_, err := suite.usecase.CreateReservation(suite.ctx, payload)
+
+	// Assert
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpsertPurchaseLimit() {
+	// Arrange
+	payload := orderRequest.PurchaseLimitReq{
+		EventId:     "event-id",
+		MaxPerOrder: 4,
+	}
+	suite.mockOrderRepositoryCommand.On("UpsertPurchaseLimit", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{}))
+
+	// Act
+	result, err := suite.usecase.UpsertPurchaseLimit(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, result.MaxPerOrder)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelReservation() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPending)))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPending,
+		constants.OrderStatusCancelled).Return(mockChannel(getMockOrder(constants.OrderStatusCancelled)))
+	suite.mockTicketRepositoryCommand.On("IncreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryCommand.On("DecreasePurchaseCounter", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+
+	// Act
+	result, err := suite.usecase.CancelReservation(suite.ctx, orderRequest.CancelReservationReq{UserId: "user-id", OrderId: "order-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.OrderStatusCancelled, result.Status)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "IncreaseTotalRemaining", mock.Anything, "ticket-id", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelReservationResale() {
+	// Arrange
+	resaleOrder := getMockOrder(constants.OrderStatusPending)
+	resaleOrder.Data.(*orderEntity.Order).ResaleListingId = "listing-id"
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(resaleOrder))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPending,
+		constants.OrderStatusCancelled).Return(mockChannel(getMockOrder(constants.OrderStatusCancelled)))
+	suite.mockResaleUsecaseCommand.On("ReleaseListing", mock.Anything, "order-id").Return(nil)
+
+	// Act
+	result, err := suite.usecase.CancelReservation(suite.ctx, orderRequest.CancelReservationReq{UserId: "user-id", OrderId: "order-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.OrderStatusCancelled, result.Status)
+	suite.mockResaleUsecaseCommand.AssertCalled(suite.T(), "ReleaseListing", mock.Anything, "order-id")
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "IncreaseTotalRemaining", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelReservationErrPaid() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+
+	// Act
+	_, err := suite.usecase.CancelReservation(suite.ctx, orderRequest.CancelReservationReq{UserId: "user-id", OrderId: "order-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("order is PAID and cannot be cancelled"), err)
+	suite.mockOrderRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReleaseOrderPaid() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPaid,
+		constants.OrderStatusRefunded).Return(mockChannel(getMockOrder(constants.OrderStatusRefunded)))
+	suite.mockTicketRepositoryCommand.On("IncreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryCommand.On("DecreasePurchaseCounter", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+
+	// Act
+	result, err := suite.usecase.ReleaseOrder(suite.ctx, "order-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.OrderStatusRefunded, result.Status)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "IncreaseTotalRemaining", mock.Anything, "ticket-id", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReleaseHold() {
+	// Arrange
+	orderDetail := *getMockOrder(constants.OrderStatusRefunded).Data.(*orderEntity.Order)
+	orderDetail.VoucherCodes = []string{"WELCOME"}
+	orderDetail.PresaleId = "presale-id"
+	orderDetail.Seats = []orderEntity.Seat{{SeatId: "seat-1"}, {SeatId: "seat-2"}}
+	suite.mockTicketRepositoryCommand.On("IncreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(getMockTicket()))
+	suite.mockOrderRepositoryCommand.On("DecreasePurchaseCounter", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+	suite.mockVoucherUsecaseCommand.On("ReleaseVouchers", mock.Anything, "order-id").Return(nil)
+	suite.mockPresaleUsecaseCommand.On("ReleaseAllocation", mock.Anything, "presale-id", "ticket-id", 2).Return(nil)
+	suite.mockSeatUsecaseCommand.On("ReleaseSeats", mock.Anything, "order-id").Return(nil)
+
+	// Act
+	suite.usecase.ReleaseHold(suite.ctx, orderDetail)
+
+	// Assert
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "IncreaseTotalRemaining", mock.Anything, "ticket-id", 2)
+	suite.mockVoucherUsecaseCommand.AssertCalled(suite.T(), "ReleaseVouchers", mock.Anything, "order-id")
+	suite.mockPresaleUsecaseCommand.AssertCalled(suite.T(), "ReleaseAllocation", mock.Anything, "presale-id", "ticket-id", 2)
+	suite.mockSeatUsecaseCommand.AssertCalled(suite.T(), "ReleaseSeats", mock.Anything, "order-id")
+}
+
+func (suite *CommandUsecaseTestSuite) TestReleaseHoldEventCancelled() {
+	// Arrange
+	orderDetail := *getMockOrder(constants.OrderStatusRefunded).Data.(*orderEntity.Order)
+	suite.mockTicketRepositoryCommand.On("IncreaseTotalRemaining", mock.Anything, "ticket-id", 2).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderRepositoryCommand.On("DecreasePurchaseCounter", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: &orderEntity.PurchaseCounter{}}))
+
+	// Act
+	suite.usecase.ReleaseHold(suite.ctx, orderDetail)
+
+	// Assert
+	suite.mockOrderRepositoryCommand.AssertCalled(suite.T(), "DecreasePurchaseCounter", mock.Anything, mock.Anything)
+	suite.mockLogger.AssertNotCalled(suite.T(), "Error", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReleaseOrderAlreadyReleased() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusExpired)))
+
+	// Act
+	result, err := suite.usecase.ReleaseOrder(suite.ctx, "order-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.OrderStatusExpired, result.Status)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "IncreaseTotalRemaining", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
+
+func getReservationReq(quantity int) orderDto.ReservationReq {
+	return orderDto.ReservationReq{
+		UserId:      "user-id",
+		EventId:     "event-id",
+		CountryCode: "ID",
+		TicketType:  "Gold",
+		Quantity:    quantity,
+	}
+}
+
+func getMockTicket() helpers.Result {
+	return helpers.Result{
+		Data: &ticketEntity.Ticket{
+			TicketId:       "ticket-id",
+			EventId:        "event-id",
+			TicketType:     "Gold",
+			TicketPrice:    100,
+			TotalQuota:     10,
+			TotalRemaining: 10,
+			Country: ticketEntity.Country{
+				Code: "ID",
+			},
+		},
+	}
+}
+
+// getMockEarlyBirdTicket sells the first 4 of 10 tickets at the early-bird price
+func getMockEarlyBirdTicket(remaining int) helpers.Result {
+	ticketData := getMockTicket()
+	ticketDetail := ticketData.Data.(*ticketEntity.Ticket)
+	ticketDetail.TotalRemaining = remaining
+	ticketDetail.PricePhases = []ticketEntity.PricePhase{{Name: "Early Bird", Price: 80, MaxSold: 4}}
+	return ticketData
+}
+
+func getMockLimit() helpers.Result {
+	return helpers.Result{
+		Data: &orderEntity.PurchaseLimit{
+			EventId:     "event-id",
+			MaxPerUser:  6,
+			MaxPerTier:  4,
+			MaxPerOrder: 4,
+		},
+	}
+}
+
+func getMockOrder(status string) helpers.Result {
+	return helpers.Result{
+		Data: &orderEntity.Order{
+			OrderId:     "order-id",
+			UserId:      "user-id",
+			TicketId:    "ticket-id",
+			EventId:     "event-id",
+			TicketType:  "Gold",
+			CountryCode: "ID",
+			Quantity:    2,
+			TicketPrice: 100,
+			TotalPrice:  200,
+			Status:      status,
+		},
+	}
+}
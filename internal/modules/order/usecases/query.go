@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/order"
+	"ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/order/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	orderRepositoryQuery order.MongodbRepositoryQuery
+	logger               log.Logger
+}
+
+func NewQueryUsecase(omq order.MongodbRepositoryQuery, log log.Logger) order.UsecaseQuery {
+	return queryUsecase{
+		orderRepositoryQuery: omq,
+		logger:               log,
+	}
+}
+
+func (q queryUsecase) FindPurchaseLimit(origCtx context.Context, eventId string) (*response.PurchaseLimit, error) {
+	domain := "orderUsecase-FindPurchaseLimit"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.orderRepositoryQuery.FindPurchaseLimitByEventId(ctx, eventId)
+	if resp.Error != nil {
+		msg := "Error query purchase limit"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return &response.PurchaseLimit{EventId: eventId}, nil
+	}
+
+	limit, ok := resp.Data.(*entity.PurchaseLimit)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	return &response.PurchaseLimit{
+		EventId:       limit.EventId,
+		MaxPerUser:    limit.MaxPerUser,
+		MaxPerTier:    limit.MaxPerTier,
+		MaxPerCountry: limit.MaxPerCountry,
+		MaxPerOrder:   limit.MaxPerOrder,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/payment"
+	"ticket-service/internal/modules/payment/models/request"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+const headerWebhookSignature = "X-Signature"
+
+type PaymentHttpHandler struct {
+	PaymentUsecaseCommand payment.UsecaseCommand
+	PaymentUsecaseQuery   payment.UsecaseQuery
+	Logger                log.Logger
+	Validator             *validator.Validate
+}
+
+func InitPaymentHttpHandler(app *fiber.App, puc payment.UsecaseCommand, puq payment.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &PaymentHttpHandler{
+		PaymentUsecaseCommand: puc,
+		PaymentUsecaseQuery:   puq,
+		Logger:                log,
+		Validator:             validator.New(),
+	}
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/payments")
+
+	route.Post("/v1/charges", middlewares.VerifyBearer(), handler.CreateCharge)
+	route.Get("/v1/orders/:orderId", middlewares.VerifyBearer(), handler.GetOrderPayments)
+	// webhooks are authenticated by the provider signature
+	route.Post("/v1/webhooks/:provider", handler.HandleWebhook)
+	route.Post("/v1/expire-holds", middlewares.VerifyBasicAuth(), handler.ExpireHolds)
+	route.Post("/v1/refunds", middlewares.VerifyBasicAuth(), handler.RefundOrder)
+}
+
+func (p PaymentHttpHandler) CreateCharge(c *fiber.Ctx) error {
+	req := new(request.ChargeReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := p.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, p.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	resp, err := p.PaymentUsecaseCommand.CreateCharge(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Create charge success")
+}
+
+func (p PaymentHttpHandler) GetOrderPayments(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, p.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	resp, err := p.PaymentUsecaseQuery.FindOrderPayments(c.Context(), userId, c.Params("orderId"))
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Get order payment success")
+}
+
+func (p PaymentHttpHandler) HandleWebhook(c *fiber.Ctx) error {
+	signature := c.Get(headerWebhookSignature)
+	if signature == "" {
+		return helpers.RespError(c, p.Logger, errors.ForbiddenError("missing webhook signature"))
+	}
+	req := request.WebhookReq{
+		Provider:  c.Params("provider"),
+		Signature: signature,
+		Payload:   append([]byte(nil), c.Body()...),
+	}
+	resp, err := p.PaymentUsecaseCommand.HandleWebhook(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Handle webhook success")
+}
+
+func (p PaymentHttpHandler) ExpireHolds(c *fiber.Ctx) error {
+	resp, err := p.PaymentUsecaseCommand.ExpireHolds(c.Context())
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Expire holds success")
+}
+
+func (p PaymentHttpHandler) RefundOrder(c *fiber.Ctx) error {
+	req := new(request.RefundOrderReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := p.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := p.PaymentUsecaseCommand.RefundOrder(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Refund order success")
+}
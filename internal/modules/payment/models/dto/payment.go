@@ -0,0 +1,24 @@
+package dto
+
+type ChargeReq struct {
+	ReferenceId string
+	Amount      int
+	Currency    string
+	Description string
+}
+
+type Charge struct {
+	ChargeId       string
+	Status         string
+	Amount         int
+	RefundedAmount int
+	CheckoutUrl    string
+}
+
+// WebhookEvent is a provider callback after its signature has been verified
+type WebhookEvent struct {
+	EventId  string `json:"eventId"`
+	Type     string `json:"type"`
+	ChargeId string `json:"chargeId"`
+	Amount   int    `json:"amount"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+type Payment struct {
+	PaymentId      string    `json:"paymentId" bson:"paymentId"`
+	OrderId        string    `json:"orderId" bson:"orderId"`
+	UserId         string    `json:"userId" bson:"userId"`
+	Provider       string    `json:"provider" bson:"provider"`
+	ChargeId       string    `json:"chargeId" bson:"chargeId"`
+	Amount         int       `json:"amount" bson:"amount"`
+	RefundedAmount int       `json:"refundedAmount" bson:"refundedAmount"`
+	Currency       string    `json:"currency" bson:"currency"`
+	CheckoutUrl    string    `json:"checkoutUrl" bson:"checkoutUrl"`
+	Status         string    `json:"status" bson:"status"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// WebhookEvent is kept per provider event id so a redelivered callback is only applied once
+type WebhookEvent struct {
+	Provider   string    `json:"provider" bson:"provider"`
+	EventId    string    `json:"eventId" bson:"eventId"`
+	Type       string    `json:"type" bson:"type"`
+	ChargeId   string    `json:"chargeId" bson:"chargeId"`
+	Status     string    `json:"status" bson:"status"`
+	ReceivedAt time.Time `json:"receivedAt" bson:"receivedAt"`
+	UpdatedAt  time.Time `json:"updatedAt" bson:"updatedAt"`
+}
@@ -0,0 +1,17 @@
+package request
+
+type ChargeReq struct {
+	UserId  string `json:"-"`
+	OrderId string `json:"orderId" validate:"required"`
+}
+
+type WebhookReq struct {
+	Provider  string
+	Signature string
+	Payload   []byte
+}
+
+type RefundOrderReq struct {
+	OrderId string `json:"orderId" validate:"required"`
+	Amount  int    `json:"amount" validate:"required,min=1"`
+}
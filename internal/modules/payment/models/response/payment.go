@@ -0,0 +1,29 @@
+package response
+
+import "time"
+
+type Payment struct {
+	PaymentId   string    `json:"paymentId"`
+	OrderId     string    `json:"orderId"`
+	Provider    string    `json:"provider"`
+	Amount      string    `json:"amount"`
+	Status      string    `json:"status"`
+	CheckoutUrl string    `json:"checkoutUrl"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type Webhook struct {
+	EventId   string `json:"eventId"`
+	Duplicate bool   `json:"duplicate"`
+}
+
+type ExpiredHolds struct {
+	ExpiredOrders  int `json:"expiredOrders"`
+	VoidedPayments int `json:"voidedPayments"`
+}
+
+type CancelledPayments struct {
+	OrderId          string `json:"orderId"`
+	VoidedPayments   int    `json:"voidedPayments"`
+	RefundedPayments int    `json:"refundedPayments"`
+}
@@ -0,0 +1,46 @@
+package payment
+
+import (
+	"context"
+	"ticket-service/internal/modules/payment/models/dto"
+	"ticket-service/internal/modules/payment/models/entity"
+	"ticket-service/internal/modules/payment/models/request"
+	"ticket-service/internal/modules/payment/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseCommand interface {
+	CreateCharge(origCtx context.Context, payload request.ChargeReq) (*response.Payment, error)
+	HandleWebhook(origCtx context.Context, payload request.WebhookReq) (*response.Webhook, error)
+	ExpireHolds(origCtx context.Context) (*response.ExpiredHolds, error)
+	RefundOrder(origCtx context.Context, payload request.RefundOrderReq) (*response.Payment, error)
+	CancelOrderPayments(origCtx context.Context, orderId string) (*response.CancelledPayments, error)
+}
+
+type UsecaseQuery interface {
+	FindOrderPayments(origCtx context.Context, userId string, orderId string) ([]response.Payment, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindPaymentByChargeId(ctx context.Context, provider string, chargeId string) <-chan wrapper.Result
+	FindPaymentsByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	InsertOnePayment(ctx context.Context, payment entity.Payment) <-chan wrapper.Result
+	UpdatePaymentStatus(ctx context.Context, paymentId string, fromStatus string, toStatus string) <-chan wrapper.Result
+	UpdatePaymentRefunded(ctx context.Context, paymentId string, amount int) <-chan wrapper.Result
+	InitWebhookEvent(ctx context.Context, event entity.WebhookEvent) <-chan wrapper.Result
+	UpdateWebhookEventStatus(ctx context.Context, provider string, eventId string, status string) <-chan wrapper.Result
+	CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result
+}
+
+// Provider is implemented by every payment gateway, amounts are in the smallest currency unit
+type Provider interface {
+	Name() string
+	CreateCharge(ctx context.Context, payload dto.ChargeReq) (*dto.Charge, error)
+	CaptureCharge(ctx context.Context, chargeId string) (*dto.Charge, error)
+	VoidCharge(ctx context.Context, chargeId string) (*dto.Charge, error)
+	RefundCharge(ctx context.Context, chargeId string, amount int) (*dto.Charge, error)
+	ParseWebhook(payload []byte, signature string) (*dto.WebhookEvent, error)
+}
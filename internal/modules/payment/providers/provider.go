@@ -0,0 +1,32 @@
+package providers
+
+import (
+	"fmt"
+	"ticket-service/internal/modules/payment"
+)
+
+// simulatorEnvs are the service environments the in memory simulator may run in, its charges are lost on a restart
+// and are not shared between pods
+var simulatorEnvs = map[string]bool{
+	"development": true,
+	"local":       true,
+	"test":        true,
+}
+
+// NewProvider picks the payment provider configured for the service, it refuses to start without a webhook secret
+// because anyone could sign a webhook with an empty one
+func NewProvider(name string, webhookSecret string, serviceEnv string) (payment.Provider, error) {
+	if webhookSecret == "" {
+		return nil, fmt.Errorf("payment webhook secret is not set")
+	}
+
+	switch name {
+	case SimulatorName:
+		if !simulatorEnvs[serviceEnv] {
+			return nil, fmt.Errorf("payment provider %s only runs in local and test environments, not in %q", name, serviceEnv)
+		}
+		return NewSimulator(webhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
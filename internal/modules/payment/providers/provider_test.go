@@ -0,0 +1,37 @@
+package providers_test
+
+import (
+	"testing"
+
+	"ticket-service/internal/modules/payment/providers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProviderSimulator(t *testing.T) {
+	provider, err := providers.NewProvider(providers.SimulatorName, "secret", "development")
+
+	assert.NoError(t, err)
+	assert.Equal(t, providers.SimulatorName, provider.Name())
+}
+
+func TestNewProviderErrEmptySecret(t *testing.T) {
+	provider, err := providers.NewProvider(providers.SimulatorName, "", "development")
+
+	assert.Nil(t, provider)
+	assert.EqualError(t, err, "payment webhook secret is not set")
+}
+
+func TestNewProviderErrSimulatorInProduction(t *testing.T) {
+	provider, err := providers.NewProvider(providers.SimulatorName, "secret", "production")
+
+	assert.Nil(t, provider)
+	assert.Error(t, err)
+}
+
+func TestNewProviderErrUnknown(t *testing.T) {
+	provider, err := providers.NewProvider("", "secret", "production")
+
+	assert.Nil(t, provider)
+	assert.EqualError(t, err, `unknown payment provider ""`)
+}
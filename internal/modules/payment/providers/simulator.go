@@ -0,0 +1,146 @@
+package providers
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"ticket-service/internal/modules/payment"
+	"ticket-service/internal/modules/payment/models/dto"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const SimulatorName = "simulator"
+
+// Simulator is an in memory payment gateway for local runs and tests, charges do not survive a restart
+type Simulator struct {
+	secret  string
+	mu      sync.Mutex
+	charges map[string]*dto.Charge
+}
+
+func NewSimulator(secret string) *Simulator {
+	return &Simulator{
+		secret:  secret,
+		charges: make(map[string]*dto.Charge),
+	}
+}
+
+var _ payment.Provider = (*Simulator)(nil)
+
+func (s *Simulator) Name() string {
+	return SimulatorName
+}
+
+func (s *Simulator) CreateCharge(ctx context.Context, payload dto.ChargeReq) (*dto.Charge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chargeId := fmt.Sprintf("sim_ch_%s", uuid.NewString())
+	charge := &dto.Charge{
+		ChargeId:    chargeId,
+		Status:      constants.PaymentStatusPending,
+		Amount:      payload.Amount,
+		CheckoutUrl: fmt.Sprintf("https://simulator.local/checkout/%s", chargeId),
+	}
+	s.charges[chargeId] = charge
+	result := *charge
+	return &result, nil
+}
+
+func (s *Simulator) CaptureCharge(ctx context.Context, chargeId string) (*dto.Charge, error) {
+	return s.move(chargeId, constants.PaymentStatusCaptured, constants.PaymentStatusAuthorized)
+}
+
+func (s *Simulator) VoidCharge(ctx context.Context, chargeId string) (*dto.Charge, error) {
+	return s.move(chargeId, constants.PaymentStatusVoided, constants.PaymentStatusPending, constants.PaymentStatusAuthorized)
+}
+
+// RefundCharge keeps a partially refunded charge CAPTURED, it only becomes REFUNDED once the whole amount is back
+func (s *Simulator) RefundCharge(ctx context.Context, chargeId string, amount int) (*dto.Charge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	charge, ok := s.charges[chargeId]
+	if !ok {
+		return nil, errors.NotFound("charge not found")
+	}
+	if charge.Status != constants.PaymentStatusCaptured {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("charge is %s", charge.Status))
+	}
+	if amount > charge.Amount-charge.RefundedAmount {
+		return nil, errors.BadRequest("refund amount is more than the charge")
+	}
+	charge.RefundedAmount += amount
+	if charge.RefundedAmount == charge.Amount {
+		charge.Status = constants.PaymentStatusRefunded
+	}
+	result := *charge
+	return &result, nil
+}
+
+// Authorize plays the customer completing the checkout and returns the signed webhook the gateway would send
+func (s *Simulator) Authorize(chargeId string) (payload []byte, signature string, err error) {
+	charge, err := s.move(chargeId, constants.PaymentStatusAuthorized, constants.PaymentStatusPending)
+	if err != nil {
+		return nil, "", err
+	}
+	payload, _ = json.Marshal(dto.WebhookEvent{
+		EventId:  fmt.Sprintf("sim_evt_%s", uuid.NewString()),
+		Type:     constants.PaymentEventChargeAuthorized,
+		ChargeId: charge.ChargeId,
+		Amount:   charge.Amount,
+	})
+	return payload, s.Sign(payload), nil
+}
+
+// Sign returns the hex HMAC-SHA256 of the payload, the same scheme ParseWebhook verifies
+func (s *Simulator) Sign(payload []byte) string {
+	return hex.EncodeToString(s.signRaw(payload))
+}
+
+func (s *Simulator) ParseWebhook(payload []byte, signature string) (*dto.WebhookEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, s.signRaw(payload)) {
+		return nil, errors.ForbiddenError("invalid webhook signature")
+	}
+
+	var event dto.WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, errors.BadRequest("invalid webhook payload")
+	}
+	if event.EventId == "" || event.ChargeId == "" {
+		return nil, errors.BadRequest("invalid webhook payload")
+	}
+	return &event, nil
+}
+
+func (s *Simulator) signRaw(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func (s *Simulator) move(chargeId string, toStatus string, fromStatus ...string) (*dto.Charge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	charge, ok := s.charges[chargeId]
+	if !ok {
+		return nil, errors.NotFound("charge not found")
+	}
+	for _, status := range fromStatus {
+		if charge.Status == status {
+			charge.Status = toStatus
+			result := *charge
+			return &result, nil
+		}
+	}
+	return nil, errors.UnprocessableEntity(fmt.Sprintf("charge is %s", charge.Status))
+}
@@ -0,0 +1,84 @@
+package providers_test
+
+import (
+	"context"
+	"testing"
+
+	"ticket-service/internal/modules/payment/models/dto"
+	"ticket-service/internal/modules/payment/providers"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatorChargeLifecycle(t *testing.T) {
+	simulator := providers.NewSimulator("secret")
+	ctx := context.Background()
+
+	charge, err := simulator.CreateCharge(ctx, dto.ChargeReq{ReferenceId: "order-id", Amount: 200})
+	assert.NoError(t, err)
+	assert.Equal(t, constants.PaymentStatusPending, charge.Status)
+
+	_, err = simulator.CaptureCharge(ctx, charge.ChargeId)
+	assert.Equal(t, errors.UnprocessableEntity("charge is PENDING"), err)
+
+	payload, signature, err := simulator.Authorize(charge.ChargeId)
+	assert.NoError(t, err)
+
+	event, err := simulator.ParseWebhook(payload, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.PaymentEventChargeAuthorized, event.Type)
+	assert.Equal(t, charge.ChargeId, event.ChargeId)
+
+	captured, err := simulator.CaptureCharge(ctx, charge.ChargeId)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.PaymentStatusCaptured, captured.Status)
+
+	refunded, err := simulator.RefundCharge(ctx, charge.ChargeId, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.PaymentStatusRefunded, refunded.Status)
+}
+
+func TestSimulatorPartialRefunds(t *testing.T) {
+	simulator := providers.NewSimulator("secret")
+	ctx := context.Background()
+
+	charge, err := simulator.CreateCharge(ctx, dto.ChargeReq{ReferenceId: "order-id", Amount: 200})
+	assert.NoError(t, err)
+	_, _, err = simulator.Authorize(charge.ChargeId)
+	assert.NoError(t, err)
+	_, err = simulator.CaptureCharge(ctx, charge.ChargeId)
+	assert.NoError(t, err)
+
+	partial, err := simulator.RefundCharge(ctx, charge.ChargeId, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.PaymentStatusCaptured, partial.Status)
+	assert.Equal(t, 50, partial.RefundedAmount)
+
+	_, err = simulator.RefundCharge(ctx, charge.ChargeId, 200)
+	assert.Equal(t, errors.BadRequest("refund amount is more than the charge"), err)
+
+	refunded, err := simulator.RefundCharge(ctx, charge.ChargeId, 150)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.PaymentStatusRefunded, refunded.Status)
+
+	_, err = simulator.RefundCharge(ctx, charge.ChargeId, 1)
+	assert.Equal(t, errors.UnprocessableEntity("charge is REFUNDED"), err)
+}
+
+func TestSimulatorParseWebhookErrSignature(t *testing.T) {
+	simulator := providers.NewSimulator("secret")
+	other := providers.NewSimulator("other-secret")
+	payload := []byte(`{"eventId":"evt-1","type":"charge.authorized","chargeId":"ch-1"}`)
+
+	_, err := simulator.ParseWebhook(payload, other.Sign(payload))
+	assert.Equal(t, errors.ForbiddenError("invalid webhook signature"), err)
+
+	_, err = simulator.ParseWebhook(payload, "not-hex")
+	assert.Equal(t, errors.ForbiddenError("invalid webhook signature"), err)
+
+	event, err := simulator.ParseWebhook(payload, simulator.Sign(payload))
+	assert.NoError(t, err)
+	assert.Equal(t, "evt-1", event.EventId)
+}
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/payment"
+	"ticket-service/internal/modules/payment/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) payment.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) InsertOnePayment(ctx context.Context, payment entity.Payment) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "payments",
+			Document:       payment,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdatePaymentStatus moves the payment only when it is still in fromStatus, Data is nil when it is not
+func (c commandMongodbRepository) UpdatePaymentStatus(ctx context.Context, paymentId string, fromStatus string, toStatus string) <-chan wrapper.Result {
+	var payment entity.Payment
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &payment,
+			CollectionName: "payments",
+			Filter: bson.M{
+				"paymentId": paymentId,
+				"status":    fromStatus,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    toStatus,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdatePaymentRefunded records a refund on a captured payment as long as it does not exceed the captured amount,
+// the payment stays CAPTURED until the whole amount has been refunded
+func (c commandMongodbRepository) UpdatePaymentRefunded(ctx context.Context, paymentId string, amount int) <-chan wrapper.Result {
+	var payment entity.Payment
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &payment,
+			CollectionName: "payments",
+			Filter: bson.M{
+				"paymentId": paymentId,
+				"status":    constants.PaymentStatusCaptured,
+				"$expr": bson.M{
+					"$lte": bson.A{bson.M{"$add": bson.A{"$refundedAmount", amount}}, "$amount"},
+				},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"refundedAmount": amount},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		if resp.Error != nil || resp.Data == nil || payment.RefundedAmount < payment.Amount {
+			output <- resp
+			return
+		}
+
+		// the refund is already recorded, if the move fails the payment stays CAPTURED but takes no further refund
+		var refundedPayment entity.Payment
+		moved := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &refundedPayment,
+			CollectionName: "payments",
+			Filter: bson.M{
+				"paymentId": paymentId,
+				"status":    constants.PaymentStatusCaptured,
+				"$expr": bson.M{
+					"$gte": bson.A{"$refundedAmount", "$amount"},
+				},
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.PaymentStatusRefunded,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		if moved.Error != nil || moved.Data == nil {
+			output <- resp
+			return
+		}
+
+		output <- moved
+	}()
+
+	return output
+}
+
+// InitWebhookEvent stores the event the first time it is seen and returns the previous document,
+// Data is nil for a new event
+func (c commandMongodbRepository) InitWebhookEvent(ctx context.Context, event entity.WebhookEvent) <-chan wrapper.Result {
+	var webhookEvent entity.WebhookEvent
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &webhookEvent,
+			CollectionName: "payment-webhook-events",
+			Filter: bson.M{
+				"provider": event.Provider,
+				"eventId":  event.EventId,
+			},
+			Update: bson.M{
+				"$setOnInsert": event,
+			},
+			Upsert: true,
+		}, options.Before, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpdateWebhookEventStatus(ctx context.Context, provider string, eventId string, status string) <-chan wrapper.Result {
+	var webhookEvent entity.WebhookEvent
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &webhookEvent,
+			CollectionName: "payment-webhook-events",
+			Filter: bson.M{
+				"provider": provider,
+				"eventId":  eventId,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    status,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CreateUniqueIndexes keeps one payment per provider charge and one webhook event per provider event id,
+// concurrent InitWebhookEvent upserts would store a redelivered event twice without it
+func (c commandMongodbRepository) CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		for _, index := range []mongodb.CreateIndex{
+			{
+				CollectionName: "payments",
+				Keys:           bson.D{{Key: "provider", Value: 1}, {Key: "chargeId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+			{
+				CollectionName: "payment-webhook-events",
+				Keys:           bson.D{{Key: "provider", Value: 1}, {Key: "eventId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+		} {
+			resp := <-c.mongoDb.CreateIndex(index, ctx)
+			if resp.Error != nil {
+				output <- resp
+				return
+			}
+		}
+		output <- wrapper.Result{Data: "Success create index"}
+	}()
+
+	return output
+}
@@ -0,0 +1,112 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+	"ticket-service/internal/modules/payment"
+	"ticket-service/internal/modules/payment/models/entity"
+	mongoRC "ticket-service/internal/modules/payment/repositories/commands"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	"ticket-service/internal/pkg/helpers"
+	mocks "ticket-service/mocks/pkg/databases/mongodb"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CommandTestSuite struct {
+	suite.Suite
+	mockMongodb *mocks.Collections
+	mockLogger  *mocklog.Logger
+	repository  payment.MongodbRepositoryCommand
+	ctx         context.Context
+}
+
+func (suite *CommandTestSuite) SetupTest() {
+	suite.mockMongodb = new(mocks.Collections)
+	suite.mockLogger = &mocklog.Logger{}
+	suite.repository = mongoRC.NewCommandMongodbRepository(
+		suite.mockMongodb,
+		suite.mockLogger,
+	)
+	suite.ctx = context.Background()
+}
+
+func TestCommandTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandTestSuite))
+}
+
+// onFindOneAndUpdate decodes stored into the payload result the way the driver does and keeps the update it was sent
+func (suite *CommandTestSuite) onFindOneAndUpdate(stored *entity.Payment, updates *[]interface{}) *mock.Call {
+	return suite.mockMongodb.On("FindOneAndUpdate", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(payload mongodb.FindOneAndUpdate, _ options.ReturnDocument, _ context.Context) <-chan helpers.Result {
+			*updates = append(*updates, payload.Update)
+			output := make(chan helpers.Result, 1)
+			if stored == nil {
+				output <- helpers.Result{Data: nil}
+			} else {
+				*payload.Result.(*entity.Payment) = *stored
+				output <- helpers.Result{Data: payload.Result}
+			}
+			close(output)
+			return output
+		}).Once()
+}
+
+func (suite *CommandTestSuite) TestUpdatePaymentRefundedPartial() {
+	// Arrange
+	var updates []interface{}
+	suite.onFindOneAndUpdate(&entity.Payment{PaymentId: "payment-id", Amount: 100, RefundedAmount: 40,
+		Status: constants.PaymentStatusCaptured}, &updates)
+
+	// Act
+	result := <-suite.repository.UpdatePaymentRefunded(suite.ctx, "payment-id", 40)
+
+	// Assert
+	assert.NoError(suite.T(), result.Error)
+	assert.Equal(suite.T(), constants.PaymentStatusCaptured, result.Data.(*entity.Payment).Status)
+	assert.Len(suite.T(), updates, 1)
+	update, err := mongodb.MarshalUpdate(updates[0])
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), bson.M{"refundedAmount": int32(40)}, update["$inc"])
+}
+
+func (suite *CommandTestSuite) TestUpdatePaymentRefundedWhole() {
+	// Arrange
+	var updates []interface{}
+	suite.onFindOneAndUpdate(&entity.Payment{PaymentId: "payment-id", Amount: 100, RefundedAmount: 100,
+		Status: constants.PaymentStatusCaptured}, &updates)
+	suite.onFindOneAndUpdate(&entity.Payment{PaymentId: "payment-id", Amount: 100, RefundedAmount: 100,
+		Status: constants.PaymentStatusRefunded}, &updates)
+
+	// Act
+	result := <-suite.repository.UpdatePaymentRefunded(suite.ctx, "payment-id", 60)
+
+	// Assert
+	assert.NoError(suite.T(), result.Error)
+	assert.Equal(suite.T(), constants.PaymentStatusRefunded, result.Data.(*entity.Payment).Status)
+	assert.Len(suite.T(), updates, 2)
+	for _, u := range updates {
+		_, err := mongodb.MarshalUpdate(u)
+		assert.NoError(suite.T(), err)
+	}
+}
+
+func (suite *CommandTestSuite) TestUpdatePaymentRefundedErrOverRefund() {
+	// Arrange
+	var updates []interface{}
+	suite.onFindOneAndUpdate(nil, &updates)
+
+	// Act
+	result := <-suite.repository.UpdatePaymentRefunded(suite.ctx, "payment-id", 200)
+
+	// Assert
+	assert.NoError(suite.T(), result.Error)
+	assert.Nil(suite.T(), result.Data)
+	assert.Len(suite.T(), updates, 1)
+}
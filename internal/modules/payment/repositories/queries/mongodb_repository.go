@@ -0,0 +1,67 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/payment"
+	"ticket-service/internal/modules/payment/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) payment.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindPaymentByChargeId(ctx context.Context, provider string, chargeId string) <-chan wrapper.Result {
+	var payment entity.Payment
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &payment,
+			CollectionName: "payments",
+			Filter: bson.M{
+				"provider": provider,
+				"chargeId": chargeId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindPaymentsByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result {
+	var payments []entity.Payment
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &payments,
+			CollectionName: "payments",
+			Filter: bson.M{
+				"orderId": orderId,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "createdAt",
+				By:        mongodb.SortDescending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,513 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ticket-service/internal/modules/order"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/payment"
+	"ticket-service/internal/modules/payment/models/dto"
+	"ticket-service/internal/modules/payment/models/entity"
+	"ticket-service/internal/modules/payment/models/request"
+	"ticket-service/internal/modules/payment/models/response"
+	"ticket-service/internal/modules/resale"
+	"ticket-service/internal/modules/seat"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+const paymentCurrency = constants.NativeCurrency
+
+type commandUsecase struct {
+	paymentRepositoryQuery   payment.MongodbRepositoryQuery
+	paymentRepositoryCommand payment.MongodbRepositoryCommand
+	orderRepositoryQuery     order.MongodbRepositoryQuery
+	orderRepositoryCommand   order.MongodbRepositoryCommand
+	orderUsecaseCommand      order.UsecaseCommand
+	resaleUsecaseCommand     resale.UsecaseCommand
+	seatUsecaseCommand       seat.UsecaseCommand
+	provider                 payment.Provider
+	kafkaProducer            kafkaConfluent.Producer
+	logger                   log.Logger
+}
+
+func NewCommandUsecase(pmq payment.MongodbRepositoryQuery, pmc payment.MongodbRepositoryCommand, omq order.MongodbRepositoryQuery,
+	omc order.MongodbRepositoryCommand, ouc order.UsecaseCommand, ruc resale.UsecaseCommand, suc seat.UsecaseCommand,
+	provider payment.Provider, kp kafkaConfluent.Producer, log log.Logger) payment.UsecaseCommand {
+	return commandUsecase{
+		paymentRepositoryQuery:   pmq,
+		paymentRepositoryCommand: pmc,
+		orderRepositoryQuery:     omq,
+		orderRepositoryCommand:   omc,
+		orderUsecaseCommand:      ouc,
+		resaleUsecaseCommand:     ruc,
+		seatUsecaseCommand:       suc,
+		provider:                 provider,
+		kafkaProducer:            kp,
+		logger:                   log,
+	}
+}
+
+// CreateCharge returns the open charge of the order when there is one, so a retried checkout never charges twice
+func (c commandUsecase) CreateCharge(origCtx context.Context, payload request.ChargeReq) (*response.Payment, error) {
+	domain := "paymentUsecase-CreateCharge"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	orderDetail, err := c.findOrder(ctx, payload.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if orderDetail.UserId != payload.UserId {
+		return nil, errors.NotFound("order not found")
+	}
+
+	if orderDetail.Status != constants.OrderStatusPending {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("order is %s and cannot be charged", orderDetail.Status))
+	}
+
+	if time.Now().After(orderDetail.ExpiredAt) {
+		return nil, errors.UnprocessableEntity("reservation has expired")
+	}
+
+	payments, err := c.findPayments(ctx, orderDetail.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range payments {
+		if value.Status == constants.PaymentStatusPending || value.Status == constants.PaymentStatusAuthorized {
+			return mapPayment(value), nil
+		}
+	}
+
+	charge, err := c.provider.CreateCharge(ctx, dto.ChargeReq{
+		ReferenceId: orderDetail.OrderId,
+		Amount:      orderDetail.TotalPrice,
+		Currency:    paymentCurrency,
+		Description: fmt.Sprintf("%d %s ticket %s", orderDetail.Quantity, orderDetail.TicketType, orderDetail.EventId),
+	})
+	if err != nil {
+		msg := "Error create charge"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	now := time.Now()
+	paymentData := entity.Payment{
+		PaymentId:   uuid.NewString(),
+		OrderId:     orderDetail.OrderId,
+		UserId:      orderDetail.UserId,
+		Provider:    c.provider.Name(),
+		ChargeId:    charge.ChargeId,
+		Amount:      charge.Amount,
+		Currency:    paymentCurrency,
+		CheckoutUrl: charge.CheckoutUrl,
+		Status:      constants.PaymentStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	insert := <-c.paymentRepositoryCommand.InsertOnePayment(ctx, paymentData)
+	if insert.Error != nil {
+		msg := "Error insert payment"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		if _, err := c.provider.VoidCharge(ctx, charge.ChargeId); err != nil {
+			c.logger.Error(ctx, "Error void charge", fmt.Sprintf("%+v", err))
+		}
+		return nil, insert.Error
+	}
+
+	return mapPayment(paymentData), nil
+}
+
+// HandleWebhook applies a provider event once, a redelivered event that was already processed is acknowledged without side effects.
+// An error is returned when the event could not be applied so the provider retries it
+func (c commandUsecase) HandleWebhook(origCtx context.Context, payload request.WebhookReq) (*response.Webhook, error) {
+	domain := "paymentUsecase-HandleWebhook"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if payload.Provider != c.provider.Name() {
+		return nil, errors.NotFound("payment provider not found")
+	}
+
+	event, err := c.provider.ParseWebhook(payload.Payload, payload.Signature)
+	if err != nil {
+		msg := "Error parse webhook"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	now := time.Now()
+	claim := <-c.paymentRepositoryCommand.InitWebhookEvent(ctx, entity.WebhookEvent{
+		Provider:   c.provider.Name(),
+		EventId:    event.EventId,
+		Type:       event.Type,
+		ChargeId:   event.ChargeId,
+		Status:     constants.WebhookStatusReceived,
+		ReceivedAt: now,
+		UpdatedAt:  now,
+	})
+	if claim.Error != nil {
+		msg := "Error init webhook event"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", claim.Error))
+		return nil, claim.Error
+	}
+
+	if previous, ok := claim.Data.(*entity.WebhookEvent); ok && previous.Status == constants.WebhookStatusProcessed {
+		return &response.Webhook{EventId: event.EventId, Duplicate: true}, nil
+	}
+
+	status := constants.WebhookStatusProcessed
+	applyErr := c.applyWebhookEvent(ctx, *event)
+	if applyErr != nil {
+		status = constants.WebhookStatusFailed
+	}
+	<-c.paymentRepositoryCommand.UpdateWebhookEventStatus(ctx, c.provider.Name(), event.EventId, status)
+	if applyErr != nil {
+		return nil, applyErr
+	}
+
+	return &response.Webhook{EventId: event.EventId}, nil
+}
+
+// ExpireHolds releases the inventory of reservations that were not paid in time and voids their open charges.
+// Every pod runs it on a ticker, the PENDING to EXPIRED move makes sure only one of them handles an order
+func (c commandUsecase) ExpireHolds(origCtx context.Context) (*response.ExpiredHolds, error) {
+	domain := "paymentUsecase-ExpireHolds"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.orderRepositoryQuery.FindExpiredPendingOrders(ctx, time.Now())
+	if resp.Error != nil {
+		msg := "Error query expired order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	result := response.ExpiredHolds{}
+	if resp.Data == nil {
+		return &result, nil
+	}
+
+	orders, ok := resp.Data.(*[]orderEntity.Order)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, orderDetail := range *orders {
+		expired := <-c.orderRepositoryCommand.UpdateOrderStatus(ctx, orderDetail.OrderId, constants.OrderStatusPending,
+			constants.OrderStatusExpired)
+		if expired.Error != nil || expired.Data == nil {
+			continue
+		}
+		c.orderUsecaseCommand.ReleaseHold(ctx, orderDetail)
+		result.ExpiredOrders++
+
+		payments, err := c.findPayments(ctx, orderDetail.OrderId)
+		if err != nil {
+			continue
+		}
+		for _, value := range payments {
+			if c.voidPayment(ctx, value) {
+				result.VoidedPayments++
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+func (c commandUsecase) RefundOrder(origCtx context.Context, payload request.RefundOrderReq) (*response.Payment, error) {
+	domain := "paymentUsecase-RefundOrder"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	payments, err := c.findPayments(ctx, payload.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	var captured *entity.Payment
+	for i := range payments {
+		if payments[i].Status == constants.PaymentStatusCaptured {
+			captured = &payments[i]
+			break
+		}
+	}
+	if captured == nil {
+		return nil, errors.UnprocessableEntity("order has no captured payment")
+	}
+
+	if payload.Amount > captured.Amount-captured.RefundedAmount {
+		return nil, errors.BadRequest(fmt.Sprintf("amount cannot be more than $%d", captured.Amount-captured.RefundedAmount))
+	}
+
+	paymentData, err := c.refundPayment(ctx, *captured, payload.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapPayment(*paymentData), nil
+}
+
+// CancelOrderPayments voids the open charges of an order and refunds what is left of its captured payment,
+// it is safe to call again after a partial failure
+func (c commandUsecase) CancelOrderPayments(origCtx context.Context, orderId string) (*response.CancelledPayments, error) {
+	domain := "paymentUsecase-CancelOrderPayments"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	payments, err := c.findPayments(ctx, orderId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := response.CancelledPayments{OrderId: orderId}
+	for _, value := range payments {
+		switch value.Status {
+		case constants.PaymentStatusPending, constants.PaymentStatusAuthorized:
+			if !c.voidPayment(ctx, value) {
+				return nil, errors.InternalServerError("cannot void charge")
+			}
+			result.VoidedPayments++
+		case constants.PaymentStatusCaptured:
+			if value.Amount-value.RefundedAmount <= 0 {
+				continue
+			}
+			if _, err := c.refundPayment(ctx, value, value.Amount-value.RefundedAmount); err != nil {
+				return nil, err
+			}
+			result.RefundedPayments++
+		}
+	}
+
+	return &result, nil
+}
+
+func (c commandUsecase) applyWebhookEvent(ctx context.Context, event dto.WebhookEvent) error {
+	resp := <-c.paymentRepositoryQuery.FindPaymentByChargeId(ctx, c.provider.Name(), event.ChargeId)
+	if resp.Error != nil {
+		msg := "Error query payment"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return resp.Error
+	}
+
+	if resp.Data == nil {
+		return errors.NotFound("payment not found")
+	}
+
+	paymentData, ok := resp.Data.(*entity.Payment)
+	if !ok {
+		return errors.InternalServerError("cannot parsing data")
+	}
+
+	switch event.Type {
+	case constants.PaymentEventChargeAuthorized:
+		return c.applyAuthorized(ctx, *paymentData)
+	case constants.PaymentEventChargeFailed:
+		for _, fromStatus := range []string{constants.PaymentStatusPending, constants.PaymentStatusAuthorized} {
+			failed := <-c.paymentRepositoryCommand.UpdatePaymentStatus(ctx, paymentData.PaymentId, fromStatus, constants.PaymentStatusFailed)
+			if failed.Data != nil {
+				c.publishPayment(ctx, "concert-payment-failed", *paymentData)
+				break
+			}
+		}
+		return nil
+	case constants.PaymentEventChargeRefunded:
+		c.logger.Info(ctx, fmt.Sprintf("Refund confirmed by provider, payment : %s", paymentData.PaymentId), fmt.Sprintf("%+v", event))
+		return nil
+	}
+	return errors.BadRequest(fmt.Sprintf("unsupported webhook event %s", event.Type))
+}
+
+// applyAuthorized captures the charge when the reservation is still held, otherwise the authorization is voided.
+// Every step is conditional so a retried event continues where the previous attempt stopped
+func (c commandUsecase) applyAuthorized(ctx context.Context, paymentData entity.Payment) error {
+	<-c.paymentRepositoryCommand.UpdatePaymentStatus(ctx, paymentData.PaymentId, constants.PaymentStatusPending,
+		constants.PaymentStatusAuthorized)
+
+	orderDetail, err := c.findOrder(ctx, paymentData.OrderId)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case orderDetail.Status == constants.OrderStatusPaid:
+	case orderDetail.Status == constants.OrderStatusPending && time.Now().Before(orderDetail.ExpiredAt):
+		paid := <-c.orderRepositoryCommand.UpdateOrderStatus(ctx, orderDetail.OrderId, constants.OrderStatusPending,
+			constants.OrderStatusPaid)
+		if paid.Error != nil {
+			msg := "Error update order paid"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", paid.Error))
+			return paid.Error
+		}
+		if paid.Data == nil {
+			return errors.Conflict("order changed while applying payment")
+		}
+	default:
+		paymentData.Status = constants.PaymentStatusAuthorized
+		c.voidPayment(ctx, paymentData)
+		return nil
+	}
+
+	// the ticket of a resale order moves to the buyer before the charge is captured, a failed move is retried with the event
+	if orderDetail.ResaleListingId != "" {
+		if err := c.resaleUsecaseCommand.CompleteSale(ctx, orderDetail.OrderId); err != nil {
+			msg := "Error complete resale"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+			return err
+		}
+	}
+
+	// seats are sold before the charge is captured for the same reason, their hold would otherwise lapse
+	if len(orderDetail.Seats) > 0 {
+		if err := c.seatUsecaseCommand.SellSeats(ctx, orderDetail.OrderId); err != nil {
+			msg := "Error sell seat"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+			return err
+		}
+	}
+
+	if _, err := c.provider.CaptureCharge(ctx, paymentData.ChargeId); err != nil {
+		msg := "Error capture charge"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	captured := <-c.paymentRepositoryCommand.UpdatePaymentStatus(ctx, paymentData.PaymentId, constants.PaymentStatusAuthorized,
+		constants.PaymentStatusCaptured)
+	if captured.Error != nil {
+		msg := "Error update payment captured"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", captured.Error))
+		return captured.Error
+	}
+
+	if captured.Data != nil {
+		paymentData.Status = constants.PaymentStatusCaptured
+		c.publishPayment(ctx, "concert-payment-captured", paymentData)
+	}
+	return nil
+}
+
+// voidPayment cancels an open charge, it reports whether the payment was voided
+func (c commandUsecase) voidPayment(ctx context.Context, paymentData entity.Payment) bool {
+	if paymentData.Status != constants.PaymentStatusPending && paymentData.Status != constants.PaymentStatusAuthorized {
+		return false
+	}
+
+	if _, err := c.provider.VoidCharge(ctx, paymentData.ChargeId); err != nil {
+		msg := "Error void charge"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return false
+	}
+
+	voided := <-c.paymentRepositoryCommand.UpdatePaymentStatus(ctx, paymentData.PaymentId, paymentData.Status,
+		constants.PaymentStatusVoided)
+	return voided.Error == nil && voided.Data != nil
+}
+
+func (c commandUsecase) refundPayment(ctx context.Context, captured entity.Payment, amount int) (*entity.Payment, error) {
+	if _, err := c.provider.RefundCharge(ctx, captured.ChargeId, amount); err != nil {
+		msg := "Error refund charge"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	refunded := <-c.paymentRepositoryCommand.UpdatePaymentRefunded(ctx, captured.PaymentId, amount)
+	if refunded.Error != nil {
+		msg := "Error update payment refunded"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", refunded.Error))
+		return nil, refunded.Error
+	}
+
+	if refunded.Data == nil {
+		return nil, errors.Conflict("payment was refunded concurrently")
+	}
+
+	paymentData, ok := refunded.Data.(*entity.Payment)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return paymentData, nil
+}
+
+func (c commandUsecase) publishPayment(ctx context.Context, topic string, paymentData entity.Payment) {
+	marshaledKafkaData, _ := json.Marshal(paymentData)
+	c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka %s, order : %s", topic, paymentData.OrderId), fmt.Sprintf("%+v", paymentData))
+}
+
+func (c commandUsecase) findOrder(ctx context.Context, orderId string) (*orderEntity.Order, error) {
+	resp := <-c.orderRepositoryQuery.FindOrderById(ctx, orderId)
+	if resp.Error != nil {
+		msg := "Error query order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("order not found")
+	}
+
+	orderDetail, ok := resp.Data.(*orderEntity.Order)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return orderDetail, nil
+}
+
+func (c commandUsecase) findPayments(ctx context.Context, orderId string) ([]entity.Payment, error) {
+	resp := <-c.paymentRepositoryQuery.FindPaymentsByOrderId(ctx, orderId)
+	if resp.Error != nil {
+		msg := "Error query payment"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	payments, ok := resp.Data.(*[]entity.Payment)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return *payments, nil
+}
+
+func mapPayment(paymentData entity.Payment) *response.Payment {
+	return &response.Payment{
+		PaymentId:   paymentData.PaymentId,
+		OrderId:     paymentData.OrderId,
+		Provider:    paymentData.Provider,
+		Amount:      fmt.Sprintf("$%d", paymentData.Amount),
+		Status:      paymentData.Status,
+		CheckoutUrl: paymentData.CheckoutUrl,
+		CreatedAt:   paymentData.CreatedAt,
+	}
+}
@@ -0,0 +1,362 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/payment"
+	"ticket-service/internal/modules/payment/models/dto"
+	"ticket-service/internal/modules/payment/models/entity"
+	"ticket-service/internal/modules/payment/models/request"
+	uc "ticket-service/internal/modules/payment/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockorder "ticket-service/mocks/modules/order"
+	mockpayment "ticket-service/mocks/modules/payment"
+	mockresale "ticket-service/mocks/modules/resale"
+	mockseat "ticket-service/mocks/modules/seat"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockPaymentRepositoryQuery   *mockpayment.MongodbRepositoryQuery
+	mockPaymentRepositoryCommand *mockpayment.MongodbRepositoryCommand
+	mockOrderRepositoryQuery     *mockorder.MongodbRepositoryQuery
+	mockOrderRepositoryCommand   *mockorder.MongodbRepositoryCommand
+	mockOrderUsecaseCommand      *mockorder.UsecaseCommand
+	mockResaleUsecaseCommand     *mockresale.UsecaseCommand
+	mockSeatUsecaseCommand       *mockseat.UsecaseCommand
+	mockProvider                 *mockpayment.Provider
+	mockKafkaProducer            *mockkafka.Producer
+	mockLogger                   *mocklog.Logger
+	usecase                      payment.UsecaseCommand
+	ctx                          context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockPaymentRepositoryQuery = &mockpayment.MongodbRepositoryQuery{}
+	suite.mockPaymentRepositoryCommand = &mockpayment.MongodbRepositoryCommand{}
+	suite.mockOrderRepositoryQuery = &mockorder.MongodbRepositoryQuery{}
+	suite.mockOrderRepositoryCommand = &mockorder.MongodbRepositoryCommand{}
+	suite.mockOrderUsecaseCommand = &mockorder.UsecaseCommand{}
+	suite.mockResaleUsecaseCommand = &mockresale.UsecaseCommand{}
+	suite.mockSeatUsecaseCommand = &mockseat.UsecaseCommand{}
+	suite.mockProvider = &mockpayment.Provider{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockPaymentRepositoryQuery,
+		suite.mockPaymentRepositoryCommand,
+		suite.mockOrderRepositoryQuery,
+		suite.mockOrderRepositoryCommand,
+		suite.mockOrderUsecaseCommand,
+		suite.mockResaleUsecaseCommand,
+		suite.mockSeatUsecaseCommand,
+		suite.mockProvider,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+	suite.mockProvider.On("Name").Return("simulator")
+	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockPaymentRepositoryCommand.On("UpdateWebhookEventStatus", mock.Anything, "simulator", "evt-1", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &entity.WebhookEvent{}}))
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateCharge() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPending)))
+	suite.mockPaymentRepositoryQuery.On("FindPaymentsByOrderId", mock.Anything, "order-id").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockProvider.On("CreateCharge", mock.Anything, mock.MatchedBy(func(r dto.ChargeReq) bool {
+		return r.ReferenceId == "order-id" && r.Amount == 200
+	})).Return(&dto.Charge{ChargeId: "ch-1", Status: constants.PaymentStatusPending, Amount: 200, CheckoutUrl: "https://pay"}, nil)
+	suite.mockPaymentRepositoryCommand.On("InsertOnePayment", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateCharge(suite.ctx, request.ChargeReq{UserId: "user-id", OrderId: "order-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.PaymentStatusPending, result.Status)
+	assert.Equal(suite.T(), "https://pay", result.CheckoutUrl)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateChargeReturnsOpenCharge() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPending)))
+	suite.mockPaymentRepositoryQuery.On("FindPaymentsByOrderId", mock.Anything, "order-id").
+		Return(mockChannel(helpers.Result{Data: &[]entity.Payment{*getMockPayment(constants.PaymentStatusPending)}}))
+
+	// Act
+	result, err := suite.usecase.CreateCharge(suite.ctx, request.ChargeReq{UserId: "user-id", OrderId: "order-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "payment-id", result.PaymentId)
+	suite.mockProvider.AssertNotCalled(suite.T(), "CreateCharge", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateChargeErrExpired() {
+	// Arrange
+	expired := getMockOrder(constants.OrderStatusPending)
+	expired.Data.(*orderEntity.Order).ExpiredAt = time.Now().Add(-time.Minute)
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(expired))
+
+	// Act
+	_, err := suite.usecase.CreateCharge(suite.ctx, request.ChargeReq{UserId: "user-id", OrderId: "order-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("reservation has expired"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHandleWebhookAuthorizedCaptures() {
+	// Arrange
+	suite.mockWebhook(constants.PaymentEventChargeAuthorized, nil)
+	suite.mockPaymentRepositoryQuery.On("FindPaymentByChargeId", mock.Anything, "simulator", "ch-1").
+		Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusPending)}))
+	suite.mockPaymentRepositoryCommand.On("UpdatePaymentStatus", mock.Anything, "payment-id", constants.PaymentStatusPending,
+		constants.PaymentStatusAuthorized).Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusAuthorized)}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPending)))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPending,
+		constants.OrderStatusPaid).Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockProvider.On("CaptureCharge", mock.Anything, "ch-1").Return(&dto.Charge{ChargeId: "ch-1", Status: constants.PaymentStatusCaptured}, nil)
+	suite.mockPaymentRepositoryCommand.On("UpdatePaymentStatus", mock.Anything, "payment-id", constants.PaymentStatusAuthorized,
+		constants.PaymentStatusCaptured).Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusCaptured)}))
+
+	// Act
+	result, err := suite.usecase.HandleWebhook(suite.ctx, getWebhookReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), result.Duplicate)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-payment-captured", mock.Anything, mock.Anything)
+	suite.mockPaymentRepositoryCommand.AssertCalled(suite.T(), "UpdateWebhookEventStatus", mock.Anything, "simulator", "evt-1",
+		constants.WebhookStatusProcessed)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHandleWebhookAuthorizedCompletesResale() {
+	// Arrange
+	resaleOrder := getMockOrder(constants.OrderStatusPending)
+	resaleOrder.Data.(*orderEntity.Order).ResaleListingId = "listing-id"
+	suite.mockWebhook(constants.PaymentEventChargeAuthorized, nil)
+	suite.mockPaymentRepositoryQuery.On("FindPaymentByChargeId", mock.Anything, "simulator", "ch-1").
+		Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusPending)}))
+	suite.mockPaymentRepositoryCommand.On("UpdatePaymentStatus", mock.Anything, "payment-id", constants.PaymentStatusPending,
+		constants.PaymentStatusAuthorized).Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusAuthorized)}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(resaleOrder))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPending,
+		constants.OrderStatusPaid).Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockResaleUsecaseCommand.On("CompleteSale", mock.Anything, "order-id").Return(nil)
+	suite.mockProvider.On("CaptureCharge", mock.Anything, "ch-1").Return(&dto.Charge{ChargeId: "ch-1", Status: constants.PaymentStatusCaptured}, nil)
+	suite.mockPaymentRepositoryCommand.On("UpdatePaymentStatus", mock.Anything, "payment-id", constants.PaymentStatusAuthorized,
+		constants.PaymentStatusCaptured).Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusCaptured)}))
+
+	// Act
+	_, err := suite.usecase.HandleWebhook(suite.ctx, getWebhookReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockResaleUsecaseCommand.AssertCalled(suite.T(), "CompleteSale", mock.Anything, "order-id")
+	suite.mockProvider.AssertCalled(suite.T(), "CaptureCharge", mock.Anything, "ch-1")
+}
+
+func (suite *CommandUsecaseTestSuite) TestHandleWebhookAuthorizedSellsSeats() {
+	// Arrange
+	seatedOrder := getMockOrder(constants.OrderStatusPending)
+	seatedOrder.Data.(*orderEntity.Order).Seats = []orderEntity.Seat{{SeatId: "seat-1"}, {SeatId: "seat-2"}}
+	suite.mockWebhook(constants.PaymentEventChargeAuthorized, nil)
+	suite.mockPaymentRepositoryQuery.On("FindPaymentByChargeId", mock.Anything, "simulator", "ch-1").
+		Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusPending)}))
+	suite.mockPaymentRepositoryCommand.On("UpdatePaymentStatus", mock.Anything, "payment-id", constants.PaymentStatusPending,
+		constants.PaymentStatusAuthorized).Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusAuthorized)}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(seatedOrder))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPending,
+		constants.OrderStatusPaid).Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockSeatUsecaseCommand.On("SellSeats", mock.Anything, "order-id").Return(errors.Conflict("seat seat-1 is no longer held by order order-id"))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	_, err := suite.usecase.HandleWebhook(suite.ctx, getWebhookReq())
+
+	// Assert
+	assert.Error(suite.T(), err)
+	suite.mockProvider.AssertNotCalled(suite.T(), "CaptureCharge", mock.Anything, mock.Anything)
+	suite.mockPaymentRepositoryCommand.AssertCalled(suite.T(), "UpdateWebhookEventStatus", mock.Anything, "simulator", "evt-1",
+		constants.WebhookStatusFailed)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHandleWebhookAuthorizedAfterExpiryVoids() {
+	// Arrange
+	suite.mockWebhook(constants.PaymentEventChargeAuthorized, nil)
+	suite.mockPaymentRepositoryQuery.On("FindPaymentByChargeId", mock.Anything, "simulator", "ch-1").
+		Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusPending)}))
+	suite.mockPaymentRepositoryCommand.On("UpdatePaymentStatus", mock.Anything, "payment-id", constants.PaymentStatusPending,
+		constants.PaymentStatusAuthorized).Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusAuthorized)}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusExpired)))
+	suite.mockProvider.On("VoidCharge", mock.Anything, "ch-1").Return(&dto.Charge{ChargeId: "ch-1", Status: constants.PaymentStatusVoided}, nil)
+	suite.mockPaymentRepositoryCommand.On("UpdatePaymentStatus", mock.Anything, "payment-id", constants.PaymentStatusAuthorized,
+		constants.PaymentStatusVoided).Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusVoided)}))
+
+	// Act
+	_, err := suite.usecase.HandleWebhook(suite.ctx, getWebhookReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockProvider.AssertNotCalled(suite.T(), "CaptureCharge", mock.Anything, mock.Anything)
+	suite.mockOrderRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHandleWebhookDuplicate() {
+	// Arrange
+	suite.mockWebhook(constants.PaymentEventChargeAuthorized, &entity.WebhookEvent{EventId: "evt-1", Status: constants.WebhookStatusProcessed})
+
+	// Act
+	result, err := suite.usecase.HandleWebhook(suite.ctx, getWebhookReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.Duplicate)
+	suite.mockPaymentRepositoryQuery.AssertNotCalled(suite.T(), "FindPaymentByChargeId", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHandleWebhookErrSignature() {
+	// Arrange
+	suite.mockProvider.On("ParseWebhook", mock.Anything, "sig").Return(nil, errors.ForbiddenError("invalid webhook signature"))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	_, err := suite.usecase.HandleWebhook(suite.ctx, getWebhookReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.ForbiddenError("invalid webhook signature"), err)
+	suite.mockPaymentRepositoryCommand.AssertNotCalled(suite.T(), "InitWebhookEvent", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestExpireHolds() {
+	// Arrange
+	expired := getMockOrder(constants.OrderStatusPending).Data.(*orderEntity.Order)
+	suite.mockOrderRepositoryQuery.On("FindExpiredPendingOrders", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]orderEntity.Order{*expired}}))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPending,
+		constants.OrderStatusExpired).Return(mockChannel(getMockOrder(constants.OrderStatusExpired)))
+	suite.mockOrderUsecaseCommand.On("ReleaseHold", mock.Anything, *expired)
+	suite.mockPaymentRepositoryQuery.On("FindPaymentsByOrderId", mock.Anything, "order-id").
+		Return(mockChannel(helpers.Result{Data: &[]entity.Payment{*getMockPayment(constants.PaymentStatusPending)}}))
+	suite.mockProvider.On("VoidCharge", mock.Anything, "ch-1").Return(&dto.Charge{ChargeId: "ch-1", Status: constants.PaymentStatusVoided}, nil)
+	suite.mockPaymentRepositoryCommand.On("UpdatePaymentStatus", mock.Anything, "payment-id", constants.PaymentStatusPending,
+		constants.PaymentStatusVoided).Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusVoided)}))
+
+	// Act
+	result, err := suite.usecase.ExpireHolds(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.ExpiredOrders)
+	assert.Equal(suite.T(), 1, result.VoidedPayments)
+	suite.mockOrderUsecaseCommand.AssertCalled(suite.T(), "ReleaseHold", mock.Anything, *expired)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRefundOrderErrAmount() {
+	// Arrange
+	suite.mockPaymentRepositoryQuery.On("FindPaymentsByOrderId", mock.Anything, "order-id").
+		Return(mockChannel(helpers.Result{Data: &[]entity.Payment{*getMockPayment(constants.PaymentStatusCaptured)}}))
+
+	// Act
+	_, err := suite.usecase.RefundOrder(suite.ctx, request.RefundOrderReq{OrderId: "order-id", Amount: 300})
+
+	// Assert
+	assert.Equal(suite.T(), errors.BadRequest("amount cannot be more than $200"), err)
+	suite.mockProvider.AssertNotCalled(suite.T(), "RefundCharge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelOrderPayments() {
+	// Arrange
+	captured := getMockPayment(constants.PaymentStatusCaptured)
+	captured.RefundedAmount = 50
+	suite.mockPaymentRepositoryQuery.On("FindPaymentsByOrderId", mock.Anything, "order-id").
+		Return(mockChannel(helpers.Result{Data: &[]entity.Payment{*captured, *getMockPayment(constants.PaymentStatusFailed)}}))
+	suite.mockProvider.On("RefundCharge", mock.Anything, "ch-1", 150).Return(&dto.Charge{ChargeId: "ch-1", Status: constants.PaymentStatusRefunded}, nil)
+	suite.mockPaymentRepositoryCommand.On("UpdatePaymentRefunded", mock.Anything, "payment-id", 150).
+		Return(mockChannel(helpers.Result{Data: getMockPayment(constants.PaymentStatusRefunded)}))
+
+	// Act
+	result, err := suite.usecase.CancelOrderPayments(suite.ctx, "order-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.RefundedPayments)
+	assert.Equal(suite.T(), 0, result.VoidedPayments)
+}
+
+func (suite *CommandUsecaseTestSuite) mockWebhook(eventType string, previous *entity.WebhookEvent) {
+	suite.mockProvider.On("ParseWebhook", mock.Anything, "sig").
+		Return(&dto.WebhookEvent{EventId: "evt-1", Type: eventType, ChargeId: "ch-1", Amount: 200}, nil)
+	result := helpers.Result{Data: nil}
+	if previous != nil {
+		result.Data = previous
+	}
+	suite.mockPaymentRepositoryCommand.On("InitWebhookEvent", mock.Anything, mock.Anything).Return(mockChannel(result))
+}
+
+func getWebhookReq() request.WebhookReq {
+	return request.WebhookReq{
+		Provider:  "simulator",
+		Signature: "sig",
+		Payload:   []byte(`{}`),
+	}
+}
+
+func getMockOrder(status string) helpers.Result {
+	return helpers.Result{
+		Data: &orderEntity.Order{
+			OrderId:     "order-id",
+			UserId:      "user-id",
+			TicketId:    "ticket-id",
+			EventId:     "event-id",
+			TicketType:  "Gold",
+			CountryCode: "ID",
+			Quantity:    2,
+			TicketPrice: 100,
+			TotalPrice:  200,
+			Status:      status,
+			ExpiredAt:   time.Now().Add(10 * time.Minute),
+		},
+	}
+}
+
+func getMockPayment(status string) *entity.Payment {
+	return &entity.Payment{
+		PaymentId: "payment-id",
+		OrderId:   "order-id",
+		UserId:    "user-id",
+		Provider:  "simulator",
+		ChargeId:  "ch-1",
+		Amount:    200,
+		Status:    status,
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
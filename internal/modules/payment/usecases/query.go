@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/payment"
+	"ticket-service/internal/modules/payment/models/entity"
+	"ticket-service/internal/modules/payment/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	paymentRepositoryQuery payment.MongodbRepositoryQuery
+	logger                 log.Logger
+}
+
+func NewQueryUsecase(pmq payment.MongodbRepositoryQuery, log log.Logger) payment.UsecaseQuery {
+	return queryUsecase{
+		paymentRepositoryQuery: pmq,
+		logger:                 log,
+	}
+}
+
+func (q queryUsecase) FindOrderPayments(origCtx context.Context, userId string, orderId string) ([]response.Payment, error) {
+	domain := "paymentUsecase-FindOrderPayments"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.paymentRepositoryQuery.FindPaymentsByOrderId(ctx, orderId)
+	if resp.Error != nil {
+		msg := "Error query payment"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	var collectionData = make([]response.Payment, 0)
+	if resp.Data == nil {
+		return collectionData, nil
+	}
+
+	payments, ok := resp.Data.(*[]entity.Payment)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *payments {
+		if value.UserId != userId {
+			return nil, errors.NotFound("order not found")
+		}
+		collectionData = append(collectionData, *mapPayment(value))
+	}
+	return collectionData, nil
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/presale"
+	"ticket-service/internal/modules/presale/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PresaleHttpHandler struct {
+	PresaleUsecaseCommand presale.UsecaseCommand
+	PresaleUsecaseQuery   presale.UsecaseQuery
+	Logger                log.Logger
+	Validator             *validator.Validate
+}
+
+func InitPresaleHttpHandler(app *fiber.App, puc presale.UsecaseCommand, puq presale.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &PresaleHttpHandler{
+		PresaleUsecaseCommand: puc,
+		PresaleUsecaseQuery:   puq,
+		Logger:                log,
+		Validator:             validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/presales")
+
+	route.Post("/v1/unlock", middlewares.VerifyBearer(), handler.UnlockPresale)
+	route.Get("/v1/events/:eventId/access", middlewares.VerifyBearer(), handler.GetMyPresaleAccess)
+	route.Put("/v1", middlewares.VerifyBearer(), adminRole, handler.UpsertPresale)
+	route.Get("/v1/events/:eventId", middlewares.VerifyBearer(), adminRole, handler.GetPresales)
+	route.Post("/v1/:id/codes", middlewares.VerifyBearer(), adminRole, handler.ImportAccessCodes)
+}
+
+func (p PresaleHttpHandler) UnlockPresale(c *fiber.Ctx) error {
+	req := new(request.UnlockReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := p.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, p.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	resp, err := p.PresaleUsecaseCommand.UnlockPresale(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Unlock presale success")
+}
+
+func (p PresaleHttpHandler) GetMyPresaleAccess(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, p.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	userRole, _ := c.Locals("userRole").(string)
+	req := request.PresaleAccessReq{
+		UserId:   userId,
+		UserRole: userRole,
+		EventId:  c.Params("eventId"),
+	}
+	resp, err := p.PresaleUsecaseQuery.FindMyPresaleAccess(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Get presale access success")
+}
+
+func (p PresaleHttpHandler) UpsertPresale(c *fiber.Ctx) error {
+	req := new(request.PresaleReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := p.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := p.PresaleUsecaseCommand.UpsertPresale(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Update presale success")
+}
+
+func (p PresaleHttpHandler) GetPresales(c *fiber.Ctx) error {
+	resp, err := p.PresaleUsecaseQuery.FindPresales(c.Context(), c.Params("eventId"))
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Get presales success")
+}
+
+func (p PresaleHttpHandler) ImportAccessCodes(c *fiber.Ctx) error {
+	req := new(request.ImportCodesReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := p.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest(err.Error()))
+	}
+	req.PresaleId = c.Params("id")
+	resp, err := p.PresaleUsecaseCommand.ImportAccessCodes(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Import access codes success")
+}
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"ticket-service/internal/modules/presale/models/entity"
+	"time"
+)
+
+type AccessReq struct {
+	EventId  string
+	UserId   string
+	UserRole string
+}
+
+// Access describes the presale running for an event, Sold is keyed by ticket id
+type Access struct {
+	PresaleId         string
+	Name              string
+	EndAt             time.Time
+	AllocationPercent int
+	Eligible          bool
+	Sold              map[string]int
+}
+
+// Remaining is how much of the allocation of a ticket with totalQuota is left
+func (a Access) Remaining(ticketId string, totalQuota int) int {
+	presale := entity.Presale{AllocationPercent: a.AllocationPercent}
+	return presale.Allocation(totalQuota) - a.Sold[ticketId]
+}
+
+type AllocationReq struct {
+	EventId    string
+	UserId     string
+	UserRole   string
+	TicketId   string
+	TotalQuota int
+	Quantity   int
+}
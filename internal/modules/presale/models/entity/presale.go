@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// Presale is a window before general sale, only users whose role is listed or who unlocked one of its
+// access codes may buy and they share AllocationPercent of the TotalQuota of every ticket
+type Presale struct {
+	PresaleId         string    `json:"presaleId" bson:"presaleId"`
+	EventId           string    `json:"eventId" bson:"eventId"`
+	Name              string    `json:"name" bson:"name"`
+	AllowedRoles      []string  `json:"allowedRoles" bson:"allowedRoles"`
+	AllocationPercent int       `json:"allocationPercent" bson:"allocationPercent"`
+	StartAt           time.Time `json:"startAt" bson:"startAt"`
+	EndAt             time.Time `json:"endAt" bson:"endAt"`
+	CreatedAt         time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt         time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Allocation is how many tickets of a tier with totalQuota are sold during the presale
+func (p Presale) Allocation(totalQuota int) int {
+	return totalQuota * p.AllocationPercent / 100
+}
+
+// AccessCode unlocks a presale, a SINGLE code is good for one user and a MULTI code for MaxUses users (0 is unlimited)
+type AccessCode struct {
+	Code      string    `json:"code" bson:"code"`
+	PresaleId string    `json:"presaleId" bson:"presaleId"`
+	Usage     string    `json:"usage" bson:"usage"`
+	MaxUses   int       `json:"maxUses" bson:"maxUses"`
+	TotalUsed int       `json:"totalUsed" bson:"totalUsed"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// PresaleGrant records that a user unlocked a presale with an access code
+type PresaleGrant struct {
+	PresaleId string    `json:"presaleId" bson:"presaleId"`
+	UserId    string    `json:"userId" bson:"userId"`
+	Code      string    `json:"code" bson:"code"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+// PresaleAllocation keeps how many tickets of one tier were held during a presale
+type PresaleAllocation struct {
+	PresaleId string    `json:"presaleId" bson:"presaleId"`
+	TicketId  string    `json:"ticketId" bson:"ticketId"`
+	TotalSold int       `json:"totalSold" bson:"totalSold"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
@@ -0,0 +1,32 @@
+package request
+
+import "time"
+
+type PresaleReq struct {
+	PresaleId         string    `json:"presaleId"`
+	EventId           string    `json:"eventId" validate:"required"`
+	Name              string    `json:"name" validate:"required"`
+	AllowedRoles      []string  `json:"allowedRoles" validate:"dive,required"`
+	AllocationPercent int       `json:"allocationPercent" validate:"required,min=1,max=100"`
+	StartAt           time.Time `json:"startAt" validate:"required"`
+	EndAt             time.Time `json:"endAt" validate:"required,gtfield=StartAt"`
+}
+
+type ImportCodesReq struct {
+	PresaleId string   `json:"-"`
+	Usage     string   `json:"usage" validate:"required,oneof=SINGLE MULTI"`
+	MaxUses   int      `json:"maxUses" validate:"min=0"`
+	Codes     []string `json:"codes" validate:"required,min=1,max=5000,dive,required,alphanum,max=32"`
+}
+
+type UnlockReq struct {
+	UserId  string `json:"-"`
+	EventId string `json:"eventId" validate:"required"`
+	Code    string `json:"code" validate:"required"`
+}
+
+type PresaleAccessReq struct {
+	UserId   string `json:"-"`
+	UserRole string `json:"-"`
+	EventId  string `json:"-"`
+}
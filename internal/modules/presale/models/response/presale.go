@@ -0,0 +1,26 @@
+package response
+
+import "time"
+
+type Presale struct {
+	PresaleId         string    `json:"presaleId"`
+	EventId           string    `json:"eventId"`
+	Name              string    `json:"name"`
+	AllowedRoles      []string  `json:"allowedRoles"`
+	AllocationPercent int       `json:"allocationPercent"`
+	StartAt           time.Time `json:"startAt"`
+	EndAt             time.Time `json:"endAt"`
+}
+
+type ImportedCodes struct {
+	PresaleId string `json:"presaleId"`
+	Imported  int    `json:"imported"`
+}
+
+type PresaleAccess struct {
+	PresaleId string    `json:"presaleId"`
+	EventId   string    `json:"eventId"`
+	Name      string    `json:"name"`
+	EndAt     time.Time `json:"endAt"`
+	Eligible  bool      `json:"eligible"`
+}
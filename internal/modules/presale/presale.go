@@ -0,0 +1,46 @@
+package presale
+
+import (
+	"context"
+	"ticket-service/internal/modules/presale/models/dto"
+	"ticket-service/internal/modules/presale/models/entity"
+	"ticket-service/internal/modules/presale/models/request"
+	"ticket-service/internal/modules/presale/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"time"
+)
+
+type UsecaseCommand interface {
+	UpsertPresale(origCtx context.Context, payload request.PresaleReq) (*response.Presale, error)
+	ImportAccessCodes(origCtx context.Context, payload request.ImportCodesReq) (*response.ImportedCodes, error)
+	UnlockPresale(origCtx context.Context, payload request.UnlockReq) (*response.PresaleAccess, error)
+	HoldAllocation(origCtx context.Context, payload dto.AllocationReq) (string, error)
+	ReleaseAllocation(origCtx context.Context, presaleId string, ticketId string, quantity int) error
+}
+
+type UsecaseQuery interface {
+	CheckPresaleAccess(origCtx context.Context, payload dto.AccessReq) (*dto.Access, error)
+	FindMyPresaleAccess(origCtx context.Context, payload request.PresaleAccessReq) (*response.PresaleAccess, error)
+	FindPresales(origCtx context.Context, eventId string) ([]response.Presale, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindPresaleById(ctx context.Context, presaleId string) <-chan wrapper.Result
+	FindActivePresale(ctx context.Context, eventId string, now time.Time) <-chan wrapper.Result
+	FindPresalesByEventId(ctx context.Context, eventId string) <-chan wrapper.Result
+	FindAccessCode(ctx context.Context, presaleId string, code string) <-chan wrapper.Result
+	FindGrant(ctx context.Context, presaleId string, userId string) <-chan wrapper.Result
+	FindAllocationsByPresaleId(ctx context.Context, presaleId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertPresale(ctx context.Context, presale entity.Presale) <-chan wrapper.Result
+	UpsertAccessCode(ctx context.Context, accessCode entity.AccessCode) <-chan wrapper.Result
+	IncreaseAccessCodeUsed(ctx context.Context, presaleId string, code string) <-chan wrapper.Result
+	DecreaseAccessCodeUsed(ctx context.Context, presaleId string, code string) <-chan wrapper.Result
+	InsertOneGrant(ctx context.Context, grant entity.PresaleGrant) <-chan wrapper.Result
+	InitAllocation(ctx context.Context, presaleId string, ticketId string) <-chan wrapper.Result
+	IncreaseAllocation(ctx context.Context, presaleId string, ticketId string, quantity int, allocation int) <-chan wrapper.Result
+	DecreaseAllocation(ctx context.Context, presaleId string, ticketId string, quantity int) <-chan wrapper.Result
+	CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result
+}
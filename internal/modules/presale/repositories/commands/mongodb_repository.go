@@ -0,0 +1,288 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/presale"
+	"ticket-service/internal/modules/presale/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) presale.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) UpsertPresale(ctx context.Context, presale entity.Presale) <-chan wrapper.Result {
+	var updated entity.Presale
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &updated,
+			CollectionName: "presales",
+			Filter: bson.M{
+				"presaleId": presale.PresaleId,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"eventId":           presale.EventId,
+					"name":              presale.Name,
+					"allowedRoles":      presale.AllowedRoles,
+					"allocationPercent": presale.AllocationPercent,
+					"startAt":           presale.StartAt,
+					"endAt":             presale.EndAt,
+					"updatedAt":         presale.UpdatedAt,
+				},
+				"$setOnInsert": bson.M{
+					"createdAt": presale.UpdatedAt,
+				},
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpsertAccessCode writes the usage of an access code and keeps how often it was used,
+// so importing a list again does not hand out used codes a second time
+func (c commandMongodbRepository) UpsertAccessCode(ctx context.Context, accessCode entity.AccessCode) <-chan wrapper.Result {
+	var updated entity.AccessCode
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &updated,
+			CollectionName: "presale-access-codes",
+			Filter: bson.M{
+				"presaleId": accessCode.PresaleId,
+				"code":      accessCode.Code,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"usage":     accessCode.Usage,
+					"maxUses":   accessCode.MaxUses,
+					"updatedAt": accessCode.UpdatedAt,
+				},
+				"$setOnInsert": bson.M{
+					"totalUsed": 0,
+					"createdAt": accessCode.UpdatedAt,
+				},
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// IncreaseAccessCodeUsed counts one use of a code as long as it has uses left, Data is nil when it has not
+func (c commandMongodbRepository) IncreaseAccessCodeUsed(ctx context.Context, presaleId string, code string) <-chan wrapper.Result {
+	var accessCode entity.AccessCode
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &accessCode,
+			CollectionName: "presale-access-codes",
+			Filter: bson.M{
+				"presaleId": presaleId,
+				"code":      code,
+				"$expr": bson.M{
+					"$or": bson.A{
+						bson.M{"$lte": bson.A{"$maxUses", 0}},
+						bson.M{"$lt": bson.A{"$totalUsed", "$maxUses"}},
+					},
+				},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"totalUsed": 1},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) DecreaseAccessCodeUsed(ctx context.Context, presaleId string, code string) <-chan wrapper.Result {
+	var accessCode entity.AccessCode
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &accessCode,
+			CollectionName: "presale-access-codes",
+			Filter: bson.M{
+				"presaleId": presaleId,
+				"code":      code,
+				"totalUsed": bson.M{"$gt": 0},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"totalUsed": -1},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// InsertOneGrant stores that a user unlocked a presale, a second grant of the same user fails on the unique index
+func (c commandMongodbRepository) InsertOneGrant(ctx context.Context, grant entity.PresaleGrant) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "presale-grants",
+			Document:       grant,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// InitAllocation makes sure the allocation document exists before it is conditionally incremented
+func (c commandMongodbRepository) InitAllocation(ctx context.Context, presaleId string, ticketId string) <-chan wrapper.Result {
+	var allocation entity.PresaleAllocation
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &allocation,
+			CollectionName: "presale-allocations",
+			Filter: bson.M{
+				"presaleId": presaleId,
+				"ticketId":  ticketId,
+			},
+			Update: bson.M{
+				"$setOnInsert": bson.M{
+					"totalSold": 0,
+					"createdAt": time.Now(),
+					"updatedAt": time.Now(),
+				},
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// IncreaseAllocation holds quantity tickets of the presale allocation, Data is nil when the allocation has no room
+func (c commandMongodbRepository) IncreaseAllocation(ctx context.Context, presaleId string, ticketId string, quantity int, allocation int) <-chan wrapper.Result {
+	var updated entity.PresaleAllocation
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &updated,
+			CollectionName: "presale-allocations",
+			Filter: bson.M{
+				"presaleId": presaleId,
+				"ticketId":  ticketId,
+				"totalSold": bson.M{"$lte": allocation - quantity},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"totalSold": quantity},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) DecreaseAllocation(ctx context.Context, presaleId string, ticketId string, quantity int) <-chan wrapper.Result {
+	var updated entity.PresaleAllocation
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &updated,
+			CollectionName: "presale-allocations",
+			Filter: bson.M{
+				"presaleId": presaleId,
+				"ticketId":  ticketId,
+				"totalSold": bson.M{"$gte": quantity},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"totalSold": -quantity},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CreateUniqueIndexes backs the upserts of this repository and keeps a user at one grant per presale,
+// concurrent upserts would create duplicate presales, codes and allocations without it
+func (c commandMongodbRepository) CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		for _, index := range []mongodb.CreateIndex{
+			{
+				CollectionName: "presales",
+				Keys:           bson.D{{Key: "presaleId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+			{
+				CollectionName: "presale-access-codes",
+				Keys:           bson.D{{Key: "presaleId", Value: 1}, {Key: "code", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+			{
+				CollectionName: "presale-grants",
+				Keys:           bson.D{{Key: "presaleId", Value: 1}, {Key: "userId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+			{
+				CollectionName: "presale-allocations",
+				Keys:           bson.D{{Key: "presaleId", Value: 1}, {Key: "ticketId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+		} {
+			resp := <-c.mongoDb.CreateIndex(index, ctx)
+			if resp.Error != nil {
+				output <- resp
+				return
+			}
+		}
+		output <- wrapper.Result{Data: "Success create index"}
+	}()
+
+	return output
+}
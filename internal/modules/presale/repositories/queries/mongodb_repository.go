@@ -0,0 +1,148 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/presale"
+	"ticket-service/internal/modules/presale/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) presale.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindPresaleById(ctx context.Context, presaleId string) <-chan wrapper.Result {
+	var presale entity.Presale
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &presale,
+			CollectionName: "presales",
+			Filter: bson.M{
+				"presaleId": presaleId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindActivePresale finds the presale of an event whose window contains now, windows of one event do not overlap
+func (q queryMongodbRepository) FindActivePresale(ctx context.Context, eventId string, now time.Time) <-chan wrapper.Result {
+	var presale entity.Presale
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &presale,
+			CollectionName: "presales",
+			Filter: bson.M{
+				"eventId": eventId,
+				"startAt": bson.M{"$lte": now},
+				"endAt":   bson.M{"$gt": now},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindPresalesByEventId(ctx context.Context, eventId string) <-chan wrapper.Result {
+	var presales []entity.Presale
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &presales,
+			CollectionName: "presales",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "startAt",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindAccessCode(ctx context.Context, presaleId string, code string) <-chan wrapper.Result {
+	var accessCode entity.AccessCode
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &accessCode,
+			CollectionName: "presale-access-codes",
+			Filter: bson.M{
+				"presaleId": presaleId,
+				"code":      code,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindGrant(ctx context.Context, presaleId string, userId string) <-chan wrapper.Result {
+	var grant entity.PresaleGrant
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &grant,
+			CollectionName: "presale-grants",
+			Filter: bson.M{
+				"presaleId": presaleId,
+				"userId":    userId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindAllocationsByPresaleId(ctx context.Context, presaleId string) <-chan wrapper.Result {
+	var allocations []entity.PresaleAllocation
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &allocations,
+			CollectionName: "presale-allocations",
+			Filter: bson.M{
+				"presaleId": presaleId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
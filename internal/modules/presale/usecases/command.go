@@ -0,0 +1,357 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"ticket-service/internal/modules/presale"
+	"ticket-service/internal/modules/presale/models/dto"
+	"ticket-service/internal/modules/presale/models/entity"
+	"ticket-service/internal/modules/presale/models/request"
+	"ticket-service/internal/modules/presale/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	presaleRepositoryQuery   presale.MongodbRepositoryQuery
+	presaleRepositoryCommand presale.MongodbRepositoryCommand
+	logger                   log.Logger
+}
+
+func NewCommandUsecase(pmq presale.MongodbRepositoryQuery, pmc presale.MongodbRepositoryCommand, log log.Logger) presale.UsecaseCommand {
+	return commandUsecase{
+		presaleRepositoryQuery:   pmq,
+		presaleRepositoryCommand: pmc,
+		logger:                   log,
+	}
+}
+
+func (c commandUsecase) UpsertPresale(origCtx context.Context, payload request.PresaleReq) (*response.Presale, error) {
+	domain := "presaleUsecase-UpsertPresale"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	presaleId := payload.PresaleId
+	if presaleId == "" {
+		presaleId = uuid.NewString()
+	}
+
+	resp := <-c.presaleRepositoryQuery.FindPresalesByEventId(ctx, payload.EventId)
+	if resp.Error != nil {
+		msg := "Error query presale"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data != nil {
+		presales, ok := resp.Data.(*[]entity.Presale)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		// a user must never be in two presales of one event at the same time
+		for _, value := range *presales {
+			if value.PresaleId != presaleId && payload.StartAt.Before(value.EndAt) && value.StartAt.Before(payload.EndAt) {
+				return nil, errors.Conflict(fmt.Sprintf("presale overlaps with %s", value.Name))
+			}
+		}
+	}
+
+	presaleData := entity.Presale{
+		PresaleId:         presaleId,
+		EventId:           payload.EventId,
+		Name:              payload.Name,
+		AllowedRoles:      payload.AllowedRoles,
+		AllocationPercent: payload.AllocationPercent,
+		StartAt:           payload.StartAt,
+		EndAt:             payload.EndAt,
+		UpdatedAt:         time.Now(),
+	}
+	upsert := <-c.presaleRepositoryCommand.UpsertPresale(ctx, presaleData)
+	if upsert.Error != nil {
+		msg := "Error upsert presale"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", upsert.Error))
+		return nil, upsert.Error
+	}
+
+	if updated, ok := upsert.Data.(*entity.Presale); ok {
+		presaleData = *updated
+	}
+
+	return mapPresale(presaleData), nil
+}
+
+// ImportAccessCodes adds a list of codes to a presale, a code that is imported again keeps its uses
+func (c commandUsecase) ImportAccessCodes(origCtx context.Context, payload request.ImportCodesReq) (*response.ImportedCodes, error) {
+	domain := "presaleUsecase-ImportAccessCodes"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if len(payload.Codes) > constants.MaxAccessCodesPerImport {
+		return nil, errors.BadRequest(fmt.Sprintf("maximum %d codes per import", constants.MaxAccessCodesPerImport))
+	}
+
+	resp := <-c.presaleRepositoryQuery.FindPresaleById(ctx, payload.PresaleId)
+	if resp.Error != nil {
+		msg := "Error query presale"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("presale not found")
+	}
+
+	maxUses := payload.MaxUses
+	if payload.Usage == constants.AccessCodeUsageSingle {
+		maxUses = 1
+	}
+
+	now := time.Now()
+	seen := make(map[string]bool)
+	for _, value := range payload.Codes {
+		code := normalizeCode(value)
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		upsert := <-c.presaleRepositoryCommand.UpsertAccessCode(ctx, entity.AccessCode{
+			Code:      code,
+			PresaleId: payload.PresaleId,
+			Usage:     payload.Usage,
+			MaxUses:   maxUses,
+			UpdatedAt: now,
+		})
+		if upsert.Error != nil {
+			msg := "Error upsert access code"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", upsert.Error))
+			return nil, upsert.Error
+		}
+	}
+
+	return &response.ImportedCodes{
+		PresaleId: payload.PresaleId,
+		Imported:  len(seen),
+	}, nil
+}
+
+// UnlockPresale spends one use of an access code on the user, unlocking again is a no-op
+func (c commandUsecase) UnlockPresale(origCtx context.Context, payload request.UnlockReq) (*response.PresaleAccess, error) {
+	domain := "presaleUsecase-UnlockPresale"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	presaleData, err := findActivePresale(ctx, c.presaleRepositoryQuery, c.logger, payload.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if presaleData == nil {
+		return nil, errors.NotFound("no presale is running for this event")
+	}
+
+	granted, err := hasGrant(ctx, c.presaleRepositoryQuery, c.logger, presaleData.PresaleId, payload.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	if granted {
+		return mapPresaleAccess(*presaleData, true), nil
+	}
+
+	code := normalizeCode(payload.Code)
+	resp := <-c.presaleRepositoryQuery.FindAccessCode(ctx, presaleData.PresaleId, code)
+	if resp.Error != nil {
+		msg := "Error query access code"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("access code not found")
+	}
+
+	used := <-c.presaleRepositoryCommand.IncreaseAccessCodeUsed(ctx, presaleData.PresaleId, code)
+	if used.Error != nil {
+		msg := "Error increase access code used"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", used.Error))
+		return nil, used.Error
+	}
+
+	if used.Data == nil {
+		return nil, errors.UnprocessableEntity("access code has been used")
+	}
+
+	insert := <-c.presaleRepositoryCommand.InsertOneGrant(ctx, entity.PresaleGrant{
+		PresaleId: presaleData.PresaleId,
+		UserId:    payload.UserId,
+		Code:      code,
+		CreatedAt: time.Now(),
+	})
+	if insert.Error != nil {
+		msg := "Error insert presale grant"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		<-c.presaleRepositoryCommand.DecreaseAccessCodeUsed(ctx, presaleData.PresaleId, code)
+		return nil, insert.Error
+	}
+
+	return mapPresaleAccess(*presaleData, true), nil
+}
+
+// HoldAllocation admits a reservation during a presale and holds its tickets from the presale allocation,
+// it returns the id of the presale or an empty string when the event is not in presale
+func (c commandUsecase) HoldAllocation(origCtx context.Context, payload dto.AllocationReq) (string, error) {
+	domain := "presaleUsecase-HoldAllocation"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	presaleData, err := findActivePresale(ctx, c.presaleRepositoryQuery, c.logger, payload.EventId)
+	if err != nil {
+		return "", err
+	}
+
+	if presaleData == nil {
+		return "", nil
+	}
+
+	eligible, err := isEligible(ctx, c.presaleRepositoryQuery, c.logger, *presaleData, payload.UserId, payload.UserRole)
+	if err != nil {
+		return "", err
+	}
+
+	if !eligible {
+		return "", errPresaleOnly(*presaleData)
+	}
+
+	initAllocation := <-c.presaleRepositoryCommand.InitAllocation(ctx, presaleData.PresaleId, payload.TicketId)
+	if initAllocation.Error != nil {
+		msg := "Error init presale allocation"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", initAllocation.Error))
+		return "", initAllocation.Error
+	}
+
+	allocation := <-c.presaleRepositoryCommand.IncreaseAllocation(ctx, presaleData.PresaleId, payload.TicketId, payload.Quantity,
+		presaleData.Allocation(payload.TotalQuota))
+	if allocation.Error != nil {
+		msg := "Error increase presale allocation"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", allocation.Error))
+		return "", allocation.Error
+	}
+
+	if allocation.Data == nil {
+		return "", errors.UnprocessableEntity("presale allocation is not enough")
+	}
+
+	return presaleData.PresaleId, nil
+}
+
+// ReleaseAllocation gives tickets held by HoldAllocation back to the presale allocation
+func (c commandUsecase) ReleaseAllocation(origCtx context.Context, presaleId string, ticketId string, quantity int) error {
+	domain := "presaleUsecase-ReleaseAllocation"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.presaleRepositoryCommand.DecreaseAllocation(ctx, presaleId, ticketId, quantity)
+	if resp.Error != nil {
+		msg := "Error decrease presale allocation"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return resp.Error
+	}
+	return nil
+}
+
+// findActivePresale returns the presale running for an event, nil when there is none
+func findActivePresale(ctx context.Context, repository presale.MongodbRepositoryQuery, logger log.Logger, eventId string) (*entity.Presale, error) {
+	resp := <-repository.FindActivePresale(ctx, eventId, time.Now())
+	if resp.Error != nil {
+		msg := "Error query presale"
+		logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	presaleData, ok := resp.Data.(*entity.Presale)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return presaleData, nil
+}
+
+// isEligible admits a user by the role in their token or by an access code they unlocked
+func isEligible(ctx context.Context, repository presale.MongodbRepositoryQuery, logger log.Logger, presaleData entity.Presale,
+	userId string, userRole string) (bool, error) {
+	for _, value := range presaleData.AllowedRoles {
+		if value == userRole {
+			return true, nil
+		}
+	}
+	if userId == "" {
+		return false, nil
+	}
+	return hasGrant(ctx, repository, logger, presaleData.PresaleId, userId)
+}
+
+func hasGrant(ctx context.Context, repository presale.MongodbRepositoryQuery, logger log.Logger, presaleId string, userId string) (bool, error) {
+	resp := <-repository.FindGrant(ctx, presaleId, userId)
+	if resp.Error != nil {
+		msg := "Error query presale grant"
+		logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return false, resp.Error
+	}
+	return resp.Data != nil, nil
+}
+
+func errPresaleOnly(presaleData entity.Presale) error {
+	return errors.ForbiddenError(fmt.Sprintf("tickets are only available to %s members until %s", presaleData.Name,
+		presaleData.EndAt.Format(time.RFC3339)))
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func mapPresale(presaleData entity.Presale) *response.Presale {
+	return &response.Presale{
+		PresaleId:         presaleData.PresaleId,
+		EventId:           presaleData.EventId,
+		Name:              presaleData.Name,
+		AllowedRoles:      presaleData.AllowedRoles,
+		AllocationPercent: presaleData.AllocationPercent,
+		StartAt:           presaleData.StartAt,
+		EndAt:             presaleData.EndAt,
+	}
+}
+
+func mapPresaleAccess(presaleData entity.Presale, eligible bool) *response.PresaleAccess {
+	return &response.PresaleAccess{
+		PresaleId: presaleData.PresaleId,
+		EventId:   presaleData.EventId,
+		Name:      presaleData.Name,
+		EndAt:     presaleData.EndAt,
+		Eligible:  eligible,
+	}
+}
@@ -0,0 +1,249 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticket-service/internal/modules/presale"
+	"ticket-service/internal/modules/presale/models/dto"
+	"ticket-service/internal/modules/presale/models/entity"
+	"ticket-service/internal/modules/presale/models/request"
+	uc "ticket-service/internal/modules/presale/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockpresale "ticket-service/mocks/modules/presale"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockPresaleRepositoryQuery   *mockpresale.MongodbRepositoryQuery
+	mockPresaleRepositoryCommand *mockpresale.MongodbRepositoryCommand
+	mockLogger                   *mocklog.Logger
+	usecase                      presale.UsecaseCommand
+	ctx                          context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockPresaleRepositoryQuery = &mockpresale.MongodbRepositoryQuery{}
+	suite.mockPresaleRepositoryCommand = &mockpresale.MongodbRepositoryCommand{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockPresaleRepositoryQuery,
+		suite.mockPresaleRepositoryCommand,
+		suite.mockLogger,
+	)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpsertPresaleErrOverlap() {
+	// Arrange
+	existing := []entity.Presale{getMockPresale()}
+	payload := request.PresaleReq{
+		EventId:           "event-id",
+		Name:              "Card Holder",
+		AllocationPercent: 10,
+		StartAt:           existing[0].EndAt.Add(-time.Minute),
+		EndAt:             existing[0].EndAt.Add(time.Hour),
+	}
+	suite.mockPresaleRepositoryQuery.On("FindPresalesByEventId", mock.Anything, "event-id").Return(mockChannel(helpers.Result{Data: &existing}))
+
+	// Act
+	_, err := suite.usecase.UpsertPresale(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("presale overlaps with Fan Club"), err)
+	suite.mockPresaleRepositoryCommand.AssertNotCalled(suite.T(), "UpsertPresale", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestImportAccessCodesSingleUse() {
+	// Arrange
+	presaleData := getMockPresale()
+	suite.mockPresaleRepositoryQuery.On("FindPresaleById", mock.Anything, "presale-id").Return(mockChannel(helpers.Result{Data: &presaleData}))
+	suite.mockPresaleRepositoryCommand.On("UpsertAccessCode", mock.Anything, mock.MatchedBy(func(a entity.AccessCode) bool {
+		return a.MaxUses == 1 && a.Usage == constants.AccessCodeUsageSingle
+	})).Return(func(context.Context, entity.AccessCode) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &entity.AccessCode{}})
+	})
+
+	// Act
+	result, err := suite.usecase.ImportAccessCodes(suite.ctx, request.ImportCodesReq{
+		PresaleId: "presale-id",
+		Usage:     constants.AccessCodeUsageSingle,
+		MaxUses:   10,
+		Codes:     []string{"abc123", "ABC123", "xyz789"},
+	})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.Imported)
+	suite.mockPresaleRepositoryCommand.AssertNumberOfCalls(suite.T(), "UpsertAccessCode", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUnlockPresale() {
+	// Arrange
+	presaleData := getMockPresale()
+	suite.mockPresaleRepositoryQuery.On("FindActivePresale", mock.Anything, "event-id", mock.Anything).Return(mockChannel(helpers.Result{Data: &presaleData}))
+	suite.mockPresaleRepositoryQuery.On("FindGrant", mock.Anything, "presale-id", "user-id").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockPresaleRepositoryQuery.On("FindAccessCode", mock.Anything, "presale-id", "ABC123").Return(mockChannel(helpers.Result{Data: &entity.AccessCode{}}))
+	suite.mockPresaleRepositoryCommand.On("IncreaseAccessCodeUsed", mock.Anything, "presale-id", "ABC123").Return(mockChannel(helpers.Result{Data: &entity.AccessCode{TotalUsed: 1}}))
+	suite.mockPresaleRepositoryCommand.On("InsertOneGrant", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.UnlockPresale(suite.ctx, request.UnlockReq{UserId: "user-id", EventId: "event-id", Code: " abc123 "})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.Eligible)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUnlockPresaleAlreadyGranted() {
+	// Arrange
+	presaleData := getMockPresale()
+	suite.mockPresaleRepositoryQuery.On("FindActivePresale", mock.Anything, "event-id", mock.Anything).Return(mockChannel(helpers.Result{Data: &presaleData}))
+	suite.mockPresaleRepositoryQuery.On("FindGrant", mock.Anything, "presale-id", "user-id").Return(mockChannel(helpers.Result{Data: &entity.PresaleGrant{}}))
+
+	// Act
+	result, err := suite.usecase.UnlockPresale(suite.ctx, request.UnlockReq{UserId: "user-id", EventId: "event-id", Code: "OTHER"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), result.Eligible)
+	suite.mockPresaleRepositoryCommand.AssertNotCalled(suite.T(), "IncreaseAccessCodeUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUnlockPresaleErrUsed() {
+	// Arrange
+	presaleData := getMockPresale()
+	suite.mockPresaleRepositoryQuery.On("FindActivePresale", mock.Anything, "event-id", mock.Anything).Return(mockChannel(helpers.Result{Data: &presaleData}))
+	suite.mockPresaleRepositoryQuery.On("FindGrant", mock.Anything, "presale-id", "user-id").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockPresaleRepositoryQuery.On("FindAccessCode", mock.Anything, "presale-id", "ABC123").Return(mockChannel(helpers.Result{Data: &entity.AccessCode{}}))
+	suite.mockPresaleRepositoryCommand.On("IncreaseAccessCodeUsed", mock.Anything, "presale-id", "ABC123").Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.UnlockPresale(suite.ctx, request.UnlockReq{UserId: "user-id", EventId: "event-id", Code: "ABC123"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("access code has been used"), err)
+	suite.mockPresaleRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneGrant", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUnlockPresaleErrGrant() {
+	// Arrange
+	presaleData := getMockPresale()
+	suite.mockPresaleRepositoryQuery.On("FindActivePresale", mock.Anything, "event-id", mock.Anything).Return(mockChannel(helpers.Result{Data: &presaleData}))
+	suite.mockPresaleRepositoryQuery.On("FindGrant", mock.Anything, "presale-id", "user-id").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockPresaleRepositoryQuery.On("FindAccessCode", mock.Anything, "presale-id", "ABC123").Return(mockChannel(helpers.Result{Data: &entity.AccessCode{}}))
+	suite.mockPresaleRepositoryCommand.On("IncreaseAccessCodeUsed", mock.Anything, "presale-id", "ABC123").Return(mockChannel(helpers.Result{Data: &entity.AccessCode{TotalUsed: 1}}))
+	suite.mockPresaleRepositoryCommand.On("InsertOneGrant", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error mongodb")}))
+	suite.mockPresaleRepositoryCommand.On("DecreaseAccessCodeUsed", mock.Anything, "presale-id", "ABC123").Return(mockChannel(helpers.Result{Data: &entity.AccessCode{}}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	_, err := suite.usecase.UnlockPresale(suite.ctx, request.UnlockReq{UserId: "user-id", EventId: "event-id", Code: "ABC123"})
+
+	// Assert
+	assert.Error(suite.T(), err)
+	suite.mockPresaleRepositoryCommand.AssertCalled(suite.T(), "DecreaseAccessCodeUsed", mock.Anything, "presale-id", "ABC123")
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldAllocationNoPresale() {
+	// Arrange
+	suite.mockPresaleRepositoryQuery.On("FindActivePresale", mock.Anything, "event-id", mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	presaleId, err := suite.usecase.HoldAllocation(suite.ctx, getAllocationReq(""))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), presaleId)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldAllocationByRole() {
+	// Arrange
+	presaleData := getMockPresale()
+	suite.mockPresaleRepositoryQuery.On("FindActivePresale", mock.Anything, "event-id", mock.Anything).Return(mockChannel(helpers.Result{Data: &presaleData}))
+	suite.mockPresaleRepositoryCommand.On("InitAllocation", mock.Anything, "presale-id", "ticket-id").Return(mockChannel(helpers.Result{Data: &entity.PresaleAllocation{}}))
+	suite.mockPresaleRepositoryCommand.On("IncreaseAllocation", mock.Anything, "presale-id", "ticket-id", 2, 20).Return(mockChannel(helpers.Result{Data: &entity.PresaleAllocation{TotalSold: 2}}))
+
+	// Act
+	presaleId, err := suite.usecase.HoldAllocation(suite.ctx, getAllocationReq("fanclub"))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "presale-id", presaleId)
+	suite.mockPresaleRepositoryQuery.AssertNotCalled(suite.T(), "FindGrant", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldAllocationErrNotEligible() {
+	// Arrange
+	presaleData := getMockPresale()
+	suite.mockPresaleRepositoryQuery.On("FindActivePresale", mock.Anything, "event-id", mock.Anything).Return(mockChannel(helpers.Result{Data: &presaleData}))
+	suite.mockPresaleRepositoryQuery.On("FindGrant", mock.Anything, "presale-id", "user-id").Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.HoldAllocation(suite.ctx, getAllocationReq(constants.RoleUser))
+
+	// Assert
+	assert.Equal(suite.T(), 403, err.(*errors.ErrorString).Code())
+	suite.mockPresaleRepositoryCommand.AssertNotCalled(suite.T(), "InitAllocation", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldAllocationErrSoldOut() {
+	// Arrange
+	presaleData := getMockPresale()
+	suite.mockPresaleRepositoryQuery.On("FindActivePresale", mock.Anything, "event-id", mock.Anything).Return(mockChannel(helpers.Result{Data: &presaleData}))
+	suite.mockPresaleRepositoryQuery.On("FindGrant", mock.Anything, "presale-id", "user-id").Return(mockChannel(helpers.Result{Data: &entity.PresaleGrant{}}))
+	suite.mockPresaleRepositoryCommand.On("InitAllocation", mock.Anything, "presale-id", "ticket-id").Return(mockChannel(helpers.Result{Data: &entity.PresaleAllocation{}}))
+	suite.mockPresaleRepositoryCommand.On("IncreaseAllocation", mock.Anything, "presale-id", "ticket-id", 2, 20).Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.HoldAllocation(suite.ctx, getAllocationReq(constants.RoleUser))
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("presale allocation is not enough"), err)
+}
+
+func getMockPresale() entity.Presale {
+	return entity.Presale{
+		PresaleId:         "presale-id",
+		EventId:           "event-id",
+		Name:              "Fan Club",
+		AllowedRoles:      []string{"fanclub"},
+		AllocationPercent: 20,
+		StartAt:           time.Now().Add(-time.Hour),
+		EndAt:             time.Now().Add(time.Hour),
+	}
+}
+
+func getAllocationReq(userRole string) dto.AllocationReq {
+	return dto.AllocationReq{
+		EventId:    "event-id",
+		UserId:     "user-id",
+		UserRole:   userRole,
+		TicketId:   "ticket-id",
+		TotalQuota: 100,
+		Quantity:   2,
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/presale"
+	"ticket-service/internal/modules/presale/models/dto"
+	"ticket-service/internal/modules/presale/models/entity"
+	"ticket-service/internal/modules/presale/models/request"
+	"ticket-service/internal/modules/presale/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	presaleRepositoryQuery presale.MongodbRepositoryQuery
+	logger                 log.Logger
+}
+
+func NewQueryUsecase(pmq presale.MongodbRepositoryQuery, log log.Logger) presale.UsecaseQuery {
+	return queryUsecase{
+		presaleRepositoryQuery: pmq,
+		logger:                 log,
+	}
+}
+
+// CheckPresaleAccess returns nil when the event is not in presale, a forbidden error when the user is not
+// eligible and otherwise the presale with how much of its allocation is sold per ticket
+func (q queryUsecase) CheckPresaleAccess(origCtx context.Context, payload dto.AccessReq) (*dto.Access, error) {
+	domain := "presaleUsecase-CheckPresaleAccess"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	presaleData, err := findActivePresale(ctx, q.presaleRepositoryQuery, q.logger, payload.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if presaleData == nil {
+		return nil, nil
+	}
+
+	eligible, err := isEligible(ctx, q.presaleRepositoryQuery, q.logger, *presaleData, payload.UserId, payload.UserRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if !eligible {
+		return nil, errPresaleOnly(*presaleData)
+	}
+
+	resp := <-q.presaleRepositoryQuery.FindAllocationsByPresaleId(ctx, presaleData.PresaleId)
+	if resp.Error != nil {
+		msg := "Error query presale allocation"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	sold := make(map[string]int)
+	if resp.Data != nil {
+		allocations, ok := resp.Data.(*[]entity.PresaleAllocation)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		for _, value := range *allocations {
+			sold[value.TicketId] = value.TotalSold
+		}
+	}
+
+	return &dto.Access{
+		PresaleId:         presaleData.PresaleId,
+		Name:              presaleData.Name,
+		EndAt:             presaleData.EndAt,
+		AllocationPercent: presaleData.AllocationPercent,
+		Eligible:          true,
+		Sold:              sold,
+	}, nil
+}
+
+func (q queryUsecase) FindMyPresaleAccess(origCtx context.Context, payload request.PresaleAccessReq) (*response.PresaleAccess, error) {
+	domain := "presaleUsecase-FindMyPresaleAccess"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	presaleData, err := findActivePresale(ctx, q.presaleRepositoryQuery, q.logger, payload.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if presaleData == nil {
+		return nil, errors.NotFound("no presale is running for this event")
+	}
+
+	eligible, err := isEligible(ctx, q.presaleRepositoryQuery, q.logger, *presaleData, payload.UserId, payload.UserRole)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapPresaleAccess(*presaleData, eligible), nil
+}
+
+func (q queryUsecase) FindPresales(origCtx context.Context, eventId string) ([]response.Presale, error) {
+	domain := "presaleUsecase-FindPresales"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.presaleRepositoryQuery.FindPresalesByEventId(ctx, eventId)
+	if resp.Error != nil {
+		msg := "Error query presale"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	result := make([]response.Presale, 0)
+	if resp.Data == nil {
+		return result, nil
+	}
+
+	presales, ok := resp.Data.(*[]entity.Presale)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *presales {
+		result = append(result, *mapPresale(value))
+	}
+	return result, nil
+}
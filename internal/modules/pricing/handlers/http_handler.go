@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/pricing"
+	"ticket-service/internal/modules/pricing/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PricingHttpHandler struct {
+	PricingUsecaseCommand pricing.UsecaseCommand
+	PricingUsecaseQuery   pricing.UsecaseQuery
+	Logger                log.Logger
+	Validator             *validator.Validate
+}
+
+func InitPricingHttpHandler(app *fiber.App, puc pricing.UsecaseCommand, puq pricing.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &PricingHttpHandler{
+		PricingUsecaseCommand: puc,
+		PricingUsecaseQuery:   puq,
+		Logger:                log,
+		Validator:             validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/pricing")
+
+	route.Put("/v1/rules", middlewares.VerifyBearer(), adminRole, handler.UpsertPricingRule)
+	route.Get("/v1/changes/:ticketId", middlewares.VerifyBearer(), adminRole, handler.GetPriceChanges)
+	route.Post("/v1/recalculate", middlewares.VerifyBasicAuth(), handler.RecalculatePrices)
+}
+
+func (p PricingHttpHandler) UpsertPricingRule(c *fiber.Ctx) error {
+	req := new(request.PricingRuleReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := p.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := p.PricingUsecaseCommand.UpsertPricingRule(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Update pricing rule success")
+}
+
+func (p PricingHttpHandler) GetPriceChanges(c *fiber.Ctx) error {
+	ticketId := c.Params("ticketId")
+	if ticketId == "" {
+		return helpers.RespError(c, p.Logger, errors.BadRequest("ticketId is required"))
+	}
+	resp, err := p.PricingUsecaseQuery.FindPriceChanges(c.Context(), ticketId)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Get price change success")
+}
+
+func (p PricingHttpHandler) RecalculatePrices(c *fiber.Ctx) error {
+	resp, err := p.PricingUsecaseCommand.RecalculatePrices(c.Context())
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Recalculate prices success")
+}
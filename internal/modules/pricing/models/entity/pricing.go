@@ -0,0 +1,125 @@
+package entity
+
+import (
+	"ticket-service/internal/pkg/constants"
+	"time"
+)
+
+// PricingRule prices one tier from demand. The matching adjustments are added up and applied to BasePrice,
+// the result is kept between FloorPrice and CeilingPrice
+type PricingRule struct {
+	TicketId     string       `json:"ticketId" bson:"ticketId"`
+	EventId      string       `json:"eventId" bson:"eventId"`
+	CountryCode  string       `json:"countryCode" bson:"countryCode"`
+	TicketType   string       `json:"ticketType" bson:"ticketType"`
+	Enabled      bool         `json:"enabled" bson:"enabled"`
+	BasePrice    int          `json:"basePrice" bson:"basePrice"`
+	FloorPrice   int          `json:"floorPrice" bson:"floorPrice"`
+	CeilingPrice int          `json:"ceilingPrice" bson:"ceilingPrice"`
+	SalesStartAt time.Time    `json:"salesStartAt" bson:"salesStartAt"`
+	ShowAt       time.Time    `json:"showAt" bson:"showAt"`
+	Adjustments  []Adjustment `json:"adjustments" bson:"adjustments"`
+	CurrentPrice int          `json:"currentPrice" bson:"currentPrice"`
+	CalculatedAt time.Time    `json:"calculatedAt,omitempty" bson:"calculatedAt,omitempty"`
+	CreatedAt    time.Time    `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time    `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Adjustment moves the price by Percent, which may be negative, while its factor is above or below Threshold
+type Adjustment struct {
+	Factor    string `json:"factor" bson:"factor"`
+	Operator  string `json:"operator" bson:"operator"`
+	Threshold int    `json:"threshold" bson:"threshold"`
+	Percent   int    `json:"percent" bson:"percent"`
+}
+
+func (a Adjustment) matches(signals Signals) bool {
+	value := signals.value(a.Factor)
+	if a.Operator == constants.PricingOperatorBelow {
+		return value < a.Threshold
+	}
+	return value > a.Threshold
+}
+
+// Signals is the demand of a tier at the time of a recalculation
+type Signals struct {
+	SellThrough int `json:"sellThrough" bson:"sellThrough"`
+	Pace        int `json:"pace" bson:"pace"`
+	HoursToShow int `json:"hoursToShow" bson:"hoursToShow"`
+	Waitlist    int `json:"waitlist" bson:"waitlist"`
+}
+
+func (s Signals) value(factor string) int {
+	switch factor {
+	case constants.PricingFactorSellThrough:
+		return s.SellThrough
+	case constants.PricingFactorPace:
+		return s.Pace
+	case constants.PricingFactorHoursToShow:
+		return s.HoursToShow
+	case constants.PricingFactorWaitlist:
+		return s.Waitlist
+	}
+	return 0
+}
+
+// NewSignals measures a tier with totalQuota tickets of which remaining are left and waitlist entries waiting
+func (r PricingRule) NewSignals(totalQuota int, remaining int, waitlist int, now time.Time) Signals {
+	signals := Signals{Waitlist: waitlist}
+	if totalQuota > 0 {
+		signals.SellThrough = (totalQuota - remaining) * 100 / totalQuota
+	}
+	if r.ShowAt.After(now) {
+		signals.HoursToShow = int(r.ShowAt.Sub(now) / time.Hour)
+	}
+
+	// a tier is on pace when it sold the same share of its quota as the share of the sales window that passed
+	window := r.ShowAt.Sub(r.SalesStartAt)
+	elapsed := now.Sub(r.SalesStartAt)
+	switch {
+	case window <= 0 || elapsed >= window:
+		signals.Pace = signals.SellThrough
+	case elapsed <= 0:
+		signals.Pace = 100
+	default:
+		signals.Pace = int(int64(signals.SellThrough) * int64(window) / int64(elapsed))
+	}
+	return signals
+}
+
+// Price applies the matching adjustments to BasePrice, it reports which ones matched
+func (r PricingRule) Price(signals Signals) (int, []Adjustment) {
+	applied := make([]Adjustment, 0)
+	percent := 100
+	for _, value := range r.Adjustments {
+		if value.matches(signals) {
+			applied = append(applied, value)
+			percent += value.Percent
+		}
+	}
+	if percent < 0 {
+		percent = 0
+	}
+
+	price := r.BasePrice * percent / 100
+	if price < r.FloorPrice {
+		price = r.FloorPrice
+	}
+	if price > r.CeilingPrice {
+		price = r.CeilingPrice
+	}
+	return price, applied
+}
+
+// PriceChange is the audit record of one price a rule set, a disabled rule logs the change back to the ticket price
+type PriceChange struct {
+	ChangeId    string       `json:"changeId" bson:"changeId"`
+	TicketId    string       `json:"ticketId" bson:"ticketId"`
+	EventId     string       `json:"eventId" bson:"eventId"`
+	OldPrice    int          `json:"oldPrice" bson:"oldPrice"`
+	NewPrice    int          `json:"newPrice" bson:"newPrice"`
+	Signals     Signals      `json:"signals" bson:"signals"`
+	Adjustments []Adjustment `json:"adjustments" bson:"adjustments"`
+	Reason      string       `json:"reason" bson:"reason"`
+	ChangedAt   time.Time    `json:"changedAt" bson:"changedAt"`
+}
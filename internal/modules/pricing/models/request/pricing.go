@@ -0,0 +1,23 @@
+package request
+
+import "time"
+
+type PricingRuleReq struct {
+	EventId      string          `json:"eventId" validate:"required"`
+	CountryCode  string          `json:"countryCode" validate:"required"`
+	TicketType   string          `json:"ticketType" validate:"required"`
+	Enabled      bool            `json:"enabled"`
+	BasePrice    int             `json:"basePrice" validate:"min=0"`
+	FloorPrice   int             `json:"floorPrice" validate:"required,min=1"`
+	CeilingPrice int             `json:"ceilingPrice" validate:"required,gtefield=FloorPrice"`
+	SalesStartAt time.Time       `json:"salesStartAt" validate:"required"`
+	ShowAt       time.Time       `json:"showAt" validate:"required"`
+	Adjustments  []AdjustmentReq `json:"adjustments" validate:"max=20,dive"`
+}
+
+type AdjustmentReq struct {
+	Factor    string `json:"factor" validate:"required,oneof=SELL_THROUGH PACE HOURS_TO_SHOW WAITLIST"`
+	Operator  string `json:"operator" validate:"required,oneof=ABOVE BELOW"`
+	Threshold int    `json:"threshold" validate:"min=0"`
+	Percent   int    `json:"percent" validate:"required,min=-90,max=200"`
+}
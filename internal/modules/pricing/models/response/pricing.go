@@ -0,0 +1,44 @@
+package response
+
+import "time"
+
+type PricingRule struct {
+	TicketId     string       `json:"ticketId"`
+	EventId      string       `json:"eventId"`
+	CountryCode  string       `json:"countryCode"`
+	TicketType   string       `json:"ticketType"`
+	Enabled      bool         `json:"enabled"`
+	BasePrice    string       `json:"basePrice"`
+	FloorPrice   string       `json:"floorPrice"`
+	CeilingPrice string       `json:"ceilingPrice"`
+	SalesStartAt time.Time    `json:"salesStartAt"`
+	ShowAt       time.Time    `json:"showAt"`
+	Adjustments  []Adjustment `json:"adjustments"`
+	CurrentPrice string       `json:"currentPrice,omitempty"`
+}
+
+type Adjustment struct {
+	Factor    string `json:"factor"`
+	Operator  string `json:"operator"`
+	Threshold int    `json:"threshold"`
+	Percent   int    `json:"percent"`
+}
+
+type PriceChange struct {
+	ChangeId    string       `json:"changeId"`
+	TicketId    string       `json:"ticketId"`
+	OldPrice    string       `json:"oldPrice"`
+	NewPrice    string       `json:"newPrice"`
+	SellThrough int          `json:"sellThrough"`
+	Pace        int          `json:"pace"`
+	HoursToShow int          `json:"hoursToShow"`
+	Waitlist    int          `json:"waitlist"`
+	Adjustments []Adjustment `json:"adjustments"`
+	Reason      string       `json:"reason"`
+	ChangedAt   time.Time    `json:"changedAt"`
+}
+
+type Recalculation struct {
+	Recalculated int `json:"recalculated"`
+	Changed      int `json:"changed"`
+}
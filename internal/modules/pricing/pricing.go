@@ -0,0 +1,31 @@
+package pricing
+
+import (
+	"context"
+	"ticket-service/internal/modules/pricing/models/entity"
+	"ticket-service/internal/modules/pricing/models/request"
+	"ticket-service/internal/modules/pricing/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"time"
+)
+
+type UsecaseCommand interface {
+	UpsertPricingRule(origCtx context.Context, payload request.PricingRuleReq) (*response.PricingRule, error)
+	RecalculatePrices(origCtx context.Context) (*response.Recalculation, error)
+}
+
+type UsecaseQuery interface {
+	FindPriceChanges(origCtx context.Context, ticketId string) ([]response.PriceChange, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindPricingRuleByTicketId(ctx context.Context, ticketId string) <-chan wrapper.Result
+	FindEnabledPricingRules(ctx context.Context) <-chan wrapper.Result
+	FindPriceChangesByTicketId(ctx context.Context, ticketId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertPricingRule(ctx context.Context, rule entity.PricingRule) <-chan wrapper.Result
+	UpdateCurrentPrice(ctx context.Context, ticketId string, fromPrice int, toPrice int, calculatedAt time.Time) <-chan wrapper.Result
+	InsertOnePriceChange(ctx context.Context, change entity.PriceChange) <-chan wrapper.Result
+}
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/pricing"
+	"ticket-service/internal/modules/pricing/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) pricing.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) UpsertPricingRule(ctx context.Context, rule entity.PricingRule) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "pricing-rules",
+			Filter: bson.M{
+				"ticketId": rule.TicketId,
+			},
+			Document: rule,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateCurrentPrice moves a rule from one price to the next, Data is nil when another run already moved it
+func (c commandMongodbRepository) UpdateCurrentPrice(ctx context.Context, ticketId string, fromPrice int, toPrice int, calculatedAt time.Time) <-chan wrapper.Result {
+	var rule entity.PricingRule
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &rule,
+			CollectionName: "pricing-rules",
+			Filter: bson.M{
+				"ticketId":     ticketId,
+				"currentPrice": fromPrice,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"currentPrice": toPrice,
+					"calculatedAt": calculatedAt,
+					"updatedAt":    calculatedAt,
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) InsertOnePriceChange(ctx context.Context, change entity.PriceChange) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "price-changes",
+			Document:       change,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
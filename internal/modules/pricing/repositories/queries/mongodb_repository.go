@@ -0,0 +1,85 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/pricing"
+	"ticket-service/internal/modules/pricing/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) pricing.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindPricingRuleByTicketId(ctx context.Context, ticketId string) <-chan wrapper.Result {
+	var rule entity.PricingRule
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &rule,
+			CollectionName: "pricing-rules",
+			Filter: bson.M{
+				"ticketId": ticketId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindEnabledPricingRules(ctx context.Context) <-chan wrapper.Result {
+	var rules []entity.PricingRule
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &rules,
+			CollectionName: "pricing-rules",
+			Filter: bson.M{
+				"enabled": true,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindPriceChangesByTicketId(ctx context.Context, ticketId string) <-chan wrapper.Result {
+	var changes []entity.PriceChange
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &changes,
+			CollectionName: "price-changes",
+			Filter: bson.M{
+				"ticketId": ticketId,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "changedAt",
+				By:        mongodb.SortDescending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,374 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ticket-service/internal/modules/pricing"
+	"ticket-service/internal/modules/pricing/models/entity"
+	"ticket-service/internal/modules/pricing/models/request"
+	"ticket-service/internal/modules/pricing/models/response"
+	"ticket-service/internal/modules/ticket"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	ticketRequest "ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/modules/waitlist"
+	waitlistEntity "ticket-service/internal/modules/waitlist/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	pricingRepositoryQuery   pricing.MongodbRepositoryQuery
+	pricingRepositoryCommand pricing.MongodbRepositoryCommand
+	ticketRepositoryQuery    ticket.MongodbRepositoryQuery
+	ticketRepositoryCommand  ticket.MongodbRepositoryCommand
+	waitlistRepositoryQuery  waitlist.MongodbRepositoryQuery
+	kafkaProducer            kafkaConfluent.Producer
+	logger                   log.Logger
+}
+
+func NewCommandUsecase(pmq pricing.MongodbRepositoryQuery, pmc pricing.MongodbRepositoryCommand, tmq ticket.MongodbRepositoryQuery,
+	tmc ticket.MongodbRepositoryCommand, wmq waitlist.MongodbRepositoryQuery, kp kafkaConfluent.Producer, log log.Logger) pricing.UsecaseCommand {
+	return commandUsecase{
+		pricingRepositoryQuery:   pmq,
+		pricingRepositoryCommand: pmc,
+		ticketRepositoryQuery:    tmq,
+		ticketRepositoryCommand:  tmc,
+		waitlistRepositoryQuery:  wmq,
+		kafkaProducer:            kp,
+		logger:                   log,
+	}
+}
+
+// UpsertPricingRule applies the rule right away. Disabling a rule hands the tier back to its own prices
+func (c commandUsecase) UpsertPricingRule(origCtx context.Context, payload request.PricingRuleReq) (*response.PricingRule, error) {
+	domain := "pricingUsecase-UpsertPricingRule"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if !payload.ShowAt.After(payload.SalesStartAt) {
+		return nil, errors.BadRequest("showAt must be after salesStartAt")
+	}
+
+	ticketDetail, err := c.findTicket(ctx, payload.EventId, payload.CountryCode, payload.TicketType)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := c.findPricingRule(ctx, ticketDetail.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rule := entity.PricingRule{
+		TicketId:     ticketDetail.TicketId,
+		EventId:      payload.EventId,
+		CountryCode:  payload.CountryCode,
+		TicketType:   payload.TicketType,
+		Enabled:      payload.Enabled,
+		BasePrice:    payload.BasePrice,
+		FloorPrice:   payload.FloorPrice,
+		CeilingPrice: payload.CeilingPrice,
+		SalesStartAt: payload.SalesStartAt,
+		ShowAt:       payload.ShowAt,
+		Adjustments:  make([]entity.Adjustment, 0),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if rule.BasePrice == 0 {
+		rule.BasePrice = ticketDetail.TicketPrice
+	}
+	for _, value := range payload.Adjustments {
+		rule.Adjustments = append(rule.Adjustments, entity.Adjustment{
+			Factor:    value.Factor,
+			Operator:  value.Operator,
+			Threshold: value.Threshold,
+			Percent:   value.Percent,
+		})
+	}
+	if existing != nil {
+		rule.CurrentPrice = existing.CurrentPrice
+		rule.CalculatedAt = existing.CalculatedAt
+		rule.CreatedAt = existing.CreatedAt
+	}
+
+	resp := <-c.pricingRepositoryCommand.UpsertPricingRule(ctx, rule)
+	if resp.Error != nil {
+		msg := "Error upsert pricing rule"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if rule.Enabled {
+		if _, err := c.recalculate(ctx, &rule, ticketDetail, now); err != nil {
+			return nil, err
+		}
+	} else if rule.CurrentPrice > 0 {
+		if err := c.disable(ctx, &rule, ticketDetail, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return mapPricingRule(rule), nil
+}
+
+// RecalculatePrices runs every enabled rule, it is called on a schedule and is safe to run from several pods
+func (c commandUsecase) RecalculatePrices(origCtx context.Context) (*response.Recalculation, error) {
+	domain := "pricingUsecase-RecalculatePrices"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.pricingRepositoryQuery.FindEnabledPricingRules(ctx)
+	if resp.Error != nil {
+		msg := "Error query pricing rule"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	result := &response.Recalculation{}
+	if resp.Data == nil {
+		return result, nil
+	}
+
+	rules, ok := resp.Data.(*[]entity.PricingRule)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	now := time.Now()
+	for i := range *rules {
+		rule := &(*rules)[i]
+		// one tier failing should not hold back the others, it is picked up again on the next run
+		ticketDetail, err := c.findTicket(ctx, rule.EventId, rule.CountryCode, rule.TicketType)
+		if err != nil {
+			continue
+		}
+		changed, err := c.recalculate(ctx, rule, ticketDetail, now)
+		if err != nil {
+			continue
+		}
+		result.Recalculated++
+		if changed {
+			result.Changed++
+		}
+	}
+
+	return result, nil
+}
+
+// recalculate prices the tier from its demand now. Orders keep the TicketPrice they were reserved at,
+// so a new price only reaches reservations made after it is set
+func (c commandUsecase) recalculate(ctx context.Context, rule *entity.PricingRule, ticketDetail *ticketEntity.Ticket,
+	now time.Time) (bool, error) {
+	waiting, err := c.countWaiting(ctx, ticketDetail.TicketId)
+	if err != nil {
+		return false, err
+	}
+
+	signals := rule.NewSignals(ticketDetail.TotalQuota, ticketDetail.TotalRemaining, waiting, now)
+	price, applied := rule.Price(signals)
+	if price == rule.CurrentPrice {
+		return false, nil
+	}
+
+	oldPrice := rule.CurrentPrice
+	if oldPrice == 0 {
+		oldPrice = ticketDetail.PriceAt(now, ticketDetail.Sold()).Price
+	}
+
+	// the ticket is set first, a retry after a failure below sets the same price again
+	updated := <-c.ticketRepositoryCommand.UpdateDynamicPrice(ctx, ticketDetail.TicketId, price)
+	if updated.Error != nil {
+		msg := "Error update dynamic price"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", updated.Error))
+		return false, updated.Error
+	}
+
+	claimed, err := c.claimPrice(ctx, rule, price, now)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	change := entity.PriceChange{
+		ChangeId:    uuid.NewString(),
+		TicketId:    rule.TicketId,
+		EventId:     rule.EventId,
+		OldPrice:    oldPrice,
+		NewPrice:    price,
+		Signals:     signals,
+		Adjustments: applied,
+		Reason:      constants.PriceChangeReasonRecalculated,
+		ChangedAt:   now,
+	}
+	if err := c.logPriceChange(ctx, change); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c commandUsecase) disable(ctx context.Context, rule *entity.PricingRule, ticketDetail *ticketEntity.Ticket, now time.Time) error {
+	updated := <-c.ticketRepositoryCommand.UpdateDynamicPrice(ctx, ticketDetail.TicketId, 0)
+	if updated.Error != nil {
+		msg := "Error update dynamic price"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", updated.Error))
+		return updated.Error
+	}
+
+	oldPrice := rule.CurrentPrice
+	claimed, err := c.claimPrice(ctx, rule, 0, now)
+	if err != nil || !claimed {
+		return err
+	}
+
+	ticketDetail.DynamicPrice = 0
+	return c.logPriceChange(ctx, entity.PriceChange{
+		ChangeId:    uuid.NewString(),
+		TicketId:    rule.TicketId,
+		EventId:     rule.EventId,
+		OldPrice:    oldPrice,
+		NewPrice:    ticketDetail.PriceAt(now, ticketDetail.Sold()).Price,
+		Adjustments: make([]entity.Adjustment, 0),
+		Reason:      constants.PriceChangeReasonDisabled,
+		ChangedAt:   now,
+	})
+}
+
+// claimPrice moves the rule to its new price, only the run that moved it logs the change
+func (c commandUsecase) claimPrice(ctx context.Context, rule *entity.PricingRule, price int, now time.Time) (bool, error) {
+	resp := <-c.pricingRepositoryCommand.UpdateCurrentPrice(ctx, rule.TicketId, rule.CurrentPrice, price, now)
+	if resp.Error != nil {
+		msg := "Error update pricing rule"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return false, resp.Error
+	}
+
+	if resp.Data == nil {
+		return false, nil
+	}
+	rule.CurrentPrice = price
+	rule.CalculatedAt = now
+	return true, nil
+}
+
+func (c commandUsecase) logPriceChange(ctx context.Context, change entity.PriceChange) error {
+	resp := <-c.pricingRepositoryCommand.InsertOnePriceChange(ctx, change)
+	if resp.Error != nil {
+		msg := "Error insert price change"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return resp.Error
+	}
+
+	marshaledKafkaData, _ := json.Marshal(change)
+	topic := "concert-price-changed"
+	c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka price changed, ticket : %s", change.TicketId), fmt.Sprintf("%+v", change))
+	return nil
+}
+
+func (c commandUsecase) countWaiting(ctx context.Context, ticketId string) (int, error) {
+	resp := <-c.waitlistRepositoryQuery.FindWaitlist(ctx, ticketId)
+	if resp.Error != nil {
+		msg := "Error query waitlist"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return 0, resp.Error
+	}
+
+	if resp.Data == nil {
+		return 0, nil
+	}
+
+	waitlistDetail, ok := resp.Data.(*waitlistEntity.Waitlist)
+	if !ok {
+		return 0, errors.InternalServerError("cannot parsing data")
+	}
+	return waitlistDetail.Waiting, nil
+}
+
+func (c commandUsecase) findTicket(ctx context.Context, eventId string, countryCode string, ticketType string) (*ticketEntity.Ticket, error) {
+	resp := <-c.ticketRepositoryQuery.FindTicketByType(ctx, ticketRequest.TicketTypeReq{
+		EventId:     eventId,
+		CountryCode: countryCode,
+		TicketType:  ticketType,
+	})
+	if resp.Error != nil {
+		msg := "Error query ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	ticketDetail, ok := resp.Data.(*ticketEntity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return ticketDetail, nil
+}
+
+func (c commandUsecase) findPricingRule(ctx context.Context, ticketId string) (*entity.PricingRule, error) {
+	resp := <-c.pricingRepositoryQuery.FindPricingRuleByTicketId(ctx, ticketId)
+	if resp.Error != nil {
+		msg := "Error query pricing rule"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	rule, ok := resp.Data.(*entity.PricingRule)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return rule, nil
+}
+
+func mapPricingRule(rule entity.PricingRule) *response.PricingRule {
+	result := &response.PricingRule{
+		TicketId:     rule.TicketId,
+		EventId:      rule.EventId,
+		CountryCode:  rule.CountryCode,
+		TicketType:   rule.TicketType,
+		Enabled:      rule.Enabled,
+		BasePrice:    fmt.Sprintf("$%d", rule.BasePrice),
+		FloorPrice:   fmt.Sprintf("$%d", rule.FloorPrice),
+		CeilingPrice: fmt.Sprintf("$%d", rule.CeilingPrice),
+		SalesStartAt: rule.SalesStartAt,
+		ShowAt:       rule.ShowAt,
+		Adjustments:  mapAdjustments(rule.Adjustments),
+	}
+	if rule.CurrentPrice > 0 {
+		result.CurrentPrice = fmt.Sprintf("$%d", rule.CurrentPrice)
+	}
+	return result
+}
+
+func mapAdjustments(adjustments []entity.Adjustment) []response.Adjustment {
+	result := make([]response.Adjustment, 0)
+	for _, value := range adjustments {
+		result = append(result, response.Adjustment{
+			Factor:    value.Factor,
+			Operator:  value.Operator,
+			Threshold: value.Threshold,
+			Percent:   value.Percent,
+		})
+	}
+	return result
+}
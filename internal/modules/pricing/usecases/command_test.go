@@ -0,0 +1,296 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticket-service/internal/modules/pricing"
+	"ticket-service/internal/modules/pricing/models/entity"
+	"ticket-service/internal/modules/pricing/models/request"
+	uc "ticket-service/internal/modules/pricing/usecases"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	waitlistEntity "ticket-service/internal/modules/waitlist/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockpricing "ticket-service/mocks/modules/pricing"
+	mockticket "ticket-service/mocks/modules/ticket"
+	mockwaitlist "ticket-service/mocks/modules/waitlist"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockPricingRepositoryQuery   *mockpricing.MongodbRepositoryQuery
+	mockPricingRepositoryCommand *mockpricing.MongodbRepositoryCommand
+	mockTicketRepositoryQuery    *mockticket.MongodbRepositoryQuery
+	mockTicketRepositoryCommand  *mockticket.MongodbRepositoryCommand
+	mockWaitlistRepositoryQuery  *mockwaitlist.MongodbRepositoryQuery
+	mockKafkaProducer            *mockkafka.Producer
+	mockLogger                   *mocklog.Logger
+	usecase                      pricing.UsecaseCommand
+	ctx                          context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockPricingRepositoryQuery = &mockpricing.MongodbRepositoryQuery{}
+	suite.mockPricingRepositoryCommand = &mockpricing.MongodbRepositoryCommand{}
+	suite.mockTicketRepositoryQuery = &mockticket.MongodbRepositoryQuery{}
+	suite.mockTicketRepositoryCommand = &mockticket.MongodbRepositoryCommand{}
+	suite.mockWaitlistRepositoryQuery = &mockwaitlist.MongodbRepositoryQuery{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockPricingRepositoryQuery,
+		suite.mockPricingRepositoryCommand,
+		suite.mockTicketRepositoryQuery,
+		suite.mockTicketRepositoryCommand,
+		suite.mockWaitlistRepositoryQuery,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+	suite.mockKafkaProducer.On("Publish", "concert-price-changed", mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockWaitlistRepositoryQuery.On("FindWaitlist", mock.Anything, "ticket-id").
+		Return(mockChannel(helpers.Result{Data: &waitlistEntity.Waitlist{TicketId: "ticket-id", Waiting: 100}}))
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestRecalculatePricesClampsToCeiling() {
+	// Arrange
+	suite.mockPricingRepositoryQuery.On("FindEnabledPricingRules", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.PricingRule{getMockPricingRule(0)}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(10)))
+	// sell-through 90 and waitlist 100 add 70%, the ceiling keeps it at 150
+	suite.mockTicketRepositoryCommand.On("UpdateDynamicPrice", mock.Anything, "ticket-id", 150).
+		Return(mockChannel(getMockTicket(10)))
+	suite.mockPricingRepositoryCommand.On("UpdateCurrentPrice", mock.Anything, "ticket-id", 0, 150, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &entity.PricingRule{}}))
+	suite.mockPricingRepositoryCommand.On("InsertOnePriceChange", mock.Anything, mock.MatchedBy(func(c entity.PriceChange) bool {
+		return c.OldPrice == 100 && c.NewPrice == 150 && c.Signals.SellThrough == 90 && c.Signals.Waitlist == 100 &&
+			len(c.Adjustments) == 2 && c.Reason == constants.PriceChangeReasonRecalculated
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.RecalculatePrices(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Recalculated)
+	assert.Equal(suite.T(), 1, result.Changed)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-price-changed", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRecalculatePricesClampsToFloor() {
+	// Arrange
+	rule := getMockPricingRule(0)
+	rule.Adjustments = []entity.Adjustment{
+		{Factor: constants.PricingFactorSellThrough, Operator: constants.PricingOperatorBelow, Threshold: 10, Percent: -50},
+	}
+	suite.mockPricingRepositoryQuery.On("FindEnabledPricingRules", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.PricingRule{rule}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(100)))
+	suite.mockTicketRepositoryCommand.On("UpdateDynamicPrice", mock.Anything, "ticket-id", 80).
+		Return(mockChannel(getMockTicket(100)))
+	suite.mockPricingRepositoryCommand.On("UpdateCurrentPrice", mock.Anything, "ticket-id", 0, 80, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &entity.PricingRule{}}))
+	suite.mockPricingRepositoryCommand.On("InsertOnePriceChange", mock.Anything, mock.MatchedBy(func(c entity.PriceChange) bool {
+		return c.NewPrice == 80
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.RecalculatePrices(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Changed)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRecalculatePricesUnchanged() {
+	// Arrange
+	suite.mockPricingRepositoryQuery.On("FindEnabledPricingRules", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.PricingRule{getMockPricingRule(150)}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(10)))
+
+	// Act
+	result, err := suite.usecase.RecalculatePrices(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Recalculated)
+	assert.Equal(suite.T(), 0, result.Changed)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateDynamicPrice", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockPricingRepositoryCommand.AssertNotCalled(suite.T(), "InsertOnePriceChange", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRecalculatePricesChangedByOtherRun() {
+	// Arrange
+	suite.mockPricingRepositoryQuery.On("FindEnabledPricingRules", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.PricingRule{getMockPricingRule(0)}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(10)))
+	suite.mockTicketRepositoryCommand.On("UpdateDynamicPrice", mock.Anything, "ticket-id", 150).
+		Return(mockChannel(getMockTicket(10)))
+	// another pod already moved the rule, it logs the change
+	suite.mockPricingRepositoryCommand.On("UpdateCurrentPrice", mock.Anything, "ticket-id", 0, 150, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	result, err := suite.usecase.RecalculatePrices(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.Changed)
+	suite.mockPricingRepositoryCommand.AssertNotCalled(suite.T(), "InsertOnePriceChange", mock.Anything, mock.Anything)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "Publish", "concert-price-changed", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRecalculatePricesSkipsFailedTier() {
+	// Arrange
+	suite.mockPricingRepositoryQuery.On("FindEnabledPricingRules", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.PricingRule{getMockPricingRule(0)}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(10)))
+	suite.mockTicketRepositoryCommand.On("UpdateDynamicPrice", mock.Anything, "ticket-id", 150).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	result, err := suite.usecase.RecalculatePrices(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, result.Recalculated)
+	suite.mockPricingRepositoryCommand.AssertNotCalled(suite.T(), "UpdateCurrentPrice", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpsertPricingRule() {
+	// Arrange
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(10)))
+	suite.mockPricingRepositoryQuery.On("FindPricingRuleByTicketId", mock.Anything, "ticket-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockPricingRepositoryCommand.On("UpsertPricingRule", mock.Anything, mock.MatchedBy(func(r entity.PricingRule) bool {
+		return r.TicketId == "ticket-id" && r.BasePrice == 100 && r.CurrentPrice == 0
+	})).Return(mockChannel(helpers.Result{Data: "Success upsert data"}))
+	suite.mockTicketRepositoryCommand.On("UpdateDynamicPrice", mock.Anything, "ticket-id", 150).
+		Return(mockChannel(getMockTicket(10)))
+	suite.mockPricingRepositoryCommand.On("UpdateCurrentPrice", mock.Anything, "ticket-id", 0, 150, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &entity.PricingRule{}}))
+	suite.mockPricingRepositoryCommand.On("InsertOnePriceChange", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.UpsertPricingRule(suite.ctx, getPricingRuleReq(true))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$100", result.BasePrice)
+	assert.Equal(suite.T(), "$150", result.CurrentPrice)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpsertPricingRuleDisabled() {
+	// Arrange
+	existing := getMockPricingRule(150)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(10)))
+	suite.mockPricingRepositoryQuery.On("FindPricingRuleByTicketId", mock.Anything, "ticket-id").
+		Return(mockChannel(helpers.Result{Data: &existing}))
+	suite.mockPricingRepositoryCommand.On("UpsertPricingRule", mock.Anything, mock.MatchedBy(func(r entity.PricingRule) bool {
+		return !r.Enabled && r.CurrentPrice == 150
+	})).Return(mockChannel(helpers.Result{Data: "Success upsert data"}))
+	suite.mockTicketRepositoryCommand.On("UpdateDynamicPrice", mock.Anything, "ticket-id", 0).
+		Return(mockChannel(getMockTicket(10)))
+	suite.mockPricingRepositoryCommand.On("UpdateCurrentPrice", mock.Anything, "ticket-id", 150, 0, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &entity.PricingRule{}}))
+	suite.mockPricingRepositoryCommand.On("InsertOnePriceChange", mock.Anything, mock.MatchedBy(func(c entity.PriceChange) bool {
+		return c.OldPrice == 150 && c.NewPrice == 100 && c.Reason == constants.PriceChangeReasonDisabled
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.UpsertPricingRule(suite.ctx, getPricingRuleReq(false))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "", result.CurrentPrice)
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpsertPricingRuleErrShowBeforeSales() {
+	// Arrange
+	payload := getPricingRuleReq(true)
+	payload.ShowAt = payload.SalesStartAt.Add(-time.Hour)
+
+	// Act
+	_, err := suite.usecase.UpsertPricingRule(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.BadRequest("showAt must be after salesStartAt"), err)
+	suite.mockPricingRepositoryCommand.AssertNotCalled(suite.T(), "UpsertPricingRule", mock.Anything, mock.Anything)
+}
+
+func getMockPricingRule(currentPrice int) entity.PricingRule {
+	return entity.PricingRule{
+		TicketId:     "ticket-id",
+		EventId:      "event-id",
+		CountryCode:  "ID",
+		TicketType:   "Gold",
+		Enabled:      true,
+		BasePrice:    100,
+		FloorPrice:   80,
+		CeilingPrice: 150,
+		SalesStartAt: time.Now().Add(-24 * time.Hour),
+		ShowAt:       time.Now().Add(24 * time.Hour),
+		Adjustments: []entity.Adjustment{
+			{Factor: constants.PricingFactorSellThrough, Operator: constants.PricingOperatorAbove, Threshold: 80, Percent: 30},
+			{Factor: constants.PricingFactorWaitlist, Operator: constants.PricingOperatorAbove, Threshold: 50, Percent: 40},
+		},
+		CurrentPrice: currentPrice,
+	}
+}
+
+func getPricingRuleReq(enabled bool) request.PricingRuleReq {
+	return request.PricingRuleReq{
+		EventId:      "event-id",
+		CountryCode:  "ID",
+		TicketType:   "Gold",
+		Enabled:      enabled,
+		FloorPrice:   80,
+		CeilingPrice: 150,
+		SalesStartAt: time.Now().Add(-24 * time.Hour),
+		ShowAt:       time.Now().Add(24 * time.Hour),
+		Adjustments: []request.AdjustmentReq{
+			{Factor: constants.PricingFactorSellThrough, Operator: constants.PricingOperatorAbove, Threshold: 80, Percent: 30},
+			{Factor: constants.PricingFactorWaitlist, Operator: constants.PricingOperatorAbove, Threshold: 50, Percent: 40},
+		},
+	}
+}
+
+func getMockTicket(remaining int) helpers.Result {
+	return helpers.Result{
+		Data: &ticketEntity.Ticket{
+			TicketId:       "ticket-id",
+			EventId:        "event-id",
+			TicketType:     "Gold",
+			TicketPrice:    100,
+			TotalQuota:     100,
+			TotalRemaining: remaining,
+		},
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/pricing"
+	"ticket-service/internal/modules/pricing/models/entity"
+	"ticket-service/internal/modules/pricing/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	pricingRepositoryQuery pricing.MongodbRepositoryQuery
+	logger                 log.Logger
+}
+
+func NewQueryUsecase(pmq pricing.MongodbRepositoryQuery, log log.Logger) pricing.UsecaseQuery {
+	return queryUsecase{
+		pricingRepositoryQuery: pmq,
+		logger:                 log,
+	}
+}
+
+func (q queryUsecase) FindPriceChanges(origCtx context.Context, ticketId string) ([]response.PriceChange, error) {
+	domain := "pricingUsecase-FindPriceChanges"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.pricingRepositoryQuery.FindPriceChangesByTicketId(ctx, ticketId)
+	if resp.Error != nil {
+		msg := "Error query price change"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	var collectionData = make([]response.PriceChange, 0)
+	if resp.Data == nil {
+		return collectionData, nil
+	}
+
+	changes, ok := resp.Data.(*[]entity.PriceChange)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *changes {
+		collectionData = append(collectionData, response.PriceChange{
+			ChangeId:    value.ChangeId,
+			TicketId:    value.TicketId,
+			OldPrice:    fmt.Sprintf("$%d", value.OldPrice),
+			NewPrice:    fmt.Sprintf("$%d", value.NewPrice),
+			SellThrough: value.Signals.SellThrough,
+			Pace:        value.Signals.Pace,
+			HoursToShow: value.Signals.HoursToShow,
+			Waitlist:    value.Signals.Waitlist,
+			Adjustments: mapAdjustments(value.Adjustments),
+			Reason:      value.Reason,
+			ChangedAt:   value.ChangedAt,
+		})
+	}
+	return collectionData, nil
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/purchase"
+	"ticket-service/internal/modules/purchase/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type PurchaseHttpHandler struct {
+	PurchaseUsecaseCommand purchase.UsecaseCommand
+	PurchaseUsecaseQuery   purchase.UsecaseQuery
+	Logger                 log.Logger
+	Validator              *validator.Validate
+}
+
+func InitPurchaseHttpHandler(app *fiber.App, puc purchase.UsecaseCommand, puq purchase.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &PurchaseHttpHandler{
+		PurchaseUsecaseCommand: puc,
+		PurchaseUsecaseQuery:   puq,
+		Logger:                 log,
+		Validator:              validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/purchases")
+
+	route.Post("/v1", middlewares.VerifyBearer(), handler.StartPurchase)
+	route.Get("/v1/sagas/stuck", middlewares.VerifyBearer(), adminRole, handler.GetStuckSagas)
+	route.Post("/v1/sagas/resume", middlewares.VerifyBasicAuth(), handler.ResumeSagas)
+	route.Post("/v1/sagas/:id/retry", middlewares.VerifyBearer(), adminRole, handler.RetrySaga)
+	route.Get("/v1/:id", middlewares.VerifyBearer(), handler.GetMyPurchase)
+}
+
+func (p PurchaseHttpHandler) StartPurchase(c *fiber.Ctx) error {
+	req := new(request.PurchaseReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := p.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, p.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	req.UserRole, _ = c.Locals("userRole").(string)
+	resp, err := p.PurchaseUsecaseCommand.StartPurchase(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Start purchase success")
+}
+
+func (p PurchaseHttpHandler) GetMyPurchase(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, p.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	resp, err := p.PurchaseUsecaseQuery.FindMyPurchase(c.Context(), userId, c.Params("id"))
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Get purchase success")
+}
+
+func (p PurchaseHttpHandler) GetStuckSagas(c *fiber.Ctx) error {
+	req := new(request.StuckSagaReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := p.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, p.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := p.PurchaseUsecaseQuery.FindStuckSagas(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Get stuck saga success")
+}
+
+func (p PurchaseHttpHandler) ResumeSagas(c *fiber.Ctx) error {
+	resp, err := p.PurchaseUsecaseCommand.ResumeSagas(c.Context())
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Resume saga success")
+}
+
+func (p PurchaseHttpHandler) RetrySaga(c *fiber.Ctx) error {
+	resp, err := p.PurchaseUsecaseCommand.RetrySaga(c.Context(), c.Params("id"))
+	if err != nil {
+		return helpers.RespCustomError(c, p.Logger, err)
+	}
+	return helpers.RespSuccess(c, p.Logger, resp, "Retry saga success")
+}
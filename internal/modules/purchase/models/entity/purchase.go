@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+// Saga is the persisted state of one purchase, OrderId is chosen up front so a step interrupted by a restart
+// can tell whether it already took effect. LockedUntil is both the lease of the owning worker and the time
+// the saga is due again
+type Saga struct {
+	SagaId       string     `json:"sagaId" bson:"sagaId"`
+	UserId       string     `json:"userId" bson:"userId"`
+	UserRole     string     `json:"userRole" bson:"userRole"`
+	EventId      string     `json:"eventId" bson:"eventId"`
+	TicketType   string     `json:"ticketType" bson:"ticketType"`
+	CountryCode  string     `json:"countryCode" bson:"countryCode"`
+	Quantity     int        `json:"quantity" bson:"quantity"`
+	VoucherCodes []string   `json:"voucherCodes,omitempty" bson:"voucherCodes,omitempty"`
+	OrderId      string     `json:"orderId" bson:"orderId"`
+	PaymentId    string     `json:"paymentId" bson:"paymentId"`
+	CheckoutUrl  string     `json:"checkoutUrl" bson:"checkoutUrl"`
+	Status       string     `json:"status" bson:"status"`
+	CurrentStep  string     `json:"currentStep" bson:"currentStep"`
+	StepDeadline time.Time  `json:"stepDeadline" bson:"stepDeadline"`
+	Attempts     int        `json:"attempts" bson:"attempts"`
+	LastError    string     `json:"lastError" bson:"lastError"`
+	Steps        []SagaStep `json:"steps" bson:"steps"`
+	LockedBy     string     `json:"lockedBy" bson:"lockedBy"`
+	LockedUntil  time.Time  `json:"lockedUntil" bson:"lockedUntil"`
+	CreatedAt    time.Time  `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt" bson:"updatedAt"`
+}
+
+type SagaStep struct {
+	Name       string    `json:"name" bson:"name"`
+	Status     string    `json:"status" bson:"status"`
+	Error      string    `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt" bson:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty" bson:"finishedAt,omitempty"`
+}
@@ -0,0 +1,15 @@
+package request
+
+type PurchaseReq struct {
+	UserId       string   `json:"-"`
+	UserRole     string   `json:"-"`
+	EventId      string   `json:"eventId" validate:"required"`
+	CountryCode  string   `json:"countryCode" validate:"required"`
+	TicketType   string   `json:"ticketType" validate:"required"`
+	Quantity     int      `json:"quantity" validate:"required,min=1"`
+	VoucherCodes []string `json:"voucherCodes" validate:"omitempty,max=3,dive,required"`
+}
+
+type StuckSagaReq struct {
+	OlderThanMinutes int `query:"olderThanMinutes" validate:"min=0"`
+}
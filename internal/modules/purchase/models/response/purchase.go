@@ -0,0 +1,36 @@
+package response
+
+import "time"
+
+type Saga struct {
+	SagaId      string     `json:"sagaId"`
+	UserId      string     `json:"userId"`
+	EventId     string     `json:"eventId"`
+	TicketType  string     `json:"ticketType"`
+	Quantity    int        `json:"quantity"`
+	OrderId     string     `json:"orderId"`
+	PaymentId   string     `json:"paymentId"`
+	CheckoutUrl string     `json:"checkoutUrl"`
+	Status      string     `json:"status"`
+	CurrentStep string     `json:"currentStep"`
+	Attempts    int        `json:"attempts"`
+	LastError   string     `json:"lastError"`
+	Steps       []SagaStep `json:"steps"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+type SagaStep struct {
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+}
+
+type ResumedSagas struct {
+	Resumed     int `json:"resumed"`
+	Completed   int `json:"completed"`
+	Compensated int `json:"compensated"`
+	Failed      int `json:"failed"`
+}
@@ -0,0 +1,34 @@
+package purchase
+
+import (
+	"context"
+	"ticket-service/internal/modules/purchase/models/entity"
+	"ticket-service/internal/modules/purchase/models/request"
+	"ticket-service/internal/modules/purchase/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"time"
+)
+
+type UsecaseCommand interface {
+	StartPurchase(origCtx context.Context, payload request.PurchaseReq) (*response.Saga, error)
+	ResumeSagas(origCtx context.Context) (*response.ResumedSagas, error)
+	RetrySaga(origCtx context.Context, sagaId string) (*response.Saga, error)
+}
+
+type UsecaseQuery interface {
+	FindMyPurchase(origCtx context.Context, userId string, sagaId string) (*response.Saga, error)
+	FindStuckSagas(origCtx context.Context, payload request.StuckSagaReq) ([]response.Saga, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindSagaById(ctx context.Context, sagaId string) <-chan wrapper.Result
+	FindResumableSagas(ctx context.Context, now time.Time) <-chan wrapper.Result
+	FindStuckSagas(ctx context.Context, updatedBefore time.Time) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	InsertOneSaga(ctx context.Context, saga entity.Saga) <-chan wrapper.Result
+	ClaimSaga(ctx context.Context, sagaId string, owner string, now time.Time) <-chan wrapper.Result
+	SaveSaga(ctx context.Context, saga entity.Saga, owner string) <-chan wrapper.Result
+	ReopenFailedSaga(ctx context.Context, sagaId string, owner string) <-chan wrapper.Result
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/purchase"
+	"ticket-service/internal/modules/purchase/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) purchase.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) InsertOneSaga(ctx context.Context, saga entity.Saga) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "purchase-sagas",
+			Document:       saga,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// ClaimSaga takes the lease of an unfinished saga that is due, Data is nil when another worker holds it
+func (c commandMongodbRepository) ClaimSaga(ctx context.Context, sagaId string, owner string, now time.Time) <-chan wrapper.Result {
+	var saga entity.Saga
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &saga,
+			CollectionName: "purchase-sagas",
+			Filter: bson.M{
+				"sagaId":      sagaId,
+				"status":      bson.M{"$in": bson.A{constants.SagaStatusRunning, constants.SagaStatusCompensating}},
+				"lockedUntil": bson.M{"$lt": now},
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"lockedBy":    owner,
+					"lockedUntil": now.Add(constants.SagaLeaseDuration),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// SaveSaga checkpoints the progress of a saga as long as owner still holds its lease, Data is nil when the lease was lost
+func (c commandMongodbRepository) SaveSaga(ctx context.Context, saga entity.Saga, owner string) <-chan wrapper.Result {
+	var updated entity.Saga
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &updated,
+			CollectionName: "purchase-sagas",
+			Filter: bson.M{
+				"sagaId":   saga.SagaId,
+				"lockedBy": owner,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"paymentId":    saga.PaymentId,
+					"checkoutUrl":  saga.CheckoutUrl,
+					"status":       saga.Status,
+					"currentStep":  saga.CurrentStep,
+					"stepDeadline": saga.StepDeadline,
+					"attempts":     saga.Attempts,
+					"lastError":    saga.LastError,
+					"steps":        saga.Steps,
+					"lockedUntil":  saga.LockedUntil,
+					"updatedAt":    time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// ReopenFailedSaga hands a saga whose compensation gave up back to owner for another round of compensation
+func (c commandMongodbRepository) ReopenFailedSaga(ctx context.Context, sagaId string, owner string) <-chan wrapper.Result {
+	var saga entity.Saga
+	output := make(chan wrapper.Result)
+
+	go func() {
+		now := time.Now()
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &saga,
+			CollectionName: "purchase-sagas",
+			Filter: bson.M{
+				"sagaId": sagaId,
+				"status": constants.SagaStatusFailed,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":      constants.SagaStatusCompensating,
+					"attempts":    0,
+					"lockedBy":    owner,
+					"lockedUntil": now.Add(constants.SagaLeaseDuration),
+					"updatedAt":   now,
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
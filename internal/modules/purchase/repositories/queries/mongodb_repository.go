@@ -0,0 +1,100 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/purchase"
+	"ticket-service/internal/modules/purchase/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) purchase.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindSagaById(ctx context.Context, sagaId string) <-chan wrapper.Result {
+	var saga entity.Saga
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &saga,
+			CollectionName: "purchase-sagas",
+			Filter: bson.M{
+				"sagaId": sagaId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindResumableSagas returns the unfinished sagas that are due and not leased by a live worker
+func (q queryMongodbRepository) FindResumableSagas(ctx context.Context, now time.Time) <-chan wrapper.Result {
+	var sagas []entity.Saga
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &sagas,
+			CollectionName: "purchase-sagas",
+			Filter: bson.M{
+				"status":      bson.M{"$in": bson.A{constants.SagaStatusRunning, constants.SagaStatusCompensating}},
+				"lockedUntil": bson.M{"$lt": now},
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "lockedUntil",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindStuckSagas returns the sagas that gave up compensating and the unfinished ones that did not move since updatedBefore
+func (q queryMongodbRepository) FindStuckSagas(ctx context.Context, updatedBefore time.Time) <-chan wrapper.Result {
+	var sagas []entity.Saga
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &sagas,
+			CollectionName: "purchase-sagas",
+			Filter: bson.M{
+				"$or": bson.A{
+					bson.M{"status": constants.SagaStatusFailed},
+					bson.M{
+						"status":    bson.M{"$in": bson.A{constants.SagaStatusRunning, constants.SagaStatusCompensating}},
+						"updatedAt": bson.M{"$lt": updatedBefore},
+					},
+				},
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "updatedAt",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
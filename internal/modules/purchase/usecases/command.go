@@ -0,0 +1,550 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	eticketRequest "ticket-service/internal/modules/eticket/models/request"
+	"ticket-service/internal/modules/order"
+	orderDto "ticket-service/internal/modules/order/models/dto"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/payment"
+	paymentRequest "ticket-service/internal/modules/payment/models/request"
+	"ticket-service/internal/modules/purchase"
+	"ticket-service/internal/modules/purchase/models/entity"
+	"ticket-service/internal/modules/purchase/models/request"
+	"ticket-service/internal/modules/purchase/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	purchaseRepositoryQuery   purchase.MongodbRepositoryQuery
+	purchaseRepositoryCommand purchase.MongodbRepositoryCommand
+	orderUsecaseCommand       order.UsecaseCommand
+	orderRepositoryQuery      order.MongodbRepositoryQuery
+	paymentUsecaseCommand     payment.UsecaseCommand
+	eticketUsecaseCommand     eticket.UsecaseCommand
+	eticketRepositoryQuery    eticket.MongodbRepositoryQuery
+	eticketRepositoryCommand  eticket.MongodbRepositoryCommand
+	kafkaProducer             kafkaConfluent.Producer
+	logger                    log.Logger
+	workerId                  string
+}
+
+func NewCommandUsecase(pmq purchase.MongodbRepositoryQuery, pmc purchase.MongodbRepositoryCommand, ouc order.UsecaseCommand,
+	omq order.MongodbRepositoryQuery, puc payment.UsecaseCommand, euc eticket.UsecaseCommand, emq eticket.MongodbRepositoryQuery,
+	emc eticket.MongodbRepositoryCommand, kp kafkaConfluent.Producer, log log.Logger) purchase.UsecaseCommand {
+	return commandUsecase{
+		purchaseRepositoryQuery:   pmq,
+		purchaseRepositoryCommand: pmc,
+		orderUsecaseCommand:       ouc,
+		orderRepositoryQuery:      omq,
+		paymentUsecaseCommand:     puc,
+		eticketUsecaseCommand:     euc,
+		eticketRepositoryQuery:    emq,
+		eticketRepositoryCommand:  emc,
+		kafkaProducer:             kp,
+		logger:                    log,
+		workerId:                  uuid.NewString(),
+	}
+}
+
+// StartPurchase runs the saga until it has to wait for the buyer to pay, the response carries the checkout url of the charge
+func (c commandUsecase) StartPurchase(origCtx context.Context, payload request.PurchaseReq) (*response.Saga, error) {
+	domain := "purchaseUsecase-StartPurchase"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	now := time.Now()
+	saga := entity.Saga{
+		SagaId:       uuid.NewString(),
+		UserId:       payload.UserId,
+		UserRole:     payload.UserRole,
+		EventId:      payload.EventId,
+		TicketType:   payload.TicketType,
+		CountryCode:  payload.CountryCode,
+		Quantity:     payload.Quantity,
+		VoucherCodes: payload.VoucherCodes,
+		OrderId:      uuid.NewString(),
+		Status:       constants.SagaStatusRunning,
+		LockedBy:     c.workerId,
+		LockedUntil:  now.Add(constants.SagaLeaseDuration),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	startStep(&saga, constants.SagaStepReserve, now)
+
+	insert := <-c.purchaseRepositoryCommand.InsertOneSaga(ctx, saga)
+	if insert.Error != nil {
+		msg := "Error insert saga"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		return nil, insert.Error
+	}
+
+	if err := c.advance(ctx, &saga); err != nil {
+		return nil, err
+	}
+
+	if saga.Status == constants.SagaStatusCompensated || saga.Status == constants.SagaStatusFailed {
+		return nil, errors.UnprocessableEntity(saga.LastError)
+	}
+
+	return mapSaga(saga), nil
+}
+
+// ResumeSagas continues every saga that is due and not leased by a live worker, which covers sagas left behind by a restarted pod
+func (c commandUsecase) ResumeSagas(origCtx context.Context) (*response.ResumedSagas, error) {
+	domain := "purchaseUsecase-ResumeSagas"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.purchaseRepositoryQuery.FindResumableSagas(ctx, time.Now())
+	if resp.Error != nil {
+		msg := "Error query resumable saga"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	result := response.ResumedSagas{}
+	if resp.Data == nil {
+		return &result, nil
+	}
+
+	sagas, ok := resp.Data.(*[]entity.Saga)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *sagas {
+		claimed := <-c.purchaseRepositoryCommand.ClaimSaga(ctx, value.SagaId, c.workerId, time.Now())
+		if claimed.Error != nil || claimed.Data == nil {
+			continue
+		}
+
+		saga, ok := claimed.Data.(*entity.Saga)
+		if !ok {
+			continue
+		}
+
+		result.Resumed++
+		if err := c.advance(ctx, saga); err != nil {
+			continue
+		}
+
+		switch saga.Status {
+		case constants.SagaStatusCompleted:
+			result.Completed++
+		case constants.SagaStatusCompensated:
+			result.Compensated++
+		case constants.SagaStatusFailed:
+			result.Failed++
+		}
+	}
+
+	return &result, nil
+}
+
+// RetrySaga runs the compensation of a saga that gave up again, once an operator fixed what made it fail
+func (c commandUsecase) RetrySaga(origCtx context.Context, sagaId string) (*response.Saga, error) {
+	domain := "purchaseUsecase-RetrySaga"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.purchaseRepositoryCommand.ReopenFailedSaga(ctx, sagaId, c.workerId)
+	if resp.Error != nil {
+		msg := "Error reopen saga"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("failed saga not found")
+	}
+
+	saga, ok := resp.Data.(*entity.Saga)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if err := c.advance(ctx, saga); err != nil {
+		return nil, err
+	}
+
+	return mapSaga(*saga), nil
+}
+
+// advance moves the saga forward, or backwards through its compensations, until it finishes or has to wait.
+// Every transition is checkpointed so another worker can pick up from the last one
+func (c commandUsecase) advance(ctx context.Context, saga *entity.Saga) error {
+	for {
+		var wait bool
+		switch saga.Status {
+		case constants.SagaStatusRunning:
+			wait = c.forward(ctx, saga)
+		case constants.SagaStatusCompensating:
+			wait = c.compensate(ctx, saga)
+		default:
+			return nil
+		}
+
+		if wait {
+			saga.LockedUntil = time.Now().Add(constants.SagaRetryDelay)
+		} else {
+			saga.LockedUntil = time.Now().Add(constants.SagaLeaseDuration)
+		}
+
+		saved := <-c.purchaseRepositoryCommand.SaveSaga(ctx, *saga, c.workerId)
+		if saved.Error != nil {
+			msg := "Error save saga"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", saved.Error))
+			return saved.Error
+		}
+
+		if saved.Data == nil {
+			return errors.Conflict("saga is owned by another worker")
+		}
+
+		if wait {
+			return nil
+		}
+	}
+}
+
+// forward runs the current step once, it reports whether the saga has to wait before the step is tried again
+func (c commandUsecase) forward(ctx context.Context, saga *entity.Saga) bool {
+	now := time.Now()
+	if now.After(saga.StepDeadline) {
+		startCompensation(saga, fmt.Sprintf("step %s timed out", saga.CurrentStep), now)
+		return false
+	}
+
+	done, err := c.runStep(ctx, saga)
+	if err != nil {
+		saga.Attempts++
+		if !isRetryable(err) || saga.Attempts >= constants.SagaMaxAttempts {
+			startCompensation(saga, err.Error(), now)
+			return false
+		}
+		saga.LastError = err.Error()
+		return true
+	}
+
+	if !done {
+		return true
+	}
+
+	finishStep(saga, now)
+	return false
+}
+
+// compensate undoes the latest step that still has an effect, it reports whether the saga has to wait before retrying
+func (c commandUsecase) compensate(ctx context.Context, saga *entity.Saga) bool {
+	now := time.Now()
+	step := pendingCompensation(saga)
+	if step == nil {
+		saga.Status = constants.SagaStatusCompensated
+		c.publishSaga(ctx, "concert-purchase-cancelled", *saga)
+		return false
+	}
+
+	if err := c.compensateStep(ctx, *saga, step.Name); err != nil {
+		saga.Attempts++
+		saga.LastError = err.Error()
+		if saga.Attempts >= constants.SagaMaxAttempts {
+			saga.Status = constants.SagaStatusFailed
+			msg := "Saga compensation failed"
+			c.logger.Error(ctx, msg, fmt.Sprintf("saga %s step %s: %+v", saga.SagaId, step.Name, err))
+			return false
+		}
+		return true
+	}
+
+	step.Status = constants.SagaStepStatusCompensated
+	step.FinishedAt = now
+	saga.Attempts = 0
+	return false
+}
+
+// runStep reports false without an error while the step is waiting on something outside the saga
+func (c commandUsecase) runStep(ctx context.Context, saga *entity.Saga) (bool, error) {
+	switch saga.CurrentStep {
+	case constants.SagaStepReserve:
+		return true, c.reserve(ctx, *saga)
+	case constants.SagaStepCharge:
+		return true, c.charge(ctx, saga)
+	case constants.SagaStepAwaitPayment:
+		return c.awaitPayment(ctx, *saga)
+	case constants.SagaStepIssue:
+		_, err := c.eticketUsecaseCommand.IssueTickets(ctx, eticketRequest.IssueTicketReq{OrderId: saga.OrderId})
+		return true, err
+	case constants.SagaStepNotify:
+		c.publishSaga(ctx, "concert-purchase-completed", *saga)
+		return true, nil
+	}
+	return false, errors.InternalServerError(fmt.Sprintf("unknown saga step %s", saga.CurrentStep))
+}
+
+func (c commandUsecase) reserve(ctx context.Context, saga entity.Saga) error {
+	// the order id is chosen by the saga, finding the order means the reservation went through before a restart
+	existing, err := c.findOrder(ctx, saga.OrderId)
+	if err != nil || existing != nil {
+		return err
+	}
+
+	_, err = c.orderUsecaseCommand.CreateReservation(ctx, orderDto.ReservationReq{
+		UserId:       saga.UserId,
+		UserRole:     saga.UserRole,
+		OrderId:      saga.OrderId,
+		EventId:      saga.EventId,
+		CountryCode:  saga.CountryCode,
+		TicketType:   saga.TicketType,
+		Quantity:     saga.Quantity,
+		VoucherCodes: saga.VoucherCodes,
+	})
+	return err
+}
+
+func (c commandUsecase) charge(ctx context.Context, saga *entity.Saga) error {
+	// the charge may have been paid already when the saga is resumed, charging again would fail on the order status
+	orderDetail, err := c.findOrder(ctx, saga.OrderId)
+	if err != nil {
+		return err
+	}
+	if orderDetail != nil && orderDetail.Status == constants.OrderStatusPaid {
+		return nil
+	}
+
+	paymentData, err := c.paymentUsecaseCommand.CreateCharge(ctx, paymentRequest.ChargeReq{
+		UserId:  saga.UserId,
+		OrderId: saga.OrderId,
+	})
+	if err != nil {
+		return err
+	}
+
+	saga.PaymentId = paymentData.PaymentId
+	saga.CheckoutUrl = paymentData.CheckoutUrl
+	return nil
+}
+
+func (c commandUsecase) awaitPayment(ctx context.Context, saga entity.Saga) (bool, error) {
+	orderDetail, err := c.findOrder(ctx, saga.OrderId)
+	if err != nil {
+		return false, err
+	}
+
+	if orderDetail == nil {
+		return false, errors.NotFound("order not found")
+	}
+
+	switch orderDetail.Status {
+	case constants.OrderStatusPaid:
+		return true, nil
+	case constants.OrderStatusPending:
+		return false, nil
+	}
+	return false, errors.UnprocessableEntity(fmt.Sprintf("order is %s", orderDetail.Status))
+}
+
+// compensateStep undoes one step, every compensation is safe to repeat and succeeds when there is nothing left to undo
+func (c commandUsecase) compensateStep(ctx context.Context, saga entity.Saga, step string) error {
+	switch step {
+	case constants.SagaStepIssue:
+		return c.revokeTickets(ctx, saga.OrderId)
+	case constants.SagaStepCharge:
+		_, err := c.paymentUsecaseCommand.CancelOrderPayments(ctx, saga.OrderId)
+		return err
+	case constants.SagaStepReserve:
+		_, err := c.orderUsecaseCommand.ReleaseOrder(ctx, saga.OrderId)
+		if err != nil && errorCode(err) == http.StatusNotFound {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (c commandUsecase) revokeTickets(ctx context.Context, orderId string) error {
+	resp := <-c.eticketRepositoryQuery.FindIssuedTicketsByOrderId(ctx, orderId)
+	if resp.Error != nil {
+		msg := "Error query issued ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil
+	}
+
+	issuedTickets, ok := resp.Data.(*[]eticketEntity.IssuedTicket)
+	if !ok {
+		return errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *issuedTickets {
+		if value.Status != constants.IssuedTicketStatusActive {
+			continue
+		}
+		revoked := <-c.eticketRepositoryCommand.UpdateIssuedTicketRevoked(ctx, value.IssuedTicketId)
+		if revoked.Error != nil {
+			msg := "Error revoke issued ticket"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", revoked.Error))
+			return revoked.Error
+		}
+	}
+	return nil
+}
+
+// findOrder returns nil without an error when the order does not exist
+func (c commandUsecase) findOrder(ctx context.Context, orderId string) (*orderEntity.Order, error) {
+	resp := <-c.orderRepositoryQuery.FindOrderById(ctx, orderId)
+	if resp.Error != nil {
+		msg := "Error query order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	orderDetail, ok := resp.Data.(*orderEntity.Order)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return orderDetail, nil
+}
+
+func (c commandUsecase) publishSaga(ctx context.Context, topic string, saga entity.Saga) {
+	purchaseEvent := map[string]interface{}{
+		"sagaId":     saga.SagaId,
+		"orderId":    saga.OrderId,
+		"userId":     saga.UserId,
+		"eventId":    saga.EventId,
+		"ticketType": saga.TicketType,
+		"quantity":   saga.Quantity,
+		"status":     saga.Status,
+		"lastError":  saga.LastError,
+	}
+	marshaledKafkaData, _ := json.Marshal(purchaseEvent)
+	c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka %s, saga : %s", topic, saga.SagaId), fmt.Sprintf("%+v", purchaseEvent))
+}
+
+func startStep(saga *entity.Saga, step string, now time.Time) {
+	saga.CurrentStep = step
+	saga.StepDeadline = now.Add(constants.SagaStepTimeouts[step])
+	saga.Attempts = 0
+	saga.Steps = append(saga.Steps, entity.SagaStep{
+		Name:      step,
+		Status:    constants.SagaStepStatusRunning,
+		StartedAt: now,
+	})
+}
+
+func finishStep(saga *entity.Saga, now time.Time) {
+	current := &saga.Steps[len(saga.Steps)-1]
+	current.Status = constants.SagaStepStatusDone
+	current.FinishedAt = now
+	saga.LastError = ""
+
+	for i, step := range constants.SagaSteps {
+		if step == saga.CurrentStep && i+1 < len(constants.SagaSteps) {
+			startStep(saga, constants.SagaSteps[i+1], now)
+			return
+		}
+	}
+
+	saga.Status = constants.SagaStatusCompleted
+	saga.StepDeadline = time.Time{}
+}
+
+func startCompensation(saga *entity.Saga, reason string, now time.Time) {
+	current := &saga.Steps[len(saga.Steps)-1]
+	current.Status = constants.SagaStepStatusFailed
+	current.Error = reason
+	current.FinishedAt = now
+	saga.Status = constants.SagaStatusCompensating
+	saga.LastError = reason
+	saga.Attempts = 0
+	saga.StepDeadline = time.Time{}
+}
+
+// pendingCompensation returns the latest step that may have left an effect behind, a failed step is included
+// because it can fail half way, e.g. after issuing some of the tickets
+func pendingCompensation(saga *entity.Saga) *entity.SagaStep {
+	for i := len(saga.Steps) - 1; i >= 0; i-- {
+		step := &saga.Steps[i]
+		if step.Status != constants.SagaStepStatusDone && step.Status != constants.SagaStepStatusFailed {
+			continue
+		}
+		switch step.Name {
+		case constants.SagaStepIssue, constants.SagaStepCharge, constants.SagaStepReserve:
+			return step
+		}
+	}
+	return nil
+}
+
+// isRetryable treats conflicts and anything that is not a client error as transient
+func isRetryable(err error) bool {
+	code := errorCode(err)
+	return code >= http.StatusInternalServerError || code == http.StatusConflict
+}
+
+func errorCode(err error) int {
+	if errString, ok := err.(*errors.ErrorString); ok {
+		return errString.Code()
+	}
+	return http.StatusInternalServerError
+}
+
+func mapSaga(saga entity.Saga) *response.Saga {
+	steps := make([]response.SagaStep, 0)
+	for _, value := range saga.Steps {
+		steps = append(steps, response.SagaStep{
+			Name:       value.Name,
+			Status:     value.Status,
+			Error:      value.Error,
+			StartedAt:  value.StartedAt,
+			FinishedAt: value.FinishedAt,
+		})
+	}
+	return &response.Saga{
+		SagaId:      saga.SagaId,
+		UserId:      saga.UserId,
+		EventId:     saga.EventId,
+		TicketType:  saga.TicketType,
+		Quantity:    saga.Quantity,
+		OrderId:     saga.OrderId,
+		PaymentId:   saga.PaymentId,
+		CheckoutUrl: saga.CheckoutUrl,
+		Status:      saga.Status,
+		CurrentStep: saga.CurrentStep,
+		Attempts:    saga.Attempts,
+		LastError:   saga.LastError,
+		Steps:       steps,
+		CreatedAt:   saga.CreatedAt,
+		UpdatedAt:   saga.UpdatedAt,
+	}
+}
@@ -0,0 +1,268 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eticketResponse "ticket-service/internal/modules/eticket/models/response"
+	orderDto "ticket-service/internal/modules/order/models/dto"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	orderResponse "ticket-service/internal/modules/order/models/response"
+	paymentResponse "ticket-service/internal/modules/payment/models/response"
+	"ticket-service/internal/modules/purchase"
+	"ticket-service/internal/modules/purchase/models/entity"
+	"ticket-service/internal/modules/purchase/models/request"
+	uc "ticket-service/internal/modules/purchase/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mocketicket "ticket-service/mocks/modules/eticket"
+	mockorder "ticket-service/mocks/modules/order"
+	mockpayment "ticket-service/mocks/modules/payment"
+	mockpurchase "ticket-service/mocks/modules/purchase"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockPurchaseRepositoryQuery   *mockpurchase.MongodbRepositoryQuery
+	mockPurchaseRepositoryCommand *mockpurchase.MongodbRepositoryCommand
+	mockOrderUsecaseCommand       *mockorder.UsecaseCommand
+	mockOrderRepositoryQuery      *mockorder.MongodbRepositoryQuery
+	mockPaymentUsecaseCommand     *mockpayment.UsecaseCommand
+	mockEticketUsecaseCommand     *mocketicket.UsecaseCommand
+	mockEticketRepositoryQuery    *mocketicket.MongodbRepositoryQuery
+	mockEticketRepositoryCommand  *mocketicket.MongodbRepositoryCommand
+	mockKafkaProducer             *mockkafka.Producer
+	mockLogger                    *mocklog.Logger
+	usecase                       purchase.UsecaseCommand
+	ctx                           context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockPurchaseRepositoryQuery = &mockpurchase.MongodbRepositoryQuery{}
+	suite.mockPurchaseRepositoryCommand = &mockpurchase.MongodbRepositoryCommand{}
+	suite.mockOrderUsecaseCommand = &mockorder.UsecaseCommand{}
+	suite.mockOrderRepositoryQuery = &mockorder.MongodbRepositoryQuery{}
+	suite.mockPaymentUsecaseCommand = &mockpayment.UsecaseCommand{}
+	suite.mockEticketUsecaseCommand = &mocketicket.UsecaseCommand{}
+	suite.mockEticketRepositoryQuery = &mocketicket.MongodbRepositoryQuery{}
+	suite.mockEticketRepositoryCommand = &mocketicket.MongodbRepositoryCommand{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockPurchaseRepositoryQuery,
+		suite.mockPurchaseRepositoryCommand,
+		suite.mockOrderUsecaseCommand,
+		suite.mockOrderRepositoryQuery,
+		suite.mockPaymentUsecaseCommand,
+		suite.mockEticketUsecaseCommand,
+		suite.mockEticketRepositoryQuery,
+		suite.mockEticketRepositoryCommand,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockPurchaseRepositoryCommand.On("SaveSaga", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(context.Context, entity.Saga, string) <-chan helpers.Result {
+			return mockChannel(helpers.Result{Data: &entity.Saga{}})
+		})
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestStartPurchaseWaitsForPayment() {
+	// Arrange
+	suite.mockPurchaseRepositoryCommand.On("InsertOneSaga", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: nil})).Once()
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, mock.Anything).
+		Return(func(context.Context, string) <-chan helpers.Result {
+			return mockChannel(getMockOrder(constants.OrderStatusPending))
+		})
+	suite.mockOrderUsecaseCommand.On("CreateReservation", mock.Anything, mock.MatchedBy(func(r orderDto.ReservationReq) bool {
+		return r.OrderId != "" && r.UserId == "user-id" && r.Quantity == 2
+	})).Return(&orderResponse.Reservation{Status: constants.OrderStatusPending}, nil)
+	suite.mockPaymentUsecaseCommand.On("CreateCharge", mock.Anything, mock.Anything).
+		Return(&paymentResponse.Payment{PaymentId: "payment-id", CheckoutUrl: "https://pay"}, nil)
+
+	// Act
+	result, err := suite.usecase.StartPurchase(suite.ctx, getPurchaseReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.SagaStatusRunning, result.Status)
+	assert.Equal(suite.T(), constants.SagaStepAwaitPayment, result.CurrentStep)
+	assert.Equal(suite.T(), "https://pay", result.CheckoutUrl)
+	assert.Equal(suite.T(), constants.SagaStepStatusDone, result.Steps[0].Status)
+	assert.Equal(suite.T(), constants.SagaStepStatusDone, result.Steps[1].Status)
+	suite.mockEticketUsecaseCommand.AssertNotCalled(suite.T(), "IssueTickets", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestStartPurchaseErrSoldOut() {
+	// Arrange
+	suite.mockPurchaseRepositoryCommand.On("InsertOneSaga", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockOrderUsecaseCommand.On("CreateReservation", mock.Anything, mock.Anything).
+		Return(nil, errors.UnprocessableEntity("ticket quota is not enough"))
+	suite.mockOrderUsecaseCommand.On("ReleaseOrder", mock.Anything, mock.Anything).Return(nil, errors.NotFound("order not found"))
+
+	// Act
+	_, err := suite.usecase.StartPurchase(suite.ctx, getPurchaseReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("ticket quota is not enough"), err)
+	suite.mockPaymentUsecaseCommand.AssertNotCalled(suite.T(), "CreateCharge", mock.Anything, mock.Anything)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-purchase-cancelled", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestResumeSagasCompletes() {
+	// Arrange
+	saga := getMockSaga(constants.SagaStatusRunning, constants.SagaStepAwaitPayment)
+	suite.mockResumable(saga)
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockEticketUsecaseCommand.On("IssueTickets", mock.Anything, mock.Anything).Return([]eticketResponse.IssuedTicket{}, nil)
+
+	// Act
+	result, err := suite.usecase.ResumeSagas(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Resumed)
+	assert.Equal(suite.T(), 1, result.Completed)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-purchase-completed", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestResumeSagasCompensatesTimeout() {
+	// Arrange
+	saga := getMockSaga(constants.SagaStatusRunning, constants.SagaStepAwaitPayment)
+	saga.StepDeadline = time.Now().Add(-time.Minute)
+	suite.mockResumable(saga)
+	suite.mockPaymentUsecaseCommand.On("CancelOrderPayments", mock.Anything, "order-id").
+		Return(&paymentResponse.CancelledPayments{OrderId: "order-id", VoidedPayments: 1}, nil)
+	suite.mockOrderUsecaseCommand.On("ReleaseOrder", mock.Anything, "order-id").
+		Return(&orderResponse.Reservation{Status: constants.OrderStatusCancelled}, nil)
+
+	// Act
+	result, err := suite.usecase.ResumeSagas(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Compensated)
+	suite.mockOrderRepositoryQuery.AssertNotCalled(suite.T(), "FindOrderById", mock.Anything, mock.Anything)
+	suite.mockPaymentUsecaseCommand.AssertCalled(suite.T(), "CancelOrderPayments", mock.Anything, "order-id")
+	suite.mockOrderUsecaseCommand.AssertCalled(suite.T(), "ReleaseOrder", mock.Anything, "order-id")
+}
+
+func (suite *CommandUsecaseTestSuite) TestResumeSagasGivesUpCompensation() {
+	// Arrange
+	saga := getMockSaga(constants.SagaStatusCompensating, constants.SagaStepIssue)
+	saga.Steps[len(saga.Steps)-1].Status = constants.SagaStepStatusFailed
+	saga.Attempts = constants.SagaMaxAttempts - 1
+	suite.mockResumable(saga)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketsByOrderId", mock.Anything, "order-id").
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error Mongodb Connection")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	result, err := suite.usecase.ResumeSagas(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Failed)
+	suite.mockPaymentUsecaseCommand.AssertNotCalled(suite.T(), "CancelOrderPayments", mock.Anything, mock.Anything)
+	suite.mockPurchaseRepositoryCommand.AssertCalled(suite.T(), "SaveSaga", mock.Anything, mock.MatchedBy(func(s entity.Saga) bool {
+		return s.Status == constants.SagaStatusFailed
+	}), mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRetrySagaErrNotFailed() {
+	// Arrange
+	suite.mockPurchaseRepositoryCommand.On("ReopenFailedSaga", mock.Anything, "saga-id", mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.RetrySaga(suite.ctx, "saga-id")
+
+	// Assert
+	assert.Equal(suite.T(), errors.NotFound("failed saga not found"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) mockResumable(saga *entity.Saga) {
+	suite.mockPurchaseRepositoryQuery.On("FindResumableSagas", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.Saga{*saga}}))
+	suite.mockPurchaseRepositoryCommand.On("ClaimSaga", mock.Anything, "saga-id", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: saga}))
+}
+
+func getPurchaseReq() request.PurchaseReq {
+	return request.PurchaseReq{
+		UserId:      "user-id",
+		EventId:     "event-id",
+		CountryCode: "ID",
+		TicketType:  "Gold",
+		Quantity:    2,
+	}
+}
+
+// getMockSaga returns a saga whose earlier steps are done and whose current step is running
+func getMockSaga(status string, currentStep string) *entity.Saga {
+	now := time.Now()
+	steps := make([]entity.SagaStep, 0)
+	for _, step := range constants.SagaSteps {
+		if step == currentStep {
+			steps = append(steps, entity.SagaStep{Name: step, Status: constants.SagaStepStatusRunning, StartedAt: now})
+			break
+		}
+		steps = append(steps, entity.SagaStep{Name: step, Status: constants.SagaStepStatusDone, StartedAt: now, FinishedAt: now})
+	}
+	return &entity.Saga{
+		SagaId:       "saga-id",
+		UserId:       "user-id",
+		EventId:      "event-id",
+		TicketType:   "Gold",
+		CountryCode:  "ID",
+		Quantity:     2,
+		OrderId:      "order-id",
+		PaymentId:    "payment-id",
+		Status:       status,
+		CurrentStep:  currentStep,
+		StepDeadline: now.Add(time.Minute),
+		Steps:        steps,
+	}
+}
+
+func getMockOrder(status string) helpers.Result {
+	return helpers.Result{
+		Data: &orderEntity.Order{
+			OrderId:    "order-id",
+			UserId:     "user-id",
+			TicketId:   "ticket-id",
+			EventId:    "event-id",
+			TicketType: "Gold",
+			Quantity:   2,
+			TotalPrice: 200,
+			Status:     status,
+			ExpiredAt:  time.Now().Add(10 * time.Minute),
+		},
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/purchase"
+	"ticket-service/internal/modules/purchase/models/entity"
+	"ticket-service/internal/modules/purchase/models/request"
+	"ticket-service/internal/modules/purchase/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	purchaseRepositoryQuery purchase.MongodbRepositoryQuery
+	logger                  log.Logger
+}
+
+func NewQueryUsecase(pmq purchase.MongodbRepositoryQuery, log log.Logger) purchase.UsecaseQuery {
+	return queryUsecase{
+		purchaseRepositoryQuery: pmq,
+		logger:                  log,
+	}
+}
+
+func (q queryUsecase) FindMyPurchase(origCtx context.Context, userId string, sagaId string) (*response.Saga, error) {
+	domain := "purchaseUsecase-FindMyPurchase"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.purchaseRepositoryQuery.FindSagaById(ctx, sagaId)
+	if resp.Error != nil {
+		msg := "Error query saga"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("purchase not found")
+	}
+
+	saga, ok := resp.Data.(*entity.Saga)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if saga.UserId != userId {
+		return nil, errors.NotFound("purchase not found")
+	}
+
+	return mapSaga(*saga), nil
+}
+
+func (q queryUsecase) FindStuckSagas(origCtx context.Context, payload request.StuckSagaReq) ([]response.Saga, error) {
+	domain := "purchaseUsecase-FindStuckSagas"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	olderThan := constants.SagaStuckAfter
+	if payload.OlderThanMinutes > 0 {
+		olderThan = time.Duration(payload.OlderThanMinutes) * time.Minute
+	}
+
+	resp := <-q.purchaseRepositoryQuery.FindStuckSagas(ctx, time.Now().Add(-olderThan))
+	if resp.Error != nil {
+		msg := "Error query stuck saga"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	var collectionData = make([]response.Saga, 0)
+	if resp.Data == nil {
+		return collectionData, nil
+	}
+
+	sagas, ok := resp.Data.(*[]entity.Saga)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *sagas {
+		collectionData = append(collectionData, *mapSaga(value))
+	}
+	return collectionData, nil
+}
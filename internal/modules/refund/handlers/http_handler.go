@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/refund"
+	"ticket-service/internal/modules/refund/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type RefundHttpHandler struct {
+	RefundUsecaseCommand refund.UsecaseCommand
+	RefundUsecaseQuery   refund.UsecaseQuery
+	Logger               log.Logger
+	Validator            *validator.Validate
+}
+
+func InitRefundHttpHandler(app *fiber.App, ruc refund.UsecaseCommand, ruq refund.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &RefundHttpHandler{
+		RefundUsecaseCommand: ruc,
+		RefundUsecaseQuery:   ruq,
+		Logger:               log,
+		Validator:            validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/refunds")
+
+	route.Post("/v1", middlewares.VerifyBearer(), handler.RequestRefund)
+	route.Get("/v1/mine", middlewares.VerifyBearer(), handler.GetMyRefunds)
+	route.Get("/v1/policies/:eventId", middlewares.VerifyBearer(), handler.GetRefundPolicy)
+	route.Put("/v1/policies", middlewares.VerifyBearer(), adminRole, handler.UpsertRefundPolicy)
+	route.Get("/v1/pending-approval", middlewares.VerifyBearer(), adminRole, handler.GetPendingApprovalRefunds)
+	route.Post("/v1/:id/approve", middlewares.VerifyBearer(), adminRole, handler.ApproveRefund)
+	route.Post("/v1/:id/reject", middlewares.VerifyBearer(), adminRole, handler.RejectRefund)
+	route.Post("/v1/events/:eventId/cancel", middlewares.VerifyBearer(), adminRole, handler.CancelEvent)
+}
+
+func (r RefundHttpHandler) RequestRefund(c *fiber.Ctx) error {
+	req := new(request.RefundReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := r.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, r.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	resp, err := r.RefundUsecaseCommand.RequestRefund(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Request refund success")
+}
+
+func (r RefundHttpHandler) GetMyRefunds(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, r.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	resp, err := r.RefundUsecaseQuery.FindMyRefunds(c.Context(), userId)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Get my refund success")
+}
+
+func (r RefundHttpHandler) GetRefundPolicy(c *fiber.Ctx) error {
+	eventId := c.Params("eventId")
+	if eventId == "" {
+		return helpers.RespError(c, r.Logger, errors.BadRequest("eventId is required"))
+	}
+	resp, err := r.RefundUsecaseQuery.FindRefundPolicy(c.Context(), eventId)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Get refund policy success")
+}
+
+func (r RefundHttpHandler) UpsertRefundPolicy(c *fiber.Ctx) error {
+	req := new(request.RefundPolicyReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := r.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := r.RefundUsecaseCommand.UpsertRefundPolicy(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Update refund policy success")
+}
+
+func (r RefundHttpHandler) GetPendingApprovalRefunds(c *fiber.Ctx) error {
+	resp, err := r.RefundUsecaseQuery.FindPendingApprovalRefunds(c.Context())
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Get pending approval refund success")
+}
+
+func (r RefundHttpHandler) ApproveRefund(c *fiber.Ctx) error {
+	req, err := r.parseReviewReq(c)
+	if err != nil {
+		return helpers.RespError(c, r.Logger, err)
+	}
+	resp, err := r.RefundUsecaseCommand.ApproveRefund(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Approve refund success")
+}
+
+func (r RefundHttpHandler) RejectRefund(c *fiber.Ctx) error {
+	req, err := r.parseReviewReq(c)
+	if err != nil {
+		return helpers.RespError(c, r.Logger, err)
+	}
+	resp, err := r.RefundUsecaseCommand.RejectRefund(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Reject refund success")
+}
+
+func (r RefundHttpHandler) CancelEvent(c *fiber.Ctx) error {
+	req := new(request.CancelEventReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := r.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest(err.Error()))
+	}
+	adminId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, r.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.AdminId = adminId
+	req.EventId = c.Params("eventId")
+	resp, err := r.RefundUsecaseCommand.CancelEvent(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Cancel event success")
+}
+
+func (r RefundHttpHandler) parseReviewReq(c *fiber.Ctx) (*request.RefundReviewReq, error) {
+	req := new(request.RefundReviewReq)
+	if err := c.BodyParser(req); err != nil {
+		return nil, errors.BadRequest("bad request")
+	}
+
+	if err := r.Validator.Struct(req); err != nil {
+		return nil, errors.BadRequest(err.Error())
+	}
+	adminId, ok := c.Locals("userId").(string)
+	if !ok {
+		return nil, errors.UnauthorizedError("invalid user")
+	}
+	req.AdminId = adminId
+	req.RefundId = c.Params("id")
+	return req, nil
+}
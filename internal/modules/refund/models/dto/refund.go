@@ -0,0 +1,11 @@
+package dto
+
+// RefundReview moves a refund out of FromStatus, the update is skipped when it is no longer there
+type RefundReview struct {
+	RefundId   string
+	FromStatus string
+	Status     string
+	Amount     int
+	ReviewedBy string
+	ReviewNote string
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"ticket-service/internal/pkg/constants"
+	"time"
+)
+
+type Refund struct {
+	RefundId   string    `json:"refundId" bson:"refundId"`
+	OrderId    string    `json:"orderId" bson:"orderId"`
+	UserId     string    `json:"userId" bson:"userId"`
+	EventId    string    `json:"eventId" bson:"eventId"`
+	TicketId   string    `json:"ticketId" bson:"ticketId"`
+	Quantity   int       `json:"quantity" bson:"quantity"`
+	TotalPrice int       `json:"totalPrice" bson:"totalPrice"`
+	Amount     int       `json:"amount" bson:"amount"`
+	Reason     string    `json:"reason" bson:"reason"`
+	Initiator  string    `json:"initiator" bson:"initiator"`
+	Status     string    `json:"status" bson:"status"`
+	ReviewedBy string    `json:"reviewedBy,omitempty" bson:"reviewedBy,omitempty"`
+	ReviewNote string    `json:"reviewNote,omitempty" bson:"reviewNote,omitempty"`
+	CreatedAt  time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// RefundPolicy is configured per event, without a policy every refund needs an admin exception
+type RefundPolicy struct {
+	EventId    string    `json:"eventId" bson:"eventId"`
+	Type       string    `json:"type" bson:"type"`
+	Percentage int       `json:"percentage" bson:"percentage"`
+	DeadlineAt time.Time `json:"deadlineAt" bson:"deadlineAt"`
+	CreatedAt  time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Amount is what the policy gives back for totalPrice at the given time, ok is false when an exception is needed
+func (p RefundPolicy) Amount(totalPrice int, now time.Time) (amount int, ok bool) {
+	if !p.DeadlineAt.IsZero() && now.After(p.DeadlineAt) {
+		return 0, false
+	}
+	switch p.Type {
+	case constants.RefundPolicyFull:
+		return totalPrice, true
+	case constants.RefundPolicyPartial:
+		return totalPrice * p.Percentage / 100, true
+	}
+	return 0, false
+}
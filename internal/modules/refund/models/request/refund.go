@@ -0,0 +1,30 @@
+package request
+
+import "time"
+
+type RefundReq struct {
+	UserId  string `json:"-"`
+	OrderId string `json:"orderId" validate:"required"`
+	Reason  string `json:"reason" validate:"required,max=500"`
+}
+
+// RefundReviewReq is used by admins, Amount is only read on approval and defaults to the full order price
+type RefundReviewReq struct {
+	AdminId  string `json:"-"`
+	RefundId string `json:"-"`
+	Amount   int    `json:"amount" validate:"min=0"`
+	Note     string `json:"note" validate:"max=500"`
+}
+
+type RefundPolicyReq struct {
+	EventId    string    `json:"eventId" validate:"required"`
+	Type       string    `json:"type" validate:"required,oneof=FULL PARTIAL NONE"`
+	Percentage int       `json:"percentage" validate:"min=0,max=100"`
+	DeadlineAt time.Time `json:"deadlineAt"`
+}
+
+type CancelEventReq struct {
+	AdminId string `json:"-"`
+	EventId string `json:"-"`
+	Reason  string `json:"reason" validate:"required,max=500"`
+}
@@ -0,0 +1,31 @@
+package response
+
+import "time"
+
+type Refund struct {
+	RefundId   string    `json:"refundId"`
+	OrderId    string    `json:"orderId"`
+	EventId    string    `json:"eventId"`
+	Quantity   int       `json:"quantity"`
+	TotalPrice string    `json:"totalPrice"`
+	Amount     string    `json:"amount"`
+	Reason     string    `json:"reason"`
+	Initiator  string    `json:"initiator"`
+	Status     string    `json:"status"`
+	ReviewNote string    `json:"reviewNote,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type RefundPolicy struct {
+	EventId    string    `json:"eventId"`
+	Type       string    `json:"type"`
+	Percentage int       `json:"percentage"`
+	DeadlineAt time.Time `json:"deadlineAt"`
+}
+
+type EventCancellation struct {
+	EventId         string `json:"eventId"`
+	RefundedOrders  int    `json:"refundedOrders"`
+	CancelledOrders int    `json:"cancelledOrders"`
+	FailedOrders    int    `json:"failedOrders"`
+}
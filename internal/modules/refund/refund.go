@@ -0,0 +1,38 @@
+package refund
+
+import (
+	"context"
+	"ticket-service/internal/modules/refund/models/dto"
+	"ticket-service/internal/modules/refund/models/entity"
+	"ticket-service/internal/modules/refund/models/request"
+	"ticket-service/internal/modules/refund/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseCommand interface {
+	RequestRefund(origCtx context.Context, payload request.RefundReq) (*response.Refund, error)
+	ApproveRefund(origCtx context.Context, payload request.RefundReviewReq) (*response.Refund, error)
+	RejectRefund(origCtx context.Context, payload request.RefundReviewReq) (*response.Refund, error)
+	UpsertRefundPolicy(origCtx context.Context, payload request.RefundPolicyReq) (*response.RefundPolicy, error)
+	CancelEvent(origCtx context.Context, payload request.CancelEventReq) (*response.EventCancellation, error)
+}
+
+type UsecaseQuery interface {
+	FindMyRefunds(origCtx context.Context, userId string) ([]response.Refund, error)
+	FindPendingApprovalRefunds(origCtx context.Context) ([]response.Refund, error)
+	FindRefundPolicy(origCtx context.Context, eventId string) (*response.RefundPolicy, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindRefundById(ctx context.Context, refundId string) <-chan wrapper.Result
+	FindRefundsByUserId(ctx context.Context, userId string) <-chan wrapper.Result
+	FindRefundsByStatus(ctx context.Context, status string) <-chan wrapper.Result
+	FindPendingRefundByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result
+	FindRefundPolicyByEventId(ctx context.Context, eventId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	InsertOneRefund(ctx context.Context, refund entity.Refund) <-chan wrapper.Result
+	UpdateRefundReview(ctx context.Context, payload dto.RefundReview) <-chan wrapper.Result
+	UpsertRefundPolicy(ctx context.Context, policy entity.RefundPolicy) <-chan wrapper.Result
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/refund"
+	"ticket-service/internal/modules/refund/models/dto"
+	"ticket-service/internal/modules/refund/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) refund.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) InsertOneRefund(ctx context.Context, refund entity.Refund) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "refunds",
+			Document:       refund,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateRefundReview moves the refund only when it is still in FromStatus, Data is nil when it is not
+func (c commandMongodbRepository) UpdateRefundReview(ctx context.Context, payload dto.RefundReview) <-chan wrapper.Result {
+	var refund entity.Refund
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &refund,
+			CollectionName: "refunds",
+			Filter: bson.M{
+				"refundId": payload.RefundId,
+				"status":   payload.FromStatus,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":     payload.Status,
+					"amount":     payload.Amount,
+					"reviewedBy": payload.ReviewedBy,
+					"reviewNote": payload.ReviewNote,
+					"updatedAt":  time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpsertRefundPolicy(ctx context.Context, policy entity.RefundPolicy) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "refund-policies",
+			Filter: bson.M{
+				"eventId": policy.EventId,
+			},
+			Document: policy,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,129 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/refund"
+	"ticket-service/internal/modules/refund/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) refund.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindRefundById(ctx context.Context, refundId string) <-chan wrapper.Result {
+	var refund entity.Refund
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &refund,
+			CollectionName: "refunds",
+			Filter: bson.M{
+				"refundId": refundId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindRefundsByUserId(ctx context.Context, userId string) <-chan wrapper.Result {
+	var refunds []entity.Refund
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &refunds,
+			CollectionName: "refunds",
+			Filter: bson.M{
+				"userId": userId,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "createdAt",
+				By:        mongodb.SortDescending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindRefundsByStatus(ctx context.Context, status string) <-chan wrapper.Result {
+	var refunds []entity.Refund
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &refunds,
+			CollectionName: "refunds",
+			Filter: bson.M{
+				"status": status,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "createdAt",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindPendingRefundByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result {
+	var refund entity.Refund
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &refund,
+			CollectionName: "refunds",
+			Filter: bson.M{
+				"orderId": orderId,
+				"status":  constants.RefundStatusPendingApproval,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindRefundPolicyByEventId(ctx context.Context, eventId string) <-chan wrapper.Result {
+	var policy entity.RefundPolicy
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &policy,
+			CollectionName: "refund-policies",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,530 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ticket-service/internal/modules/access"
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/order"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/refund"
+	"ticket-service/internal/modules/refund/models/dto"
+	"ticket-service/internal/modules/refund/models/entity"
+	"ticket-service/internal/modules/refund/models/request"
+	"ticket-service/internal/modules/refund/models/response"
+	"ticket-service/internal/modules/ticket"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	refundRepositoryQuery    refund.MongodbRepositoryQuery
+	refundRepositoryCommand  refund.MongodbRepositoryCommand
+	orderRepositoryQuery     order.MongodbRepositoryQuery
+	orderRepositoryCommand   order.MongodbRepositoryCommand
+	orderUsecaseCommand      order.UsecaseCommand
+	eticketRepositoryQuery   eticket.MongodbRepositoryQuery
+	eticketRepositoryCommand eticket.MongodbRepositoryCommand
+	ticketRepositoryCommand  ticket.MongodbRepositoryCommand
+	accessUsecaseCommand     access.UsecaseCommand
+	kafkaProducer            kafkaConfluent.Producer
+	logger                   log.Logger
+}
+
+func NewCommandUsecase(rmq refund.MongodbRepositoryQuery, rmc refund.MongodbRepositoryCommand, omq order.MongodbRepositoryQuery,
+	omc order.MongodbRepositoryCommand, ouc order.UsecaseCommand, emq eticket.MongodbRepositoryQuery,
+	emc eticket.MongodbRepositoryCommand, tmc ticket.MongodbRepositoryCommand, auc access.UsecaseCommand, kp kafkaConfluent.Producer, log log.Logger) refund.UsecaseCommand {
+	return commandUsecase{
+		refundRepositoryQuery:    rmq,
+		refundRepositoryCommand:  rmc,
+		orderRepositoryQuery:     omq,
+		orderRepositoryCommand:   omc,
+		orderUsecaseCommand:      ouc,
+		eticketRepositoryQuery:   emq,
+		eticketRepositoryCommand: emc,
+		ticketRepositoryCommand:  tmc,
+		accessUsecaseCommand:     auc,
+		kafkaProducer:            kp,
+		logger:                   log,
+	}
+}
+
+// RequestRefund executes the refund right away when the event policy covers it,
+// anything outside the policy waits for an admin to approve it as an exception
+func (c commandUsecase) RequestRefund(origCtx context.Context, payload request.RefundReq) (*response.Refund, error) {
+	domain := "refundUsecase-RequestRefund"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	orderDetail, err := c.findOrder(ctx, payload.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	if orderDetail.UserId != payload.UserId {
+		return nil, errors.NotFound("order not found")
+	}
+
+	if orderDetail.ResaleListingId != "" {
+		return nil, errors.UnprocessableEntity("resale orders cannot be refunded")
+	}
+
+	if orderDetail.Status != constants.OrderStatusPaid {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("order is %s and cannot be refunded", orderDetail.Status))
+	}
+
+	pendingData := <-c.refundRepositoryQuery.FindPendingRefundByOrderId(ctx, orderDetail.OrderId)
+	if pendingData.Error != nil {
+		msg := "Error query pending refund"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", pendingData.Error))
+		return nil, pendingData.Error
+	}
+
+	if pendingData.Data != nil {
+		return nil, errors.Conflict("order already has a refund waiting for approval")
+	}
+
+	issuedTickets, err := c.findIssuedTickets(ctx, orderDetail.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, issuedTicket := range issuedTickets {
+		if issuedTicket.Status == constants.IssuedTicketStatusUsed {
+			return nil, errors.UnprocessableEntity("ticket of this order has been used")
+		}
+		if issuedTicket.Status == constants.IssuedTicketStatusListed {
+			return nil, errors.UnprocessableEntity("ticket of this order is listed for resale")
+		}
+		if issuedTicket.UserId != orderDetail.UserId {
+			return nil, errors.UnprocessableEntity("ticket of this order has been transferred")
+		}
+	}
+
+	policy, err := c.findRefundPolicy(ctx, orderDetail.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	refundData := entity.Refund{
+		RefundId:   uuid.NewString(),
+		OrderId:    orderDetail.OrderId,
+		UserId:     orderDetail.UserId,
+		EventId:    orderDetail.EventId,
+		TicketId:   orderDetail.TicketId,
+		Quantity:   orderDetail.Quantity,
+		TotalPrice: orderDetail.TotalPrice,
+		Reason:     payload.Reason,
+		Initiator:  constants.RefundInitiatorUser,
+		Status:     constants.RefundStatusPendingApproval,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if amount, ok := policy.Amount(orderDetail.TotalPrice, now); ok && amount > 0 {
+		refundData.Amount = amount
+		refundData.Status = constants.RefundStatusApproved
+	}
+
+	insert := <-c.refundRepositoryCommand.InsertOneRefund(ctx, refundData)
+	if insert.Error != nil {
+		msg := "Error insert refund"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		return nil, insert.Error
+	}
+
+	if refundData.Status == constants.RefundStatusApproved {
+		if err := c.executeRefund(ctx, *orderDetail, refundData); err != nil {
+			return nil, err
+		}
+	}
+
+	return mapRefund(refundData), nil
+}
+
+func (c commandUsecase) ApproveRefund(origCtx context.Context, payload request.RefundReviewReq) (*response.Refund, error) {
+	domain := "refundUsecase-ApproveRefund"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	refundData, err := c.findRefund(ctx, payload.RefundId)
+	if err != nil {
+		return nil, err
+	}
+
+	if refundData.Status != constants.RefundStatusPendingApproval {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("refund is already %s", refundData.Status))
+	}
+
+	amount := payload.Amount
+	if amount == 0 {
+		amount = refundData.TotalPrice
+	}
+	if amount > refundData.TotalPrice {
+		return nil, errors.BadRequest(fmt.Sprintf("amount cannot be more than $%d", refundData.TotalPrice))
+	}
+
+	orderDetail, err := c.findOrder(ctx, refundData.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	approved := <-c.refundRepositoryCommand.UpdateRefundReview(ctx, dto.RefundReview{
+		RefundId:   refundData.RefundId,
+		FromStatus: constants.RefundStatusPendingApproval,
+		Status:     constants.RefundStatusApproved,
+		Amount:     amount,
+		ReviewedBy: payload.AdminId,
+		ReviewNote: payload.Note,
+	})
+	if approved.Error != nil {
+		msg := "Error approve refund"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", approved.Error))
+		return nil, approved.Error
+	}
+
+	if approved.Data == nil {
+		return nil, errors.Conflict("refund is already reviewed")
+	}
+
+	refundData.Status = constants.RefundStatusApproved
+	refundData.Amount = amount
+	refundData.ReviewedBy = payload.AdminId
+	refundData.ReviewNote = payload.Note
+	if err := c.executeRefund(ctx, *orderDetail, *refundData); err != nil {
+		return nil, err
+	}
+
+	return mapRefund(*refundData), nil
+}
+
+func (c commandUsecase) RejectRefund(origCtx context.Context, payload request.RefundReviewReq) (*response.Refund, error) {
+	domain := "refundUsecase-RejectRefund"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	rejected := <-c.refundRepositoryCommand.UpdateRefundReview(ctx, dto.RefundReview{
+		RefundId:   payload.RefundId,
+		FromStatus: constants.RefundStatusPendingApproval,
+		Status:     constants.RefundStatusRejected,
+		ReviewedBy: payload.AdminId,
+		ReviewNote: payload.Note,
+	})
+	if rejected.Error != nil {
+		msg := "Error reject refund"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", rejected.Error))
+		return nil, rejected.Error
+	}
+
+	if rejected.Data == nil {
+		return nil, errors.UnprocessableEntity("refund is not waiting for approval")
+	}
+
+	refundData, ok := rejected.Data.(*entity.Refund)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	return mapRefund(*refundData), nil
+}
+
+func (c commandUsecase) UpsertRefundPolicy(origCtx context.Context, payload request.RefundPolicyReq) (*response.RefundPolicy, error) {
+	domain := "refundUsecase-UpsertRefundPolicy"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if payload.Type == constants.RefundPolicyPartial && (payload.Percentage < 1 || payload.Percentage > 99) {
+		return nil, errors.BadRequest("percentage must be between 1 and 99 for a partial refund")
+	}
+
+	policy := entity.RefundPolicy{
+		EventId:    payload.EventId,
+		Type:       payload.Type,
+		Percentage: payload.Percentage,
+		DeadlineAt: payload.DeadlineAt,
+		UpdatedAt:  time.Now(),
+	}
+	resp := <-c.refundRepositoryCommand.UpsertRefundPolicy(ctx, policy)
+	if resp.Error != nil {
+		msg := "Error upsert refund policy"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapRefundPolicy(policy), nil
+}
+
+// CancelEvent marks the event cancelled on its tiers, refunds every paid order of the event in full regardless of
+// its policy and releases unpaid holds
+func (c commandUsecase) CancelEvent(origCtx context.Context, payload request.CancelEventReq) (*response.EventCancellation, error) {
+	domain := "refundUsecase-CancelEvent"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	// the tiers are cancelled first, so no new order takes them and the released orders are not restocked
+	cancelled := <-c.ticketRepositoryCommand.CancelEventTickets(ctx, payload.EventId)
+	if cancelled.Error != nil {
+		msg := "Error cancel event tickets"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", cancelled.Error))
+		return nil, cancelled.Error
+	}
+
+	result := response.EventCancellation{
+		EventId: payload.EventId,
+	}
+
+	paidOrders, err := c.findOrdersByEventId(ctx, payload.EventId, constants.OrderStatusPaid)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, orderDetail := range paidOrders {
+		now := time.Now()
+		refundData := entity.Refund{
+			RefundId:   uuid.NewString(),
+			OrderId:    orderDetail.OrderId,
+			UserId:     orderDetail.UserId,
+			EventId:    orderDetail.EventId,
+			TicketId:   orderDetail.TicketId,
+			Quantity:   orderDetail.Quantity,
+			TotalPrice: orderDetail.TotalPrice,
+			Amount:     orderDetail.TotalPrice,
+			Reason:     payload.Reason,
+			Initiator:  constants.RefundInitiatorOrganiser,
+			Status:     constants.RefundStatusApproved,
+			ReviewedBy: payload.AdminId,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		insert := <-c.refundRepositoryCommand.InsertOneRefund(ctx, refundData)
+		if insert.Error != nil {
+			msg := "Error insert refund"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+			result.FailedOrders++
+			continue
+		}
+		if err := c.executeRefund(ctx, orderDetail, refundData); err != nil {
+			result.FailedOrders++
+			continue
+		}
+		result.RefundedOrders++
+	}
+
+	pendingOrders, err := c.findOrdersByEventId(ctx, payload.EventId, constants.OrderStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, orderDetail := range pendingOrders {
+		cancelled := <-c.orderRepositoryCommand.UpdateOrderStatus(ctx, orderDetail.OrderId, constants.OrderStatusPending,
+			constants.OrderStatusCancelled)
+		if cancelled.Error != nil || cancelled.Data == nil {
+			result.FailedOrders++
+			continue
+		}
+		c.orderUsecaseCommand.ReleaseHold(ctx, orderDetail)
+		result.CancelledOrders++
+	}
+
+	marshaledKafkaData, _ := json.Marshal(result)
+	topic := "concert-event-cancelled"
+	c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka event cancelled, event : %s", payload.EventId), fmt.Sprintf("%+v", result))
+
+	return &result, nil
+}
+
+// executeRefund is guarded by the PAID to REFUNDED move of the order, so a refund is never executed twice.
+// When the order has already left PAID the approved refund is closed as rejected
+func (c commandUsecase) executeRefund(ctx context.Context, orderDetail orderEntity.Order, refundData entity.Refund) error {
+	refunded := <-c.orderRepositoryCommand.UpdateOrderStatus(ctx, orderDetail.OrderId, constants.OrderStatusPaid,
+		constants.OrderStatusRefunded)
+	if refunded.Error != nil || refunded.Data == nil {
+		<-c.refundRepositoryCommand.UpdateRefundReview(ctx, dto.RefundReview{
+			RefundId:   refundData.RefundId,
+			FromStatus: constants.RefundStatusApproved,
+			Status:     constants.RefundStatusRejected,
+			ReviewedBy: refundData.ReviewedBy,
+			ReviewNote: "order is no longer paid",
+		})
+		if refunded.Error != nil {
+			msg := "Error refund order"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", refunded.Error))
+			return refunded.Error
+		}
+		return errors.Conflict("order is no longer paid")
+	}
+
+	issuedTickets, err := c.findIssuedTickets(ctx, orderDetail.OrderId)
+	if err != nil {
+		msg := "Error query issued ticket to revoke"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", orderDetail))
+	}
+	for _, issuedTicket := range issuedTickets {
+		revoked := <-c.eticketRepositoryCommand.UpdateIssuedTicketRevoked(ctx, issuedTicket.IssuedTicketId)
+		if revoked.Error != nil {
+			msg := "Error revoke issued ticket"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", issuedTicket))
+		}
+		if issuedTicket.TicketType != constants.TicketTypeOnline {
+			continue
+		}
+		if err := c.accessUsecaseCommand.RevokePass(ctx, issuedTicket.IssuedTicketId); err != nil {
+			msg := "Error revoke access pass"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", issuedTicket))
+		}
+	}
+
+	c.orderUsecaseCommand.ReleaseHold(ctx, orderDetail)
+
+	marshaledKafkaData, _ := json.Marshal(refundData)
+	topic := "concert-order-refund"
+	c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka order refund, order : %s", orderDetail.OrderId), fmt.Sprintf("%+v", refundData))
+	return nil
+}
+
+func (c commandUsecase) findOrder(ctx context.Context, orderId string) (*orderEntity.Order, error) {
+	resp := <-c.orderRepositoryQuery.FindOrderById(ctx, orderId)
+	if resp.Error != nil {
+		msg := "Error query order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("order not found")
+	}
+
+	orderDetail, ok := resp.Data.(*orderEntity.Order)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return orderDetail, nil
+}
+
+func (c commandUsecase) findOrdersByEventId(ctx context.Context, eventId string, status string) ([]orderEntity.Order, error) {
+	resp := <-c.orderRepositoryQuery.FindOrdersByEventId(ctx, eventId, status)
+	if resp.Error != nil {
+		msg := "Error query order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	orders, ok := resp.Data.(*[]orderEntity.Order)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return *orders, nil
+}
+
+func (c commandUsecase) findIssuedTickets(ctx context.Context, orderId string) ([]eticketEntity.IssuedTicket, error) {
+	resp := <-c.eticketRepositoryQuery.FindIssuedTicketsByOrderId(ctx, orderId)
+	if resp.Error != nil {
+		msg := "Error query issued ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	issuedTickets, ok := resp.Data.(*[]eticketEntity.IssuedTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return *issuedTickets, nil
+}
+
+func (c commandUsecase) findRefund(ctx context.Context, refundId string) (*entity.Refund, error) {
+	resp := <-c.refundRepositoryQuery.FindRefundById(ctx, refundId)
+	if resp.Error != nil {
+		msg := "Error query refund"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("refund not found")
+	}
+
+	refundData, ok := resp.Data.(*entity.Refund)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return refundData, nil
+}
+
+func (c commandUsecase) findRefundPolicy(ctx context.Context, eventId string) (*entity.RefundPolicy, error) {
+	resp := <-c.refundRepositoryQuery.FindRefundPolicyByEventId(ctx, eventId)
+	if resp.Error != nil {
+		msg := "Error query refund policy"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	// events without configuration do not refund unless an admin approves it
+	if resp.Data == nil {
+		return &entity.RefundPolicy{EventId: eventId, Type: constants.RefundPolicyNone}, nil
+	}
+
+	policy, ok := resp.Data.(*entity.RefundPolicy)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return policy, nil
+}
+
+func mapRefund(refundData entity.Refund) *response.Refund {
+	return &response.Refund{
+		RefundId:   refundData.RefundId,
+		OrderId:    refundData.OrderId,
+		EventId:    refundData.EventId,
+		Quantity:   refundData.Quantity,
+		TotalPrice: fmt.Sprintf("$%d", refundData.TotalPrice),
+		Amount:     fmt.Sprintf("$%d", refundData.Amount),
+		Reason:     refundData.Reason,
+		Initiator:  refundData.Initiator,
+		Status:     refundData.Status,
+		ReviewNote: refundData.ReviewNote,
+		CreatedAt:  refundData.CreatedAt,
+	}
+}
+
+func mapRefundPolicy(policy entity.RefundPolicy) *response.RefundPolicy {
+	return &response.RefundPolicy{
+		EventId:    policy.EventId,
+		Type:       policy.Type,
+		Percentage: policy.Percentage,
+		DeadlineAt: policy.DeadlineAt,
+	}
+}
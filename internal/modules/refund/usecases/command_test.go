@@ -0,0 +1,344 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/refund"
+	"ticket-service/internal/modules/refund/models/dto"
+	"ticket-service/internal/modules/refund/models/entity"
+	"ticket-service/internal/modules/refund/models/request"
+	uc "ticket-service/internal/modules/refund/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockaccess "ticket-service/mocks/modules/access"
+	mocketicket "ticket-service/mocks/modules/eticket"
+	mockorder "ticket-service/mocks/modules/order"
+	mockrefund "ticket-service/mocks/modules/refund"
+	mockticket "ticket-service/mocks/modules/ticket"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockRefundRepositoryQuery    *mockrefund.MongodbRepositoryQuery
+	mockRefundRepositoryCommand  *mockrefund.MongodbRepositoryCommand
+	mockOrderRepositoryQuery     *mockorder.MongodbRepositoryQuery
+	mockOrderRepositoryCommand   *mockorder.MongodbRepositoryCommand
+	mockOrderUsecaseCommand      *mockorder.UsecaseCommand
+	mockEticketRepositoryQuery   *mocketicket.MongodbRepositoryQuery
+	mockEticketRepositoryCommand *mocketicket.MongodbRepositoryCommand
+	mockTicketRepositoryCommand  *mockticket.MongodbRepositoryCommand
+	mockAccessUsecaseCommand     *mockaccess.UsecaseCommand
+	mockKafkaProducer            *mockkafka.Producer
+	mockLogger                   *mocklog.Logger
+	usecase                      refund.UsecaseCommand
+	ctx                          context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockRefundRepositoryQuery = &mockrefund.MongodbRepositoryQuery{}
+	suite.mockRefundRepositoryCommand = &mockrefund.MongodbRepositoryCommand{}
+	suite.mockOrderRepositoryQuery = &mockorder.MongodbRepositoryQuery{}
+	suite.mockOrderRepositoryCommand = &mockorder.MongodbRepositoryCommand{}
+	suite.mockOrderUsecaseCommand = &mockorder.UsecaseCommand{}
+	suite.mockEticketRepositoryQuery = &mocketicket.MongodbRepositoryQuery{}
+	suite.mockEticketRepositoryCommand = &mocketicket.MongodbRepositoryCommand{}
+	suite.mockTicketRepositoryCommand = &mockticket.MongodbRepositoryCommand{}
+	suite.mockAccessUsecaseCommand = &mockaccess.UsecaseCommand{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockRefundRepositoryQuery,
+		suite.mockRefundRepositoryCommand,
+		suite.mockOrderRepositoryQuery,
+		suite.mockOrderRepositoryCommand,
+		suite.mockOrderUsecaseCommand,
+		suite.mockEticketRepositoryQuery,
+		suite.mockEticketRepositoryCommand,
+		suite.mockTicketRepositoryCommand,
+		suite.mockAccessUsecaseCommand,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockRefundRepositoryCommand.On("InsertOneRefund", mock.Anything, mock.Anything).
+		Return(func(context.Context, entity.Refund) <-chan helpers.Result {
+			return mockChannel(helpers.Result{Data: "Success insert data"})
+		})
+	suite.mockRefundRepositoryQuery.On("FindPendingRefundByOrderId", mock.Anything, "order-id").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketsByOrderId", mock.Anything, "order-id").
+		Return(func(context.Context, string) <-chan helpers.Result {
+			return mockChannel(getMockIssuedTickets("user-id"))
+		})
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketRevoked", mock.Anything, mock.Anything).
+		Return(func(context.Context, string) <-chan helpers.Result {
+			return mockChannel(helpers.Result{Data: &eticketEntity.IssuedTicket{}})
+		})
+	suite.mockOrderUsecaseCommand.On("ReleaseHold", mock.Anything, mock.Anything)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestRequestRefundWithinPolicy() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockRefundRepositoryQuery.On("FindRefundPolicyByEventId", mock.Anything, "event-id").Return(mockChannel(helpers.Result{
+		Data: &entity.RefundPolicy{EventId: "event-id", Type: constants.RefundPolicyPartial, Percentage: 50, DeadlineAt: time.Now().Add(time.Hour)},
+	}))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPaid,
+		constants.OrderStatusRefunded).Return(mockChannel(getMockOrder(constants.OrderStatusRefunded)))
+
+	// Act
+	result, err := suite.usecase.RequestRefund(suite.ctx, getRefundReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.RefundStatusApproved, result.Status)
+	assert.Equal(suite.T(), "$100", result.Amount)
+	suite.mockEticketRepositoryCommand.AssertNumberOfCalls(suite.T(), "UpdateIssuedTicketRevoked", 2)
+	suite.mockOrderUsecaseCommand.AssertCalled(suite.T(), "ReleaseHold", mock.Anything, *getMockOrder(constants.OrderStatusPaid).Data.(*orderEntity.Order))
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-order-refund", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRequestRefundRevokesAccessPass() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockRefundRepositoryQuery.On("FindRefundPolicyByEventId", mock.Anything, "event-id").Return(mockChannel(helpers.Result{
+		Data: &entity.RefundPolicy{EventId: "event-id", Type: constants.RefundPolicyFull, DeadlineAt: time.Now().Add(time.Hour)},
+	}))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPaid,
+		constants.OrderStatusRefunded).Return(mockChannel(getMockOrder(constants.OrderStatusRefunded)))
+	suite.mockEticketRepositoryQuery.ExpectedCalls = nil
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketsByOrderId", mock.Anything, "order-id").
+		Return(func(context.Context, string) <-chan helpers.Result {
+			return mockChannel(helpers.Result{Data: &[]eticketEntity.IssuedTicket{
+				{IssuedTicketId: "issued-1", OrderId: "order-id", UserId: "user-id", TicketType: constants.TicketTypeOnline,
+					Status: constants.IssuedTicketStatusActive},
+			}})
+		})
+	suite.mockAccessUsecaseCommand.On("RevokePass", mock.Anything, "issued-1").Return(nil)
+
+	// Act
+	result, err := suite.usecase.RequestRefund(suite.ctx, getRefundReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.RefundStatusApproved, result.Status)
+	suite.mockEticketRepositoryCommand.AssertCalled(suite.T(), "UpdateIssuedTicketRevoked", mock.Anything, "issued-1")
+	suite.mockAccessUsecaseCommand.AssertCalled(suite.T(), "RevokePass", mock.Anything, "issued-1")
+}
+
+func (suite *CommandUsecaseTestSuite) TestRequestRefundAfterDeadlineNeedsApproval() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockRefundRepositoryQuery.On("FindRefundPolicyByEventId", mock.Anything, "event-id").Return(mockChannel(helpers.Result{
+		Data: &entity.RefundPolicy{EventId: "event-id", Type: constants.RefundPolicyFull, DeadlineAt: time.Now().Add(-time.Hour)},
+	}))
+
+	// Act
+	result, err := suite.usecase.RequestRefund(suite.ctx, getRefundReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.RefundStatusPendingApproval, result.Status)
+	suite.mockOrderRepositoryCommand.AssertNotCalled(suite.T(), "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "Publish", "concert-order-refund", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRequestRefundErrTicketTransferred() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.ExpectedCalls = nil
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketsByOrderId", mock.Anything, "order-id").Return(mockChannel(getMockIssuedTickets("friend-id")))
+
+	// Act
+	_, err := suite.usecase.RequestRefund(suite.ctx, getRefundReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("ticket of this order has been transferred"), err)
+	suite.mockRefundRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneRefund", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRequestRefundErrNotPaid() {
+	// Arrange
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPending)))
+
+	// Act
+	_, err := suite.usecase.RequestRefund(suite.ctx, getRefundReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("order is PENDING and cannot be refunded"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestApproveRefund() {
+	// Arrange
+	pending := getMockRefund(constants.RefundStatusPendingApproval)
+	suite.mockRefundRepositoryQuery.On("FindRefundById", mock.Anything, "refund-id").Return(mockChannel(helpers.Result{Data: pending}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockRefundRepositoryCommand.On("UpdateRefundReview", mock.Anything, mock.MatchedBy(func(r dto.RefundReview) bool {
+		return r.Status == constants.RefundStatusApproved && r.Amount == 150
+	})).Return(mockChannel(helpers.Result{Data: pending}))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPaid,
+		constants.OrderStatusRefunded).Return(mockChannel(getMockOrder(constants.OrderStatusRefunded)))
+
+	// Act
+	result, err := suite.usecase.ApproveRefund(suite.ctx, request.RefundReviewReq{AdminId: "admin-id", RefundId: "refund-id", Amount: 150})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$150", result.Amount)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-order-refund", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestApproveRefundErrOrderNoLongerPaid() {
+	// Arrange
+	pending := getMockRefund(constants.RefundStatusPendingApproval)
+	suite.mockRefundRepositoryQuery.On("FindRefundById", mock.Anything, "refund-id").Return(mockChannel(helpers.Result{Data: pending}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(getMockOrder(constants.OrderStatusPaid)))
+	suite.mockRefundRepositoryCommand.On("UpdateRefundReview", mock.Anything, mock.Anything).
+		Return(func(context.Context, dto.RefundReview) <-chan helpers.Result {
+			return mockChannel(helpers.Result{Data: pending})
+		})
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPaid,
+		constants.OrderStatusRefunded).Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.ApproveRefund(suite.ctx, request.RefundReviewReq{AdminId: "admin-id", RefundId: "refund-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("order is no longer paid"), err)
+	suite.mockRefundRepositoryCommand.AssertCalled(suite.T(), "UpdateRefundReview", mock.Anything, mock.MatchedBy(func(r dto.RefundReview) bool {
+		return r.FromStatus == constants.RefundStatusApproved && r.Status == constants.RefundStatusRejected
+	}))
+	suite.mockOrderUsecaseCommand.AssertNotCalled(suite.T(), "ReleaseHold", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestApproveRefundErrAmountTooHigh() {
+	// Arrange
+	suite.mockRefundRepositoryQuery.On("FindRefundById", mock.Anything, "refund-id").
+		Return(mockChannel(helpers.Result{Data: getMockRefund(constants.RefundStatusPendingApproval)}))
+
+	// Act
+	_, err := suite.usecase.ApproveRefund(suite.ctx, request.RefundReviewReq{AdminId: "admin-id", RefundId: "refund-id", Amount: 500})
+
+	// Assert
+	assert.Equal(suite.T(), errors.BadRequest("amount cannot be more than $200"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEvent() {
+	// Arrange
+	paid := getMockOrder(constants.OrderStatusPaid).Data.(*orderEntity.Order)
+	pending := getMockOrder(constants.OrderStatusPending).Data.(*orderEntity.Order)
+	suite.mockOrderRepositoryQuery.On("FindOrdersByEventId", mock.Anything, "event-id", constants.OrderStatusPaid).
+		Return(mockChannel(helpers.Result{Data: &[]orderEntity.Order{*paid}}))
+	suite.mockOrderRepositoryQuery.On("FindOrdersByEventId", mock.Anything, "event-id", constants.OrderStatusPending).
+		Return(mockChannel(helpers.Result{Data: &[]orderEntity.Order{*pending}}))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPaid,
+		constants.OrderStatusRefunded).Return(mockChannel(getMockOrder(constants.OrderStatusRefunded)))
+	suite.mockOrderRepositoryCommand.On("UpdateOrderStatus", mock.Anything, "order-id", constants.OrderStatusPending,
+		constants.OrderStatusCancelled).Return(mockChannel(getMockOrder(constants.OrderStatusCancelled)))
+	suite.mockTicketRepositoryCommand.On("CancelEventTickets", mock.Anything, "event-id").Return(mockChannel(helpers.Result{Data: int64(3)}))
+
+	// Act
+	result, err := suite.usecase.CancelEvent(suite.ctx, request.CancelEventReq{AdminId: "admin-id", EventId: "event-id", Reason: "weather"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.RefundedOrders)
+	assert.Equal(suite.T(), 1, result.CancelledOrders)
+	assert.Equal(suite.T(), 0, result.FailedOrders)
+	suite.mockRefundRepositoryCommand.AssertCalled(suite.T(), "InsertOneRefund", mock.Anything, mock.MatchedBy(func(r entity.Refund) bool {
+		return r.Initiator == constants.RefundInitiatorOrganiser && r.Amount == 200
+	}))
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-event-cancelled", mock.Anything, mock.Anything)
+	suite.mockTicketRepositoryCommand.AssertCalled(suite.T(), "CancelEventTickets", mock.Anything, "event-id")
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelEventErrCancelTickets() {
+	// Arrange
+	suite.mockTicketRepositoryCommand.On("CancelEventTickets", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("Error mongodb connection")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	result, err := suite.usecase.CancelEvent(suite.ctx, request.CancelEventReq{AdminId: "admin-id", EventId: "event-id", Reason: "weather"})
+
+	// Assert
+	assert.Error(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	suite.mockOrderRepositoryQuery.AssertNotCalled(suite.T(), "FindOrdersByEventId", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockKafkaProducer.AssertNotCalled(suite.T(), "Publish", "concert-event-cancelled", mock.Anything, mock.Anything)
+}
+
+func getRefundReq() request.RefundReq {
+	return request.RefundReq{
+		UserId:  "user-id",
+		OrderId: "order-id",
+		Reason:  "cannot attend",
+	}
+}
+
+func getMockOrder(status string) helpers.Result {
+	return helpers.Result{
+		Data: &orderEntity.Order{
+			OrderId:     "order-id",
+			UserId:      "user-id",
+			TicketId:    "ticket-id",
+			EventId:     "event-id",
+			TicketType:  "Gold",
+			CountryCode: "ID",
+			Quantity:    2,
+			TicketPrice: 100,
+			TotalPrice:  200,
+			Status:      status,
+		},
+	}
+}
+
+func getMockIssuedTickets(userId string) helpers.Result {
+	return helpers.Result{
+		Data: &[]eticketEntity.IssuedTicket{
+			{IssuedTicketId: "issued-1", OrderId: "order-id", UserId: userId, Status: constants.IssuedTicketStatusActive},
+			{IssuedTicketId: "issued-2", OrderId: "order-id", UserId: userId, Status: constants.IssuedTicketStatusActive},
+		},
+	}
+}
+
+func getMockRefund(status string) *entity.Refund {
+	return &entity.Refund{
+		RefundId:   "refund-id",
+		OrderId:    "order-id",
+		UserId:     "user-id",
+		EventId:    "event-id",
+		TicketId:   "ticket-id",
+		Quantity:   2,
+		TotalPrice: 200,
+		Initiator:  constants.RefundInitiatorUser,
+		Status:     status,
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
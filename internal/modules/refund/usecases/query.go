@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/refund"
+	"ticket-service/internal/modules/refund/models/entity"
+	"ticket-service/internal/modules/refund/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	refundRepositoryQuery refund.MongodbRepositoryQuery
+	logger                log.Logger
+}
+
+func NewQueryUsecase(rmq refund.MongodbRepositoryQuery, log log.Logger) refund.UsecaseQuery {
+	return queryUsecase{
+		refundRepositoryQuery: rmq,
+		logger:                log,
+	}
+}
+
+func (q queryUsecase) FindMyRefunds(origCtx context.Context, userId string) ([]response.Refund, error) {
+	domain := "refundUsecase-FindMyRefunds"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.refundRepositoryQuery.FindRefundsByUserId(ctx, userId)
+	if resp.Error != nil {
+		msg := "Error query refund"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapRefunds(resp.Data)
+}
+
+func (q queryUsecase) FindPendingApprovalRefunds(origCtx context.Context) ([]response.Refund, error) {
+	domain := "refundUsecase-FindPendingApprovalRefunds"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.refundRepositoryQuery.FindRefundsByStatus(ctx, constants.RefundStatusPendingApproval)
+	if resp.Error != nil {
+		msg := "Error query refund"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapRefunds(resp.Data)
+}
+
+func (q queryUsecase) FindRefundPolicy(origCtx context.Context, eventId string) (*response.RefundPolicy, error) {
+	domain := "refundUsecase-FindRefundPolicy"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.refundRepositoryQuery.FindRefundPolicyByEventId(ctx, eventId)
+	if resp.Error != nil {
+		msg := "Error query refund policy"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return &response.RefundPolicy{EventId: eventId, Type: constants.RefundPolicyNone}, nil
+	}
+
+	policy, ok := resp.Data.(*entity.RefundPolicy)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	return mapRefundPolicy(*policy), nil
+}
+
+func mapRefunds(data interface{}) ([]response.Refund, error) {
+	var collectionData = make([]response.Refund, 0)
+	if data == nil {
+		return collectionData, nil
+	}
+
+	refunds, ok := data.(*[]entity.Refund)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *refunds {
+		collectionData = append(collectionData, *mapRefund(value))
+	}
+	return collectionData, nil
+}
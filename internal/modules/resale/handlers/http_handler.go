@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/resale"
+	"ticket-service/internal/modules/resale/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ResaleHttpHandler struct {
+	ResaleUsecaseCommand resale.UsecaseCommand
+	ResaleUsecaseQuery   resale.UsecaseQuery
+	Logger               log.Logger
+	Validator            *validator.Validate
+}
+
+func InitResaleHttpHandler(app *fiber.App, ruc resale.UsecaseCommand, ruq resale.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &ResaleHttpHandler{
+		ResaleUsecaseCommand: ruc,
+		ResaleUsecaseQuery:   ruq,
+		Logger:               log,
+		Validator:            validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/resale")
+
+	route.Get("/v1/events/:eventId/listings", middlewares.VerifyBearer(), handler.GetListings)
+	route.Get("/v1/listings/me", middlewares.VerifyBearer(), handler.GetMyListings)
+	route.Post("/v1/listings", middlewares.VerifyBearer(), handler.CreateListing)
+	route.Post("/v1/listings/:id/cancel", middlewares.VerifyBearer(), handler.CancelListing)
+	route.Post("/v1/listings/:id/buy", middlewares.VerifyBearer(), handler.BuyListing)
+	route.Put("/v1/rules", middlewares.VerifyBearer(), adminRole, handler.UpsertResaleRule)
+}
+
+func (r ResaleHttpHandler) GetListings(c *fiber.Ctx) error {
+	eventId := c.Params("eventId")
+	if eventId == "" {
+		return helpers.RespError(c, r.Logger, errors.BadRequest("eventId is required"))
+	}
+	resp, err := r.ResaleUsecaseQuery.FindListings(c.Context(), eventId)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Get listing success")
+}
+
+func (r ResaleHttpHandler) GetMyListings(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, r.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	resp, err := r.ResaleUsecaseQuery.FindMyListings(c.Context(), userId)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Get my listing success")
+}
+
+func (r ResaleHttpHandler) CreateListing(c *fiber.Ctx) error {
+	req := new(request.ListingReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := r.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, r.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	resp, err := r.ResaleUsecaseCommand.CreateListing(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Create listing success")
+}
+
+func (r ResaleHttpHandler) CancelListing(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, r.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.ListingActionReq{
+		UserId:    userId,
+		ListingId: c.Params("id"),
+	}
+	resp, err := r.ResaleUsecaseCommand.CancelListing(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Cancel listing success")
+}
+
+func (r ResaleHttpHandler) BuyListing(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, r.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.ListingActionReq{
+		UserId:    userId,
+		ListingId: c.Params("id"),
+	}
+	resp, err := r.ResaleUsecaseCommand.BuyListing(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Buy listing success")
+}
+
+func (r ResaleHttpHandler) UpsertResaleRule(c *fiber.Ctx) error {
+	req := new(request.ResaleRuleReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := r.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, r.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := r.ResaleUsecaseCommand.UpsertResaleRule(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, r.Logger, err)
+	}
+	return helpers.RespSuccess(c, r.Logger, resp, "Update resale rule success")
+}
@@ -0,0 +1,58 @@
+package entity
+
+import "time"
+
+// ResaleRule is configured per event, without a rule its tickets cannot be resold.
+// MaxPricePercent caps the price against face value and listings disappear at CutoffAt
+type ResaleRule struct {
+	EventId         string    `json:"eventId" bson:"eventId"`
+	Enabled         bool      `json:"enabled" bson:"enabled"`
+	MaxPricePercent int       `json:"maxPricePercent" bson:"maxPricePercent"`
+	FeePercent      int       `json:"feePercent" bson:"feePercent"`
+	CutoffAt        time.Time `json:"cutoffAt" bson:"cutoffAt"`
+	CreatedAt       time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// PriceCap is the highest price a ticket of faceValue may be listed at
+func (r ResaleRule) PriceCap(faceValue int) int {
+	return faceValue * r.MaxPricePercent / 100
+}
+
+// Listing keeps the fee and payout it was listed with, a later change of the rule does not touch them
+type Listing struct {
+	ListingId      string    `json:"listingId" bson:"listingId"`
+	IssuedTicketId string    `json:"issuedTicketId" bson:"issuedTicketId"`
+	EventId        string    `json:"eventId" bson:"eventId"`
+	TicketId       string    `json:"ticketId" bson:"ticketId"`
+	TicketType     string    `json:"ticketType" bson:"ticketType"`
+	CountryCode    string    `json:"countryCode" bson:"countryCode"`
+	SellerId       string    `json:"sellerId" bson:"sellerId"`
+	QrVersion      int       `json:"qrVersion" bson:"qrVersion"`
+	FaceValue      int       `json:"faceValue" bson:"faceValue"`
+	Price          int       `json:"price" bson:"price"`
+	PlatformFee    int       `json:"platformFee" bson:"platformFee"`
+	SellerPayout   int       `json:"sellerPayout" bson:"sellerPayout"`
+	Status         string    `json:"status" bson:"status"`
+	CutoffAt       time.Time `json:"cutoffAt" bson:"cutoffAt"`
+	BuyerId        string    `json:"buyerId,omitempty" bson:"buyerId,omitempty"`
+	OrderId        string    `json:"orderId,omitempty" bson:"orderId,omitempty"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Sale records what the buyer paid, what the platform keeps and what is owed to the seller
+type Sale struct {
+	SaleId         string    `json:"saleId" bson:"saleId"`
+	ListingId      string    `json:"listingId" bson:"listingId"`
+	OrderId        string    `json:"orderId" bson:"orderId"`
+	IssuedTicketId string    `json:"issuedTicketId" bson:"issuedTicketId"`
+	EventId        string    `json:"eventId" bson:"eventId"`
+	SellerId       string    `json:"sellerId" bson:"sellerId"`
+	BuyerId        string    `json:"buyerId" bson:"buyerId"`
+	Price          int       `json:"price" bson:"price"`
+	PlatformFee    int       `json:"platformFee" bson:"platformFee"`
+	SellerPayout   int       `json:"sellerPayout" bson:"sellerPayout"`
+	PayoutStatus   string    `json:"payoutStatus" bson:"payoutStatus"`
+	SoldAt         time.Time `json:"soldAt" bson:"soldAt"`
+}
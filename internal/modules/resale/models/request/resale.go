@@ -0,0 +1,22 @@
+package request
+
+import "time"
+
+type ResaleRuleReq struct {
+	EventId         string    `json:"eventId" validate:"required"`
+	Enabled         bool      `json:"enabled"`
+	MaxPricePercent int       `json:"maxPricePercent" validate:"required,min=1,max=100"`
+	FeePercent      int       `json:"feePercent" validate:"min=0,max=50"`
+	CutoffAt        time.Time `json:"cutoffAt" validate:"required"`
+}
+
+type ListingReq struct {
+	UserId         string `json:"-"`
+	IssuedTicketId string `json:"issuedTicketId" validate:"required"`
+	Price          int    `json:"price" validate:"required,min=1"`
+}
+
+type ListingActionReq struct {
+	UserId    string `json:"-"`
+	ListingId string `json:"-"`
+}
@@ -0,0 +1,32 @@
+package response
+
+import "time"
+
+type ResaleRule struct {
+	EventId         string    `json:"eventId"`
+	Enabled         bool      `json:"enabled"`
+	MaxPricePercent int       `json:"maxPricePercent"`
+	FeePercent      int       `json:"feePercent"`
+	CutoffAt        time.Time `json:"cutoffAt"`
+}
+
+// Listing is shown to buyers, the fee and payout are only filled in for the seller
+type Listing struct {
+	ListingId    string    `json:"listingId"`
+	EventId      string    `json:"eventId"`
+	TicketType   string    `json:"ticketType"`
+	CountryCode  string    `json:"countryCode"`
+	FaceValue    string    `json:"faceValue"`
+	Price        string    `json:"price"`
+	PlatformFee  string    `json:"platformFee,omitempty"`
+	SellerPayout string    `json:"sellerPayout,omitempty"`
+	Status       string    `json:"status"`
+	CutoffAt     time.Time `json:"cutoffAt"`
+}
+
+type Purchase struct {
+	ListingId string    `json:"listingId"`
+	OrderId   string    `json:"orderId"`
+	Price     string    `json:"price"`
+	ExpiredAt time.Time `json:"expiredAt"`
+}
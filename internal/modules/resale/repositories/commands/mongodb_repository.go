@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/resale"
+	"ticket-service/internal/modules/resale/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) resale.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) UpsertResaleRule(ctx context.Context, rule entity.ResaleRule) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "resale-rules",
+			Filter: bson.M{
+				"eventId": rule.EventId,
+			},
+			Document: rule,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) InsertOneListing(ctx context.Context, listing entity.Listing) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "resale-listings",
+			Document:       listing,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateListingStatus moves a listing from one status to another, Data is nil when it is not in fromStatus anymore
+func (c commandMongodbRepository) UpdateListingStatus(ctx context.Context, listingId string, fromStatus string, toStatus string) <-chan wrapper.Result {
+	var listing entity.Listing
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &listing,
+			CollectionName: "resale-listings",
+			Filter: bson.M{
+				"listingId": listingId,
+				"status":    fromStatus,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    toStatus,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateListingReserved holds a listing for one buyer, Data is nil when it was taken, cancelled or is past its cutoff
+func (c commandMongodbRepository) UpdateListingReserved(ctx context.Context, listingId string, buyerId string, orderId string, now time.Time) <-chan wrapper.Result {
+	var listing entity.Listing
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &listing,
+			CollectionName: "resale-listings",
+			Filter: bson.M{
+				"listingId": listingId,
+				"status":    constants.ListingStatusListed,
+				"cutoffAt":  bson.M{"$gt": now},
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.ListingStatusReserved,
+					"buyerId":   buyerId,
+					"orderId":   orderId,
+					"updatedAt": now,
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateListingReleased puts a listing back on sale when the order holding it did not go through
+func (c commandMongodbRepository) UpdateListingReleased(ctx context.Context, orderId string) <-chan wrapper.Result {
+	var listing entity.Listing
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &listing,
+			CollectionName: "resale-listings",
+			Filter: bson.M{
+				"orderId": orderId,
+				"status":  constants.ListingStatusReserved,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.ListingStatusListed,
+					"updatedAt": time.Now(),
+				},
+				"$unset": bson.M{
+					"buyerId": "",
+					"orderId": "",
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpdateListingSold(ctx context.Context, orderId string) <-chan wrapper.Result {
+	var listing entity.Listing
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &listing,
+			CollectionName: "resale-listings",
+			Filter: bson.M{
+				"orderId": orderId,
+				"status":  constants.ListingStatusReserved,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.ListingStatusSold,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// InitSale records a sale once per listing, a retried completion gets the first record back
+func (c commandMongodbRepository) InitSale(ctx context.Context, sale entity.Sale) <-chan wrapper.Result {
+	var updated entity.Sale
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &updated,
+			CollectionName: "resale-sales",
+			Filter: bson.M{
+				"listingId": sale.ListingId,
+			},
+			Update: bson.M{
+				"$setOnInsert": sale,
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,132 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/resale"
+	"ticket-service/internal/modules/resale/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) resale.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindResaleRuleByEventId(ctx context.Context, eventId string) <-chan wrapper.Result {
+	var rule entity.ResaleRule
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &rule,
+			CollectionName: "resale-rules",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindListingById(ctx context.Context, listingId string) <-chan wrapper.Result {
+	var listing entity.Listing
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &listing,
+			CollectionName: "resale-listings",
+			Filter: bson.M{
+				"listingId": listingId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindListingByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result {
+	var listing entity.Listing
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &listing,
+			CollectionName: "resale-listings",
+			Filter: bson.M{
+				"orderId": orderId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindOpenListingsByEventId finds what buyers can still purchase, listings past their cutoff are left out
+func (q queryMongodbRepository) FindOpenListingsByEventId(ctx context.Context, eventId string, now time.Time) <-chan wrapper.Result {
+	var listings []entity.Listing
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &listings,
+			CollectionName: "resale-listings",
+			Filter: bson.M{
+				"eventId":  eventId,
+				"status":   constants.ListingStatusListed,
+				"cutoffAt": bson.M{"$gt": now},
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "price",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindListingsBySellerId(ctx context.Context, sellerId string) <-chan wrapper.Result {
+	var listings []entity.Listing
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &listings,
+			CollectionName: "resale-listings",
+			Filter: bson.M{
+				"sellerId": sellerId,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "createdAt",
+				By:        mongodb.SortDescending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,42 @@
+package resale
+
+import (
+	"context"
+	"ticket-service/internal/modules/resale/models/entity"
+	"ticket-service/internal/modules/resale/models/request"
+	"ticket-service/internal/modules/resale/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"time"
+)
+
+type UsecaseCommand interface {
+	UpsertResaleRule(origCtx context.Context, payload request.ResaleRuleReq) (*response.ResaleRule, error)
+	CreateListing(origCtx context.Context, payload request.ListingReq) (*response.Listing, error)
+	CancelListing(origCtx context.Context, payload request.ListingActionReq) (*response.Listing, error)
+	BuyListing(origCtx context.Context, payload request.ListingActionReq) (*response.Purchase, error)
+	CompleteSale(origCtx context.Context, orderId string) error
+	ReleaseListing(origCtx context.Context, orderId string) error
+}
+
+type UsecaseQuery interface {
+	FindListings(origCtx context.Context, eventId string) ([]response.Listing, error)
+	FindMyListings(origCtx context.Context, userId string) ([]response.Listing, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindResaleRuleByEventId(ctx context.Context, eventId string) <-chan wrapper.Result
+	FindListingById(ctx context.Context, listingId string) <-chan wrapper.Result
+	FindListingByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result
+	FindOpenListingsByEventId(ctx context.Context, eventId string, now time.Time) <-chan wrapper.Result
+	FindListingsBySellerId(ctx context.Context, sellerId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertResaleRule(ctx context.Context, rule entity.ResaleRule) <-chan wrapper.Result
+	InsertOneListing(ctx context.Context, listing entity.Listing) <-chan wrapper.Result
+	UpdateListingStatus(ctx context.Context, listingId string, fromStatus string, toStatus string) <-chan wrapper.Result
+	UpdateListingReserved(ctx context.Context, listingId string, buyerId string, orderId string, now time.Time) <-chan wrapper.Result
+	UpdateListingReleased(ctx context.Context, orderId string) <-chan wrapper.Result
+	UpdateListingSold(ctx context.Context, orderId string) <-chan wrapper.Result
+	InitSale(ctx context.Context, sale entity.Sale) <-chan wrapper.Result
+}
@@ -0,0 +1,522 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ticket-service/internal/modules/access"
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/order"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/resale"
+	"ticket-service/internal/modules/resale/models/entity"
+	"ticket-service/internal/modules/resale/models/request"
+	"ticket-service/internal/modules/resale/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	resaleRepositoryQuery    resale.MongodbRepositoryQuery
+	resaleRepositoryCommand  resale.MongodbRepositoryCommand
+	eticketRepositoryQuery   eticket.MongodbRepositoryQuery
+	eticketRepositoryCommand eticket.MongodbRepositoryCommand
+	orderRepositoryQuery     order.MongodbRepositoryQuery
+	orderRepositoryCommand   order.MongodbRepositoryCommand
+	accessUsecaseCommand     access.UsecaseCommand
+	kafkaProducer            kafkaConfluent.Producer
+	logger                   log.Logger
+}
+
+func NewCommandUsecase(rmq resale.MongodbRepositoryQuery, rmc resale.MongodbRepositoryCommand, emq eticket.MongodbRepositoryQuery,
+	emc eticket.MongodbRepositoryCommand, omq order.MongodbRepositoryQuery, omc order.MongodbRepositoryCommand,
+	auc access.UsecaseCommand, kp kafkaConfluent.Producer, log log.Logger) resale.UsecaseCommand {
+	return commandUsecase{
+		resaleRepositoryQuery:    rmq,
+		resaleRepositoryCommand:  rmc,
+		eticketRepositoryQuery:   emq,
+		eticketRepositoryCommand: emc,
+		orderRepositoryQuery:     omq,
+		orderRepositoryCommand:   omc,
+		accessUsecaseCommand:     auc,
+		kafkaProducer:            kp,
+		logger:                   log,
+	}
+}
+
+func (c commandUsecase) UpsertResaleRule(origCtx context.Context, payload request.ResaleRuleReq) (*response.ResaleRule, error) {
+	domain := "resaleUsecase-UpsertResaleRule"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	rule := entity.ResaleRule{
+		EventId:         payload.EventId,
+		Enabled:         payload.Enabled,
+		MaxPricePercent: payload.MaxPricePercent,
+		FeePercent:      payload.FeePercent,
+		CutoffAt:        payload.CutoffAt,
+		UpdatedAt:       time.Now(),
+	}
+	resp := <-c.resaleRepositoryCommand.UpsertResaleRule(ctx, rule)
+	if resp.Error != nil {
+		msg := "Error upsert resale rule"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapResaleRule(rule), nil
+}
+
+// CreateListing takes the ticket out of use while it is on sale, its qr code cannot be shown or scanned until
+// the listing is cancelled or the ticket moves to its buyer
+func (c commandUsecase) CreateListing(origCtx context.Context, payload request.ListingReq) (*response.Listing, error) {
+	domain := "resaleUsecase-CreateListing"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	issuedTicket, err := c.findIssuedTicket(ctx, payload.IssuedTicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuedTicket.UserId != payload.UserId {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	if issuedTicket.Status != constants.IssuedTicketStatusActive {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("ticket is %s and cannot be listed", issuedTicket.Status))
+	}
+
+	rule, err := c.findResaleRule(ctx, issuedTicket.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !now.Before(rule.CutoffAt) {
+		return nil, errors.UnprocessableEntity("resale for this event has closed")
+	}
+
+	orderDetail, err := c.findOrder(ctx, issuedTicket.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	faceValue := orderDetail.TicketPrice
+	if priceCap := rule.PriceCap(faceValue); payload.Price > priceCap {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("price cannot be higher than $%d, %d%% of the face value",
+			priceCap, rule.MaxPricePercent))
+	}
+
+	listed := <-c.eticketRepositoryCommand.UpdateIssuedTicketStatus(ctx, eticketEntity.IssuedTicket{
+		IssuedTicketId: issuedTicket.IssuedTicketId,
+		UserId:         issuedTicket.UserId,
+		QrVersion:      issuedTicket.QrVersion,
+		Status:         constants.IssuedTicketStatusListed,
+	}, constants.IssuedTicketStatusActive)
+	if listed.Error != nil {
+		msg := "Error update ticket listed"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", listed.Error))
+		return nil, listed.Error
+	}
+
+	if listed.Data == nil {
+		return nil, errors.Conflict("ticket has changed, please try again")
+	}
+
+	platformFee := payload.Price * rule.FeePercent / 100
+	listing := entity.Listing{
+		ListingId:      uuid.NewString(),
+		IssuedTicketId: issuedTicket.IssuedTicketId,
+		EventId:        issuedTicket.EventId,
+		TicketId:       issuedTicket.TicketId,
+		TicketType:     issuedTicket.TicketType,
+		CountryCode:    issuedTicket.CountryCode,
+		SellerId:       issuedTicket.UserId,
+		QrVersion:      issuedTicket.QrVersion,
+		FaceValue:      faceValue,
+		Price:          payload.Price,
+		PlatformFee:    platformFee,
+		SellerPayout:   payload.Price - platformFee,
+		Status:         constants.ListingStatusListed,
+		CutoffAt:       rule.CutoffAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	insert := <-c.resaleRepositoryCommand.InsertOneListing(ctx, listing)
+	if insert.Error != nil {
+		msg := "Error insert listing"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		c.unlistTicket(ctx, listing)
+		return nil, insert.Error
+	}
+
+	return mapListing(listing, true), nil
+}
+
+func (c commandUsecase) CancelListing(origCtx context.Context, payload request.ListingActionReq) (*response.Listing, error) {
+	domain := "resaleUsecase-CancelListing"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	listing, err := c.findListing(ctx, payload.ListingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if listing.SellerId != payload.UserId {
+		return nil, errors.NotFound("listing not found")
+	}
+
+	cancelled := <-c.resaleRepositoryCommand.UpdateListingStatus(ctx, listing.ListingId, constants.ListingStatusListed,
+		constants.ListingStatusCancelled)
+	if cancelled.Error != nil {
+		msg := "Error cancel listing"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", cancelled.Error))
+		return nil, cancelled.Error
+	}
+
+	if cancelled.Data == nil {
+		return nil, errors.UnprocessableEntity("listing is no longer on sale")
+	}
+
+	c.unlistTicket(ctx, *listing)
+
+	listing.Status = constants.ListingStatusCancelled
+	return mapListing(*listing, true), nil
+}
+
+// BuyListing holds the listing for the buyer behind a pending order at the listed price, the ticket only changes
+// hands once that order is paid. The order does not touch the inventory of the event
+func (c commandUsecase) BuyListing(origCtx context.Context, payload request.ListingActionReq) (*response.Purchase, error) {
+	domain := "resaleUsecase-BuyListing"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	listing, err := c.findListing(ctx, payload.ListingId)
+	if err != nil {
+		return nil, err
+	}
+
+	if listing.SellerId == payload.UserId {
+		return nil, errors.BadRequest("cannot buy your own listing")
+	}
+
+	now := time.Now()
+	if !now.Before(listing.CutoffAt) {
+		return nil, errors.UnprocessableEntity("resale for this event has closed")
+	}
+
+	orderId := uuid.NewString()
+	reserved := <-c.resaleRepositoryCommand.UpdateListingReserved(ctx, listing.ListingId, payload.UserId, orderId, now)
+	if reserved.Error != nil {
+		msg := "Error reserve listing"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", reserved.Error))
+		return nil, reserved.Error
+	}
+
+	if reserved.Data == nil {
+		return nil, errors.Conflict("listing is no longer on sale")
+	}
+
+	orderData := orderEntity.Order{
+		OrderId:         orderId,
+		UserId:          payload.UserId,
+		TicketId:        listing.TicketId,
+		EventId:         listing.EventId,
+		TicketType:      listing.TicketType,
+		CountryCode:     listing.CountryCode,
+		Quantity:        1,
+		TicketPrice:     listing.Price,
+		SubtotalPrice:   listing.Price,
+		TotalPrice:      listing.Price,
+		ResaleListingId: listing.ListingId,
+		Status:          constants.OrderStatusPending,
+		ExpiredAt:       now.Add(constants.OrderHoldDuration),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	insert := <-c.orderRepositoryCommand.InsertOneOrder(ctx, orderData)
+	if insert.Error != nil {
+		msg := "Error insert resale order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		<-c.resaleRepositoryCommand.UpdateListingReleased(ctx, orderId)
+		return nil, insert.Error
+	}
+
+	return &response.Purchase{
+		ListingId: listing.ListingId,
+		OrderId:   orderId,
+		Price:     fmt.Sprintf("$%d", listing.Price),
+		ExpiredAt: orderData.ExpiredAt,
+	}, nil
+}
+
+// CompleteSale moves the ticket to the buyer of a paid resale order and records what is owed to the seller.
+// Every step is conditional so it can be called again for the same order
+func (c commandUsecase) CompleteSale(origCtx context.Context, orderId string) error {
+	domain := "resaleUsecase-CompleteSale"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	listing, err := c.findListingByOrderId(ctx, orderId)
+	if err != nil {
+		return err
+	}
+
+	switch listing.Status {
+	case constants.ListingStatusSold:
+		return nil
+	case constants.ListingStatusReserved:
+	default:
+		return errors.Conflict(fmt.Sprintf("listing is %s and cannot be sold", listing.Status))
+	}
+
+	now := time.Now()
+	resold := <-c.eticketRepositoryCommand.UpdateIssuedTicketResold(ctx, eticketEntity.IssuedTicket{
+		IssuedTicketId: listing.IssuedTicketId,
+		UserId:         listing.SellerId,
+		QrVersion:      listing.QrVersion,
+	}, eticketEntity.Ownership{
+		UserId:      listing.BuyerId,
+		Via:         constants.OwnershipViaResale,
+		ReferenceId: listing.OrderId,
+		AcquiredAt:  now,
+	})
+	if resold.Error != nil {
+		msg := "Error update ticket resold"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resold.Error))
+		return resold.Error
+	}
+
+	// nothing matched either because an earlier attempt already moved the ticket or because it changed underneath the listing
+	if resold.Data == nil {
+		issuedTicket, err := c.findIssuedTicket(ctx, listing.IssuedTicketId)
+		if err != nil {
+			return err
+		}
+		if issuedTicket.UserId != listing.BuyerId {
+			return errors.Conflict("ticket has changed since it was listed")
+		}
+	}
+
+	// the buyer gets a pass signed for the bumped qr version, the seller's one stops working
+	if listing.TicketType == constants.TicketTypeOnline {
+		if _, err := c.accessUsecaseCommand.IssuePass(ctx, listing.IssuedTicketId); err != nil {
+			msg := "Error issue access pass"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		}
+	}
+
+	sale := entity.Sale{
+		SaleId:         uuid.NewString(),
+		ListingId:      listing.ListingId,
+		OrderId:        listing.OrderId,
+		IssuedTicketId: listing.IssuedTicketId,
+		EventId:        listing.EventId,
+		SellerId:       listing.SellerId,
+		BuyerId:        listing.BuyerId,
+		Price:          listing.Price,
+		PlatformFee:    listing.PlatformFee,
+		SellerPayout:   listing.SellerPayout,
+		PayoutStatus:   constants.PayoutStatusPending,
+		SoldAt:         now,
+	}
+	saleData := <-c.resaleRepositoryCommand.InitSale(ctx, sale)
+	if saleData.Error != nil {
+		msg := "Error init sale"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", saleData.Error))
+		return saleData.Error
+	}
+
+	sold := <-c.resaleRepositoryCommand.UpdateListingSold(ctx, orderId)
+	if sold.Error != nil {
+		msg := "Error update listing sold"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", sold.Error))
+		return sold.Error
+	}
+
+	if recorded, ok := saleData.Data.(*entity.Sale); ok {
+		sale = *recorded
+	}
+	marshaledKafkaData, _ := json.Marshal(sale)
+	topic := "concert-resale-sold"
+	c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka resale sold, listing : %s", sale.ListingId), fmt.Sprintf("%+v", sale))
+
+	return nil
+}
+
+// ReleaseListing puts the listing held by an unpaid order back on sale
+func (c commandUsecase) ReleaseListing(origCtx context.Context, orderId string) error {
+	domain := "resaleUsecase-ReleaseListing"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	released := <-c.resaleRepositoryCommand.UpdateListingReleased(ctx, orderId)
+	if released.Error != nil {
+		msg := "Error release listing"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", released.Error))
+		return released.Error
+	}
+
+	return nil
+}
+
+func (c commandUsecase) unlistTicket(ctx context.Context, listing entity.Listing) {
+	resp := <-c.eticketRepositoryCommand.UpdateIssuedTicketStatus(ctx, eticketEntity.IssuedTicket{
+		IssuedTicketId: listing.IssuedTicketId,
+		UserId:         listing.SellerId,
+		QrVersion:      listing.QrVersion,
+		Status:         constants.IssuedTicketStatusActive,
+	}, constants.IssuedTicketStatusListed)
+	if resp.Error != nil || resp.Data == nil {
+		msg := "Error unlist ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", listing))
+	}
+}
+
+func (c commandUsecase) findIssuedTicket(ctx context.Context, issuedTicketId string) (*eticketEntity.IssuedTicket, error) {
+	resp := <-c.eticketRepositoryQuery.FindIssuedTicketById(ctx, issuedTicketId)
+	if resp.Error != nil {
+		msg := "Error query issued ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	issuedTicket, ok := resp.Data.(*eticketEntity.IssuedTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return issuedTicket, nil
+}
+
+func (c commandUsecase) findOrder(ctx context.Context, orderId string) (*orderEntity.Order, error) {
+	resp := <-c.orderRepositoryQuery.FindOrderById(ctx, orderId)
+	if resp.Error != nil {
+		msg := "Error query order"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("order not found")
+	}
+
+	orderDetail, ok := resp.Data.(*orderEntity.Order)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return orderDetail, nil
+}
+
+func (c commandUsecase) findResaleRule(ctx context.Context, eventId string) (*entity.ResaleRule, error) {
+	resp := <-c.resaleRepositoryQuery.FindResaleRuleByEventId(ctx, eventId)
+	if resp.Error != nil {
+		msg := "Error query resale rule"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	// unlike transfers, events without configuration do not allow resale
+	if resp.Data == nil {
+		return nil, errors.UnprocessableEntity("resale is not available for this event")
+	}
+
+	rule, ok := resp.Data.(*entity.ResaleRule)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if !rule.Enabled {
+		return nil, errors.UnprocessableEntity("resale is not available for this event")
+	}
+	return rule, nil
+}
+
+func (c commandUsecase) findListing(ctx context.Context, listingId string) (*entity.Listing, error) {
+	resp := <-c.resaleRepositoryQuery.FindListingById(ctx, listingId)
+	return c.parseListing(ctx, resp.Data, resp.Error)
+}
+
+func (c commandUsecase) findListingByOrderId(ctx context.Context, orderId string) (*entity.Listing, error) {
+	resp := <-c.resaleRepositoryQuery.FindListingByOrderId(ctx, orderId)
+	return c.parseListing(ctx, resp.Data, resp.Error)
+}
+
+func (c commandUsecase) parseListing(ctx context.Context, data interface{}, err error) (*entity.Listing, error) {
+	if err != nil {
+		msg := "Error query listing"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	if data == nil {
+		return nil, errors.NotFound("listing not found")
+	}
+
+	listing, ok := data.(*entity.Listing)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return listing, nil
+}
+
+func mapResaleRule(rule entity.ResaleRule) *response.ResaleRule {
+	return &response.ResaleRule{
+		EventId:         rule.EventId,
+		Enabled:         rule.Enabled,
+		MaxPricePercent: rule.MaxPricePercent,
+		FeePercent:      rule.FeePercent,
+		CutoffAt:        rule.CutoffAt,
+	}
+}
+
+// mapListing leaves the fee and payout out unless the listing is shown to its seller
+func mapListing(listing entity.Listing, seller bool) *response.Listing {
+	result := &response.Listing{
+		ListingId:   listing.ListingId,
+		EventId:     listing.EventId,
+		TicketType:  listing.TicketType,
+		CountryCode: listing.CountryCode,
+		FaceValue:   fmt.Sprintf("$%d", listing.FaceValue),
+		Price:       fmt.Sprintf("$%d", listing.Price),
+		Status:      listing.Status,
+		CutoffAt:    listing.CutoffAt,
+	}
+	if seller {
+		result.PlatformFee = fmt.Sprintf("$%d", listing.PlatformFee)
+		result.SellerPayout = fmt.Sprintf("$%d", listing.SellerPayout)
+	}
+	return result
+}
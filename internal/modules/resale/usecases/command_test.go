@@ -0,0 +1,371 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/resale"
+	"ticket-service/internal/modules/resale/models/entity"
+	"ticket-service/internal/modules/resale/models/request"
+	uc "ticket-service/internal/modules/resale/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockaccess "ticket-service/mocks/modules/access"
+	mocketicket "ticket-service/mocks/modules/eticket"
+	mockorder "ticket-service/mocks/modules/order"
+	mockresale "ticket-service/mocks/modules/resale"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockResaleRepositoryQuery    *mockresale.MongodbRepositoryQuery
+	mockResaleRepositoryCommand  *mockresale.MongodbRepositoryCommand
+	mockEticketRepositoryQuery   *mocketicket.MongodbRepositoryQuery
+	mockEticketRepositoryCommand *mocketicket.MongodbRepositoryCommand
+	mockOrderRepositoryQuery     *mockorder.MongodbRepositoryQuery
+	mockOrderRepositoryCommand   *mockorder.MongodbRepositoryCommand
+	mockAccessUsecaseCommand     *mockaccess.UsecaseCommand
+	mockKafkaProducer            *mockkafka.Producer
+	mockLogger                   *mocklog.Logger
+	usecase                      resale.UsecaseCommand
+	ctx                          context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockResaleRepositoryQuery = &mockresale.MongodbRepositoryQuery{}
+	suite.mockResaleRepositoryCommand = &mockresale.MongodbRepositoryCommand{}
+	suite.mockEticketRepositoryQuery = &mocketicket.MongodbRepositoryQuery{}
+	suite.mockEticketRepositoryCommand = &mocketicket.MongodbRepositoryCommand{}
+	suite.mockOrderRepositoryQuery = &mockorder.MongodbRepositoryQuery{}
+	suite.mockOrderRepositoryCommand = &mockorder.MongodbRepositoryCommand{}
+	suite.mockAccessUsecaseCommand = &mockaccess.UsecaseCommand{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockResaleRepositoryQuery,
+		suite.mockResaleRepositoryCommand,
+		suite.mockEticketRepositoryQuery,
+		suite.mockEticketRepositoryCommand,
+		suite.mockOrderRepositoryQuery,
+		suite.mockOrderRepositoryCommand,
+		suite.mockAccessUsecaseCommand,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+	suite.mockKafkaProducer.On("Publish", "concert-resale-sold", mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateListing() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("seller-id")}))
+	suite.mockResaleRepositoryQuery.On("FindResaleRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: getMockResaleRule()}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").
+		Return(mockChannel(helpers.Result{Data: &orderEntity.Order{OrderId: "order-id", TicketPrice: 100}}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketStatus", mock.Anything, mock.MatchedBy(func(t eticketEntity.IssuedTicket) bool {
+		return t.UserId == "seller-id" && t.Status == constants.IssuedTicketStatusListed
+	}), constants.IssuedTicketStatusActive).Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("seller-id")}))
+	suite.mockResaleRepositoryCommand.On("InsertOneListing", mock.Anything, mock.MatchedBy(func(l entity.Listing) bool {
+		return l.Price == 90 && l.FaceValue == 100 && l.PlatformFee == 9 && l.SellerPayout == 81 && l.QrVersion == 1
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateListing(suite.ctx, request.ListingReq{UserId: "seller-id", IssuedTicketId: "issued-id", Price: 90})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.ListingStatusListed, result.Status)
+	assert.Equal(suite.T(), "$9", result.PlatformFee)
+	assert.Equal(suite.T(), "$81", result.SellerPayout)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateListingErrAboveCap() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("seller-id")}))
+	suite.mockResaleRepositoryQuery.On("FindResaleRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: getMockResaleRule()}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").
+		Return(mockChannel(helpers.Result{Data: &orderEntity.Order{OrderId: "order-id", TicketPrice: 100}}))
+
+	// Act
+	_, err := suite.usecase.CreateListing(suite.ctx, request.ListingReq{UserId: "seller-id", IssuedTicketId: "issued-id", Price: 101})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("price cannot be higher than $100, 100% of the face value"), err)
+	suite.mockEticketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateIssuedTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateListingErrAfterCutoff() {
+	// Arrange
+	rule := getMockResaleRule()
+	rule.CutoffAt = time.Now().Add(-time.Minute)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("seller-id")}))
+	suite.mockResaleRepositoryQuery.On("FindResaleRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: rule}))
+
+	// Act
+	_, err := suite.usecase.CreateListing(suite.ctx, request.ListingReq{UserId: "seller-id", IssuedTicketId: "issued-id", Price: 100})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("resale for this event has closed"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateListingErrNoRule() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("seller-id")}))
+	suite.mockResaleRepositoryQuery.On("FindResaleRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.CreateListing(suite.ctx, request.ListingReq{UserId: "seller-id", IssuedTicketId: "issued-id", Price: 100})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("resale is not available for this event"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelListing() {
+	// Arrange
+	suite.mockResaleRepositoryQuery.On("FindListingById", mock.Anything, "listing-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusListed)}))
+	suite.mockResaleRepositoryCommand.On("UpdateListingStatus", mock.Anything, "listing-id", constants.ListingStatusListed,
+		constants.ListingStatusCancelled).Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusCancelled)}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketStatus", mock.Anything, mock.MatchedBy(func(t eticketEntity.IssuedTicket) bool {
+		return t.UserId == "seller-id" && t.Status == constants.IssuedTicketStatusActive
+	}), constants.IssuedTicketStatusListed).Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("seller-id")}))
+
+	// Act
+	result, err := suite.usecase.CancelListing(suite.ctx, request.ListingActionReq{UserId: "seller-id", ListingId: "listing-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.ListingStatusCancelled, result.Status)
+	suite.mockEticketRepositoryCommand.AssertExpectations(suite.T())
+}
+
+func (suite *CommandUsecaseTestSuite) TestBuyListing() {
+	// Arrange
+	suite.mockResaleRepositoryQuery.On("FindListingById", mock.Anything, "listing-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusListed)}))
+	suite.mockResaleRepositoryCommand.On("UpdateListingReserved", mock.Anything, "listing-id", "buyer-id", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusReserved)}))
+	suite.mockOrderRepositoryCommand.On("InsertOneOrder", mock.Anything, mock.MatchedBy(func(o orderEntity.Order) bool {
+		return o.UserId == "buyer-id" && o.ResaleListingId == "listing-id" && o.Quantity == 1 && o.TotalPrice == 90 &&
+			o.Status == constants.OrderStatusPending
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.BuyListing(suite.ctx, request.ListingActionReq{UserId: "buyer-id", ListingId: "listing-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$90", result.Price)
+	assert.NotEmpty(suite.T(), result.OrderId)
+}
+
+func (suite *CommandUsecaseTestSuite) TestBuyListingErrTaken() {
+	// Arrange
+	suite.mockResaleRepositoryQuery.On("FindListingById", mock.Anything, "listing-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusListed)}))
+	suite.mockResaleRepositoryCommand.On("UpdateListingReserved", mock.Anything, "listing-id", "buyer-id", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.BuyListing(suite.ctx, request.ListingActionReq{UserId: "buyer-id", ListingId: "listing-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("listing is no longer on sale"), err)
+	suite.mockOrderRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneOrder", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestBuyListingErrOwnListing() {
+	// Arrange
+	suite.mockResaleRepositoryQuery.On("FindListingById", mock.Anything, "listing-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusListed)}))
+
+	// Act
+	_, err := suite.usecase.BuyListing(suite.ctx, request.ListingActionReq{UserId: "seller-id", ListingId: "listing-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.BadRequest("cannot buy your own listing"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCompleteSale() {
+	// Arrange
+	suite.mockResaleRepositoryQuery.On("FindListingByOrderId", mock.Anything, "resale-order-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusReserved)}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketResold", mock.Anything, mock.MatchedBy(func(t eticketEntity.IssuedTicket) bool {
+		return t.UserId == "seller-id" && t.QrVersion == 1
+	}), mock.MatchedBy(func(o eticketEntity.Ownership) bool {
+		return o.UserId == "buyer-id" && o.Via == constants.OwnershipViaResale
+	})).Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("buyer-id")}))
+	suite.mockResaleRepositoryCommand.On("InitSale", mock.Anything, mock.MatchedBy(func(s entity.Sale) bool {
+		return s.PlatformFee == 9 && s.SellerPayout == 81 && s.PayoutStatus == constants.PayoutStatusPending
+	})).Return(mockChannel(helpers.Result{Data: &entity.Sale{ListingId: "listing-id"}}))
+	suite.mockResaleRepositoryCommand.On("UpdateListingSold", mock.Anything, "resale-order-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusSold)}))
+
+	// Act
+	err := suite.usecase.CompleteSale(suite.ctx, "resale-order-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-resale-sold", mock.Anything, mock.Anything)
+	suite.mockAccessUsecaseCommand.AssertNotCalled(suite.T(), "IssuePass", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCompleteSaleOnlineSignsPass() {
+	// Arrange
+	listing := getMockListing(constants.ListingStatusReserved)
+	listing.TicketType = constants.TicketTypeOnline
+	suite.mockResaleRepositoryQuery.On("FindListingByOrderId", mock.Anything, "resale-order-id").
+		Return(mockChannel(helpers.Result{Data: listing}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketResold", mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("buyer-id")}))
+	suite.mockAccessUsecaseCommand.On("IssuePass", mock.Anything, "issued-id").Return(nil, nil)
+	suite.mockResaleRepositoryCommand.On("InitSale", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &entity.Sale{ListingId: "listing-id"}}))
+	suite.mockResaleRepositoryCommand.On("UpdateListingSold", mock.Anything, "resale-order-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusSold)}))
+
+	// Act
+	err := suite.usecase.CompleteSale(suite.ctx, "resale-order-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockAccessUsecaseCommand.AssertCalled(suite.T(), "IssuePass", mock.Anything, "issued-id")
+}
+
+func (suite *CommandUsecaseTestSuite) TestCompleteSaleRetried() {
+	// Arrange
+	suite.mockResaleRepositoryQuery.On("FindListingByOrderId", mock.Anything, "resale-order-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusReserved)}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketResold", mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("buyer-id")}))
+	suite.mockResaleRepositoryCommand.On("InitSale", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &entity.Sale{ListingId: "listing-id"}}))
+	suite.mockResaleRepositoryCommand.On("UpdateListingSold", mock.Anything, "resale-order-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusSold)}))
+
+	// Act
+	err := suite.usecase.CompleteSale(suite.ctx, "resale-order-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockResaleRepositoryCommand.AssertCalled(suite.T(), "UpdateListingSold", mock.Anything, "resale-order-id")
+}
+
+func (suite *CommandUsecaseTestSuite) TestCompleteSaleErrTicketChanged() {
+	// Arrange
+	suite.mockResaleRepositoryQuery.On("FindListingByOrderId", mock.Anything, "resale-order-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusReserved)}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketResold", mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("seller-id")}))
+
+	// Act
+	err := suite.usecase.CompleteSale(suite.ctx, "resale-order-id")
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("ticket has changed since it was listed"), err)
+	suite.mockResaleRepositoryCommand.AssertNotCalled(suite.T(), "InitSale", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCompleteSaleAlreadySold() {
+	// Arrange
+	suite.mockResaleRepositoryQuery.On("FindListingByOrderId", mock.Anything, "resale-order-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusSold)}))
+
+	// Act
+	err := suite.usecase.CompleteSale(suite.ctx, "resale-order-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockEticketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateIssuedTicketResold", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReleaseListing() {
+	// Arrange
+	suite.mockResaleRepositoryCommand.On("UpdateListingReleased", mock.Anything, "resale-order-id").
+		Return(mockChannel(helpers.Result{Data: getMockListing(constants.ListingStatusListed)}))
+
+	// Act
+	err := suite.usecase.ReleaseListing(suite.ctx, "resale-order-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+}
+
+func getMockIssuedTicket(userId string) *eticketEntity.IssuedTicket {
+	return &eticketEntity.IssuedTicket{
+		IssuedTicketId: "issued-id",
+		OrderId:        "order-id",
+		UserId:         userId,
+		EventId:        "event-id",
+		TicketType:     "Gold",
+		Status:         constants.IssuedTicketStatusActive,
+		QrVersion:      1,
+	}
+}
+
+func getMockResaleRule() *entity.ResaleRule {
+	return &entity.ResaleRule{
+		EventId:         "event-id",
+		Enabled:         true,
+		MaxPricePercent: 100,
+		FeePercent:      10,
+		CutoffAt:        time.Now().Add(24 * time.Hour),
+	}
+}
+
+func getMockListing(status string) *entity.Listing {
+	return &entity.Listing{
+		ListingId:      "listing-id",
+		IssuedTicketId: "issued-id",
+		EventId:        "event-id",
+		TicketType:     "Gold",
+		SellerId:       "seller-id",
+		QrVersion:      1,
+		FaceValue:      100,
+		Price:          90,
+		PlatformFee:    9,
+		SellerPayout:   81,
+		Status:         status,
+		CutoffAt:       time.Now().Add(24 * time.Hour),
+		BuyerId:        "buyer-id",
+		OrderId:        "resale-order-id",
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
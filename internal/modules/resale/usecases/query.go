@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/resale"
+	"ticket-service/internal/modules/resale/models/entity"
+	"ticket-service/internal/modules/resale/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	resaleRepositoryQuery resale.MongodbRepositoryQuery
+	logger                log.Logger
+}
+
+func NewQueryUsecase(rmq resale.MongodbRepositoryQuery, log log.Logger) resale.UsecaseQuery {
+	return queryUsecase{
+		resaleRepositoryQuery: rmq,
+		logger:                log,
+	}
+}
+
+func (q queryUsecase) FindListings(origCtx context.Context, eventId string) ([]response.Listing, error) {
+	domain := "resaleUsecase-FindListings"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.resaleRepositoryQuery.FindOpenListingsByEventId(ctx, eventId, time.Now())
+	if resp.Error != nil {
+		msg := "Error query listing"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapListings(resp.Data, false)
+}
+
+func (q queryUsecase) FindMyListings(origCtx context.Context, userId string) ([]response.Listing, error) {
+	domain := "resaleUsecase-FindMyListings"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.resaleRepositoryQuery.FindListingsBySellerId(ctx, userId)
+	if resp.Error != nil {
+		msg := "Error query listing"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapListings(resp.Data, true)
+}
+
+func mapListings(data interface{}, seller bool) ([]response.Listing, error) {
+	var collectionData = make([]response.Listing, 0)
+	if data == nil {
+		return collectionData, nil
+	}
+
+	listings, ok := data.(*[]entity.Listing)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *listings {
+		collectionData = append(collectionData, *mapListing(value, seller))
+	}
+	return collectionData, nil
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/seat"
+	"ticket-service/internal/modules/seat/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type SeatHttpHandler struct {
+	SeatUsecaseCommand seat.UsecaseCommand
+	SeatUsecaseQuery   seat.UsecaseQuery
+	Logger             log.Logger
+	Validator          *validator.Validate
+}
+
+func InitSeatHttpHandler(app *fiber.App, suc seat.UsecaseCommand, suq seat.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &SeatHttpHandler{
+		SeatUsecaseCommand: suc,
+		SeatUsecaseQuery:   suq,
+		Logger:             log,
+		Validator:          validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/seats")
+
+	route.Put("/v1/maps", middlewares.VerifyBearer(), adminRole, handler.UpsertSeatMap)
+	route.Get("/v1/availability", middlewares.VerifyBearer(), handler.GetAvailability)
+}
+
+func (s SeatHttpHandler) UpsertSeatMap(c *fiber.Ctx) error {
+	req := new(request.SeatMapReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, s.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := s.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, s.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := s.SeatUsecaseCommand.UpsertSeatMap(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, s.Logger, err)
+	}
+	return helpers.RespSuccess(c, s.Logger, resp, "Update seat map success")
+}
+
+func (s SeatHttpHandler) GetAvailability(c *fiber.Ctx) error {
+	req := new(request.AvailabilityReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, s.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := s.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, s.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := s.SeatUsecaseQuery.FindAvailability(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, s.Logger, err)
+	}
+	return helpers.RespSuccess(c, s.Logger, resp, "Get seat availability success")
+}
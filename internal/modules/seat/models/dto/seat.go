@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// HoldReq holds the given SeatIds, or the best Quantity adjacent seats of the tier when none are given
+type HoldReq struct {
+	EventId    string
+	TicketType string
+	OrderId    string
+	SeatIds    []string
+	Quantity   int
+	HeldUntil  time.Time
+}
+
+type Seat struct {
+	SeatId  string
+	Section string
+	Row     string
+	Number  int
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"ticket-service/internal/pkg/constants"
+	"time"
+)
+
+// SeatMap is the layout of the venue of one event, sections and rows are listed from the best to the worst
+type SeatMap struct {
+	EventId   string    `json:"eventId" bson:"eventId"`
+	VenueName string    `json:"venueName" bson:"venueName"`
+	Sections  []Section `json:"sections" bson:"sections"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+type Section struct {
+	Name string `json:"name" bson:"name"`
+	Rows []Row  `json:"rows" bson:"rows"`
+}
+
+// Row holds seats numbered from 1 to Seats, all of them sold as TicketType
+type Row struct {
+	Name       string `json:"name" bson:"name"`
+	TicketType string `json:"ticketType" bson:"ticketType"`
+	Seats      int    `json:"seats" bson:"seats"`
+}
+
+// Seat is one sellable seat, SectionRank and RowRank keep the order of the seat map so the best seats sort first
+type Seat struct {
+	SeatId      string    `json:"seatId" bson:"seatId"`
+	EventId     string    `json:"eventId" bson:"eventId"`
+	TicketType  string    `json:"ticketType" bson:"ticketType"`
+	Section     string    `json:"section" bson:"section"`
+	Row         string    `json:"row" bson:"row"`
+	Number      int       `json:"number" bson:"number"`
+	SectionRank int       `json:"sectionRank" bson:"sectionRank"`
+	RowRank     int       `json:"rowRank" bson:"rowRank"`
+	Status      string    `json:"status" bson:"status"`
+	OrderId     string    `json:"orderId,omitempty" bson:"orderId,omitempty"`
+	HeldUntil   time.Time `json:"heldUntil,omitempty" bson:"heldUntil,omitempty"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// IsAvailable treats a hold past HeldUntil as released, the reservation holding it has expired
+func (s Seat) IsAvailable(now time.Time) bool {
+	switch s.Status {
+	case constants.SeatStatusAvailable:
+		return true
+	case constants.SeatStatusHeld:
+		return !now.Before(s.HeldUntil)
+	}
+	return false
+}
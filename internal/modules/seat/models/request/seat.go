@@ -0,0 +1,23 @@
+package request
+
+type SeatMapReq struct {
+	EventId   string       `json:"eventId" validate:"required"`
+	VenueName string       `json:"venueName" validate:"required"`
+	Sections  []SectionReq `json:"sections" validate:"required,min=1,max=100,dive"`
+}
+
+type SectionReq struct {
+	Name string   `json:"name" validate:"required"`
+	Rows []RowReq `json:"rows" validate:"required,min=1,max=200,dive"`
+}
+
+type RowReq struct {
+	Name       string `json:"name" validate:"required"`
+	TicketType string `json:"ticketType" validate:"required"`
+	Seats      int    `json:"seats" validate:"required,min=1,max=500"`
+}
+
+type AvailabilityReq struct {
+	EventId    string `json:"eventId" validate:"required"`
+	TicketType string `json:"ticketType"`
+}
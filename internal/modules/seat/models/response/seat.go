@@ -0,0 +1,32 @@
+package response
+
+type SeatMap struct {
+	EventId    string `json:"eventId"`
+	VenueName  string `json:"venueName"`
+	TotalSeats int    `json:"totalSeats"`
+}
+
+// Availability never tells who holds a seat, only whether it can still be picked
+type Availability struct {
+	EventId        string    `json:"eventId"`
+	VenueName      string    `json:"venueName"`
+	TotalAvailable int       `json:"totalAvailable"`
+	Sections       []Section `json:"sections"`
+}
+
+type Section struct {
+	Name string `json:"name"`
+	Rows []Row  `json:"rows"`
+}
+
+type Row struct {
+	Name       string `json:"name"`
+	TicketType string `json:"ticketType"`
+	Seats      []Seat `json:"seats"`
+}
+
+type Seat struct {
+	SeatId    string `json:"seatId"`
+	Number    int    `json:"number"`
+	Available bool   `json:"available"`
+}
@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/seat"
+	"ticket-service/internal/modules/seat/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) seat.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) UpsertSeatMap(ctx context.Context, seatMap entity.SeatMap) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "seat-maps",
+			Filter: bson.M{
+				"eventId": seatMap.EventId,
+			},
+			Document: seatMap,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpsertSeat updates the layout of a seat and leaves its status alone, so remapping never frees a held or sold seat
+func (c commandMongodbRepository) UpsertSeat(ctx context.Context, payload entity.Seat) <-chan wrapper.Result {
+	var seat entity.Seat
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &seat,
+			CollectionName: "seats",
+			Filter: bson.M{
+				"seatId": payload.SeatId,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"eventId":     payload.EventId,
+					"ticketType":  payload.TicketType,
+					"section":     payload.Section,
+					"row":         payload.Row,
+					"number":      payload.Number,
+					"sectionRank": payload.SectionRank,
+					"rowRank":     payload.RowRank,
+					"updatedAt":   payload.UpdatedAt,
+				},
+				"$setOnInsert": bson.M{
+					"status":    constants.SeatStatusAvailable,
+					"createdAt": payload.CreatedAt,
+				},
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateSeatStatus moves a seat nobody holds, Data is nil when the seat is not in fromStatus
+func (c commandMongodbRepository) UpdateSeatStatus(ctx context.Context, seatId string, fromStatus string, toStatus string) <-chan wrapper.Result {
+	var seat entity.Seat
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &seat,
+			CollectionName: "seats",
+			Filter: bson.M{
+				"seatId": seatId,
+				"status": fromStatus,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    toStatus,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateSeatHeld takes a seat that is available or whose hold has expired, Data is nil when another order has it
+func (c commandMongodbRepository) UpdateSeatHeld(ctx context.Context, seatId string, orderId string, heldUntil time.Time, now time.Time) <-chan wrapper.Result {
+	var seat entity.Seat
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &seat,
+			CollectionName: "seats",
+			Filter: bson.M{
+				"seatId": seatId,
+				"$or": []bson.M{
+					{"status": constants.SeatStatusAvailable},
+					{"status": constants.SeatStatusHeld, "heldUntil": bson.M{"$lte": now}},
+				},
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.SeatStatusHeld,
+					"orderId":   orderId,
+					"heldUntil": heldUntil,
+					"updatedAt": now,
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateSeatSold keeps a held seat for good, Data is nil when the seat is no longer held by the order
+func (c commandMongodbRepository) UpdateSeatSold(ctx context.Context, seatId string, orderId string) <-chan wrapper.Result {
+	var seat entity.Seat
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &seat,
+			CollectionName: "seats",
+			Filter: bson.M{
+				"seatId":  seatId,
+				"orderId": orderId,
+				"status":  constants.SeatStatusHeld,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.SeatStatusSold,
+					"updatedAt": time.Now(),
+				},
+				"$unset": bson.M{
+					"heldUntil": "",
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateSeatReleased puts a seat of the order back on sale, Data is nil when the order no longer has it
+func (c commandMongodbRepository) UpdateSeatReleased(ctx context.Context, seatId string, orderId string) <-chan wrapper.Result {
+	var seat entity.Seat
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &seat,
+			CollectionName: "seats",
+			Filter: bson.M{
+				"seatId":  seatId,
+				"orderId": orderId,
+				"status":  bson.M{"$in": []string{constants.SeatStatusHeld, constants.SeatStatusSold}},
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.SeatStatusAvailable,
+					"updatedAt": time.Now(),
+				},
+				"$unset": bson.M{
+					"orderId":   "",
+					"heldUntil": "",
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CreateUniqueIndexes keeps one seat per seatId and one seat map per event,
+// concurrent UpsertSeat calls would create the same seat twice without it
+func (c commandMongodbRepository) CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		for _, index := range []mongodb.CreateIndex{
+			{
+				CollectionName: "seats",
+				Keys:           bson.D{{Key: "seatId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+			{
+				CollectionName: "seat-maps",
+				Keys:           bson.D{{Key: "eventId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+		} {
+			resp := <-c.mongoDb.CreateIndex(index, ctx)
+			if resp.Error != nil {
+				output <- resp
+				return
+			}
+		}
+		output <- wrapper.Result{Data: "Success create index"}
+	}()
+
+	return output
+}
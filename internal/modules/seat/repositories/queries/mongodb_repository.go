@@ -0,0 +1,101 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/seat"
+	"ticket-service/internal/modules/seat/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) seat.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindSeatMapByEventId(ctx context.Context, eventId string) <-chan wrapper.Result {
+	var seatMap entity.SeatMap
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &seatMap,
+			CollectionName: "seat-maps",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindSeatsByEventId(ctx context.Context, eventId string) <-chan wrapper.Result {
+	var seats []entity.Seat
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &seats,
+			CollectionName: "seats",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindSeatsByTicketType(ctx context.Context, eventId string, ticketType string) <-chan wrapper.Result {
+	var seats []entity.Seat
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &seats,
+			CollectionName: "seats",
+			Filter: bson.M{
+				"eventId":    eventId,
+				"ticketType": ticketType,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindSeatsByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result {
+	var seats []entity.Seat
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &seats,
+			CollectionName: "seats",
+			Filter: bson.M{
+				"orderId": orderId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,39 @@
+package seat
+
+import (
+	"context"
+	"ticket-service/internal/modules/seat/models/dto"
+	"ticket-service/internal/modules/seat/models/entity"
+	"ticket-service/internal/modules/seat/models/request"
+	"ticket-service/internal/modules/seat/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"time"
+)
+
+type UsecaseCommand interface {
+	UpsertSeatMap(origCtx context.Context, payload request.SeatMapReq) (*response.SeatMap, error)
+	HoldSeats(origCtx context.Context, payload dto.HoldReq) ([]dto.Seat, error)
+	SellSeats(origCtx context.Context, orderId string) error
+	ReleaseSeats(origCtx context.Context, orderId string) error
+}
+
+type UsecaseQuery interface {
+	FindAvailability(origCtx context.Context, payload request.AvailabilityReq) (*response.Availability, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindSeatMapByEventId(ctx context.Context, eventId string) <-chan wrapper.Result
+	FindSeatsByEventId(ctx context.Context, eventId string) <-chan wrapper.Result
+	FindSeatsByTicketType(ctx context.Context, eventId string, ticketType string) <-chan wrapper.Result
+	FindSeatsByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertSeatMap(ctx context.Context, seatMap entity.SeatMap) <-chan wrapper.Result
+	UpsertSeat(ctx context.Context, seat entity.Seat) <-chan wrapper.Result
+	UpdateSeatStatus(ctx context.Context, seatId string, fromStatus string, toStatus string) <-chan wrapper.Result
+	UpdateSeatHeld(ctx context.Context, seatId string, orderId string, heldUntil time.Time, now time.Time) <-chan wrapper.Result
+	UpdateSeatSold(ctx context.Context, seatId string, orderId string) <-chan wrapper.Result
+	UpdateSeatReleased(ctx context.Context, seatId string, orderId string) <-chan wrapper.Result
+	CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result
+}
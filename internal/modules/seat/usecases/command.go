@@ -0,0 +1,399 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"ticket-service/internal/modules/seat"
+	"ticket-service/internal/modules/seat/models/dto"
+	"ticket-service/internal/modules/seat/models/entity"
+	"ticket-service/internal/modules/seat/models/request"
+	"ticket-service/internal/modules/seat/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	seatRepositoryQuery   seat.MongodbRepositoryQuery
+	seatRepositoryCommand seat.MongodbRepositoryCommand
+	logger                log.Logger
+}
+
+func NewCommandUsecase(smq seat.MongodbRepositoryQuery, smc seat.MongodbRepositoryCommand, log log.Logger) seat.UsecaseCommand {
+	return commandUsecase{
+		seatRepositoryQuery:   smq,
+		seatRepositoryCommand: smc,
+		logger:                log,
+	}
+}
+
+// UpsertSeatMap creates the seats of the map and updates the layout of the ones that exist. Seats left out of the
+// map are removed from sale, which is refused while one of them is held or sold
+func (c commandUsecase) UpsertSeatMap(origCtx context.Context, payload request.SeatMapReq) (*response.SeatMap, error) {
+	domain := "seatUsecase-UpsertSeatMap"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	now := time.Now()
+	seatMap := entity.SeatMap{
+		EventId:   payload.EventId,
+		VenueName: payload.VenueName,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	seats := make([]entity.Seat, 0)
+	sectionNames := make(map[string]bool)
+	for sectionRank, section := range payload.Sections {
+		if sectionNames[section.Name] {
+			return nil, errors.BadRequest(fmt.Sprintf("section %s is listed twice", section.Name))
+		}
+		sectionNames[section.Name] = true
+
+		rows := make([]entity.Row, 0)
+		rowNames := make(map[string]bool)
+		for rowRank, row := range section.Rows {
+			if rowNames[row.Name] {
+				return nil, errors.BadRequest(fmt.Sprintf("row %s of section %s is listed twice", row.Name, section.Name))
+			}
+			rowNames[row.Name] = true
+			rows = append(rows, entity.Row{
+				Name:       row.Name,
+				TicketType: row.TicketType,
+				Seats:      row.Seats,
+			})
+			for number := 1; number <= row.Seats; number++ {
+				seats = append(seats, entity.Seat{
+					SeatId:      seatId(payload.EventId, section.Name, row.Name, number),
+					EventId:     payload.EventId,
+					TicketType:  row.TicketType,
+					Section:     section.Name,
+					Row:         row.Name,
+					Number:      number,
+					SectionRank: sectionRank,
+					RowRank:     rowRank,
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				})
+			}
+		}
+		seatMap.Sections = append(seatMap.Sections, entity.Section{
+			Name: section.Name,
+			Rows: rows,
+		})
+	}
+
+	existing, err := c.findSeats(ctx, <-c.seatRepositoryQuery.FindSeatsByEventId(ctx, payload.EventId))
+	if err != nil {
+		return nil, err
+	}
+
+	mapped := make(map[string]bool)
+	for _, value := range seats {
+		mapped[value.SeatId] = true
+	}
+	dropped := make([]entity.Seat, 0)
+	for _, value := range existing {
+		if mapped[value.SeatId] || value.Status == constants.SeatStatusRemoved {
+			continue
+		}
+		if !value.IsAvailable(now) {
+			return nil, errors.UnprocessableEntity(fmt.Sprintf("seat %s is held or sold and cannot be removed", value.SeatId))
+		}
+		dropped = append(dropped, value)
+	}
+
+	resp := <-c.seatRepositoryCommand.UpsertSeatMap(ctx, seatMap)
+	if resp.Error != nil {
+		msg := "Error upsert seat map"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	for _, value := range seats {
+		upserted := <-c.seatRepositoryCommand.UpsertSeat(ctx, value)
+		if upserted.Error != nil {
+			msg := "Error upsert seat"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", upserted.Error))
+			return nil, upserted.Error
+		}
+		// a seat put back on the map goes on sale again
+		if seatDetail, ok := upserted.Data.(*entity.Seat); ok && seatDetail.Status == constants.SeatStatusRemoved {
+			<-c.seatRepositoryCommand.UpdateSeatStatus(ctx, value.SeatId, constants.SeatStatusRemoved, constants.SeatStatusAvailable)
+		}
+	}
+
+	for _, value := range dropped {
+		removed := <-c.seatRepositoryCommand.UpdateSeatStatus(ctx, value.SeatId, value.Status, constants.SeatStatusRemoved)
+		if removed.Error != nil || removed.Data == nil {
+			msg := "Error remove seat"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", value))
+		}
+	}
+
+	return &response.SeatMap{
+		EventId:    seatMap.EventId,
+		VenueName:  seatMap.VenueName,
+		TotalSeats: len(seats),
+	}, nil
+}
+
+// HoldSeats holds seats for an order until HeldUntil. Tiers without a seat map are not seated, nil is returned
+// and the order only takes from TotalRemaining
+func (c commandUsecase) HoldSeats(origCtx context.Context, payload dto.HoldReq) ([]dto.Seat, error) {
+	domain := "seatUsecase-HoldSeats"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	seats, err := c.findSeats(ctx, <-c.seatRepositoryQuery.FindSeatsByTicketType(ctx, payload.EventId, payload.TicketType))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(seats) == 0 {
+		if len(payload.SeatIds) > 0 {
+			return nil, errors.UnprocessableEntity(fmt.Sprintf("%s tickets have no reserved seating", payload.TicketType))
+		}
+		return nil, nil
+	}
+
+	if len(payload.SeatIds) > 0 {
+		if len(payload.SeatIds) != payload.Quantity {
+			return nil, errors.UnprocessableEntity(fmt.Sprintf("pick %d seats for %d tickets", payload.Quantity, payload.Quantity))
+		}
+
+		seatById := make(map[string]entity.Seat)
+		for _, value := range seats {
+			seatById[value.SeatId] = value
+		}
+		picked := make([]entity.Seat, 0)
+		now := time.Now()
+		for _, id := range payload.SeatIds {
+			value, ok := seatById[id]
+			if !ok {
+				return nil, errors.NotFound(fmt.Sprintf("seat %s not found", id))
+			}
+			if !value.IsAvailable(now) {
+				return nil, errors.Conflict(fmt.Sprintf("seat %s is not available", id))
+			}
+			picked = append(picked, value)
+		}
+
+		held, err := c.claimSeats(ctx, picked, payload, now)
+		if err != nil {
+			return nil, err
+		}
+		if !held {
+			return nil, errors.Conflict("one of the seats was just taken, please pick again")
+		}
+		return mapHeldSeats(picked), nil
+	}
+
+	// best-available picks again from fresh seats when a concurrent order took one of them first
+	for attempt := 0; attempt < constants.SeatHoldAttempts; attempt++ {
+		if attempt > 0 {
+			seats, err = c.findSeats(ctx, <-c.seatRepositoryQuery.FindSeatsByTicketType(ctx, payload.EventId, payload.TicketType))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		now := time.Now()
+		picked := bestAvailable(seats, payload.Quantity, now)
+		if picked == nil {
+			return nil, errors.UnprocessableEntity(fmt.Sprintf("no %d adjacent seats available", payload.Quantity))
+		}
+
+		held, err := c.claimSeats(ctx, picked, payload, now)
+		if err != nil {
+			return nil, err
+		}
+		if held {
+			return mapHeldSeats(picked), nil
+		}
+	}
+
+	return nil, errors.Conflict("seats are selling fast, please try again")
+}
+
+// SellSeats keeps the seats of a paid order for good, a seat whose hold was taken over by another order is reported
+func (c commandUsecase) SellSeats(origCtx context.Context, orderId string) error {
+	domain := "seatUsecase-SellSeats"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	seats, err := c.findSeats(ctx, <-c.seatRepositoryQuery.FindSeatsByOrderId(ctx, orderId))
+	if err != nil {
+		return err
+	}
+
+	for _, value := range seats {
+		if value.Status == constants.SeatStatusSold {
+			continue
+		}
+		sold := <-c.seatRepositoryCommand.UpdateSeatSold(ctx, value.SeatId, orderId)
+		if sold.Error != nil {
+			msg := "Error sell seat"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", sold.Error))
+			return sold.Error
+		}
+		if sold.Data == nil {
+			return errors.Conflict(fmt.Sprintf("seat %s is no longer held by order %s", value.SeatId, orderId))
+		}
+	}
+	return nil
+}
+
+// ReleaseSeats puts every seat of the order back on sale, it is safe to call again
+func (c commandUsecase) ReleaseSeats(origCtx context.Context, orderId string) error {
+	domain := "seatUsecase-ReleaseSeats"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	seats, err := c.findSeats(ctx, <-c.seatRepositoryQuery.FindSeatsByOrderId(ctx, orderId))
+	if err != nil {
+		return err
+	}
+
+	for _, value := range seats {
+		released := <-c.seatRepositoryCommand.UpdateSeatReleased(ctx, value.SeatId, orderId)
+		if released.Error != nil {
+			msg := "Error release seat"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", value))
+		}
+	}
+	return nil
+}
+
+// claimSeats holds every picked seat or none of them, held is false when another order took one of them first
+func (c commandUsecase) claimSeats(ctx context.Context, picked []entity.Seat, payload dto.HoldReq, now time.Time) (bool, error) {
+	for i, value := range picked {
+		resp := <-c.seatRepositoryCommand.UpdateSeatHeld(ctx, value.SeatId, payload.OrderId, payload.HeldUntil, now)
+		if resp.Error == nil && resp.Data != nil {
+			continue
+		}
+
+		for _, claimed := range picked[:i] {
+			<-c.seatRepositoryCommand.UpdateSeatReleased(ctx, claimed.SeatId, payload.OrderId)
+		}
+		if resp.Error != nil {
+			msg := "Error hold seat"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+			return false, resp.Error
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+func (c commandUsecase) findSeats(ctx context.Context, resp wrapper.Result) ([]entity.Seat, error) {
+	if resp.Error != nil {
+		msg := "Error query seat"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	seats, ok := resp.Data.(*[]entity.Seat)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return *seats, nil
+}
+
+// bestAvailable picks quantity adjacent seats in the best row that still has them, as close to the middle of the
+// row as possible. Adjacent seats have consecutive numbers, a removed seat splits a row
+func bestAvailable(seats []entity.Seat, quantity int, now time.Time) []entity.Seat {
+	rows := make(map[string][]entity.Seat)
+	keys := make([]string, 0)
+	for _, value := range seats {
+		key := fmt.Sprintf("%s:%s", value.Section, value.Row)
+		if _, ok := rows[key]; !ok {
+			keys = append(keys, key)
+		}
+		rows[key] = append(rows[key], value)
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := rows[keys[i]][0], rows[keys[j]][0]
+		if a.SectionRank != b.SectionRank {
+			return a.SectionRank < b.SectionRank
+		}
+		return a.RowRank < b.RowRank
+	})
+
+	for _, key := range keys {
+		row := rows[key]
+		sort.Slice(row, func(i, j int) bool {
+			return row[i].Number < row[j].Number
+		})
+		center := float64(row[0].Number+row[len(row)-1].Number) / 2
+
+		best := -1
+		bestDistance := 0.0
+		for start := 0; start+quantity <= len(row); start++ {
+			window := row[start : start+quantity]
+			if !isAdjacentAndAvailable(window, now) {
+				continue
+			}
+			distance := math.Abs(float64(window[0].Number+window[quantity-1].Number)/2 - center)
+			if best < 0 || distance < bestDistance {
+				best = start
+				bestDistance = distance
+			}
+		}
+		if best >= 0 {
+			return row[best : best+quantity]
+		}
+	}
+	return nil
+}
+
+// seatId is stable across remaps, so holds and sales survive a new version of the seat map
+func seatId(eventId string, section string, row string, number int) string {
+	return fmt.Sprintf("%s:%s:%s:%d", eventId, section, row, number)
+}
+
+func isAdjacentAndAvailable(window []entity.Seat, now time.Time) bool {
+	for i, value := range window {
+		if !value.IsAvailable(now) {
+			return false
+		}
+		if i > 0 && value.Number != window[i-1].Number+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func mapHeldSeats(seats []entity.Seat) []dto.Seat {
+	result := make([]dto.Seat, 0)
+	for _, value := range seats {
+		result = append(result, dto.Seat{
+			SeatId:  value.SeatId,
+			Section: value.Section,
+			Row:     value.Row,
+			Number:  value.Number,
+		})
+	}
+	return result
+}
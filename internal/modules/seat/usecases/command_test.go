@@ -0,0 +1,187 @@
+package usecases_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"ticket-service/internal/modules/seat"
+	"ticket-service/internal/modules/seat/models/dto"
+	"ticket-service/internal/modules/seat/models/entity"
+	"ticket-service/internal/modules/seat/models/request"
+	uc "ticket-service/internal/modules/seat/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockseat "ticket-service/mocks/modules/seat"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockSeatRepositoryQuery   *mockseat.MongodbRepositoryQuery
+	mockSeatRepositoryCommand *mockseat.MongodbRepositoryCommand
+	mockLogger                *mocklog.Logger
+	usecase                   seat.UsecaseCommand
+	ctx                       context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockSeatRepositoryQuery = &mockseat.MongodbRepositoryQuery{}
+	suite.mockSeatRepositoryCommand = &mockseat.MongodbRepositoryCommand{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockSeatRepositoryQuery,
+		suite.mockSeatRepositoryCommand,
+		suite.mockLogger,
+	)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldSeatsBestAvailable() {
+	// Arrange
+	seats := getMockRow("A", 0, 6)
+	seats[2].Status = constants.SeatStatusSold
+	seats[4].Status = constants.SeatStatusSold
+	seats = append(seats, getMockRow("B", 1, 6)...)
+	suite.mockSeatRepositoryQuery.On("FindSeatsByTicketType", mock.Anything, "event-id", "VIP").
+		Return(mockChannel(helpers.Result{Data: &seats}))
+	suite.mockSeatRepositoryCommand.On("UpdateSeatHeld", mock.Anything, mock.Anything, "order-id", mock.Anything, mock.Anything).
+		Return(func(context.Context, string, string, time.Time, time.Time) <-chan helpers.Result {
+			return mockChannel(helpers.Result{Data: &entity.Seat{}})
+		})
+
+	// Act
+	result, err := suite.usecase.HoldSeats(suite.ctx, getMockHoldReq(3))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 3)
+	assert.Equal(suite.T(), "B", result[0].Row)
+	assert.Equal(suite.T(), []int{2, 3, 4}, []int{result[0].Number, result[1].Number, result[2].Number})
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldSeatsNotSeated() {
+	// Arrange
+	suite.mockSeatRepositoryQuery.On("FindSeatsByTicketType", mock.Anything, "event-id", "VIP").
+		Return(mockChannel(helpers.Result{Data: &[]entity.Seat{}}))
+
+	// Act
+	result, err := suite.usecase.HoldSeats(suite.ctx, getMockHoldReq(2))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Nil(suite.T(), result)
+	suite.mockSeatRepositoryCommand.AssertNotCalled(suite.T(), "UpdateSeatHeld", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldSeatsErrSeatTaken() {
+	// Arrange
+	seats := getMockRow("A", 0, 4)
+	seats[1].Status = constants.SeatStatusHeld
+	seats[1].HeldUntil = time.Now().Add(time.Minute)
+	suite.mockSeatRepositoryQuery.On("FindSeatsByTicketType", mock.Anything, "event-id", "VIP").
+		Return(mockChannel(helpers.Result{Data: &seats}))
+	payload := getMockHoldReq(2)
+	payload.SeatIds = []string{seats[0].SeatId, seats[1].SeatId}
+
+	// Act
+	result, err := suite.usecase.HoldSeats(suite.ctx, payload)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.Conflict("seat event-id:Stalls:A:2 is not available"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestHoldSeatsReleasesPartialClaim() {
+	// Arrange
+	seats := getMockRow("A", 0, 2)
+	suite.mockSeatRepositoryQuery.On("FindSeatsByTicketType", mock.Anything, "event-id", "VIP").
+		Return(mockChannel(helpers.Result{Data: &seats}))
+	suite.mockSeatRepositoryCommand.On("UpdateSeatHeld", mock.Anything, seats[0].SeatId, "order-id", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &entity.Seat{}}))
+	suite.mockSeatRepositoryCommand.On("UpdateSeatHeld", mock.Anything, seats[1].SeatId, "order-id", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockSeatRepositoryCommand.On("UpdateSeatReleased", mock.Anything, seats[0].SeatId, "order-id").
+		Return(mockChannel(helpers.Result{Data: &entity.Seat{}}))
+	payload := getMockHoldReq(2)
+	payload.SeatIds = []string{seats[0].SeatId, seats[1].SeatId}
+
+	// Act
+	result, err := suite.usecase.HoldSeats(suite.ctx, payload)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.Conflict("one of the seats was just taken, please pick again"), err)
+	suite.mockSeatRepositoryCommand.AssertCalled(suite.T(), "UpdateSeatReleased", mock.Anything, seats[0].SeatId, "order-id")
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpsertSeatMapErrSoldSeatDropped() {
+	// Arrange
+	seats := getMockRow("A", 0, 4)
+	seats[3].Status = constants.SeatStatusSold
+	suite.mockSeatRepositoryQuery.On("FindSeatsByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: &seats}))
+	payload := request.SeatMapReq{
+		EventId:   "event-id",
+		VenueName: "Grand Hall",
+		Sections: []request.SectionReq{
+			{Name: "Stalls", Rows: []request.RowReq{{Name: "A", TicketType: "VIP", Seats: 3}}},
+		},
+	}
+
+	// Act
+	result, err := suite.usecase.UpsertSeatMap(suite.ctx, payload)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.UnprocessableEntity("seat event-id:Stalls:A:4 is held or sold and cannot be removed"), err)
+	suite.mockSeatRepositoryCommand.AssertNotCalled(suite.T(), "UpsertSeatMap", mock.Anything, mock.Anything)
+}
+
+func getMockRow(row string, rowRank int, count int) []entity.Seat {
+	seats := make([]entity.Seat, 0)
+	for number := 1; number <= count; number++ {
+		seats = append(seats, entity.Seat{
+			SeatId:     fmt.Sprintf("event-id:Stalls:%s:%d", row, number),
+			EventId:    "event-id",
+			TicketType: "VIP",
+			Section:    "Stalls",
+			Row:        row,
+			Number:     number,
+			RowRank:    rowRank,
+			Status:     constants.SeatStatusAvailable,
+		})
+	}
+	return seats
+}
+
+func getMockHoldReq(quantity int) dto.HoldReq {
+	return dto.HoldReq{
+		EventId:    "event-id",
+		TicketType: "VIP",
+		OrderId:    "order-id",
+		Quantity:   quantity,
+		HeldUntil:  time.Now().Add(15 * time.Minute),
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
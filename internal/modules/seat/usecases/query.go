@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/seat"
+	"ticket-service/internal/modules/seat/models/entity"
+	"ticket-service/internal/modules/seat/models/request"
+	"ticket-service/internal/modules/seat/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	seatRepositoryQuery seat.MongodbRepositoryQuery
+	logger              log.Logger
+}
+
+func NewQueryUsecase(smq seat.MongodbRepositoryQuery, log log.Logger) seat.UsecaseQuery {
+	return queryUsecase{
+		seatRepositoryQuery: smq,
+		logger:              log,
+	}
+}
+
+// FindAvailability lays the seats out like the seat map, narrowed down to one tier when TicketType is set
+func (q queryUsecase) FindAvailability(origCtx context.Context, payload request.AvailabilityReq) (*response.Availability, error) {
+	domain := "seatUsecase-FindAvailability"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	mapData := <-q.seatRepositoryQuery.FindSeatMapByEventId(ctx, payload.EventId)
+	if mapData.Error != nil {
+		msg := "Error query seat map"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", mapData.Error))
+		return nil, mapData.Error
+	}
+
+	if mapData.Data == nil {
+		return nil, errors.NotFound("seat map not found")
+	}
+
+	seatMap, ok := mapData.Data.(*entity.SeatMap)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	seatData := <-q.seatRepositoryQuery.FindSeatsByEventId(ctx, payload.EventId)
+	if seatData.Error != nil {
+		msg := "Error query seat"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", seatData.Error))
+		return nil, seatData.Error
+	}
+
+	seatById := make(map[string]entity.Seat)
+	if seatData.Data != nil {
+		seats, ok := seatData.Data.(*[]entity.Seat)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		for _, value := range *seats {
+			seatById[value.SeatId] = value
+		}
+	}
+
+	now := time.Now()
+	result := response.Availability{
+		EventId:   seatMap.EventId,
+		VenueName: seatMap.VenueName,
+		Sections:  make([]response.Section, 0),
+	}
+	for _, section := range seatMap.Sections {
+		rows := make([]response.Row, 0)
+		for _, row := range section.Rows {
+			if payload.TicketType != "" && row.TicketType != payload.TicketType {
+				continue
+			}
+			seats := make([]response.Seat, 0)
+			for number := 1; number <= row.Seats; number++ {
+				id := seatId(seatMap.EventId, section.Name, row.Name, number)
+				value, ok := seatById[id]
+				available := ok && value.IsAvailable(now)
+				if available {
+					result.TotalAvailable++
+				}
+				seats = append(seats, response.Seat{
+					SeatId:    id,
+					Number:    number,
+					Available: available,
+				})
+			}
+			rows = append(rows, response.Row{
+				Name:       row.Name,
+				TicketType: row.TicketType,
+				Seats:      seats,
+			})
+		}
+		if len(rows) > 0 {
+			result.Sections = append(result.Sections, response.Section{
+				Name: section.Name,
+				Rows: rows,
+			})
+		}
+	}
+
+	return &result, nil
+}
@@ -1,37 +1,50 @@
 package handlers
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
 	"ticket-service/internal/modules/ticket"
 	"ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/pkg/constants"
 	"ticket-service/internal/pkg/errors"
 	"ticket-service/internal/pkg/helpers"
 	"ticket-service/internal/pkg/log"
 	"ticket-service/internal/pkg/redis"
+	"time"
 
 	middlewares "ticket-service/configs/middleware"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 )
 
 type TicketHttpHandler struct {
-	TicketUsecaseQuery ticket.UsecaseQuery
-	Logger             log.Logger
-	Validator          *validator.Validate
+	TicketUsecaseQuery  ticket.UsecaseQuery
+	TicketUsecaseStream ticket.UsecaseStream
+	Logger              log.Logger
+	Validator           *validator.Validate
 }
 
-func InitTicketHttpHandler(app *fiber.App, tuq ticket.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+func InitTicketHttpHandler(app *fiber.App, tuq ticket.UsecaseQuery, tus ticket.UsecaseStream, log log.Logger, redisClient redis.Collections) {
 	handler := &TicketHttpHandler{
-		TicketUsecaseQuery: tuq,
-		Logger:             log,
-		Validator:          validator.New(),
+		TicketUsecaseQuery:  tuq,
+		TicketUsecaseStream: tus,
+		Logger:              log,
+		Validator:           validator.New(),
 	}
 	middlewares := middlewares.NewMiddlewares(redisClient)
 	route := app.Group("/api/tickets")
 
 	route.Get("/v1/list", middlewares.VerifyBearer(), handler.GetTickets)
-	// route.Get("/v1/list-available", middlewares.VerifyBearer(), handler.GetAvailableTicket)
+	route.Get("/v1/list-available", middlewares.VerifyBearer(), handler.GetAvailableTicket)
 	route.Get("/v1/online", middlewares.VerifyBearer(), handler.GetOnlineTicket)
+	route.Get("/v1/quote", middlewares.VerifyBearer(), handler.GetQuote)
+	route.Get("/v1/search", middlewares.VerifyBearer(), handler.SearchTickets)
+	route.Get("/v1/stream", middlewares.VerifyBearer(), handler.GetStream)
+	route.Get("/v1/ws", middlewares.VerifyBearer(), handler.UpgradeStream, websocket.New(handler.StreamSocket))
 }
 
 func (t TicketHttpHandler) GetTickets(c *fiber.Ctx) error {
@@ -43,11 +56,14 @@ func (t TicketHttpHandler) GetTickets(c *fiber.Ctx) error {
 	if err := t.Validator.Struct(req); err != nil {
 		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
 	}
-	resp, err := t.TicketUsecaseQuery.FindTickets(c.Context(), *req)
+	// the token decides who gets in during a presale
+	req.UserId, _ = c.Locals("userId").(string)
+	req.UserRole, _ = c.Locals("userRole").(string)
+	resp, metaData, err := t.TicketUsecaseQuery.FindTickets(c.Context(), *req)
 	if err != nil {
 		return helpers.RespCustomError(c, t.Logger, err)
 	}
-	return helpers.RespSuccess(c, t.Logger, resp, "Get ticket success")
+	return helpers.RespPagination(c, t.Logger, resp, *metaData, "Get ticket success")
 }
 
 func (t TicketHttpHandler) GetOnlineTicket(c *fiber.Ctx) error {
@@ -59,6 +75,8 @@ func (t TicketHttpHandler) GetOnlineTicket(c *fiber.Ctx) error {
 	if err := t.Validator.Struct(req); err != nil {
 		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
 	}
+	// without a currency the prices are converted to the currency of the country of the user
+	req.UserId, _ = c.Locals("userId").(string)
 	resp, err := t.TicketUsecaseQuery.FindOnlineTicket(c.Context(), *req)
 	if err != nil {
 		return helpers.RespCustomError(c, t.Logger, err)
@@ -66,10 +84,171 @@ func (t TicketHttpHandler) GetOnlineTicket(c *fiber.Ctx) error {
 	return helpers.RespSuccess(c, t.Logger, resp, "Get online ticket success")
 }
 
-// func (t TicketHttpHandler) GetAvailableTicket(c *fiber.Ctx) error {
-// 	resp, err := t.TicketUsecaseQuery.FindAvailableTicket(c.Context())
-// 	if err != nil {
-// 		return helpers.RespCustomError(c, t.Logger, err)
-// 	}
-// 	return helpers.RespSuccess(c, t.Logger, resp, "Get available ticket success")
-// }
+func (t TicketHttpHandler) GetQuote(c *fiber.Ctx) error {
+	req := new(request.QuoteReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := t.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
+	}
+	req.UserId, _ = c.Locals("userId").(string)
+	resp, err := t.TicketUsecaseQuery.QuoteTicket(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespSuccess(c, t.Logger, resp, "Get quote success")
+}
+
+func (t TicketHttpHandler) GetAvailableTicket(c *fiber.Ctx) error {
+	req := new(request.AvailabilityReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := t.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, metaData, err := t.TicketUsecaseQuery.FindAvailableTicket(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespPagination(c, t.Logger, resp, *metaData, "Get available ticket success")
+}
+
+func (t TicketHttpHandler) SearchTickets(c *fiber.Ctx) error {
+	req := new(request.SearchReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := t.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, metaData, err := t.TicketUsecaseQuery.SearchTickets(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespPagination(c, t.Logger, resp, *metaData, "Search ticket success")
+}
+
+// GetStream pushes availability deltas as server-sent events, the heartbeat comment keeps proxies from closing an
+// idle stream and is how a gone client is noticed
+func (t TicketHttpHandler) GetStream(c *fiber.Ctx) error {
+	req := new(request.StreamReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := t.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
+	}
+	req.UserId, _ = c.Locals("userId").(string)
+	// the stream outlives the request context, it ends when the client is gone
+	ctx, cancel := context.WithCancel(context.Background())
+	deltas, err := t.TicketUsecaseStream.Subscribe(ctx, *req)
+	if err != nil {
+		cancel()
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		heartbeat := time.NewTicker(constants.StreamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		fmt.Fprintf(w, "retry: %d\n\n", constants.StreamHeartbeatInterval.Milliseconds())
+		if err := w.Flush(); err != nil {
+			return
+		}
+		for {
+			select {
+			case delta, ok := <-deltas:
+				if !ok {
+					return
+				}
+				marshaledDelta, err := json.Marshal(delta)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: availability\ndata: %s\n\n", marshaledDelta)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// UpgradeStream checks the request before the websocket handshake, errors can't be answered as json after it
+func (t TicketHttpHandler) UpgradeStream(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return helpers.RespError(c, t.Logger, errors.CustomError("websocket upgrade required", fiber.StatusUpgradeRequired,
+			fiber.StatusUpgradeRequired))
+	}
+	req := new(request.StreamReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := t.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
+	}
+	req.UserId, _ = c.Locals("userId").(string)
+	c.Locals("streamReq", *req)
+	return c.Next()
+}
+
+// StreamSocket writes every delta as a json text message and pings on each heartbeat, a client that stops answering
+// the pings is dropped once its read deadline passes
+func (t TicketHttpHandler) StreamSocket(conn *websocket.Conn) {
+	req, _ := conn.Locals("streamReq").(request.StreamReq)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	deltas, err := t.TicketUsecaseStream.Subscribe(ctx, req)
+	if err != nil {
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, err.Error()),
+			time.Now().Add(time.Second))
+		return
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * constants.StreamHeartbeatInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * constants.StreamHeartbeatInterval))
+	})
+	// the client never sends anything but control frames, reading only notices when it is gone
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(constants.StreamHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case delta, ok := <-deltas:
+			if !ok {
+				return
+			}
+			if err := conn.WriteJSON(delta); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
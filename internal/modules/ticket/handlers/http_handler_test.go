@@ -7,6 +7,7 @@ import (
 	"testing"
 	"ticket-service/internal/modules/ticket/handlers"
 	"ticket-service/internal/modules/ticket/models/response"
+	"ticket-service/internal/pkg/constants"
 	"ticket-service/internal/pkg/errors"
 	mockcert "ticket-service/mocks/modules/ticket"
 	mocklog "ticket-service/mocks/pkg/log"
@@ -24,6 +25,7 @@ type ticketHttpHandlerTestSuite struct {
 	suite.Suite
 
 	cUQ       *mockcert.UsecaseQuery
+	cUS       *mockcert.UsecaseStream
 	cLog      *mocklog.Logger
 	validator *validator.Validate
 	handler   *handlers.TicketHttpHandler
@@ -33,16 +35,18 @@ type ticketHttpHandlerTestSuite struct {
 
 func (suite *ticketHttpHandlerTestSuite) SetupTest() {
 	suite.cUQ = new(mockcert.UsecaseQuery)
+	suite.cUS = new(mockcert.UsecaseStream)
 	suite.cLog = new(mocklog.Logger)
 	suite.validator = validator.New()
 	suite.cRedis = new(mockredis.Collections)
 	suite.handler = &handlers.TicketHttpHandler{
-		TicketUsecaseQuery: suite.cUQ,
-		Logger:             suite.cLog,
-		Validator:          suite.validator,
+		TicketUsecaseQuery:  suite.cUQ,
+		TicketUsecaseStream: suite.cUS,
+		Logger:              suite.cLog,
+		Validator:           suite.validator,
 	}
 	suite.app = fiber.New()
-	handlers.InitTicketHttpHandler(suite.app, suite.cUQ, suite.cLog, suite.cRedis)
+	handlers.InitTicketHttpHandler(suite.app, suite.cUQ, suite.cUS, suite.cLog, suite.cRedis)
 }
 
 func TestUserHttpHandlerTestSuite(t *testing.T) {
@@ -60,7 +64,7 @@ func (suite *ticketHttpHandlerTestSuite) TestGetTickets() {
 		},
 		Suggestion: []response.SuggestionTicket{},
 	}
-	suite.cUQ.On("FindTickets", mock.Anything, mock.Anything).Return(response, nil)
+	suite.cUQ.On("FindTickets", mock.Anything, mock.Anything).Return(response, &constants.MetaData{Page: 1, Count: 1}, nil)
 	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
@@ -85,7 +89,7 @@ func (suite *ticketHttpHandlerTestSuite) TestGetTicketsErrQuery() {
 		},
 		Suggestion: []response.SuggestionTicket{},
 	}
-	suite.cUQ.On("FindTickets", mock.Anything, mock.Anything).Return(response, nil)
+	suite.cUQ.On("FindTickets", mock.Anything, mock.Anything).Return(response, &constants.MetaData{Page: 1, Count: 1}, nil)
 	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
@@ -101,7 +105,7 @@ func (suite *ticketHttpHandlerTestSuite) TestGetTicketsErrQuery() {
 
 func (suite *ticketHttpHandlerTestSuite) TestGetTicketsErr() {
 
-	suite.cUQ.On("FindTickets", mock.Anything, mock.Anything).Return(nil, errors.BadRequest("error"))
+	suite.cUQ.On("FindTickets", mock.Anything, mock.Anything).Return(nil, nil, errors.BadRequest("error"))
 	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
@@ -115,6 +119,20 @@ func (suite *ticketHttpHandlerTestSuite) TestGetTicketsErr() {
 	assert.Nil(suite.T(), err)
 }
 
+func (suite *ticketHttpHandlerTestSuite) TestGetTicketsErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().SetRequestURI("/v1/list?countryCode=1&eventId=1&sortBy=name&size=500")
+	ctx.Request().Header.SetMethod(fiber.MethodGet)
+
+	err := suite.handler.GetTickets(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, ctx.Response().StatusCode())
+	suite.cUQ.AssertNotCalled(suite.T(), "FindTickets", mock.Anything, mock.Anything)
+}
+
 func (suite *ticketHttpHandlerTestSuite) TestGetOnlineTicket() {
 
 	response := &response.Ticket{
@@ -169,3 +187,114 @@ func (suite *ticketHttpHandlerTestSuite) TestGetOnlineTicketErr() {
 	err := suite.handler.GetOnlineTicket(ctx)
 	assert.Nil(suite.T(), err)
 }
+
+func (suite *ticketHttpHandlerTestSuite) TestGetAvailableTicket() {
+
+	response := []response.TicketCountry{
+		{
+			EventId:     "1",
+			CountryCode: "ID",
+		},
+	}
+	suite.cUQ.On("FindAvailableTicket", mock.Anything, mock.Anything).Return(response, &constants.MetaData{Page: 1, Count: 1}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().SetRequestURI("/v1/list-available?continentCode=AS&sortBy=sellThrough&sortOrder=desc")
+	ctx.Request().Header.SetMethod(fiber.MethodGet)
+	ctx.Request().Header.SetContentType("application/json")
+
+	err := suite.handler.GetAvailableTicket(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, ctx.Response().StatusCode())
+}
+
+func (suite *ticketHttpHandlerTestSuite) TestGetAvailableTicketErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().SetRequestURI("/v1/list-available?sortBy=price")
+	ctx.Request().Header.SetMethod(fiber.MethodGet)
+	ctx.Request().Header.SetContentType("application/json")
+
+	err := suite.handler.GetAvailableTicket(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, ctx.Response().StatusCode())
+	suite.cUQ.AssertNotCalled(suite.T(), "FindAvailableTicket", mock.Anything, mock.Anything)
+}
+
+func (suite *ticketHttpHandlerTestSuite) TestGetStreamErrLimit() {
+	suite.cUS.On("Subscribe", mock.Anything, mock.Anything).Return(nil, errors.TooManyRequest("at most 3 streams per user"))
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().SetRequestURI("/v1/stream?eventId=1")
+	ctx.Request().Header.SetMethod(fiber.MethodGet)
+
+	err := suite.handler.GetStream(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusTooManyRequests, ctx.Response().StatusCode())
+}
+
+func (suite *ticketHttpHandlerTestSuite) TestGetStreamErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().SetRequestURI("/v1/stream")
+	ctx.Request().Header.SetMethod(fiber.MethodGet)
+
+	err := suite.handler.GetStream(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, ctx.Response().StatusCode())
+	suite.cUS.AssertNotCalled(suite.T(), "Subscribe", mock.Anything, mock.Anything)
+}
+
+func (suite *ticketHttpHandlerTestSuite) TestUpgradeStreamErrNotWebsocket() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().SetRequestURI("/v1/ws?eventId=1")
+	ctx.Request().Header.SetMethod(fiber.MethodGet)
+
+	err := suite.handler.UpgradeStream(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusUpgradeRequired, ctx.Response().StatusCode())
+}
+
+func (suite *ticketHttpHandlerTestSuite) TestSearchTickets() {
+
+	response := []response.SearchResult{
+		{
+			EventId:   "1",
+			EventName: "name",
+		},
+	}
+	suite.cUQ.On("SearchTickets", mock.Anything, mock.Anything).Return(response, &constants.MetaData{Page: 1, Count: 1}, nil)
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().SetRequestURI("/v1/search?q=coldplay&dateFrom=2024-01-01&available=true&sortBy=price")
+	ctx.Request().Header.SetMethod(fiber.MethodGet)
+
+	err := suite.handler.SearchTickets(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusOK, ctx.Response().StatusCode())
+}
+
+func (suite *ticketHttpHandlerTestSuite) TestSearchTicketsErrValidation() {
+	suite.cLog.On("Info", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	suite.cLog.On("Error", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ctx := suite.app.AcquireCtx(&fasthttp.RequestCtx{})
+	ctx.Request().SetRequestURI("/v1/search?dateFrom=01-01-2024")
+	ctx.Request().Header.SetMethod(fiber.MethodGet)
+
+	err := suite.handler.SearchTickets(ctx)
+	assert.Nil(suite.T(), err)
+	assert.Equal(suite.T(), fiber.StatusBadRequest, ctx.Response().StatusCode())
+	suite.cUQ.AssertNotCalled(suite.T(), "SearchTickets", mock.Anything, mock.Anything)
+}
@@ -1,7 +1,13 @@
 package entity
 
-import "time"
+import (
+	"sort"
+	"ticket-service/internal/pkg/constants"
+	"time"
+)
 
+// Country keeps City and Place as free text, linking the row to a venue copies the venue city and name into them
+// so the search text index covers both
 type Country struct {
 	Name  string `json:"name" bson:"name"`
 	Code  string `json:"code" bson:"code"`
@@ -9,23 +15,169 @@ type Country struct {
 	Place string `json:"place" bson:"place"`
 }
 
+// Ticket is one tier of an event in one country. Cancelled is set on every tier once the event is cancelled,
+// a cancelled tier is neither sold nor restocked again
 type Ticket struct {
-	TicketId       string    `json:"ticketId" bson:"ticketId"`
-	EventId        string    `json:"eventId" bson:"eventId"`
-	TicketType     string    `json:"ticketType" bson:"ticketType"`
-	TicketPrice    int       `json:"ticketPrice" bson:"ticketPrice"`
-	TotalQuota     int       `json:"totalQuota" bson:"totalQuota"`
-	TotalRemaining int       `json:"totalRemaining" bson:"totalRemaining"`
-	ContinentName  string    `json:"continentName" bson:"continentName"`
-	ContinentCode  string    `json:"continentCode" bson:"continentCode"`
-	Country        Country   `json:"country" bson:"country"`
-	Tag            string    `json:"tag" bson:"tag"`
-	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
-	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+	TicketId       string       `json:"ticketId" bson:"ticketId"`
+	EventId        string       `json:"eventId" bson:"eventId"`
+	EventName      string       `json:"eventName,omitempty" bson:"eventName,omitempty"`
+	Artist         string       `json:"artist,omitempty" bson:"artist,omitempty"`
+	TicketType     string       `json:"ticketType" bson:"ticketType"`
+	TicketPrice    int          `json:"ticketPrice" bson:"ticketPrice"`
+	TotalQuota     int          `json:"totalQuota" bson:"totalQuota"`
+	TotalRemaining int          `json:"totalRemaining" bson:"totalRemaining"`
+	ContinentName  string       `json:"continentName" bson:"continentName"`
+	ContinentCode  string       `json:"continentCode" bson:"continentCode"`
+	Country        Country      `json:"country" bson:"country"`
+	VenueId        string       `json:"venueId,omitempty" bson:"venueId,omitempty"`
+	EventDate      time.Time    `json:"eventDate,omitempty" bson:"eventDate,omitempty"`
+	Tag            string       `json:"tag" bson:"tag"`
+	PricePhases    []PricePhase `json:"pricePhases,omitempty" bson:"pricePhases,omitempty"`
+	DynamicPrice   int          `json:"dynamicPrice,omitempty" bson:"dynamicPrice,omitempty"`
+	Cancelled      bool         `json:"cancelled,omitempty" bson:"cancelled,omitempty"`
+	CreatedAt      time.Time    `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time    `json:"updatedAt" bson:"updatedAt"`
 }
 
+// PricePhase replaces TicketPrice while it is active. StartAt and EndAt bound it in time when they are set,
+// MaxSold ends it once that many tickets of the tier are sold. The first active phase in the list wins
+type PricePhase struct {
+	Name    string    `json:"name" bson:"name"`
+	Price   int       `json:"price" bson:"price"`
+	StartAt time.Time `json:"startAt,omitempty" bson:"startAt,omitempty"`
+	EndAt   time.Time `json:"endAt,omitempty" bson:"endAt,omitempty"`
+	MaxSold int       `json:"maxSold,omitempty" bson:"maxSold,omitempty"`
+}
+
+func (p PricePhase) activeAt(now time.Time, sold int) bool {
+	if !p.StartAt.IsZero() && now.Before(p.StartAt) {
+		return false
+	}
+	if !p.EndAt.IsZero() && !now.Before(p.EndAt) {
+		return false
+	}
+	return p.MaxSold == 0 || sold < p.MaxSold
+}
+
+// Pricing is the price of a tier at one moment. NextChangeAt is set when the next price is scheduled,
+// Remaining counts the tickets left before a quantity trigger ends the phase
+type Pricing struct {
+	Phase        string
+	Price        int
+	HasNext      bool
+	NextPrice    int
+	NextChangeAt time.Time
+	Remaining    int
+}
+
+// Sold counts the tickets of the tier that are held or paid
+func (t Ticket) Sold() int {
+	return t.TotalQuota - t.TotalRemaining
+}
+
+// PriceAt prices the tier at now with sold tickets already gone, TicketPrice applies when no phase is active.
+// A price set by dynamic pricing wins over the phases
+func (t Ticket) PriceAt(now time.Time, sold int) Pricing {
+	if t.DynamicPrice > 0 {
+		return Pricing{Phase: constants.PricePhaseDynamic, Price: t.DynamicPrice}
+	}
+	current, price := t.phaseAt(now, sold)
+	pricing := Pricing{Price: price}
+	if current >= 0 {
+		pricing.Phase = t.PricePhases[current].Name
+		if maxSold := t.PricePhases[current].MaxSold; maxSold > 0 {
+			pricing.Remaining = maxSold - sold
+		}
+	}
+
+	// scheduled changes are told with their time, otherwise the price the quantity trigger moves to
+	for _, at := range t.priceBoundaries(now) {
+		if next, nextPrice := t.phaseAt(at, sold); next != current {
+			pricing.HasNext = true
+			pricing.NextPrice = nextPrice
+			pricing.NextChangeAt = at
+			return pricing
+		}
+	}
+	if pricing.Remaining > 0 {
+		if next, nextPrice := t.phaseAt(now, t.PricePhases[current].MaxSold); next != current {
+			pricing.HasNext = true
+			pricing.NextPrice = nextPrice
+		}
+	}
+	return pricing
+}
+
+func (t Ticket) phaseAt(now time.Time, sold int) (int, int) {
+	for i, phase := range t.PricePhases {
+		if phase.activeAt(now, sold) {
+			return i, phase.Price
+		}
+	}
+	return -1, t.TicketPrice
+}
+
+func (t Ticket) priceBoundaries(now time.Time) []time.Time {
+	boundaries := make([]time.Time, 0)
+	for _, phase := range t.PricePhases {
+		for _, at := range []time.Time{phase.StartAt, phase.EndAt} {
+			if at.After(now) {
+				boundaries = append(boundaries, at)
+			}
+		}
+	}
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+	return boundaries
+}
+
+// AggregateTotalTicketPage is one page of the availability of every event and country with the number of rows on
+// every page
+type AggregateTotalTicketPage struct {
+	Meta []AggregateCount       `json:"meta" bson:"meta"`
+	Data []AggregateTotalTicket `json:"data" bson:"data"`
+}
+
+type AggregateCount struct {
+	Total int64 `json:"total" bson:"total"`
+}
+
+// AggregateTotalTicket sums the tiers of one event in one country, SellThrough is the sold share of the quota
 type AggregateTotalTicket struct {
-	Id                   string `json:"_id" bson:"_id"`
-	CountryName          string `json:"countryName" bson:"countryName"`
-	TotalAvailableTicket int    `json:"totalAvailableTicket" bson:"totalAvailableTicket"`
+	Id             AggregateTicketId `json:"_id" bson:"_id"`
+	CountryName    string            `json:"countryName" bson:"countryName"`
+	ContinentCode  string            `json:"continentCode" bson:"continentCode"`
+	ContinentName  string            `json:"continentName" bson:"continentName"`
+	TotalQuota     int               `json:"totalQuota" bson:"totalQuota"`
+	TotalRemaining int               `json:"totalRemaining" bson:"totalRemaining"`
+	SellThrough    float64           `json:"sellThrough" bson:"sellThrough"`
+	Tiers          []AggregateTier   `json:"tiers" bson:"tiers"`
+}
+
+type AggregateTicketId struct {
+	EventId     string `json:"eventId" bson:"eventId"`
+	CountryCode string `json:"countryCode" bson:"countryCode"`
+}
+
+type AggregateTier struct {
+	TicketType     string `json:"ticketType" bson:"ticketType"`
+	TotalQuota     int    `json:"totalQuota" bson:"totalQuota"`
+	TotalRemaining int    `json:"totalRemaining" bson:"totalRemaining"`
+}
+
+// Total counts the rows on every page, it is 0 when nothing matches the filter
+func (a AggregateTotalTicketPage) Total() int64 {
+	if len(a.Meta) == 0 {
+		return 0
+	}
+	return a.Meta[0].Total
+}
+
+// TicketGroup is the tiers of one event in one country, Score is the text search relevance of its best tier
+type TicketGroup struct {
+	Id        AggregateTicketId `json:"_id" bson:"_id"`
+	Score     float64           `json:"score" bson:"score"`
+	EventDate time.Time         `json:"eventDate" bson:"eventDate"`
+	Tickets   []Ticket          `json:"tickets" bson:"tickets"`
 }
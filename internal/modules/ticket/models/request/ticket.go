@@ -1,11 +1,72 @@
 package request
 
+// TicketReq filters, sort and page only shape the list, sold out detection and suggestions look at every tier
 type TicketReq struct {
 	CountryCode string `json:"countryCode" validate:"required"`
 	EventId     string `json:"eventId" validate:"required"`
+	Currency    string `json:"currency" validate:"omitempty,len=3"`
+	TicketType  string `json:"ticketType"`
+	MinPrice    int    `json:"minPrice" validate:"omitempty,min=0"`
+	MaxPrice    int    `json:"maxPrice" validate:"omitempty,min=0"`
+	Available   bool   `json:"available"`
+	SortBy      string `json:"sortBy" validate:"omitempty,oneof=price remaining ticketType"`
+	SortOrder   string `json:"sortOrder" validate:"omitempty,oneof=asc desc"`
+	Page        int64  `json:"page" validate:"omitempty,min=1"`
+	Size        int64  `json:"size" validate:"omitempty,min=1,max=100"`
+	UserId      string `json:"-"`
+	UserRole    string `json:"-"`
+}
+
+// AvailabilityReq filters are optional, without them every event and country is summarized
+type AvailabilityReq struct {
+	EventId       string `json:"eventId"`
+	Tag           string `json:"tag"`
+	ContinentCode string `json:"continentCode"`
+	SortBy        string `json:"sortBy" validate:"omitempty,oneof=country remaining sellThrough"`
+	SortOrder     string `json:"sortOrder" validate:"omitempty,oneof=asc desc"`
+	Page          int64  `json:"page" validate:"omitempty,min=1"`
+	Size          int64  `json:"size" validate:"omitempty,min=1,max=100"`
+}
+
+// StreamReq without a country streams every country of the event
+type StreamReq struct {
+	EventId     string `json:"eventId" validate:"required"`
+	CountryCode string `json:"countryCode"`
+	UserId      string `json:"-"`
+}
+
+// SearchReq prices are in whole dollars and compared with the price on sale now, dates are event days
+type SearchReq struct {
+	Query         string `json:"q" validate:"omitempty,max=100"`
+	DateFrom      string `json:"dateFrom" validate:"omitempty,datetime=2006-01-02"`
+	DateTo        string `json:"dateTo" validate:"omitempty,datetime=2006-01-02"`
+	MinPrice      int    `json:"minPrice" validate:"omitempty,min=0"`
+	MaxPrice      int    `json:"maxPrice" validate:"omitempty,min=0"`
+	ContinentCode string `json:"continentCode"`
+	Available     bool   `json:"available"`
+	SortBy        string `json:"sortBy" validate:"omitempty,oneof=relevance date price"`
+	SortOrder     string `json:"sortOrder" validate:"omitempty,oneof=asc desc"`
+	Page          int64  `json:"page" validate:"omitempty,min=1"`
+	Size          int64  `json:"size" validate:"omitempty,min=1,max=100"`
 }
 
 type CreateOnlineTicketReq struct {
 	Tag         string `json:"tag" validate:"required"`
 	CountryCode string `json:"countryCode" validate:"required"`
 }
+
+type TicketTypeReq struct {
+	EventId     string `json:"eventId" validate:"required"`
+	CountryCode string `json:"countryCode" validate:"required"`
+	TicketType  string `json:"ticketType" validate:"required"`
+}
+
+type QuoteReq struct {
+	EventId      string   `json:"eventId" validate:"required"`
+	CountryCode  string   `json:"countryCode" validate:"required"`
+	TicketType   string   `json:"ticketType" validate:"required"`
+	Quantity     int      `json:"quantity" validate:"required,min=1"`
+	VoucherCodes []string `json:"voucherCodes" validate:"max=3,dive,required"`
+	Currency     string   `json:"currency" validate:"omitempty,len=3"`
+	UserId       string   `json:"-"`
+}
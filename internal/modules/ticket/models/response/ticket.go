@@ -1,13 +1,76 @@
 package response
 
+import "time"
+
 type Ticket struct {
-	TicketType    string `json:"ticketType"`
-	TicketPrice   string `json:"ticketPrice"`
-	ContinentName string `json:"continentName"`
-	ContinentCode string `json:"continentCode"`
-	CountryName   string `json:"countryName"`
-	CountryCode   string `json:"countryCode"`
-	IsSold        bool   `json:"isSold"`
+	TicketType    string        `json:"ticketType"`
+	TicketPrice   string        `json:"ticketPrice"`
+	Currency      string        `json:"currency"`
+	DisplayPrice  *DisplayPrice `json:"displayPrice,omitempty"`
+	PricePhase    string        `json:"pricePhase,omitempty"`
+	NextPrice     *PriceChange  `json:"nextPrice,omitempty"`
+	ContinentName string        `json:"continentName"`
+	ContinentCode string        `json:"continentCode"`
+	CountryName   string        `json:"countryName"`
+	CountryCode   string        `json:"countryCode"`
+	Venue         *Venue        `json:"venue,omitempty"`
+	IsSold        bool          `json:"isSold"`
+}
+
+// Venue is resolved from the venue the tier is linked to, so a rename shows without rewriting the tier
+type Venue struct {
+	VenueId   string  `json:"venueId"`
+	Name      string  `json:"name"`
+	Address   string  `json:"address"`
+	CityName  string  `json:"cityName"`
+	Capacity  int     `json:"capacity"`
+	Timezone  string  `json:"timezone"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// PriceChange is the next price of a tier, At is set when the change is scheduled and
+// Remaining when it comes after that many more tickets are sold
+type PriceChange struct {
+	TicketPrice string     `json:"ticketPrice"`
+	At          *time.Time `json:"at,omitempty"`
+	Remaining   int        `json:"remaining,omitempty"`
+}
+
+// DisplayPrice is a price converted to the currency of the caller, it is indicative and the
+// order is still charged in the native currency
+type DisplayPrice struct {
+	Currency   string    `json:"currency"`
+	Amount     string    `json:"amount"`
+	Indicative bool      `json:"indicative"`
+	AsOf       time.Time `json:"asOf"`
+}
+
+// Quote is the line-item price of an order before it is reserved, Total is what the order will charge
+type Quote struct {
+	EventId      string        `json:"eventId"`
+	TicketType   string        `json:"ticketType"`
+	CountryCode  string        `json:"countryCode"`
+	Quantity     int           `json:"quantity"`
+	TicketPrice  string        `json:"ticketPrice"`
+	PricePhase   string        `json:"pricePhase,omitempty"`
+	FaceValue    string        `json:"faceValue"`
+	Discount     string        `json:"discount"`
+	VoucherCodes []string      `json:"voucherCodes"`
+	ServiceFee   string        `json:"serviceFee"`
+	FacilityFee  string        `json:"facilityFee"`
+	Taxes        []Tax         `json:"taxes"`
+	TotalTax     string        `json:"totalTax"`
+	Total        string        `json:"total"`
+	Currency     string        `json:"currency"`
+	DisplayTotal *DisplayPrice `json:"displayTotal,omitempty"`
+}
+
+type Tax struct {
+	Name         string `json:"name"`
+	Jurisdiction string `json:"jurisdiction"`
+	Percent      int    `json:"percent"`
+	Amount       string `json:"amount"`
 }
 
 type SuggestionTicket struct {
@@ -22,13 +85,89 @@ type SuggestionTicket struct {
 	IsSold              bool   `json:"isSold"`
 }
 
+// TicketResp lists the tiers of the country. Suggestion holds the tiers of the best ranked alternative country
+// for clients that predate Suggestions
 type TicketResp struct {
-	Tickets    []Ticket           `json:"tickets"`
-	Suggestion []SuggestionTicket `json:"suggestion"`
+	Tickets     []Ticket            `json:"tickets"`
+	Suggestion  []SuggestionTicket  `json:"suggestion"`
+	Suggestions []CountrySuggestion `json:"suggestions,omitempty"`
+	Presale     *Presale            `json:"presale,omitempty"`
 }
 
+// CountrySuggestion is another country still selling the tag. DistanceKm and TravelHours are left out when
+// the user or the country cannot be placed on the map
+type CountrySuggestion struct {
+	Rank          int                `json:"rank"`
+	CountryCode   string             `json:"countryCode"`
+	CountryName   string             `json:"countryName"`
+	ContinentCode string             `json:"continentCode"`
+	ContinentName string             `json:"continentName"`
+	SameContinent bool               `json:"sameContinent"`
+	DistanceKm    *int               `json:"distanceKm,omitempty"`
+	TravelHours   *float64           `json:"travelHours,omitempty"`
+	LowestPrice   string             `json:"lowestPrice"`
+	Venue         *Venue             `json:"venue,omitempty"`
+	Tickets       []SuggestionTicket `json:"tickets"`
+}
+
+type Presale struct {
+	Name  string    `json:"name"`
+	EndAt time.Time `json:"endAt"`
+}
+
+// TicketCountry is the availability of one event in one country, SellThrough is a percentage of the quota
 type TicketCountry struct {
-	CountryName string `json:"countryName"`
-	CountryCode string `json:"countryCode"`
-	IsSold      bool   `json:"isSold"`
+	EventId        string        `json:"eventId"`
+	CountryName    string        `json:"countryName"`
+	CountryCode    string        `json:"countryCode"`
+	ContinentCode  string        `json:"continentCode"`
+	ContinentName  string        `json:"continentName"`
+	TotalQuota     int           `json:"totalQuota"`
+	TotalRemaining int           `json:"totalRemaining"`
+	TotalSold      int           `json:"totalSold"`
+	SellThrough    float64       `json:"sellThrough"`
+	IsSold         bool          `json:"isSold"`
+	Tiers          []TierSummary `json:"tiers"`
+}
+
+type TierSummary struct {
+	TicketType     string  `json:"ticketType"`
+	TotalQuota     int     `json:"totalQuota"`
+	TotalRemaining int     `json:"totalRemaining"`
+	TotalSold      int     `json:"totalSold"`
+	SellThrough    float64 `json:"sellThrough"`
+	IsSold         bool    `json:"isSold"`
+}
+
+// AvailabilityDelta is the state of one tier right after its inventory changed, a client applies it as is
+type AvailabilityDelta struct {
+	EventId        string    `json:"eventId"`
+	CountryCode    string    `json:"countryCode"`
+	TicketId       string    `json:"ticketId"`
+	TicketType     string    `json:"ticketType"`
+	TicketPrice    string    `json:"ticketPrice"`
+	TotalQuota     int       `json:"totalQuota"`
+	TotalRemaining int       `json:"totalRemaining"`
+	IsSold         bool      `json:"isSold"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// SearchResult is one event in one country, LowestPrice is the cheapest tier on sale now
+type SearchResult struct {
+	EventId        string     `json:"eventId"`
+	EventName      string     `json:"eventName,omitempty"`
+	Artist         string     `json:"artist,omitempty"`
+	Tag            string     `json:"tag"`
+	EventDate      *time.Time `json:"eventDate,omitempty"`
+	CountryCode    string     `json:"countryCode"`
+	CountryName    string     `json:"countryName"`
+	ContinentCode  string     `json:"continentCode"`
+	ContinentName  string     `json:"continentName"`
+	City           string     `json:"city"`
+	Place          string     `json:"place"`
+	VenueId        string     `json:"venueId,omitempty"`
+	LowestPrice    string     `json:"lowestPrice,omitempty"`
+	TotalRemaining int        `json:"totalRemaining"`
+	IsSold         bool       `json:"isSold"`
+	TicketTypes    []string   `json:"ticketTypes"`
 }
@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/ticket"
+	"ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) ticket.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// DecreaseTotalRemaining only matches when the row still has enough remaining quota and is not cancelled,
+// so concurrent holds can never push totalRemaining below zero. Data is nil when it doesn't.
+func (c commandMongodbRepository) DecreaseTotalRemaining(ctx context.Context, ticketId string, quantity int) <-chan wrapper.Result {
+	var ticket entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &ticket,
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketId":       ticketId,
+				"totalRemaining": bson.M{"$gte": quantity},
+				"cancelled":      bson.M{"$ne": true},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"totalRemaining": -quantity},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// IncreaseTotalRemaining never restocks a cancelled tier, Data is nil when the row is cancelled or already full
+func (c commandMongodbRepository) IncreaseTotalRemaining(ctx context.Context, ticketId string, quantity int) <-chan wrapper.Result {
+	var ticket entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &ticket,
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketId":  ticketId,
+				"cancelled": bson.M{"$ne": true},
+				"$expr": bson.M{
+					"$lte": bson.A{bson.M{"$add": bson.A{"$totalRemaining", quantity}}, "$totalQuota"},
+				},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"totalRemaining": quantity},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CancelEventTickets marks every tier of the event as cancelled, Data is the number of tiers it changed
+func (c commandMongodbRepository) CancelEventTickets(ctx context.Context, eventId string) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpdateMany(mongodb.UpdateOne{
+			CollectionName: "ticket-detail",
+			Filter:         bson.M{"eventId": eventId},
+			Document: bson.M{
+				"cancelled": true,
+				"updatedAt": time.Now(),
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateDynamicPrice sets the price dynamic pricing asks for, a price of 0 hands the tier back to its own prices
+func (c commandMongodbRepository) UpdateDynamicPrice(ctx context.Context, ticketId string, price int) <-chan wrapper.Result {
+	var ticket entity.Ticket
+	output := make(chan wrapper.Result)
+
+	update := bson.M{
+		"$set": bson.M{
+			"dynamicPrice": price,
+			"updatedAt":    time.Now(),
+		},
+	}
+	if price == 0 {
+		update = bson.M{
+			"$set":   bson.M{"updatedAt": time.Now()},
+			"$unset": bson.M{"dynamicPrice": ""},
+		}
+	}
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &ticket,
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketId": ticketId,
+			},
+			Update: update,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateVenue links the row to a venue and copies the venue name and city into the free text the search reads
+func (c commandMongodbRepository) UpdateVenue(ctx context.Context, ticketId string, venueId string, place string,
+	city string) <-chan wrapper.Result {
+	var ticket entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &ticket,
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketId": ticketId,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"venueId":       venueId,
+					"country.place": place,
+					"country.city":  city,
+					"updatedAt":     time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CreateSearchIndex creates the text index the ticket search reads, event name and artist weigh the most
+func (c commandMongodbRepository) CreateSearchIndex(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.CreateIndex(mongodb.CreateIndex{
+			CollectionName: "ticket-detail",
+			Keys: bson.D{
+				{Key: "eventName", Value: "text"},
+				{Key: "artist", Value: "text"},
+				{Key: "tag", Value: "text"},
+				{Key: "country.name", Value: "text"},
+				{Key: "country.city", Value: "text"},
+				{Key: "country.place", Value: "text"},
+			},
+			Options: options.Index().SetName(constants.SearchIndexName).SetWeights(bson.M{
+				"eventName":     10,
+				"artist":        10,
+				"tag":           5,
+				"country.name":  3,
+				"country.city":  3,
+				"country.place": 3,
+			}),
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
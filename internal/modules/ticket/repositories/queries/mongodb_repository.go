@@ -5,9 +5,11 @@ import (
 	"ticket-service/internal/modules/ticket"
 	"ticket-service/internal/modules/ticket/models/entity"
 	"ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/pkg/constants"
 	"ticket-service/internal/pkg/databases/mongodb"
 	wrapper "ticket-service/internal/pkg/helpers"
 	"ticket-service/internal/pkg/log"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -122,27 +124,239 @@ func (q queryMongodbRepository) FindOnlineTicketByCountry(ctx context.Context, p
 	return output
 }
 
-// func (q queryMongodbRepository) FindTotalAvalailableTicket(ctx context.Context) <-chan wrapper.Result {
-// 	var ticket []entity.AggregateTotalTicket
-// 	output := make(chan wrapper.Result)
-
-// 	go func() {
-// 		resp := <-q.mongoDb.Aggregate(mongodb.Aggregate{
-// 			Result:         &ticket,
-// 			CollectionName: "ticket-detail",
-// 			Filter: []bson.M{
-// 				{
-// 					"$group": bson.M{
-// 						"_id":                  "$country.code",
-// 						"countryName":          bson.M{"$first": "$country.name"},
-// 						"totalAvailableTicket": bson.M{"$sum": "$totalRemaining"},
-// 					},
-// 				},
-// 			},
-// 		}, ctx)
-// 		output <- resp
-// 		close(output)
-// 	}()
-
-// 	return output
-// }
+func (q queryMongodbRepository) FindTicketByType(ctx context.Context, payload request.TicketTypeReq) <-chan wrapper.Result {
+	var ticket entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &ticket,
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketType":   payload.TicketType,
+				"country.code": payload.CountryCode,
+				"eventId":      payload.EventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindTicketsByEventCountry lists every tier of the event in the country, the Online tier included
+func (q queryMongodbRepository) FindTicketsByEventCountry(ctx context.Context, eventId string, countryCode string) <-chan wrapper.Result {
+	var tickets []entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &tickets,
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"country.code": countryCode,
+				"eventId":      eventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindOfflineTicketsByTag lists the offline tiers of the tag in every country, sold out ones included
+func (q queryMongodbRepository) FindOfflineTicketsByTag(ctx context.Context, tag string) <-chan wrapper.Result {
+	var tickets []entity.Ticket
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &tickets,
+			CollectionName: "ticket-detail",
+			Filter: bson.M{
+				"ticketType": bson.M{"$ne": "Online"},
+				"tag":        tag,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "ticketPrice",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindTotalAvailableTicket sums the tiers of every event and country matching the filter and returns a list holding
+// one page of them
+func (q queryMongodbRepository) FindTotalAvailableTicket(ctx context.Context, payload request.AvailabilityReq) <-chan wrapper.Result {
+	var ticket []entity.AggregateTotalTicketPage
+	output := make(chan wrapper.Result)
+
+	match := bson.M{}
+	if payload.EventId != "" {
+		match["eventId"] = payload.EventId
+	}
+	if payload.Tag != "" {
+		match["tag"] = payload.Tag
+	}
+	if payload.ContinentCode != "" {
+		match["continentCode"] = payload.ContinentCode
+	}
+
+	go func() {
+		resp := <-q.mongoDb.Aggregate(mongodb.Aggregate{
+			Result:         &ticket,
+			CollectionName: "ticket-detail",
+			Filter: []bson.M{
+				{
+					"$match": match,
+				},
+				{
+					"$sort": bson.D{{Key: "ticketPrice", Value: 1}},
+				},
+				{
+					"$group": bson.M{
+						"_id": bson.M{
+							"eventId":     "$eventId",
+							"countryCode": "$country.code",
+						},
+						"countryName":    bson.M{"$first": "$country.name"},
+						"continentCode":  bson.M{"$first": "$continentCode"},
+						"continentName":  bson.M{"$first": "$continentName"},
+						"totalQuota":     bson.M{"$sum": "$totalQuota"},
+						"totalRemaining": bson.M{"$sum": "$totalRemaining"},
+						"tiers": bson.M{"$push": bson.M{
+							"ticketType":     "$ticketType",
+							"totalQuota":     "$totalQuota",
+							"totalRemaining": "$totalRemaining",
+						}},
+					},
+				},
+				{
+					"$addFields": bson.M{
+						"sellThrough": bson.M{"$cond": bson.A{
+							bson.M{"$gt": bson.A{"$totalQuota", 0}},
+							bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{"$totalQuota", "$totalRemaining"}}, "$totalQuota"}},
+							0,
+						}},
+					},
+				},
+				{
+					"$sort": availabilitySort(payload.SortBy, payload.SortOrder),
+				},
+				{
+					"$facet": bson.M{
+						"meta": []bson.M{{"$count": "total"}},
+						"data": []bson.M{
+							{"$skip": payload.Size * (payload.Page - 1)},
+							{"$limit": payload.Size},
+						},
+					},
+				},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// availabilitySort orders by the requested field first, event and country break the ties so pages do not overlap
+func availabilitySort(sortBy string, sortOrder string) bson.D {
+	direction := 1
+	if sortOrder == "desc" {
+		direction = -1
+	}
+	sort := bson.D{}
+	switch sortBy {
+	case constants.AvailabilitySortRemaining:
+		sort = append(sort, bson.E{Key: "totalRemaining", Value: direction})
+	case constants.AvailabilitySortSellThrough:
+		sort = append(sort, bson.E{Key: "sellThrough", Value: direction})
+	case constants.AvailabilitySortCountry:
+		return append(sort, bson.E{Key: "_id.countryCode", Value: direction}, bson.E{Key: "_id.eventId", Value: 1})
+	}
+	return append(sort, bson.E{Key: "_id.eventId", Value: 1}, bson.E{Key: "_id.countryCode", Value: 1})
+}
+
+// SearchTickets matches the text and the filters per tier and groups the tiers by event and country. Price and
+// paging are left to the caller since the price on sale depends on the phases, at most SearchMaxGroups groups return
+func (q queryMongodbRepository) SearchTickets(ctx context.Context, payload request.SearchReq) <-chan wrapper.Result {
+	var ticketGroups []entity.TicketGroup
+	output := make(chan wrapper.Result)
+
+	match := bson.M{
+		"ticketType": bson.M{"$ne": "Online"},
+	}
+	score := bson.M{"$literal": 0}
+	if payload.Query != "" {
+		match["$text"] = bson.M{"$search": payload.Query}
+		score = bson.M{"$meta": "textScore"}
+	}
+	if payload.ContinentCode != "" {
+		match["continentCode"] = payload.ContinentCode
+	}
+	if payload.Available {
+		match["totalRemaining"] = bson.M{"$gt": 0}
+	}
+	eventDate := bson.M{}
+	if from, err := time.Parse(time.DateOnly, payload.DateFrom); err == nil {
+		eventDate["$gte"] = from
+	}
+	if to, err := time.Parse(time.DateOnly, payload.DateTo); err == nil {
+		eventDate["$lt"] = to.AddDate(0, 0, 1)
+	}
+	if len(eventDate) > 0 {
+		match["eventDate"] = eventDate
+	}
+
+	go func() {
+		resp := <-q.mongoDb.Aggregate(mongodb.Aggregate{
+			Result:         &ticketGroups,
+			CollectionName: "ticket-detail",
+			Filter: []bson.M{
+				{
+					"$match": match,
+				},
+				{
+					"$addFields": bson.M{"score": score},
+				},
+				{
+					"$sort": bson.D{{Key: "ticketPrice", Value: 1}},
+				},
+				{
+					"$group": bson.M{
+						"_id": bson.M{
+							"eventId":     "$eventId",
+							"countryCode": "$country.code",
+						},
+						"score":     bson.M{"$max": "$score"},
+						"eventDate": bson.M{"$min": "$eventDate"},
+						"tickets":   bson.M{"$push": "$$ROOT"},
+					},
+				},
+				{
+					"$sort": bson.D{
+						{Key: "score", Value: -1},
+						{Key: "eventDate", Value: 1},
+						{Key: "_id.eventId", Value: 1},
+						{Key: "_id.countryCode", Value: 1},
+					},
+				},
+				{
+					"$limit": constants.SearchMaxGroups,
+				},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
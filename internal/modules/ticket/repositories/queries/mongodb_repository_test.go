@@ -131,3 +131,51 @@ func (suite *CommandTestSuite) TestFindOnlineTicketByCountry() {
 	// Assert FindOne
 	suite.mockMongodb.AssertCalled(suite.T(), "FindOne", mock.Anything, mock.Anything)
 }
+
+func (suite *CommandTestSuite) TestFindTotalAvailableTicket() {
+	// Mock Aggregate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("Aggregate", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	request := request.AvailabilityReq{EventId: "id", SortBy: "remaining", Page: 2, Size: 20}
+	// Act
+	result := suite.repository.FindTotalAvailableTicket(suite.ctx, request)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert Aggregate
+	suite.mockMongodb.AssertCalled(suite.T(), "Aggregate", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandTestSuite) TestSearchTickets() {
+	// Mock Aggregate
+	expectedResult := make(chan helpers.Result)
+	suite.mockMongodb.On("Aggregate", mock.Anything, mock.Anything).Return((<-chan helpers.Result)(expectedResult))
+
+	request := request.SearchReq{Query: "coldplay", DateFrom: "2024-01-01", DateTo: "2024-12-31", Available: true}
+	// Act
+	result := suite.repository.SearchTickets(suite.ctx, request)
+	// Asset
+	assert.NotNil(suite.T(), result, "Expected a result")
+
+	// Simulate receiving a result from the channel
+	go func() {
+		expectedResult <- helpers.Result{Data: "result not nil", Error: nil}
+		close(expectedResult)
+	}()
+
+	// Wait for the goroutine to complete
+	<-result
+
+	// Assert Aggregate
+	suite.mockMongodb.AssertCalled(suite.T(), "Aggregate", mock.Anything, mock.Anything)
+}
@@ -2,15 +2,25 @@ package ticket
 
 import (
 	"context"
+	"ticket-service/internal/modules/ticket/models/entity"
 	"ticket-service/internal/modules/ticket/models/request"
 	"ticket-service/internal/modules/ticket/models/response"
+	"ticket-service/internal/pkg/constants"
 	wrapper "ticket-service/internal/pkg/helpers"
 )
 
 type UsecaseQuery interface {
-	FindTickets(origCtx context.Context, payload request.TicketReq) (*response.TicketResp, error)
+	FindTickets(origCtx context.Context, payload request.TicketReq) (*response.TicketResp, *constants.MetaData, error)
 	FindOnlineTicket(origCtx context.Context, payload request.TicketReq) (*response.Ticket, error)
-	// FindAvailableTicket(origCtx context.Context) ([]response.TicketCountry, error)
+	QuoteTicket(origCtx context.Context, payload request.QuoteReq) (*response.Quote, error)
+	FindAvailableTicket(origCtx context.Context, payload request.AvailabilityReq) ([]response.TicketCountry, *constants.MetaData, error)
+	SearchTickets(origCtx context.Context, payload request.SearchReq) ([]response.SearchResult, *constants.MetaData, error)
+}
+
+// UsecaseStream fans inventory changes out to the stream clients of every pod through redis pub/sub
+type UsecaseStream interface {
+	PublishAvailability(ctx context.Context, ticket entity.Ticket)
+	Subscribe(ctx context.Context, payload request.StreamReq) (<-chan response.AvailabilityDelta, error)
 }
 
 type MongodbRepositoryQuery interface {
@@ -18,5 +28,18 @@ type MongodbRepositoryQuery interface {
 	FindTicketByLowestPrice(ctx context.Context, tag string) <-chan wrapper.Result
 	FindOnlineTicketByCountry(ctx context.Context, payload request.TicketReq) <-chan wrapper.Result
 	FindOfflineTicketByCountryCode(ctx context.Context, countryCode string, tag string) <-chan wrapper.Result
-	// FindTotalAvalailableTicket(ctx context.Context) <-chan wrapper.Result
+	FindTicketByType(ctx context.Context, payload request.TicketTypeReq) <-chan wrapper.Result
+	FindTicketsByEventCountry(ctx context.Context, eventId string, countryCode string) <-chan wrapper.Result
+	FindOfflineTicketsByTag(ctx context.Context, tag string) <-chan wrapper.Result
+	FindTotalAvailableTicket(ctx context.Context, payload request.AvailabilityReq) <-chan wrapper.Result
+	SearchTickets(ctx context.Context, payload request.SearchReq) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	DecreaseTotalRemaining(ctx context.Context, ticketId string, quantity int) <-chan wrapper.Result
+	IncreaseTotalRemaining(ctx context.Context, ticketId string, quantity int) <-chan wrapper.Result
+	CancelEventTickets(ctx context.Context, eventId string) <-chan wrapper.Result
+	UpdateDynamicPrice(ctx context.Context, ticketId string, price int) <-chan wrapper.Result
+	UpdateVenue(ctx context.Context, ticketId string, venueId string, place string, city string) <-chan wrapper.Result
+	CreateSearchIndex(ctx context.Context) <-chan wrapper.Result
 }
@@ -4,11 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"ticket-service/internal/modules/fee"
+	feeDto "ticket-service/internal/modules/fee/models/dto"
+	"ticket-service/internal/modules/fx"
+	fxDto "ticket-service/internal/modules/fx/models/dto"
+	"ticket-service/internal/modules/presale"
+	presaleDto "ticket-service/internal/modules/presale/models/dto"
 	"ticket-service/internal/modules/ticket"
 	"ticket-service/internal/modules/ticket/models/entity"
 	"ticket-service/internal/modules/ticket/models/request"
 	"ticket-service/internal/modules/ticket/models/response"
+	"ticket-service/internal/modules/user"
+	"ticket-service/internal/modules/venue"
+	"ticket-service/internal/modules/voucher"
+	voucherDto "ticket-service/internal/modules/voucher/models/dto"
+	"ticket-service/internal/pkg/constants"
 	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
 	"ticket-service/internal/pkg/log"
 	"time"
 
@@ -19,19 +34,32 @@ import (
 
 type queryUsecase struct {
 	ticketRepositoryQuery ticket.MongodbRepositoryQuery
+	userRepositoryQuery   user.MongodbRepositoryQuery
+	presaleUsecaseQuery   presale.UsecaseQuery
+	voucherUsecaseQuery   voucher.UsecaseQuery
+	feeUsecaseQuery       fee.UsecaseQuery
+	fxUsecaseQuery        fx.UsecaseQuery
+	venueUsecaseQuery     venue.UsecaseQuery
 	kafkaProducer         kafkaConfluent.Producer
 	logger                log.Logger
 }
 
-func NewQueryUsecase(tmq ticket.MongodbRepositoryQuery, kp kafkaConfluent.Producer, log log.Logger) ticket.UsecaseQuery {
+func NewQueryUsecase(tmq ticket.MongodbRepositoryQuery, umq user.MongodbRepositoryQuery, puq presale.UsecaseQuery, vuq voucher.UsecaseQuery,
+	fuq fee.UsecaseQuery, xuq fx.UsecaseQuery, nuq venue.UsecaseQuery, kp kafkaConfluent.Producer, log log.Logger) ticket.UsecaseQuery {
 	return queryUsecase{
 		ticketRepositoryQuery: tmq,
+		userRepositoryQuery:   umq,
+		presaleUsecaseQuery:   puq,
+		voucherUsecaseQuery:   vuq,
+		feeUsecaseQuery:       fuq,
+		fxUsecaseQuery:        xuq,
+		venueUsecaseQuery:     nuq,
 		kafkaProducer:         kp,
 		logger:                log,
 	}
 }
 
-func (q queryUsecase) FindTickets(origCtx context.Context, payload request.TicketReq) (*response.TicketResp, error) {
+func (q queryUsecase) FindTickets(origCtx context.Context, payload request.TicketReq) (*response.TicketResp, *constants.MetaData, error) {
 	domain := "addressUsecase-FindTickets"
 	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
 		Start:  time.Now(),
@@ -39,105 +67,76 @@ func (q queryUsecase) FindTickets(origCtx context.Context, payload request.Ticke
 	})
 	defer span.End()
 
+	if payload.MaxPrice > 0 && payload.MinPrice > payload.MaxPrice {
+		return nil, nil, errors.BadRequest("minPrice cannot be greater than maxPrice")
+	}
+
+	presaleAccess, err := q.presaleUsecaseQuery.CheckPresaleAccess(ctx, presaleDto.AccessReq{
+		EventId:  payload.EventId,
+		UserId:   payload.UserId,
+		UserRole: payload.UserRole,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
 	resp := <-q.ticketRepositoryQuery.FindOfflineTicketByCountry(ctx, payload)
 	if resp.Error != nil {
 		msg := "Error query ticket"
 		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
-		return nil, resp.Error
+		return nil, nil, resp.Error
 	}
 
 	if resp.Data == nil {
 		msg := "Ticket Not Found"
 		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
-		return nil, errors.NotFound("ticket not found")
+		return nil, nil, errors.NotFound("ticket not found")
 	}
 
 	availableTicket, ok := resp.Data.(*[]entity.Ticket)
 	if !ok {
-		return nil, errors.InternalServerError("cannot parsing data")
+		return nil, nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	rate := q.findDisplayRate(ctx, payload.Currency, payload.UserId)
+	venues := q.findVenues(ctx, *availableTicket)
+	now := time.Now()
+	listed := listTickets(*availableTicket, now, rate, venues)
+	// during a presale only its allocation is on sale, so there is nothing to suggest elsewhere yet
+	if presaleAccess != nil {
+		for i, value := range *availableTicket {
+			listed[i].ticket.IsSold = listed[i].ticket.IsSold || presaleAccess.Remaining(value.TicketId, value.TotalQuota) <= 0
+		}
+		tickets, metaData := pageTickets(listed, payload)
+		return &response.TicketResp{
+			Tickets: tickets,
+			Presale: &response.Presale{
+				Name:  presaleAccess.Name,
+				EndAt: presaleAccess.EndAt,
+			},
+		}, &metaData, nil
 	}
 
 	var result response.TicketResp
 	var tag string
 	emptyCounter := 0
-	var collectionData = make([]response.Ticket, 0)
 	for _, value := range *availableTicket {
-		isSold := false
 		if value.TotalRemaining == 0 {
-			isSold = true
 			emptyCounter = emptyCounter + 1
 		}
-		collectionData = append(collectionData, response.Ticket{
-			TicketType:    value.TicketType,
-			TicketPrice:   fmt.Sprintf("$%d", value.TicketPrice),
-			ContinentName: value.ContinentName,
-			ContinentCode: value.ContinentCode,
-			CountryName:   value.Country.Name,
-			CountryCode:   value.Country.Code,
-			IsSold:        isSold,
-		})
 		tag = value.Tag
 	}
-	result.Tickets = collectionData
+	tickets, metaData := pageTickets(listed, payload)
+	result.Tickets = tickets
 
 	if emptyCounter >= 4 {
-		res := <-q.ticketRepositoryQuery.FindTicketByLowestPrice(ctx, tag)
-		if res.Error != nil {
-			msg := "Error query ticket"
-			q.logger.Error(ctx, msg, fmt.Sprintf("%+v", res.Error))
-			return nil, res.Error
-		}
-
-		if res.Data == nil {
-			msg := "Ticket Not Found"
-			q.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
-			return nil, errors.NotFound("ticket not found")
-		}
-
-		suggestionTicket, ok := res.Data.(*[]entity.Ticket)
-		if !ok {
-			return nil, errors.InternalServerError("cannot parsing data")
+		suggestions, err := q.suggestCountries(ctx, payload, *availableTicket, tag, now)
+		if err != nil {
+			return nil, nil, err
 		}
-
-		if len(*suggestionTicket) > 0 {
-			resp := <-q.ticketRepositoryQuery.FindOfflineTicketByCountryCode(ctx, (*suggestionTicket)[0].Country.Code, tag)
-			if resp.Error != nil {
-				msg := "Error query ticket"
-				q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
-				return nil, resp.Error
-			}
-
-			if resp.Data == nil {
-				msg := "Ticket Not Found"
-				q.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
-				return nil, errors.NotFound("ticket not found")
-			}
-
-			availableTicket, ok := resp.Data.(*[]entity.Ticket)
-			if !ok {
-				return nil, errors.InternalServerError("cannot parsing data")
-			}
-
-			var collectionData = make([]response.SuggestionTicket, 0)
-			for _, value := range *availableTicket {
-				isSold := false
-				if value.TotalRemaining == 0 {
-					isSold = true
-				}
-				discountPrice := value.TicketPrice * 80 / 100
-				collectionData = append(collectionData, response.SuggestionTicket{
-					TicketType:          value.TicketType,
-					NormalTicketPrice:   fmt.Sprintf("$%d", value.TicketPrice),
-					DiscountTicketPrice: fmt.Sprintf("$%d", discountPrice),
-					Discount:            "20%",
-					ContinentName:       value.ContinentName,
-					ContinentCode:       value.ContinentCode,
-					CountryName:         value.Country.Name,
-					CountryCode:         value.Country.Code,
-					IsSold:              isSold,
-				})
-			}
-			result.Suggestion = collectionData
+		result.Suggestions = suggestions
+		if len(suggestions) > 0 {
+			result.Suggestion = suggestions[0].Tickets
 		}
 
 		updateOnlineTicket := request.CreateOnlineTicketReq{
@@ -150,7 +149,7 @@ func (q queryUsecase) FindTickets(origCtx context.Context, payload request.Ticke
 		q.logger.Info(ctx, fmt.Sprintf("Send kafka update online bank ticket, tag : %s", tag), fmt.Sprintf("%+v", updateOnlineTicket))
 	}
 
-	return &result, nil
+	return &result, &metaData, nil
 
 }
 
@@ -209,58 +208,335 @@ func (q queryUsecase) FindOnlineTicket(origCtx context.Context, payload request.
 		return nil, errors.InternalServerError("cannot parsing data")
 	}
 
-	var result response.Ticket
-	result.CountryCode = availableTicket.Country.Code
-	result.CountryName = availableTicket.Country.Name
-	result.ContinentCode = availableTicket.ContinentCode
-	result.ContinentName = availableTicket.ContinentName
-	result.TicketPrice = fmt.Sprintf("$%d", availableTicket.TicketPrice)
-	result.TicketType = availableTicket.TicketType
-	if availableTicket.TotalRemaining == 0 {
-		result.IsSold = true
+	result := mapTicket(*availableTicket, time.Now(), q.findDisplayRate(ctx, payload.Currency, payload.UserId))
+	result.Venue = q.findVenues(ctx, []entity.Ticket{*availableTicket})[availableTicket.VenueId]
+	return &result, nil
+
+}
+
+// QuoteTicket prices an order the way CreateReservation will, with the vouchers previewed and not redeemed
+func (q queryUsecase) QuoteTicket(origCtx context.Context, payload request.QuoteReq) (*response.Quote, error) {
+	domain := "ticketUsecase-QuoteTicket"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.ticketRepositoryQuery.FindTicketByType(ctx, request.TicketTypeReq{
+		EventId:     payload.EventId,
+		CountryCode: payload.CountryCode,
+		TicketType:  payload.TicketType,
+	})
+	if resp.Error != nil {
+		msg := "Error query ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
 	}
 
-	return &result, nil
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	ticketDetail, ok := resp.Data.(*entity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	if ticketDetail.Cancelled {
+		return nil, errors.UnprocessableEntity("event is cancelled")
+	}
 
+	pricing := ticketDetail.PriceAt(time.Now(), ticketDetail.Sold())
+	discount := 0
+	voucherCodes := make([]string, 0)
+	if len(payload.VoucherCodes) > 0 {
+		quote, err := q.voucherUsecaseQuery.PreviewVouchers(ctx, payload.VoucherCodes, voucherDto.Target{
+			UserId:      payload.UserId,
+			EventId:     ticketDetail.EventId,
+			Tag:         ticketDetail.Tag,
+			TicketType:  ticketDetail.TicketType,
+			CountryCode: payload.CountryCode,
+			TicketPrice: pricing.Price,
+			Quantity:    payload.Quantity,
+		})
+		if err != nil {
+			return nil, err
+		}
+		discount = quote.TotalDiscount
+		for _, value := range quote.Vouchers {
+			voucherCodes = append(voucherCodes, value.Code)
+		}
+	}
+
+	breakdown, err := q.feeUsecaseQuery.CalculateBreakdown(ctx, feeDto.BreakdownReq{
+		EventId:     ticketDetail.EventId,
+		CountryCode: payload.CountryCode,
+		TicketPrice: pricing.Price,
+		Quantity:    payload.Quantity,
+		Discount:    discount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	taxes := make([]response.Tax, 0)
+	for _, value := range breakdown.Taxes {
+		taxes = append(taxes, response.Tax{
+			Name:         value.Name,
+			Jurisdiction: value.Jurisdiction,
+			Percent:      value.Percent,
+			Amount:       fmt.Sprintf("$%d", value.Amount),
+		})
+	}
+	result := &response.Quote{
+		EventId:      ticketDetail.EventId,
+		TicketType:   ticketDetail.TicketType,
+		CountryCode:  payload.CountryCode,
+		Quantity:     payload.Quantity,
+		TicketPrice:  fmt.Sprintf("$%d", pricing.Price),
+		PricePhase:   pricing.Phase,
+		FaceValue:    fmt.Sprintf("$%d", breakdown.FaceValue),
+		Discount:     fmt.Sprintf("$%d", breakdown.Discount),
+		VoucherCodes: voucherCodes,
+		ServiceFee:   fmt.Sprintf("$%d", breakdown.ServiceFee),
+		FacilityFee:  fmt.Sprintf("$%d", breakdown.FacilityFee),
+		Taxes:        taxes,
+		TotalTax:     fmt.Sprintf("$%d", breakdown.TotalTax),
+		Total:        fmt.Sprintf("$%d", breakdown.Total),
+		Currency:     constants.NativeCurrency,
+	}
+	if rate := q.findDisplayRate(ctx, payload.Currency, payload.UserId); rate != nil {
+		result.DisplayTotal = mapDisplayPrice(*rate, breakdown.Total)
+	}
+	return result, nil
+}
+
+// findDisplayRate never fails the request, without a rate the prices are only shown in the native currency
+func (q queryUsecase) findDisplayRate(ctx context.Context, currency string, userId string) *fxDto.Rate {
+	rate, err := q.fxUsecaseQuery.FindDisplayRate(ctx, fxDto.DisplayReq{
+		Currency: currency,
+		UserId:   userId,
+	})
+	if err != nil {
+		msg := "Error query display rate"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return nil
+	}
+	return rate
 }
 
-// func (q queryUsecase) FindAvailableTicket(origCtx context.Context) ([]response.TicketCountry, error) {
-// 	domain := "addressUsecase-FindAvailableTicket"
-// 	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
-// 		Start:  time.Now(),
-// 		Parent: apm.TraceContext{},
-// 	})
-// 	defer span.End()
-
-// 	resp := <-q.ticketRepositoryQuery.FindTotalAvalailableTicket(ctx)
-// 	if resp.Error != nil {
-// 		msg := "Error query ticket"
-// 		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
-// 		return nil, resp.Error
-// 	}
-
-// 	if resp.Data == nil {
-// 		msg := "Ticket Not Found"
-// 		q.logger.Error(ctx, msg, resp)
-// 		return nil, errors.NotFound("ticket not found")
-// 	}
-
-// 	availableTicket, ok := resp.Data.(*[]entity.AggregateTotalTicket)
-// 	if !ok {
-// 		return nil, errors.InternalServerError("cannot parsing data")
-// 	}
-
-// 	var collectionData = make([]response.TicketCountry, 0)
-// 	for _, value := range *availableTicket {
-// 		isSold := false
-// 		if value.TotalAvailableTicket == 0 {
-// 			isSold = true
-// 		}
-// 		collectionData = append(collectionData, response.TicketCountry{
-// 			CountryName: value.CountryName,
-// 			CountryCode: value.Id,
-// 			IsSold:      isSold,
-// 		})
-// 	}
-// 	return collectionData, nil
-// }
+// findVenues resolves the venues the tiers are linked to, a tier whose venue cannot be read is shown without one
+func (q queryUsecase) findVenues(ctx context.Context, tickets []entity.Ticket) map[string]*response.Venue {
+	venues := make(map[string]*response.Venue)
+	for _, value := range tickets {
+		if value.VenueId == "" {
+			continue
+		}
+		if _, ok := venues[value.VenueId]; ok {
+			continue
+		}
+		venueDetail, err := q.venueUsecaseQuery.FindVenue(ctx, value.VenueId)
+		if err != nil {
+			msg := "Error query venue"
+			q.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+			venues[value.VenueId] = nil
+			continue
+		}
+		venues[value.VenueId] = &response.Venue{
+			VenueId:   venueDetail.VenueId,
+			Name:      venueDetail.Name,
+			Address:   venueDetail.Address,
+			CityName:  venueDetail.CityName,
+			Capacity:  venueDetail.Capacity,
+			Timezone:  venueDetail.Timezone,
+			Latitude:  venueDetail.Latitude,
+			Longitude: venueDetail.Longitude,
+		}
+	}
+	return venues
+}
+
+// listedTicket keeps the price on sale now next to the mapped tier for the list filters and sort
+type listedTicket struct {
+	ticket    response.Ticket
+	price     int
+	remaining int
+}
+
+func listTickets(tickets []entity.Ticket, now time.Time, rate *fxDto.Rate, venues map[string]*response.Venue) []listedTicket {
+	listed := make([]listedTicket, 0)
+	for _, value := range tickets {
+		ticketData := mapTicket(value, now, rate)
+		ticketData.Venue = venues[value.VenueId]
+		listed = append(listed, listedTicket{
+			ticket:    ticketData,
+			price:     value.PriceAt(now, value.Sold()).Price,
+			remaining: value.TotalRemaining,
+		})
+	}
+	return listed
+}
+
+// pageTickets filters, sorts and pages the tiers of one event in one country. They are few, so this runs on the
+// whole list rather than in mongo where the price on sale now is unknown
+func pageTickets(listed []listedTicket, payload request.TicketReq) ([]response.Ticket, constants.MetaData) {
+	page, size := payload.Page, payload.Size
+	if page == 0 {
+		page = 1
+	}
+	if size == 0 {
+		size = constants.TicketPageSize
+	}
+
+	filtered := make([]listedTicket, 0)
+	for _, value := range listed {
+		if payload.TicketType != "" && !strings.EqualFold(value.ticket.TicketType, payload.TicketType) {
+			continue
+		}
+		if payload.Available && value.ticket.IsSold {
+			continue
+		}
+		if value.price < payload.MinPrice || (payload.MaxPrice > 0 && value.price > payload.MaxPrice) {
+			continue
+		}
+		filtered = append(filtered, value)
+	}
+
+	// without a sort the list keeps the order of the repository, cheapest list price first
+	desc := payload.SortOrder == "desc"
+	sort.SliceStable(filtered, func(i, j int) bool {
+		a, b := filtered[i], filtered[j]
+		switch payload.SortBy {
+		case constants.TicketSortPrice:
+			return a.price != b.price && (a.price < b.price) != desc
+		case constants.TicketSortRemaining:
+			return a.remaining != b.remaining && (a.remaining < b.remaining) != desc
+		case constants.TicketSortTicketType:
+			return a.ticket.TicketType != b.ticket.TicketType && (a.ticket.TicketType < b.ticket.TicketType) != desc
+		}
+		return false
+	})
+
+	collectionData := make([]response.Ticket, 0)
+	start := (page - 1) * size
+	for i := start; i < start+size && i < int64(len(filtered)); i++ {
+		collectionData = append(collectionData, filtered[i].ticket)
+	}
+	return collectionData, helpers.GenerateMetaData(int64(len(filtered)), int64(len(collectionData)), page, size)
+}
+
+// mapTicket shows the price of the active phase, the reservation locks in the same price
+func mapTicket(value entity.Ticket, now time.Time, rate *fxDto.Rate) response.Ticket {
+	pricing := value.PriceAt(now, value.Sold())
+	result := response.Ticket{
+		TicketType:    value.TicketType,
+		TicketPrice:   fmt.Sprintf("$%d", pricing.Price),
+		Currency:      constants.NativeCurrency,
+		PricePhase:    pricing.Phase,
+		ContinentName: value.ContinentName,
+		ContinentCode: value.ContinentCode,
+		CountryName:   value.Country.Name,
+		CountryCode:   value.Country.Code,
+		IsSold:        value.TotalRemaining == 0,
+	}
+	if pricing.HasNext {
+		result.NextPrice = &response.PriceChange{
+			TicketPrice: fmt.Sprintf("$%d", pricing.NextPrice),
+			Remaining:   pricing.Remaining,
+		}
+		if !pricing.NextChangeAt.IsZero() {
+			result.NextPrice.At = &pricing.NextChangeAt
+		}
+	}
+	if rate != nil {
+		result.DisplayPrice = mapDisplayPrice(*rate, pricing.Price)
+	}
+	return result
+}
+
+func mapDisplayPrice(rate fxDto.Rate, amount int) *response.DisplayPrice {
+	return &response.DisplayPrice{
+		Currency:   rate.Currency,
+		Amount:     rate.Format(amount),
+		Indicative: true,
+		AsOf:       rate.AsOf,
+	}
+}
+
+func (q queryUsecase) FindAvailableTicket(origCtx context.Context, payload request.AvailabilityReq) ([]response.TicketCountry,
+	*constants.MetaData, error) {
+	domain := "ticketUsecase-FindAvailableTicket"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if payload.Page == 0 {
+		payload.Page = 1
+	}
+	if payload.Size == 0 {
+		payload.Size = constants.AvailabilityPageSize
+	}
+
+	resp := <-q.ticketRepositoryQuery.FindTotalAvailableTicket(ctx, payload)
+	if resp.Error != nil {
+		msg := "Error query ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, nil, resp.Error
+	}
+
+	collectionData := make([]response.TicketCountry, 0)
+	var totalData int64
+	if resp.Data != nil {
+		availablePages, ok := resp.Data.(*[]entity.AggregateTotalTicketPage)
+		if !ok {
+			return nil, nil, errors.InternalServerError("cannot parsing data")
+		}
+		if len(*availablePages) > 0 {
+			availablePage := (*availablePages)[0]
+			totalData = availablePage.Total()
+			for _, value := range availablePage.Data {
+				collectionData = append(collectionData, mapTicketCountry(value))
+			}
+		}
+	}
+
+	metaData := helpers.GenerateMetaData(totalData, int64(len(collectionData)), payload.Page, payload.Size)
+	return collectionData, &metaData, nil
+}
+
+func mapTicketCountry(value entity.AggregateTotalTicket) response.TicketCountry {
+	result := response.TicketCountry{
+		EventId:        value.Id.EventId,
+		CountryName:    value.CountryName,
+		CountryCode:    value.Id.CountryCode,
+		ContinentCode:  value.ContinentCode,
+		ContinentName:  value.ContinentName,
+		TotalQuota:     value.TotalQuota,
+		TotalRemaining: value.TotalRemaining,
+		TotalSold:      value.TotalQuota - value.TotalRemaining,
+		SellThrough:    sellThroughPercent(value.TotalQuota, value.TotalRemaining),
+		IsSold:         value.TotalRemaining == 0,
+		Tiers:          make([]response.TierSummary, 0),
+	}
+	for _, tier := range value.Tiers {
+		result.Tiers = append(result.Tiers, response.TierSummary{
+			TicketType:     tier.TicketType,
+			TotalQuota:     tier.TotalQuota,
+			TotalRemaining: tier.TotalRemaining,
+			TotalSold:      tier.TotalQuota - tier.TotalRemaining,
+			SellThrough:    sellThroughPercent(tier.TotalQuota, tier.TotalRemaining),
+			IsSold:         tier.TotalRemaining == 0,
+		})
+	}
+	return result
+}
+
+// sellThroughPercent is the sold share of the quota rounded to one decimal, a tier without quota has sold nothing
+func sellThroughPercent(quota int, remaining int) float64 {
+	if quota <= 0 {
+		return 0
+	}
+	return math.Round(float64(quota-remaining)*1000/float64(quota)) / 10
+}
@@ -4,14 +4,27 @@ package usecases_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	feeDto "ticket-service/internal/modules/fee/models/dto"
+	fxDto "ticket-service/internal/modules/fx/models/dto"
+	presaleDto "ticket-service/internal/modules/presale/models/dto"
 	"ticket-service/internal/modules/ticket"
 	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
 	ticketRequest "ticket-service/internal/modules/ticket/models/request"
 	uc "ticket-service/internal/modules/ticket/usecases"
+	userEntity "ticket-service/internal/modules/user/models/entity"
+	venueResponse "ticket-service/internal/modules/venue/models/response"
+	voucherDto "ticket-service/internal/modules/voucher/models/dto"
 	"ticket-service/internal/pkg/errors"
 	"ticket-service/internal/pkg/helpers"
+	mockfee "ticket-service/mocks/modules/fee"
+	mockfx "ticket-service/mocks/modules/fx"
+	mockpresale "ticket-service/mocks/modules/presale"
 	mockcert "ticket-service/mocks/modules/ticket"
+	mockuser "ticket-service/mocks/modules/user"
+	mockvenue "ticket-service/mocks/modules/venue"
+	mockvoucher "ticket-service/mocks/modules/voucher"
 	mockkafka "ticket-service/mocks/pkg/kafka"
 	mocklog "ticket-service/mocks/pkg/log"
 
@@ -23,6 +36,12 @@ import (
 type QueryUsecaseTestSuite struct {
 	suite.Suite
 	mockTicketRepositoryQuery *mockcert.MongodbRepositoryQuery
+	mockUserRepositoryQuery   *mockuser.MongodbRepositoryQuery
+	mockPresaleUsecaseQuery   *mockpresale.UsecaseQuery
+	mockVoucherUsecaseQuery   *mockvoucher.UsecaseQuery
+	mockFeeUsecaseQuery       *mockfee.UsecaseQuery
+	mockFxUsecaseQuery        *mockfx.UsecaseQuery
+	mockVenueUsecaseQuery     *mockvenue.UsecaseQuery
 	mockKafkaProducer         *mockkafka.Producer
 	mockLogger                *mocklog.Logger
 	usecase                   ticket.UsecaseQuery
@@ -31,14 +50,29 @@ type QueryUsecaseTestSuite struct {
 
 func (suite *QueryUsecaseTestSuite) SetupTest() {
 	suite.mockTicketRepositoryQuery = &mockcert.MongodbRepositoryQuery{}
+	suite.mockUserRepositoryQuery = &mockuser.MongodbRepositoryQuery{}
+	suite.mockPresaleUsecaseQuery = &mockpresale.UsecaseQuery{}
+	suite.mockVoucherUsecaseQuery = &mockvoucher.UsecaseQuery{}
+	suite.mockFeeUsecaseQuery = &mockfee.UsecaseQuery{}
+	suite.mockFxUsecaseQuery = &mockfx.UsecaseQuery{}
+	suite.mockVenueUsecaseQuery = &mockvenue.UsecaseQuery{}
 	suite.mockKafkaProducer = &mockkafka.Producer{}
 	suite.mockLogger = &mocklog.Logger{}
 	suite.ctx = context.Background()
 	suite.usecase = uc.NewQueryUsecase(
 		suite.mockTicketRepositoryQuery,
+		suite.mockUserRepositoryQuery,
+		suite.mockPresaleUsecaseQuery,
+		suite.mockVoucherUsecaseQuery,
+		suite.mockFeeUsecaseQuery,
+		suite.mockFxUsecaseQuery,
+		suite.mockVenueUsecaseQuery,
 		suite.mockKafkaProducer,
 		suite.mockLogger,
 	)
+	suite.mockPresaleUsecaseQuery.On("CheckPresaleAccess", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.mockFxUsecaseQuery.On("FindDisplayRate", mock.Anything, mock.Anything).Return(nil, nil)
+	suite.mockVenueUsecaseQuery.On("FindCountries", mock.Anything, mock.Anything).Return([]venueResponse.Country{}, nil)
 }
 func TestQueryUsecaseTestSuite(t *testing.T) {
 	suite.Run(t, new(QueryUsecaseTestSuite))
@@ -65,19 +99,336 @@ func (suite *QueryUsecaseTestSuite) TestFindTicketSuccess() {
 		Error: nil,
 	}
 	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
 	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
 	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	result, err := suite.usecase.FindTickets(suite.ctx, payload)
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
 
 	// Assert
 	assert.NoError(suite.T(), err)
 	assert.NotNil(suite.T(), result)
 }
 
+func (suite *QueryUsecaseTestSuite) TestFindTicketDisplayPrice() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+		Currency:    "IDR",
+		UserId:      "user-id",
+	}
+	asOf := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	suite.mockFxUsecaseQuery.ExpectedCalls = nil
+	suite.mockFxUsecaseQuery.On("FindDisplayRate", mock.Anything, fxDto.DisplayReq{Currency: "IDR", UserId: "user-id"}).
+		Return(&fxDto.Rate{Currency: "IDR", Rate: 15500, Decimals: 0, AsOf: asOf}, nil)
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(helpers.Result{
+		Data: &[]ticketEntity.Ticket{{TicketId: "id", EventId: "id", TicketType: "type", TicketPrice: 50, TotalRemaining: 5}},
+	}))
+
+	// Act
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$50", result.Tickets[0].TicketPrice)
+	assert.Equal(suite.T(), "USD", result.Tickets[0].Currency)
+	assert.Equal(suite.T(), "IDR 775000", result.Tickets[0].DisplayPrice.Amount)
+	assert.True(suite.T(), result.Tickets[0].DisplayPrice.Indicative)
+	assert.Equal(suite.T(), asOf, result.Tickets[0].DisplayPrice.AsOf)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketErrDisplayRate() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+		Currency:    "EUR",
+	}
+	suite.mockFxUsecaseQuery.ExpectedCalls = nil
+	suite.mockFxUsecaseQuery.On("FindDisplayRate", mock.Anything, mock.Anything).Return(nil, errors.InternalServerError("error"))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(helpers.Result{
+		Data: &[]ticketEntity.Ticket{{TicketId: "id", EventId: "id", TicketType: "type", TicketPrice: 50, TotalRemaining: 5}},
+	}))
+
+	// Act
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$50", result.Tickets[0].TicketPrice)
+	assert.Nil(suite.T(), result.Tickets[0].DisplayPrice)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketVenue() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+	}
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{TicketId: "id-1", EventId: "id", TicketType: "Gold", TicketPrice: 50, TotalRemaining: 5, VenueId: "venue-id"},
+			{TicketId: "id-2", EventId: "id", TicketType: "Silver", TicketPrice: 30, TotalRemaining: 5, VenueId: "venue-id"},
+		},
+	}))
+	suite.mockVenueUsecaseQuery.On("FindVenue", mock.Anything, "venue-id").
+		Return(&venueResponse.Venue{VenueId: "venue-id", Name: "Grand Hall", CityName: "Jakarta", Capacity: 5000}, nil).Once()
+
+	// Act
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Grand Hall", result.Tickets[0].Venue.Name)
+	assert.Equal(suite.T(), "Jakarta", result.Tickets[1].Venue.CityName)
+	suite.mockVenueUsecaseQuery.AssertNumberOfCalls(suite.T(), "FindVenue", 1)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketErrVenue() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+	}
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(helpers.Result{
+		Data: &[]ticketEntity.Ticket{{TicketId: "id", EventId: "id", TicketType: "Gold", TicketPrice: 50, TotalRemaining: 5, VenueId: "venue-id"}},
+	}))
+	suite.mockVenueUsecaseQuery.On("FindVenue", mock.Anything, "venue-id").Return(nil, errors.NotFound("venue not found"))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$50", result.Tickets[0].TicketPrice)
+	assert.Nil(suite.T(), result.Tickets[0].Venue)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketPresale() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+		UserId:      "user-id",
+		UserRole:    "fanclub",
+	}
+
+	mockTicketQueryResponse := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{
+				TicketId:       "gold",
+				TicketType:     "Gold",
+				TicketPrice:    50,
+				TotalQuota:     100,
+				TotalRemaining: 90,
+			},
+			{
+				TicketId:       "silver",
+				TicketType:     "Silver",
+				TicketPrice:    30,
+				TotalQuota:     100,
+				TotalRemaining: 100,
+			},
+		},
+	}
+	suite.mockPresaleUsecaseQuery.ExpectedCalls = nil
+	suite.mockPresaleUsecaseQuery.On("CheckPresaleAccess", mock.Anything, presaleDto.AccessReq{
+		EventId:  "id",
+		UserId:   "user-id",
+		UserRole: "fanclub",
+	}).Return(&presaleDto.Access{
+		PresaleId:         "presale-id",
+		Name:              "Fan Club",
+		EndAt:             time.Now().Add(time.Hour),
+		AllocationPercent: 10,
+		Eligible:          true,
+		Sold:              map[string]int{"gold": 10},
+	}, nil)
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(mockTicketQueryResponse))
+
+	// Act
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Fan Club", result.Presale.Name)
+	assert.True(suite.T(), result.Tickets[0].IsSold)
+	assert.False(suite.T(), result.Tickets[1].IsSold)
+	suite.mockTicketRepositoryQuery.AssertNotCalled(suite.T(), "FindOfflineTicketsByTag", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketPricePhaseByQuantity() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+	}
+	mockTicketQueryResponse := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{
+				TicketId:       "id",
+				TicketType:     "Gold",
+				TicketPrice:    50,
+				TotalQuota:     1000,
+				TotalRemaining: 520,
+				PricePhases: []ticketEntity.PricePhase{
+					{Name: "Early Bird", Price: 40, MaxSold: 500},
+				},
+			},
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(mockTicketQueryResponse))
+
+	// Act
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$40", result.Tickets[0].TicketPrice)
+	assert.Equal(suite.T(), "Early Bird", result.Tickets[0].PricePhase)
+	assert.Equal(suite.T(), "$50", result.Tickets[0].NextPrice.TicketPrice)
+	assert.Equal(suite.T(), 20, result.Tickets[0].NextPrice.Remaining)
+	assert.Nil(suite.T(), result.Tickets[0].NextPrice.At)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketPricePhaseScheduled() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+	}
+	regularEnd := time.Now().Add(time.Hour)
+	mockTicketQueryResponse := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{
+				TicketId:       "id",
+				TicketType:     "Gold",
+				TicketPrice:    50,
+				TotalQuota:     1000,
+				TotalRemaining: 100,
+				PricePhases: []ticketEntity.PricePhase{
+					{Name: "Early Bird", Price: 40, MaxSold: 500},
+					{Name: "Regular", Price: 50, EndAt: regularEnd},
+					{Name: "Door", Price: 70, StartAt: regularEnd},
+				},
+			},
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(mockTicketQueryResponse))
+
+	// Act
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$50", result.Tickets[0].TicketPrice)
+	assert.Equal(suite.T(), "Regular", result.Tickets[0].PricePhase)
+	assert.Equal(suite.T(), "$70", result.Tickets[0].NextPrice.TicketPrice)
+	assert.True(suite.T(), regularEnd.Equal(*result.Tickets[0].NextPrice.At))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketWithoutPricePhase() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+	}
+	mockTicketQueryResponse := helpers.Result{
+		Data: &[]ticketEntity.Ticket{
+			{
+				TicketId:       "id",
+				TicketType:     "Gold",
+				TicketPrice:    50,
+				TotalQuota:     1000,
+				TotalRemaining: 100,
+			},
+		},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(mockTicketQueryResponse))
+
+	// Act
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$50", result.Tickets[0].TicketPrice)
+	assert.Empty(suite.T(), result.Tickets[0].PricePhase)
+	assert.Nil(suite.T(), result.Tickets[0].NextPrice)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketFilterSortPage() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+		Available:   true,
+		MaxPrice:    100,
+		SortBy:      "price",
+		SortOrder:   "desc",
+		Size:        2,
+	}
+	tickets := []ticketEntity.Ticket{
+		{TicketId: "bronze", TicketType: "Bronze", TicketPrice: 20, TotalQuota: 100, TotalRemaining: 10},
+		{TicketId: "silver", TicketType: "Silver", TicketPrice: 40, TotalQuota: 100, TotalRemaining: 0},
+		{TicketId: "gold", TicketType: "Gold", TicketPrice: 60, TotalQuota: 100, TotalRemaining: 10},
+		{TicketId: "platinum", TicketType: "Platinum", TicketPrice: 80, TotalQuota: 100, TotalRemaining: 10, DynamicPrice: 120},
+		{TicketId: "vip", TicketType: "VIP", TicketPrice: 90, TotalQuota: 100, TotalRemaining: 10},
+	}
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).
+		Return(mockChannel(helpers.Result{Data: &tickets}))
+
+	// Act
+	result, metaData, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"VIP", "Gold"}, []string{result.Tickets[0].TicketType, result.Tickets[1].TicketType})
+	assert.Equal(suite.T(), int64(3), metaData.TotalData)
+	assert.Equal(suite.T(), int64(2), metaData.TotalPage)
+	assert.Empty(suite.T(), result.Suggestions)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketErrPriceRange() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+		MinPrice:    100,
+		MaxPrice:    50,
+	}
+
+	// Act
+	result, metaData, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Nil(suite.T(), metaData)
+	assert.Equal(suite.T(), errors.BadRequest("minPrice cannot be greater than maxPrice"), err)
+	suite.mockTicketRepositoryQuery.AssertNotCalled(suite.T(), "FindOfflineTicketByCountry", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTicketErrPresaleOnly() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+		UserId:      "user-id",
+	}
+	suite.mockPresaleUsecaseQuery.ExpectedCalls = nil
+	suite.mockPresaleUsecaseQuery.On("CheckPresaleAccess", mock.Anything, mock.Anything).
+		Return(nil, errors.ForbiddenError("tickets are only available to Fan Club members"))
+
+	// Act
+	_, _, err := suite.usecase.FindTickets(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.ForbiddenError("tickets are only available to Fan Club members"), err)
+	suite.mockTicketRepositoryQuery.AssertNotCalled(suite.T(), "FindOfflineTicketByCountry", mock.Anything, mock.Anything)
+}
+
 func (suite *QueryUsecaseTestSuite) TestFindTicketErr() {
 	// Arrange
 	payload := ticketRequest.TicketReq{
@@ -99,12 +450,10 @@ func (suite *QueryUsecaseTestSuite) TestFindTicketErr() {
 		Error: errors.BadRequest("error"),
 	}
 	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
 	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	_, err := suite.usecase.FindTickets(suite.ctx, payload)
+	_, _, err := suite.usecase.FindTickets(suite.ctx, payload)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -114,13 +463,11 @@ func (suite *QueryUsecaseTestSuite) TestFindTicketErr() {
 		Error: nil,
 	}
 	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
 	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
 	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	_, err = suite.usecase.FindTickets(suite.ctx, payload)
+	_, _, err = suite.usecase.FindTickets(suite.ctx, payload)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -141,13 +488,11 @@ func (suite *QueryUsecaseTestSuite) TestFindTicketErrParse() {
 		Error: nil,
 	}
 	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
 	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
 	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	_, err := suite.usecase.FindTickets(suite.ctx, payload)
+	_, _, err := suite.usecase.FindTickets(suite.ctx, payload)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -198,13 +543,12 @@ func (suite *QueryUsecaseTestSuite) TestFindTicketSuggestion() {
 		Error: nil,
 	}
 	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketsByTag", mock.Anything, mock.Anything).Return(mockChannel(mockTicketQueryResponse))
 	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
 	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	result, err := suite.usecase.FindTickets(suite.ctx, payload)
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
 
 	// Assert
 	assert.NoError(suite.T(), err)
@@ -222,13 +566,12 @@ func (suite *QueryUsecaseTestSuite) TestFindTicketSuggestionErrParse() {
 		Error: errors.BadRequest("error"),
 	}
 	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(getMockTicketSold()))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(mockTicketLowestPrice))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(getMockTicketSold()))
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketsByTag", mock.Anything, mock.Anything).Return(mockChannel(mockTicketLowestPrice))
 	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
 	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	_, err := suite.usecase.FindTickets(suite.ctx, payload)
+	_, _, err := suite.usecase.FindTickets(suite.ctx, payload)
 
 	// Assert
 	assert.Error(suite.T(), err)
@@ -247,108 +590,89 @@ func (suite *QueryUsecaseTestSuite) TestFindTicketSuggestionErrNil() {
 	}
 
 	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(getMockTicketSold()))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(nilTicketLowestPrice))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(getMockTicketSold()))
-	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
-	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
-
-	// Act
-	_, err := suite.usecase.FindTickets(suite.ctx, payload)
-	suite.T().Log(err)
-
-	// Assert
-	assert.Error(suite.T(), err)
-}
-
-func (suite *QueryUsecaseTestSuite) TestFindTicketErrMarshal() {
-	// Arrange
-	payload := ticketRequest.TicketReq{
-		CountryCode: "code",
-		EventId:     "id",
-	}
-
-	nilTicketLowestPrice := helpers.Result{
-		Data: &ticketEntity.Country{
-			Code: "code",
-			Name: "name",
-		},
-		Error: nil,
-	}
-
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(getMockTicketSold()))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(nilTicketLowestPrice))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(getMockTicketSold()))
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketsByTag", mock.Anything, mock.Anything).Return(mockChannel(nilTicketLowestPrice))
 	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
 	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	_, err := suite.usecase.FindTickets(suite.ctx, payload)
+	_, _, err := suite.usecase.FindTickets(suite.ctx, payload)
 	suite.T().Log(err)
 
 	// Assert
 	assert.Error(suite.T(), err)
 }
 
-func (suite *QueryUsecaseTestSuite) TestFindTicketOfflineErr() {
+func (suite *QueryUsecaseTestSuite) TestFindTicketSuggestionRanked() {
 	// Arrange
 	payload := ticketRequest.TicketReq{
-		CountryCode: "code",
+		CountryCode: "ID",
 		EventId:     "id",
+		UserId:      "user-id",
 	}
-
-	mockOfflineTicket := helpers.Result{
-		Data:  nil,
-		Error: errors.BadRequest("error"),
-	}
-
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(getMockTicketSold()))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(getMockTicketSold()))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(mockOfflineTicket))
+	soldOut := getMockCountryTickets("ID", "AS", 50, 0)
+	tagTickets := append(getMockCountryTickets("FR", "EU", 10, 5), getMockCountryTickets("JP", "AS", 50, 5)...)
+	tagTickets = append(tagTickets, getMockCountryTickets("SG", "AS", 100, 5)...)
+	tagTickets = append(tagTickets, getMockCountryTickets("MY", "AS", 20, 0)...)
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(helpers.Result{Data: &soldOut}))
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketsByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{Data: &tagTickets}))
+	suite.mockUserRepositoryQuery.On("FindOneUserId", mock.Anything, "user-id").Return(mockChannel(helpers.Result{
+		Data: &userEntity.User{Country: userEntity.Country{Code: "id", Latitude: "-6.2", Longitude: "106.8"}},
+	}))
+	suite.mockVenueUsecaseQuery.ExpectedCalls = nil
+	suite.mockVenueUsecaseQuery.On("FindCountries", mock.Anything, mock.Anything).Return(getMockReferenceCountries(), nil)
 	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
-	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	_, err := suite.usecase.FindTickets(suite.ctx, payload)
-	suite.T().Log(err)
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
 
 	// Assert
-	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Suggestions, 3)
+	assert.Equal(suite.T(), []string{"SG", "JP", "FR"}, []string{result.Suggestions[0].CountryCode,
+		result.Suggestions[1].CountryCode, result.Suggestions[2].CountryCode})
+	assert.True(suite.T(), result.Suggestions[0].SameContinent)
+	assert.False(suite.T(), result.Suggestions[2].SameContinent)
+	assert.InDelta(suite.T(), 890, *result.Suggestions[0].DistanceKm, 20)
+	assert.Equal(suite.T(), 4.1, *result.Suggestions[0].TravelHours)
+	assert.Equal(suite.T(), "$100", result.Suggestions[0].LowestPrice)
+	assert.Equal(suite.T(), "$80", result.Suggestion[0].DiscountTicketPrice)
 }
 
-func (suite *QueryUsecaseTestSuite) TestFindTicketOfflineErrNil() {
+func (suite *QueryUsecaseTestSuite) TestFindTicketSuggestionSameHourCheaperFirst() {
 	// Arrange
 	payload := ticketRequest.TicketReq{
-		CountryCode: "code",
+		CountryCode: "ID",
 		EventId:     "id",
 	}
-
-	mockOfflineTicket := helpers.Result{
-		Data:  nil,
-		Error: nil,
-	}
-
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(getMockTicketSold()))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(getMockTicketSold()))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(mockOfflineTicket))
+	soldOut := getMockCountryTickets("ID", "AS", 50, 0)
+	tagTickets := append(getMockCountryTickets("SG", "AS", 100, 5), getMockCountryTickets("MY", "AS", 60, 5)...)
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(helpers.Result{Data: &soldOut}))
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketsByTag", mock.Anything, "tag").Return(mockChannel(helpers.Result{Data: &tagTickets}))
+	suite.mockVenueUsecaseQuery.ExpectedCalls = nil
+	suite.mockVenueUsecaseQuery.On("FindCountries", mock.Anything, mock.Anything).Return(getMockReferenceCountries(), nil)
 	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
-	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	_, err := suite.usecase.FindTickets(suite.ctx, payload)
-	suite.T().Log(err)
+	result, _, err := suite.usecase.FindTickets(suite.ctx, payload)
 
 	// Assert
-	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "MY", result.Suggestions[0].CountryCode)
+	assert.Equal(suite.T(), 1, result.Suggestions[0].Rank)
+	assert.Equal(suite.T(), "SG", result.Suggestions[1].CountryCode)
+	suite.mockUserRepositoryQuery.AssertNotCalled(suite.T(), "FindOneUserId", mock.Anything, mock.Anything)
 }
 
-func (suite *QueryUsecaseTestSuite) TestFindTicketOfflineErrParse() {
+func (suite *QueryUsecaseTestSuite) TestFindTicketErrMarshal() {
 	// Arrange
 	payload := ticketRequest.TicketReq{
 		CountryCode: "code",
 		EventId:     "id",
 	}
 
-	mockOfflineTicket := helpers.Result{
+	nilTicketLowestPrice := helpers.Result{
 		Data: &ticketEntity.Country{
 			Code: "code",
 			Name: "name",
@@ -357,20 +681,18 @@ func (suite *QueryUsecaseTestSuite) TestFindTicketOfflineErrParse() {
 	}
 
 	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(getMockTicketSold()))
-	suite.mockTicketRepositoryQuery.On("FindTicketByLowestPrice", mock.Anything, mock.Anything).Return(mockChannel(getMockTicketSold()))
-	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountryCode", mock.Anything, mock.Anything, mock.Anything).Return(mockChannel(mockOfflineTicket))
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketsByTag", mock.Anything, mock.Anything).Return(mockChannel(nilTicketLowestPrice))
 	suite.mockKafkaProducer.On("Publish", mock.Anything, mock.Anything, mock.Anything)
 	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
 
 	// Act
-	_, err := suite.usecase.FindTickets(suite.ctx, payload)
+	_, _, err := suite.usecase.FindTickets(suite.ctx, payload)
 	suite.T().Log(err)
 
 	// Assert
 	assert.Error(suite.T(), err)
 }
 
-// Helper function to create a channel
 func mockChannel(result helpers.Result) <-chan helpers.Result {
 	responseChan := make(chan helpers.Result)
 
@@ -453,6 +775,39 @@ func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketSuccess() {
 	assert.NotNil(suite.T(), result)
 }
 
+func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketPricePhase() {
+	// Arrange
+	payload := ticketRequest.TicketReq{
+		CountryCode: "code",
+		EventId:     "id",
+	}
+
+	mockOnlineTicket := helpers.Result{
+		Data: &ticketEntity.Ticket{
+			TicketId:       "id",
+			TicketType:     "type",
+			TicketPrice:    50,
+			TotalQuota:     10,
+			TotalRemaining: 10,
+			PricePhases: []ticketEntity.PricePhase{
+				{Name: "Door", Price: 70, StartAt: time.Now().Add(-time.Minute)},
+			},
+		},
+	}
+
+	suite.mockTicketRepositoryQuery.On("FindOfflineTicketByCountry", mock.Anything, payload).Return(mockChannel(getMockTicketSold()))
+	suite.mockTicketRepositoryQuery.On("FindOnlineTicketByCountry", mock.Anything, mock.Anything).Return(mockChannel(mockOnlineTicket))
+
+	// Act
+	result, err := suite.usecase.FindOnlineTicket(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$70", result.TicketPrice)
+	assert.Equal(suite.T(), "Door", result.PricePhase)
+	assert.Nil(suite.T(), result.NextPrice)
+}
+
 func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketErr() {
 	// Arrange
 	payload := ticketRequest.TicketReq{
@@ -647,3 +1002,207 @@ func (suite *QueryUsecaseTestSuite) TestFindOnlineTicketErrCountryParse() {
 	// Assert
 	assert.Error(suite.T(), err)
 }
+
+func (suite *QueryUsecaseTestSuite) TestQuoteTicket() {
+	// Arrange
+	payload := ticketRequest.QuoteReq{
+		EventId:      "event-id",
+		CountryCode:  "ID",
+		TicketType:   "Gold",
+		Quantity:     2,
+		VoucherCodes: []string{"PROMO10"},
+		UserId:       "user-id",
+	}
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{
+		Data: &ticketEntity.Ticket{TicketId: "ticket-id", EventId: "event-id", TicketType: "Gold", TicketPrice: 100, TotalQuota: 10, TotalRemaining: 8},
+	}))
+	suite.mockVoucherUsecaseQuery.On("PreviewVouchers", mock.Anything, []string{"PROMO10"}, mock.MatchedBy(func(t voucherDto.Target) bool {
+		return t.UserId == "user-id" && t.TicketPrice == 100 && t.Quantity == 2
+	})).Return(&voucherDto.Quote{
+		Subtotal:      200,
+		TotalDiscount: 20,
+		Total:         180,
+		Vouchers:      []voucherDto.AppliedVoucher{{Code: "PROMO10", Discount: 20}},
+	}, nil)
+	suite.mockFeeUsecaseQuery.On("CalculateBreakdown", mock.Anything, feeDto.BreakdownReq{
+		EventId:     "event-id",
+		CountryCode: "ID",
+		TicketPrice: 100,
+		Quantity:    2,
+		Discount:    20,
+	}).Return(&feeDto.Breakdown{
+		FaceValue:   200,
+		Discount:    20,
+		ServiceFee:  18,
+		FacilityFee: 4,
+		Taxes:       []feeDto.TaxLine{{Name: "PPN", Jurisdiction: "ID", Percent: 11, Amount: 22}},
+		TotalTax:    22,
+		Total:       224,
+	}, nil)
+
+	// Act
+	result, err := suite.usecase.QuoteTicket(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$200", result.FaceValue)
+	assert.Equal(suite.T(), "$20", result.Discount)
+	assert.Equal(suite.T(), []string{"PROMO10"}, result.VoucherCodes)
+	assert.Equal(suite.T(), "$18", result.ServiceFee)
+	assert.Equal(suite.T(), "$4", result.FacilityFee)
+	assert.Equal(suite.T(), "$22", result.Taxes[0].Amount)
+	assert.Equal(suite.T(), "$224", result.Total)
+	assert.Equal(suite.T(), "USD", result.Currency)
+	assert.Nil(suite.T(), result.DisplayTotal)
+}
+
+func (suite *QueryUsecaseTestSuite) TestQuoteTicketDisplayTotal() {
+	// Arrange
+	payload := ticketRequest.QuoteReq{
+		EventId:     "event-id",
+		CountryCode: "DE",
+		TicketType:  "Gold",
+		Quantity:    1,
+		UserId:      "user-id",
+	}
+	suite.mockFxUsecaseQuery.ExpectedCalls = nil
+	suite.mockFxUsecaseQuery.On("FindDisplayRate", mock.Anything, fxDto.DisplayReq{UserId: "user-id"}).
+		Return(&fxDto.Rate{Currency: "EUR", Rate: 0.92, Decimals: 2}, nil)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{
+		Data: &ticketEntity.Ticket{TicketId: "ticket-id", EventId: "event-id", TicketType: "Gold", TicketPrice: 100, TotalQuota: 10, TotalRemaining: 8},
+	}))
+	suite.mockFeeUsecaseQuery.On("CalculateBreakdown", mock.Anything, mock.Anything).Return(&feeDto.Breakdown{
+		FaceValue: 100,
+		Total:     119,
+	}, nil)
+
+	// Act
+	result, err := suite.usecase.QuoteTicket(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "$119", result.Total)
+	assert.Equal(suite.T(), "EUR 109.48", result.DisplayTotal.Amount)
+	assert.True(suite.T(), result.DisplayTotal.Indicative)
+}
+
+func (suite *QueryUsecaseTestSuite) TestQuoteTicketErrNotFound() {
+	// Arrange
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	_, err := suite.usecase.QuoteTicket(suite.ctx, ticketRequest.QuoteReq{EventId: "event-id", CountryCode: "ID", TicketType: "Gold", Quantity: 1})
+
+	// Assert
+	assert.Equal(suite.T(), errors.NotFound("ticket not found"), err)
+	suite.mockFeeUsecaseQuery.AssertNotCalled(suite.T(), "CalculateBreakdown", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestQuoteTicketErrEventCancelled() {
+	// Arrange
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{
+		Data: &ticketEntity.Ticket{TicketId: "ticket-id", EventId: "event-id", TicketType: "Gold", TicketPrice: 100, Cancelled: true},
+	}))
+
+	// Act
+	_, err := suite.usecase.QuoteTicket(suite.ctx, ticketRequest.QuoteReq{EventId: "event-id", CountryCode: "ID", TicketType: "Gold", Quantity: 1})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("event is cancelled"), err)
+	suite.mockFeeUsecaseQuery.AssertNotCalled(suite.T(), "CalculateBreakdown", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindAvailableTicket() {
+	// Arrange
+	payload := ticketRequest.AvailabilityReq{ContinentCode: "AS", SortBy: "sellThrough", SortOrder: "desc"}
+	availablePages := []ticketEntity.AggregateTotalTicketPage{{
+		Meta: []ticketEntity.AggregateCount{{Total: 45}},
+		Data: []ticketEntity.AggregateTotalTicket{{
+			Id:             ticketEntity.AggregateTicketId{EventId: "event-id", CountryCode: "ID"},
+			CountryName:    "Indonesia",
+			ContinentCode:  "AS",
+			TotalQuota:     300,
+			TotalRemaining: 100,
+			Tiers: []ticketEntity.AggregateTier{
+				{TicketType: "Bronze", TotalQuota: 200, TotalRemaining: 100},
+				{TicketType: "Gold", TotalQuota: 100, TotalRemaining: 0},
+			},
+		}},
+	}}
+	suite.mockTicketRepositoryQuery.On("FindTotalAvailableTicket", mock.Anything, mock.MatchedBy(func(req ticketRequest.AvailabilityReq) bool {
+		return req.Page == 1 && req.Size == 20 && req.ContinentCode == "AS"
+	})).Return(mockChannel(helpers.Result{Data: &availablePages}))
+
+	// Act
+	result, metaData, err := suite.usecase.FindAvailableTicket(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 1)
+	assert.Equal(suite.T(), 200, result[0].TotalSold)
+	assert.Equal(suite.T(), 66.7, result[0].SellThrough)
+	assert.False(suite.T(), result[0].IsSold)
+	assert.Equal(suite.T(), 50.0, result[0].Tiers[0].SellThrough)
+	assert.True(suite.T(), result[0].Tiers[1].IsSold)
+	assert.Equal(suite.T(), 100.0, result[0].Tiers[1].SellThrough)
+	assert.Equal(suite.T(), int64(3), metaData.TotalPage)
+	assert.Equal(suite.T(), int64(45), metaData.TotalData)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindAvailableTicketEmpty() {
+	// Arrange
+	availablePages := []ticketEntity.AggregateTotalTicketPage{{}}
+	suite.mockTicketRepositoryQuery.On("FindTotalAvailableTicket", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &availablePages}))
+
+	// Act
+	result, metaData, err := suite.usecase.FindAvailableTicket(suite.ctx, ticketRequest.AvailabilityReq{Tag: "unknown"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), result)
+	assert.Equal(suite.T(), int64(0), metaData.TotalData)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindAvailableTicketErr() {
+	// Arrange
+	suite.mockTicketRepositoryQuery.On("FindTotalAvailableTicket", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+
+	// Act
+	result, metaData, err := suite.usecase.FindAvailableTicket(suite.ctx, ticketRequest.AvailabilityReq{})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Nil(suite.T(), metaData)
+	assert.Error(suite.T(), err)
+}
+
+func getMockCountryTickets(countryCode string, continentCode string, price int, remaining int) []ticketEntity.Ticket {
+	tickets := make([]ticketEntity.Ticket, 0)
+	for _, ticketType := range []string{"Gold", "Silver", "Bronze", "Festival"} {
+		tickets = append(tickets, ticketEntity.Ticket{
+			TicketId:       countryCode + "-" + ticketType,
+			EventId:        "id",
+			TicketType:     ticketType,
+			TicketPrice:    price,
+			TotalQuota:     10,
+			TotalRemaining: remaining,
+			ContinentCode:  continentCode,
+			Country:        ticketEntity.Country{Code: countryCode, Name: countryCode},
+			Tag:            "tag",
+		})
+	}
+	return tickets
+}
+
+func getMockReferenceCountries() []venueResponse.Country {
+	return []venueResponse.Country{
+		{Code: "ID", ContinentCode: "AS", Latitude: -6.2, Longitude: 106.8},
+		{Code: "SG", ContinentCode: "AS", Latitude: 1.35, Longitude: 103.8},
+		{Code: "MY", ContinentCode: "AS", Latitude: 1.49, Longitude: 103.74},
+		{Code: "JP", ContinentCode: "AS", Latitude: 35.7, Longitude: 139.7},
+		{Code: "FR", ContinentCode: "EU", Latitude: 48.9, Longitude: 2.35},
+	}
+}
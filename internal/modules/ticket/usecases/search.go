@@ -0,0 +1,173 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/modules/ticket/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+// searchGroup is a matched event and country priced at now, lowestPrice is -1 when every tier is sold
+type searchGroup struct {
+	result      response.SearchResult
+	score       float64
+	eventDate   time.Time
+	lowestPrice int
+}
+
+func (q queryUsecase) SearchTickets(origCtx context.Context, payload request.SearchReq) ([]response.SearchResult,
+	*constants.MetaData, error) {
+	domain := "ticketUsecase-SearchTickets"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if payload.MaxPrice > 0 && payload.MinPrice > payload.MaxPrice {
+		return nil, nil, errors.BadRequest("minPrice cannot be greater than maxPrice")
+	}
+	if payload.DateFrom != "" && payload.DateTo != "" && payload.DateFrom > payload.DateTo {
+		return nil, nil, errors.BadRequest("dateFrom cannot be after dateTo")
+	}
+	if payload.Page == 0 {
+		payload.Page = 1
+	}
+	if payload.Size == 0 {
+		payload.Size = constants.SearchPageSize
+	}
+
+	resp := <-q.ticketRepositoryQuery.SearchTickets(ctx, payload)
+	if resp.Error != nil {
+		msg := "Error search ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, nil, resp.Error
+	}
+
+	groups := make([]searchGroup, 0)
+	if resp.Data != nil {
+		ticketGroups, ok := resp.Data.(*[]entity.TicketGroup)
+		if !ok {
+			return nil, nil, errors.InternalServerError("cannot parsing data")
+		}
+		now := time.Now()
+		for _, value := range *ticketGroups {
+			group := mapSearchGroup(value, now)
+			if !group.inPriceRange(payload) {
+				continue
+			}
+			groups = append(groups, group)
+		}
+	}
+	sortSearchGroups(groups, payload)
+
+	collectionData := make([]response.SearchResult, 0)
+	start := (payload.Page - 1) * payload.Size
+	for i := start; i < start+payload.Size && i < int64(len(groups)); i++ {
+		collectionData = append(collectionData, groups[i].result)
+	}
+	metaData := helpers.GenerateMetaData(int64(len(groups)), int64(len(collectionData)), payload.Page, payload.Size)
+	return collectionData, &metaData, nil
+}
+
+func mapSearchGroup(group entity.TicketGroup, now time.Time) searchGroup {
+	result := searchGroup{
+		score:       group.Score,
+		eventDate:   group.EventDate,
+		lowestPrice: -1,
+		result: response.SearchResult{
+			EventId:     group.Id.EventId,
+			CountryCode: group.Id.CountryCode,
+			IsSold:      true,
+			TicketTypes: make([]string, 0),
+		},
+	}
+	if !group.EventDate.IsZero() {
+		result.result.EventDate = &group.EventDate
+	}
+	for _, value := range group.Tickets {
+		result.result.EventName = value.EventName
+		result.result.Artist = value.Artist
+		result.result.Tag = value.Tag
+		result.result.CountryName = value.Country.Name
+		result.result.ContinentCode = value.ContinentCode
+		result.result.ContinentName = value.ContinentName
+		result.result.City = value.Country.City
+		result.result.Place = value.Country.Place
+		if value.VenueId != "" {
+			result.result.VenueId = value.VenueId
+		}
+		result.result.TicketTypes = append(result.result.TicketTypes, value.TicketType)
+		result.result.TotalRemaining += value.TotalRemaining
+		if value.TotalRemaining == 0 {
+			continue
+		}
+		result.result.IsSold = false
+		if price := value.PriceAt(now, value.Sold()).Price; result.lowestPrice < 0 || price < result.lowestPrice {
+			result.lowestPrice = price
+		}
+	}
+	if result.lowestPrice >= 0 {
+		result.result.LowestPrice = fmt.Sprintf("$%d", result.lowestPrice)
+	}
+	return result
+}
+
+// inPriceRange drops sold out groups as soon as a price bound is asked, they have no price on sale to compare
+func (s searchGroup) inPriceRange(payload request.SearchReq) bool {
+	if payload.MinPrice == 0 && payload.MaxPrice == 0 {
+		return true
+	}
+	if s.lowestPrice < 0 || s.lowestPrice < payload.MinPrice {
+		return false
+	}
+	return payload.MaxPrice == 0 || s.lowestPrice <= payload.MaxPrice
+}
+
+// sortSearchGroups defaults to relevance with a query and to the earliest event without one. Sold out groups sort
+// last by price and undated ones last by date, event and country keep the order stable between pages
+func sortSearchGroups(groups []searchGroup, payload request.SearchReq) {
+	sortBy := payload.SortBy
+	if sortBy == "" {
+		sortBy = constants.SearchSortDate
+		if payload.Query != "" {
+			sortBy = constants.SearchSortRelevance
+		}
+	}
+	desc := payload.SortOrder == "desc"
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		switch sortBy {
+		case constants.SearchSortRelevance:
+			if a.score != b.score {
+				return (a.score > b.score) != desc
+			}
+		case constants.SearchSortPrice:
+			if (a.lowestPrice < 0) != (b.lowestPrice < 0) {
+				return b.lowestPrice < 0
+			}
+			if a.lowestPrice != b.lowestPrice {
+				return (a.lowestPrice < b.lowestPrice) != desc
+			}
+		case constants.SearchSortDate:
+			if a.eventDate.IsZero() != b.eventDate.IsZero() {
+				return b.eventDate.IsZero()
+			}
+			if !a.eventDate.Equal(b.eventDate) {
+				return a.eventDate.Before(b.eventDate) != desc
+			}
+		}
+		if a.result.EventId != b.result.EventId {
+			return a.result.EventId < b.result.EventId
+		}
+		return a.result.CountryCode < b.result.CountryCode
+	})
+}
@@ -0,0 +1,93 @@
+package usecases_test
+
+import (
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	ticketRequest "ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *QueryUsecaseTestSuite) TestSearchTicketsPriceSortAndRange() {
+	// Arrange
+	dynamic := getMockSearchGroup("event-3", 0, 120, 5)
+	dynamic.Tickets[0].DynamicPrice = 90
+	ticketGroups := []ticketEntity.TicketGroup{
+		getMockSearchGroup("event-1", 0, 150, 5),
+		getMockSearchGroup("event-2", 0, 50, 0),
+		dynamic,
+		getMockSearchGroup("event-4", 0, 60, 5),
+	}
+	suite.mockTicketRepositoryQuery.On("SearchTickets", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &ticketGroups}))
+
+	// Act
+	result, metaData, err := suite.usecase.SearchTickets(suite.ctx, ticketRequest.SearchReq{SortBy: "price", MaxPrice: 100})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result, 2)
+	assert.Equal(suite.T(), "event-4", result[0].EventId)
+	assert.Equal(suite.T(), "$60", result[0].LowestPrice)
+	assert.Equal(suite.T(), "event-3", result[1].EventId)
+	assert.Equal(suite.T(), "$90", result[1].LowestPrice)
+	assert.Equal(suite.T(), int64(2), metaData.TotalData)
+}
+
+func (suite *QueryUsecaseTestSuite) TestSearchTicketsRelevancePaginated() {
+	// Arrange
+	ticketGroups := []ticketEntity.TicketGroup{
+		getMockSearchGroup("event-1", 1.5, 100, 5),
+		getMockSearchGroup("event-2", 4, 100, 0),
+		getMockSearchGroup("event-3", 2.5, 100, 5),
+	}
+	suite.mockTicketRepositoryQuery.On("SearchTickets", mock.Anything, mock.MatchedBy(func(req ticketRequest.SearchReq) bool {
+		return req.Query == "coldplay" && req.Page == 1 && req.Size == 2
+	})).Return(mockChannel(helpers.Result{Data: &ticketGroups}))
+
+	// Act
+	result, metaData, err := suite.usecase.SearchTickets(suite.ctx, ticketRequest.SearchReq{Query: "coldplay", Size: 2})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"event-2", "event-3"}, []string{result[0].EventId, result[1].EventId})
+	assert.True(suite.T(), result[0].IsSold)
+	assert.Empty(suite.T(), result[0].LowestPrice)
+	assert.Equal(suite.T(), "Music of the Spheres", result[0].EventName)
+	assert.Equal(suite.T(), int64(2), metaData.TotalPage)
+	assert.Equal(suite.T(), int64(3), metaData.TotalData)
+}
+
+func (suite *QueryUsecaseTestSuite) TestSearchTicketsErrPriceRange() {
+	// Act
+	result, metaData, err := suite.usecase.SearchTickets(suite.ctx, ticketRequest.SearchReq{MinPrice: 200, MaxPrice: 100})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Nil(suite.T(), metaData)
+	assert.Equal(suite.T(), errors.BadRequest("minPrice cannot be greater than maxPrice"), err)
+	suite.mockTicketRepositoryQuery.AssertNotCalled(suite.T(), "SearchTickets", mock.Anything, mock.Anything)
+}
+
+func getMockSearchGroup(eventId string, score float64, price int, remaining int) ticketEntity.TicketGroup {
+	return ticketEntity.TicketGroup{
+		Id:    ticketEntity.AggregateTicketId{EventId: eventId, CountryCode: "ID"},
+		Score: score,
+		Tickets: []ticketEntity.Ticket{
+			{
+				TicketId:       eventId + "-gold",
+				EventId:        eventId,
+				EventName:      "Music of the Spheres",
+				Artist:         "Coldplay",
+				TicketType:     "Gold",
+				TicketPrice:    price,
+				TotalQuota:     100,
+				TotalRemaining: remaining,
+				Country:        ticketEntity.Country{Name: "Indonesia", Code: "ID", City: "Jakarta", Place: "Grand Hall"},
+				Tag:            "coldplay-2024",
+			},
+		},
+	}
+}
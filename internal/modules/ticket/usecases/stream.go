@@ -0,0 +1,185 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"ticket-service/internal/modules/ticket"
+	"ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/modules/ticket/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+	"time"
+)
+
+// streamUsecase holds the stream clients connected to this pod. One redis subscription per pod receives the deltas
+// published by every pod and hands them to the local clients of the event
+type streamUsecase struct {
+	redisClient redis.Collections
+	logger      log.Logger
+	listenOnce  *sync.Once
+	mu          *sync.Mutex
+	subscribers map[*subscriber]struct{}
+	perUser     map[string]int
+}
+
+type subscriber struct {
+	payload request.StreamReq
+	deltas  chan response.AvailabilityDelta
+}
+
+func NewStreamUsecase(redisClient redis.Collections, log log.Logger) ticket.UsecaseStream {
+	return &streamUsecase{
+		redisClient: redisClient,
+		logger:      log,
+		listenOnce:  &sync.Once{},
+		mu:          &sync.Mutex{},
+		subscribers: make(map[*subscriber]struct{}),
+		perUser:     make(map[string]int),
+	}
+}
+
+// PublishAvailability is best effort, a lost delta is corrected by the next change of the same tier
+func (s *streamUsecase) PublishAvailability(ctx context.Context, ticket entity.Ticket) {
+	pricing := ticket.PriceAt(time.Now(), ticket.Sold())
+	delta := response.AvailabilityDelta{
+		EventId:        ticket.EventId,
+		CountryCode:    ticket.Country.Code,
+		TicketId:       ticket.TicketId,
+		TicketType:     ticket.TicketType,
+		TicketPrice:    fmt.Sprintf("$%d", pricing.Price),
+		TotalQuota:     ticket.TotalQuota,
+		TotalRemaining: ticket.TotalRemaining,
+		IsSold:         ticket.TotalRemaining == 0,
+		UpdatedAt:      ticket.UpdatedAt,
+	}
+	marshaledDelta, err := json.Marshal(delta)
+	if err != nil {
+		msg := "Error marshal availability delta"
+		s.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return
+	}
+	if err := s.redisClient.Publish(ctx, constants.RedisChannelTicketAvailability, marshaledDelta).Err(); err != nil {
+		msg := "Error publish availability delta"
+		s.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+	}
+}
+
+// Subscribe registers a client until ctx is done, then the returned channel is closed
+func (s *streamUsecase) Subscribe(ctx context.Context, payload request.StreamReq) (<-chan response.AvailabilityDelta, error) {
+	s.mu.Lock()
+	if len(s.subscribers) >= constants.StreamMaxConnections {
+		s.mu.Unlock()
+		return nil, errors.TooManyRequest("too many stream connections, please retry later")
+	}
+	if s.perUser[payload.UserId] >= constants.StreamMaxConnectionsPerUser {
+		s.mu.Unlock()
+		return nil, errors.TooManyRequest(fmt.Sprintf("at most %d streams per user", constants.StreamMaxConnectionsPerUser))
+	}
+	sub := &subscriber{
+		payload: payload,
+		deltas:  make(chan response.AvailabilityDelta, constants.StreamBufferSize),
+	}
+	s.subscribers[sub] = struct{}{}
+	s.perUser[payload.UserId]++
+	s.mu.Unlock()
+
+	s.listenOnce.Do(func() {
+		go s.listen()
+	})
+
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		delete(s.subscribers, sub)
+		s.perUser[payload.UserId]--
+		if s.perUser[payload.UserId] <= 0 {
+			delete(s.perUser, payload.UserId)
+		}
+		close(sub.deltas)
+		s.mu.Unlock()
+	}()
+
+	return sub.deltas, nil
+}
+
+// listen lives as long as the pod, go-redis resubscribes by itself after a lost connection
+func (s *streamUsecase) listen() {
+	ctx := context.Background()
+	pubsub := s.redisClient.Subscribe(ctx, constants.RedisChannelTicketAvailability)
+	for message := range pubsub.Channel() {
+		var delta response.AvailabilityDelta
+		if err := json.Unmarshal([]byte(message.Payload), &delta); err != nil {
+			msg := "Error unmarshal availability delta"
+			s.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+			continue
+		}
+		s.fanOut(delta)
+	}
+}
+
+// fanOut never blocks on a slow client, deltas carry the whole tier so skipping some loses nothing but latency
+func (s *streamUsecase) fanOut(delta response.AvailabilityDelta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers {
+		if sub.payload.EventId != delta.EventId {
+			continue
+		}
+		if sub.payload.CountryCode != "" && sub.payload.CountryCode != delta.CountryCode {
+			continue
+		}
+		select {
+		case sub.deltas <- delta:
+		default:
+		}
+	}
+}
+
+// streamRepositoryCommand publishes every inventory change whichever module made it
+type streamRepositoryCommand struct {
+	ticket.MongodbRepositoryCommand
+	ticketUsecaseStream ticket.UsecaseStream
+}
+
+func NewStreamRepositoryCommand(tmc ticket.MongodbRepositoryCommand, tus ticket.UsecaseStream) ticket.MongodbRepositoryCommand {
+	return streamRepositoryCommand{
+		MongodbRepositoryCommand: tmc,
+		ticketUsecaseStream:      tus,
+	}
+}
+
+func (s streamRepositoryCommand) DecreaseTotalRemaining(ctx context.Context, ticketId string, quantity int) <-chan wrapper.Result {
+	return s.publish(ctx, s.MongodbRepositoryCommand.DecreaseTotalRemaining(ctx, ticketId, quantity))
+}
+
+func (s streamRepositoryCommand) IncreaseTotalRemaining(ctx context.Context, ticketId string, quantity int) <-chan wrapper.Result {
+	return s.publish(ctx, s.MongodbRepositoryCommand.IncreaseTotalRemaining(ctx, ticketId, quantity))
+}
+
+func (s streamRepositoryCommand) UpdateDynamicPrice(ctx context.Context, ticketId string, price int) <-chan wrapper.Result {
+	return s.publish(ctx, s.MongodbRepositoryCommand.UpdateDynamicPrice(ctx, ticketId, price))
+}
+
+// publish passes the result on untouched, only an update that matched a ticket is published
+func (s streamRepositoryCommand) publish(ctx context.Context, result <-chan wrapper.Result) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-result
+		if resp.Error == nil {
+			if ticketDetail, ok := resp.Data.(*entity.Ticket); ok && ticketDetail != nil {
+				s.ticketUsecaseStream.PublishAvailability(ctx, *ticketDetail)
+			}
+		}
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,132 @@
+package usecases_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"ticket-service/internal/modules/ticket"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/modules/ticket/models/response"
+	uc "ticket-service/internal/modules/ticket/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockcert "ticket-service/mocks/modules/ticket"
+	mocklog "ticket-service/mocks/pkg/log"
+	mockredis "ticket-service/mocks/pkg/redis"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StreamUsecaseTestSuite struct {
+	suite.Suite
+	mockTicketRepositoryCommand *mockcert.MongodbRepositoryCommand
+	mockRedis                   *mockredis.Collections
+	mockLogger                  *mocklog.Logger
+	usecase                     ticket.UsecaseStream
+	repository                  ticket.MongodbRepositoryCommand
+	ctx                         context.Context
+}
+
+func (suite *StreamUsecaseTestSuite) SetupTest() {
+	suite.mockTicketRepositoryCommand = &mockcert.MongodbRepositoryCommand{}
+	suite.mockRedis = &mockredis.Collections{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewStreamUsecase(suite.mockRedis, suite.mockLogger)
+	suite.repository = uc.NewStreamRepositoryCommand(suite.mockTicketRepositoryCommand, suite.usecase)
+	suite.mockRedis.On("Publish", mock.Anything, constants.RedisChannelTicketAvailability, mock.Anything).
+		Return(redis.NewIntResult(1, nil))
+}
+
+func TestStreamUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StreamUsecaseTestSuite))
+}
+
+func (suite *StreamUsecaseTestSuite) TestPublishAvailability() {
+	// Arrange
+	ticketDetail := getMockStreamTicket(0)
+
+	// Act
+	suite.usecase.PublishAvailability(suite.ctx, ticketDetail)
+
+	// Assert
+	suite.mockRedis.AssertCalled(suite.T(), "Publish", mock.Anything, constants.RedisChannelTicketAvailability,
+		mock.MatchedBy(func(message []byte) bool {
+			var delta response.AvailabilityDelta
+			if err := json.Unmarshal(message, &delta); err != nil {
+				return false
+			}
+			return delta.EventId == "event-id" && delta.CountryCode == "ID" && delta.TicketPrice == "$80" && delta.IsSold
+		}))
+}
+
+func (suite *StreamUsecaseTestSuite) TestDecreaseTotalRemainingPublishes() {
+	// Arrange
+	ticketDetail := getMockStreamTicket(4)
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).
+		Return(mockChannel(helpers.Result{Data: &ticketDetail}))
+
+	// Act
+	result := <-suite.repository.DecreaseTotalRemaining(suite.ctx, "ticket-id", 2)
+
+	// Assert
+	assert.Equal(suite.T(), &ticketDetail, result.Data)
+	suite.mockRedis.AssertNumberOfCalls(suite.T(), "Publish", 1)
+}
+
+func (suite *StreamUsecaseTestSuite) TestDecreaseTotalRemainingNotMatchedSkipsPublish() {
+	// Arrange
+	suite.mockTicketRepositoryCommand.On("DecreaseTotalRemaining", mock.Anything, "ticket-id", 2).
+		Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	result := <-suite.repository.DecreaseTotalRemaining(suite.ctx, "ticket-id", 2)
+
+	// Assert
+	assert.Nil(suite.T(), result.Data)
+	suite.mockRedis.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *StreamUsecaseTestSuite) TestIncreaseTotalRemainingErrSkipsPublish() {
+	// Arrange
+	suite.mockTicketRepositoryCommand.On("IncreaseTotalRemaining", mock.Anything, "ticket-id", 2).
+		Return(mockChannel(helpers.Result{Error: errors.InternalServerError("error")}))
+
+	// Act
+	result := <-suite.repository.IncreaseTotalRemaining(suite.ctx, "ticket-id", 2)
+
+	// Assert
+	assert.Error(suite.T(), result.Error)
+	suite.mockRedis.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *StreamUsecaseTestSuite) TestUpdateVenueSkipsPublish() {
+	// Arrange
+	ticketDetail := getMockStreamTicket(4)
+	suite.mockTicketRepositoryCommand.On("UpdateVenue", mock.Anything, "ticket-id", "venue-id", "Grand Hall", "Jakarta").
+		Return(mockChannel(helpers.Result{Data: &ticketDetail}))
+
+	// Act
+	result := <-suite.repository.UpdateVenue(suite.ctx, "ticket-id", "venue-id", "Grand Hall", "Jakarta")
+
+	// Assert
+	assert.NoError(suite.T(), result.Error)
+	suite.mockRedis.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func getMockStreamTicket(remaining int) ticketEntity.Ticket {
+	return ticketEntity.Ticket{
+		TicketId:       "ticket-id",
+		EventId:        "event-id",
+		TicketType:     "Silver",
+		TicketPrice:    80,
+		TotalQuota:     100,
+		TotalRemaining: remaining,
+		Country:        ticketEntity.Country{Name: "Indonesia", Code: "ID"},
+	}
+}
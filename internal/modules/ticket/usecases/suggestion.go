@@ -0,0 +1,261 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/modules/ticket/models/response"
+	userEntity "ticket-service/internal/modules/user/models/entity"
+	venueRequest "ticket-service/internal/modules/venue/models/request"
+	venueResponse "ticket-service/internal/modules/venue/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"time"
+)
+
+// location is where distances are measured from or to, located is false when there are no coordinates
+type location struct {
+	latitude      float64
+	longitude     float64
+	located       bool
+	continentCode string
+}
+
+type candidate struct {
+	suggestion  response.CountrySuggestion
+	lowestPrice int
+	travelHours float64
+	located     bool
+}
+
+// suggestCountries ranks the other countries still selling the tag: same continent as the user first, then the
+// shorter trip and then the cheaper ticket. Trips within the same hour are a tie so the price can decide
+func (q queryUsecase) suggestCountries(ctx context.Context, payload request.TicketReq, soldOut []entity.Ticket, tag string,
+	now time.Time) ([]response.CountrySuggestion, error) {
+	resp := <-q.ticketRepositoryQuery.FindOfflineTicketsByTag(ctx, tag)
+	if resp.Error != nil {
+		msg := "Error query ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		msg := "Ticket Not Found"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	tagTickets, ok := resp.Data.(*[]entity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	countryCodes := make([]string, 0)
+	tiersByCountry := make(map[string][]entity.Ticket)
+	for _, value := range *tagTickets {
+		if value.Country.Code == payload.CountryCode {
+			continue
+		}
+		if _, ok := tiersByCountry[value.Country.Code]; !ok {
+			countryCodes = append(countryCodes, value.Country.Code)
+		}
+		tiersByCountry[value.Country.Code] = append(tiersByCountry[value.Country.Code], value)
+	}
+
+	countries := q.findReferenceCountries(ctx)
+	venues := q.findVenues(ctx, append(append([]entity.Ticket{}, soldOut...), *tagTickets...))
+	origin := q.findOrigin(ctx, payload, soldOut, countries, venues)
+
+	candidates := make([]candidate, 0)
+	for _, code := range countryCodes {
+		tiers := tiersByCountry[code]
+		lowestPrice := -1
+		for _, value := range tiers {
+			if value.TotalRemaining == 0 {
+				continue
+			}
+			if price := value.PriceAt(now, value.Sold()).Price; lowestPrice < 0 || price < lowestPrice {
+				lowestPrice = price
+			}
+		}
+		// the country sold out as well
+		if lowestPrice < 0 {
+			continue
+		}
+
+		item := candidate{
+			suggestion: response.CountrySuggestion{
+				CountryCode:   code,
+				CountryName:   tiers[0].Country.Name,
+				ContinentCode: tiers[0].ContinentCode,
+				ContinentName: tiers[0].ContinentName,
+				SameContinent: origin.continentCode != "" && origin.continentCode == tiers[0].ContinentCode,
+				LowestPrice:   fmt.Sprintf("$%d", lowestPrice),
+				Tickets:       mapSuggestionTickets(tiers, now),
+			},
+			lowestPrice: lowestPrice,
+		}
+		destination := locateCountry(code, tiers, countries, venues)
+		for _, value := range tiers {
+			if venueData := venues[value.VenueId]; venueData != nil {
+				item.suggestion.Venue = venueData
+				break
+			}
+		}
+		if origin.located && destination.located {
+			distance := helpers.DistanceKm(origin.latitude, origin.longitude, destination.latitude, destination.longitude)
+			distanceKm := int(math.Round(distance))
+			travelHours := math.Round(estimateTravelHours(distance)*10) / 10
+			item.suggestion.DistanceKm = &distanceKm
+			item.suggestion.TravelHours = &travelHours
+			item.travelHours = travelHours
+			item.located = true
+		}
+		candidates = append(candidates, item)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].rankBefore(candidates[j])
+	})
+
+	result := make([]response.CountrySuggestion, 0)
+	for i, value := range candidates {
+		if i == constants.SuggestionLimit {
+			break
+		}
+		value.suggestion.Rank = i + 1
+		result = append(result, value.suggestion)
+	}
+	return result, nil
+}
+
+func (c candidate) rankBefore(other candidate) bool {
+	if c.suggestion.SameContinent != other.suggestion.SameContinent {
+		return c.suggestion.SameContinent
+	}
+	if c.located != other.located {
+		return c.located
+	}
+	if hours, otherHours := math.Round(c.travelHours), math.Round(other.travelHours); hours != otherHours {
+		return hours < otherHours
+	}
+	if c.lowestPrice != other.lowestPrice {
+		return c.lowestPrice < other.lowestPrice
+	}
+	return c.suggestion.CountryCode < other.suggestion.CountryCode
+}
+
+// findOrigin places the user by the country of their profile. A user that cannot be placed is measured from the
+// sold out country, the one they were looking at
+func (q queryUsecase) findOrigin(ctx context.Context, payload request.TicketReq, soldOut []entity.Ticket,
+	countries map[string]venueResponse.Country, venues map[string]*response.Venue) location {
+	if userDetail := q.findUser(ctx, payload.UserId); userDetail != nil {
+		result := location{}
+		reference, ok := countries[strings.ToUpper(userDetail.Country.Code)]
+		if ok {
+			result = location{
+				latitude:      reference.Latitude,
+				longitude:     reference.Longitude,
+				located:       true,
+				continentCode: reference.ContinentCode,
+			}
+		}
+		latitude, latErr := strconv.ParseFloat(userDetail.Country.Latitude, 64)
+		longitude, lonErr := strconv.ParseFloat(userDetail.Country.Longitude, 64)
+		if latErr == nil && lonErr == nil {
+			result.latitude = latitude
+			result.longitude = longitude
+			result.located = true
+		}
+		if result.located {
+			return result
+		}
+	}
+
+	result := locateCountry(payload.CountryCode, soldOut, countries, venues)
+	if len(soldOut) > 0 {
+		result.continentCode = soldOut[0].ContinentCode
+	}
+	return result
+}
+
+// findUser never fails the request, an unknown user is placed by the country they are looking at
+func (q queryUsecase) findUser(ctx context.Context, userId string) *userEntity.User {
+	if userId == "" {
+		return nil
+	}
+	resp := <-q.userRepositoryQuery.FindOneUserId(ctx, userId)
+	if resp.Error != nil {
+		msg := "Error query user"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil
+	}
+
+	userDetail, ok := resp.Data.(*userEntity.User)
+	if !ok {
+		return nil
+	}
+	return userDetail
+}
+
+// findReferenceCountries never fails the request, without reference data countries are placed by their venues only
+func (q queryUsecase) findReferenceCountries(ctx context.Context) map[string]venueResponse.Country {
+	result := make(map[string]venueResponse.Country)
+	countries, err := q.venueUsecaseQuery.FindCountries(ctx, venueRequest.CountryListReq{})
+	if err != nil {
+		msg := "Error query reference country"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		return result
+	}
+	for _, value := range countries {
+		result[value.Code] = value
+	}
+	return result
+}
+
+// locateCountry places a country at the venue of its tiers, or at the country itself when no tier has a venue
+func locateCountry(code string, tiers []entity.Ticket, countries map[string]venueResponse.Country,
+	venues map[string]*response.Venue) location {
+	for _, value := range tiers {
+		if venueData := venues[value.VenueId]; venueData != nil && (venueData.Latitude != 0 || venueData.Longitude != 0) {
+			return location{latitude: venueData.Latitude, longitude: venueData.Longitude, located: true}
+		}
+	}
+	if reference, ok := countries[code]; ok {
+		return location{latitude: reference.Latitude, longitude: reference.Longitude, located: true}
+	}
+	return location{}
+}
+
+func estimateTravelHours(distanceKm float64) float64 {
+	if distanceKm <= constants.GroundTravelMaxKm {
+		return distanceKm / constants.GroundSpeedKmh
+	}
+	return constants.FlightOverheadHours + distanceKm/constants.FlightSpeedKmh
+}
+
+func mapSuggestionTickets(tiers []entity.Ticket, now time.Time) []response.SuggestionTicket {
+	result := make([]response.SuggestionTicket, 0)
+	for _, value := range tiers {
+		normalPrice := value.PriceAt(now, value.Sold()).Price
+		discountPrice := normalPrice * (100 - constants.SuggestionDiscount) / 100
+		result = append(result, response.SuggestionTicket{
+			TicketType:          value.TicketType,
+			NormalTicketPrice:   fmt.Sprintf("$%d", normalPrice),
+			DiscountTicketPrice: fmt.Sprintf("$%d", discountPrice),
+			Discount:            fmt.Sprintf("%d%%", constants.SuggestionDiscount),
+			ContinentName:       value.ContinentName,
+			ContinentCode:       value.ContinentCode,
+			CountryName:         value.Country.Name,
+			CountryCode:         value.Country.Code,
+			IsSold:              value.TotalRemaining == 0,
+		})
+	}
+	return result
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/tour"
+	"ticket-service/internal/modules/tour/models/request"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type TourHttpHandler struct {
+	TourUsecaseQuery tour.UsecaseQuery
+	Logger           log.Logger
+	Validator        *validator.Validate
+}
+
+func InitTourHttpHandler(app *fiber.App, tuq tour.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &TourHttpHandler{
+		TourUsecaseQuery: tuq,
+		Logger:           log,
+		Validator:        validator.New(),
+	}
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/tours")
+
+	route.Get("/v1/:tag", middlewares.VerifyBearer(), handler.GetTour)
+}
+
+func (t TourHttpHandler) GetTour(c *fiber.Ctx) error {
+	req := new(request.TourReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest("bad request"))
+	}
+	req.Tag = c.Params("tag")
+
+	if err := t.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, metaData, err := t.TourUsecaseQuery.FindTour(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespPagination(c, t.Logger, resp, *metaData, "Get tour success")
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	"time"
+)
+
+// TourPage is one page of the stops of a tour with the number of stops on every page
+type TourPage struct {
+	Meta  []TourCount `bson:"meta"`
+	Stops []TourStop  `bson:"stops"`
+}
+
+type TourCount struct {
+	Total int64 `bson:"total"`
+}
+
+// TourStop is one event of the tour in one country with its offline tiers, cheapest first
+type TourStop struct {
+	Id        TourStopId            `bson:"_id"`
+	EventDate time.Time             `bson:"eventDate"`
+	Tickets   []ticketEntity.Ticket `bson:"tickets"`
+}
+
+type TourStopId struct {
+	EventId     string `bson:"eventId"`
+	CountryCode string `bson:"countryCode"`
+}
+
+// Total counts the stops on every page, it is 0 when the tag has no tickets
+func (t TourPage) Total() int64 {
+	if len(t.Meta) == 0 {
+		return 0
+	}
+	return t.Meta[0].Total
+}
@@ -0,0 +1,7 @@
+package request
+
+type TourReq struct {
+	Tag  string `json:"tag" validate:"required"`
+	Page int64  `json:"page" validate:"omitempty,min=1"`
+	Size int64  `json:"size" validate:"omitempty,min=1,max=50"`
+}
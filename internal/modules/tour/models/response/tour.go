@@ -0,0 +1,39 @@
+package response
+
+import "time"
+
+type Tour struct {
+	Tag   string     `json:"tag"`
+	Stops []TourStop `json:"stops"`
+}
+
+// TourStop is one event of the tour in one country, LowestPrice is the cheapest tier still on sale
+type TourStop struct {
+	EventId        string     `json:"eventId"`
+	EventDate      *time.Time `json:"eventDate,omitempty"`
+	CountryCode    string     `json:"countryCode"`
+	CountryName    string     `json:"countryName"`
+	ContinentCode  string     `json:"continentCode"`
+	ContinentName  string     `json:"continentName"`
+	Venue          *Venue     `json:"venue,omitempty"`
+	LowestPrice    string     `json:"lowestPrice,omitempty"`
+	TotalRemaining int        `json:"totalRemaining"`
+	IsSold         bool       `json:"isSold"`
+	Tiers          []Tier     `json:"tiers"`
+}
+
+type Tier struct {
+	TicketType     string `json:"ticketType"`
+	TicketPrice    string `json:"ticketPrice"`
+	PricePhase     string `json:"pricePhase,omitempty"`
+	TotalQuota     int    `json:"totalQuota"`
+	TotalRemaining int    `json:"totalRemaining"`
+	IsSold         bool   `json:"isSold"`
+}
+
+type Venue struct {
+	VenueId  string `json:"venueId"`
+	Name     string `json:"name"`
+	CityName string `json:"cityName"`
+	Timezone string `json:"timezone"`
+}
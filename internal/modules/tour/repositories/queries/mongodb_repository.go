@@ -0,0 +1,79 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/tour"
+	"ticket-service/internal/modules/tour/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) tour.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// FindTourStops groups the offline tiers of the tag by event and country, earliest event first, and returns a
+// list holding one page of them
+func (q queryMongodbRepository) FindTourStops(ctx context.Context, tag string, page int64, size int64) <-chan wrapper.Result {
+	var tourPage []entity.TourPage
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.Aggregate(mongodb.Aggregate{
+			Result:         &tourPage,
+			CollectionName: "ticket-detail",
+			Filter: []bson.M{
+				{
+					"$match": bson.M{
+						"tag":        tag,
+						"ticketType": bson.M{"$ne": "Online"},
+					},
+				},
+				{
+					"$sort": bson.D{{Key: "ticketPrice", Value: 1}},
+				},
+				{
+					"$group": bson.M{
+						"_id": bson.M{
+							"eventId":     "$eventId",
+							"countryCode": "$country.code",
+						},
+						"eventDate": bson.M{"$min": "$eventDate"},
+						"tickets":   bson.M{"$push": "$$ROOT"},
+					},
+				},
+				{
+					"$sort": bson.D{
+						{Key: "eventDate", Value: 1},
+						{Key: "_id.eventId", Value: 1},
+						{Key: "_id.countryCode", Value: 1},
+					},
+				},
+				{
+					"$facet": bson.M{
+						"meta": []bson.M{{"$count": "total"}},
+						"stops": []bson.M{
+							{"$skip": size * (page - 1)},
+							{"$limit": size},
+						},
+					},
+				},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
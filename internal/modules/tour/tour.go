@@ -0,0 +1,17 @@
+package tour
+
+import (
+	"context"
+	"ticket-service/internal/modules/tour/models/request"
+	"ticket-service/internal/modules/tour/models/response"
+	"ticket-service/internal/pkg/constants"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseQuery interface {
+	FindTour(origCtx context.Context, payload request.TourReq) (*response.Tour, *constants.MetaData, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindTourStops(ctx context.Context, tag string, page int64, size int64) <-chan wrapper.Result
+}
@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/tour"
+	"ticket-service/internal/modules/tour/models/entity"
+	"ticket-service/internal/modules/tour/models/request"
+	"ticket-service/internal/modules/tour/models/response"
+	"ticket-service/internal/modules/venue"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	tourRepositoryQuery tour.MongodbRepositoryQuery
+	venueUsecaseQuery   venue.UsecaseQuery
+	redisClient         redis.Collections
+	logger              log.Logger
+}
+
+// cachedTour keeps a page together with its meta so a cache hit answers the whole request
+type cachedTour struct {
+	Tour response.Tour      `json:"tour"`
+	Meta constants.MetaData `json:"meta"`
+}
+
+func NewQueryUsecase(tmq tour.MongodbRepositoryQuery, vuq venue.UsecaseQuery, redisClient redis.Collections,
+	log log.Logger) tour.UsecaseQuery {
+	return queryUsecase{
+		tourRepositoryQuery: tmq,
+		venueUsecaseQuery:   vuq,
+		redisClient:         redisClient,
+		logger:              log,
+	}
+}
+
+func (q queryUsecase) FindTour(origCtx context.Context, payload request.TourReq) (*response.Tour, *constants.MetaData, error) {
+	domain := "tourUsecase-FindTour"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if payload.Page == 0 {
+		payload.Page = 1
+	}
+	if payload.Size == 0 {
+		payload.Size = constants.TourPageSize
+	}
+
+	key := fmt.Sprintf("%s:%s:%d:%d", constants.RedisKeyTour, payload.Tag, payload.Page, payload.Size)
+	var cached cachedTour
+	if redis.GetCache(ctx, q.redisClient, key, &cached) {
+		return &cached.Tour, &cached.Meta, nil
+	}
+
+	resp := <-q.tourRepositoryQuery.FindTourStops(ctx, payload.Tag, payload.Page, payload.Size)
+	if resp.Error != nil {
+		msg := "Error query tour"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil, errors.NotFound("tour not found")
+	}
+	tourPages, ok := resp.Data.(*[]entity.TourPage)
+	if !ok {
+		return nil, nil, errors.InternalServerError("cannot parsing data")
+	}
+	if len(*tourPages) == 0 || (*tourPages)[0].Total() == 0 {
+		return nil, nil, errors.NotFound("tour not found")
+	}
+	tourPage := (*tourPages)[0]
+
+	result := response.Tour{
+		Tag:   payload.Tag,
+		Stops: make([]response.TourStop, 0),
+	}
+	now := time.Now()
+	venues := make(map[string]*response.Venue)
+	for _, value := range tourPage.Stops {
+		result.Stops = append(result.Stops, q.mapTourStop(ctx, value, now, venues))
+	}
+	metaData := helpers.GenerateMetaData(tourPage.Total(), int64(len(result.Stops)), payload.Page, payload.Size)
+
+	redis.SetCache(ctx, q.redisClient, key, cachedTour{Tour: result, Meta: metaData}, constants.TourCacheTTL)
+	return &result, &metaData, nil
+}
+
+// mapTourStop prices every tier at its active phase, the lowest price only counts tiers still on sale
+func (q queryUsecase) mapTourStop(ctx context.Context, stop entity.TourStop, now time.Time,
+	venues map[string]*response.Venue) response.TourStop {
+	result := response.TourStop{
+		EventId:     stop.Id.EventId,
+		CountryCode: stop.Id.CountryCode,
+		IsSold:      true,
+		Tiers:       make([]response.Tier, 0),
+	}
+	if !stop.EventDate.IsZero() {
+		result.EventDate = &stop.EventDate
+	}
+
+	lowestPrice := -1
+	for _, value := range stop.Tickets {
+		result.CountryName = value.Country.Name
+		result.ContinentCode = value.ContinentCode
+		result.ContinentName = value.ContinentName
+		if result.Venue == nil && value.VenueId != "" {
+			result.Venue = q.findVenue(ctx, value.VenueId, venues)
+		}
+
+		pricing := value.PriceAt(now, value.Sold())
+		result.Tiers = append(result.Tiers, response.Tier{
+			TicketType:     value.TicketType,
+			TicketPrice:    fmt.Sprintf("$%d", pricing.Price),
+			PricePhase:     pricing.Phase,
+			TotalQuota:     value.TotalQuota,
+			TotalRemaining: value.TotalRemaining,
+			IsSold:         value.TotalRemaining == 0,
+		})
+		result.TotalRemaining += value.TotalRemaining
+		if value.TotalRemaining == 0 {
+			continue
+		}
+		result.IsSold = false
+		if lowestPrice < 0 || pricing.Price < lowestPrice {
+			lowestPrice = pricing.Price
+		}
+	}
+	if lowestPrice >= 0 {
+		result.LowestPrice = fmt.Sprintf("$%d", lowestPrice)
+	}
+	return result
+}
+
+// findVenue resolves a venue once per page, a stop whose venue cannot be read is shown without one
+func (q queryUsecase) findVenue(ctx context.Context, venueId string, venues map[string]*response.Venue) *response.Venue {
+	if venueDetail, ok := venues[venueId]; ok {
+		return venueDetail
+	}
+	venueDetail, err := q.venueUsecaseQuery.FindVenue(ctx, venueId)
+	if err != nil {
+		msg := "Error query venue"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		venues[venueId] = nil
+		return nil
+	}
+	venues[venueId] = &response.Venue{
+		VenueId:  venueDetail.VenueId,
+		Name:     venueDetail.Name,
+		CityName: venueDetail.CityName,
+		Timezone: venueDetail.Timezone,
+	}
+	return venues[venueId]
+}
@@ -0,0 +1,161 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/modules/tour"
+	"ticket-service/internal/modules/tour/models/entity"
+	"ticket-service/internal/modules/tour/models/request"
+	uc "ticket-service/internal/modules/tour/usecases"
+	venueResponse "ticket-service/internal/modules/venue/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mocktour "ticket-service/mocks/modules/tour"
+	mockvenue "ticket-service/mocks/modules/venue"
+	mocklog "ticket-service/mocks/pkg/log"
+	mockredis "ticket-service/mocks/pkg/redis"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryUsecaseTestSuite struct {
+	suite.Suite
+	mockTourRepositoryQuery *mocktour.MongodbRepositoryQuery
+	mockVenueUsecaseQuery   *mockvenue.UsecaseQuery
+	mockRedis               *mockredis.Collections
+	mockLogger              *mocklog.Logger
+	usecase                 tour.UsecaseQuery
+	ctx                     context.Context
+}
+
+func (suite *QueryUsecaseTestSuite) SetupTest() {
+	suite.mockTourRepositoryQuery = &mocktour.MongodbRepositoryQuery{}
+	suite.mockVenueUsecaseQuery = &mockvenue.UsecaseQuery{}
+	suite.mockRedis = &mockredis.Collections{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.mockRedis.On("Get", mock.Anything, mock.Anything).Return(redis.NewStringResult("", redis.Nil))
+	suite.mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(redis.NewStatusResult("OK", nil))
+	suite.usecase = uc.NewQueryUsecase(
+		suite.mockTourRepositoryQuery,
+		suite.mockVenueUsecaseQuery,
+		suite.mockRedis,
+		suite.mockLogger,
+	)
+}
+
+func TestQueryUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryUsecaseTestSuite))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTourCached() {
+	// Arrange
+	suite.mockRedis.ExpectedCalls = nil
+	suite.mockRedis.On("Get", mock.Anything, "TOUR:tour-2024:2:5").
+		Return(redis.NewStringResult(`{"tour":{"tag":"tour-2024","stops":[{"eventId":"event-id","countryCode":"ID"}]},`+
+			`"meta":{"page":2,"count":1,"totalPage":2,"totalData":6}}`, nil))
+
+	// Act
+	result, metaData, err := suite.usecase.FindTour(suite.ctx, request.TourReq{Tag: "tour-2024", Page: 2, Size: 5})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Stops, 1)
+	assert.Equal(suite.T(), int64(6), metaData.TotalData)
+	suite.mockTourRepositoryQuery.AssertNotCalled(suite.T(), "FindTourStops", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTourLowestPriceSkipsSoldTiers() {
+	// Arrange
+	eventDate := time.Date(2024, 8, 1, 19, 0, 0, 0, time.UTC)
+	tourPages := []entity.TourPage{{
+		Meta: []entity.TourCount{{Total: 12}},
+		Stops: []entity.TourStop{
+			{
+				Id:        entity.TourStopId{EventId: "event-id", CountryCode: "ID"},
+				EventDate: eventDate,
+				Tickets: []ticketEntity.Ticket{
+					getMockTicket("Bronze", 50, 0, "venue-id"),
+					getMockTicket("Silver", 80, 10, "venue-id"),
+					getMockTicket("Gold", 120, 5, "venue-id"),
+				},
+			},
+			{
+				Id:      entity.TourStopId{EventId: "event-id-2", CountryCode: "SG"},
+				Tickets: []ticketEntity.Ticket{getMockTicket("Bronze", 60, 0, "")},
+			},
+		},
+	}}
+	suite.mockTourRepositoryQuery.On("FindTourStops", mock.Anything, "tour-2024", int64(1), int64(10)).
+		Return(mockChannel(helpers.Result{Data: &tourPages}))
+	suite.mockVenueUsecaseQuery.On("FindVenue", mock.Anything, "venue-id").
+		Return(&venueResponse.Venue{VenueId: "venue-id", Name: "Grand Hall", CityName: "Jakarta"}, nil)
+
+	// Act
+	result, metaData, err := suite.usecase.FindTour(suite.ctx, request.TourReq{Tag: "tour-2024"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), result.Stops, 2)
+	assert.Equal(suite.T(), "$80", result.Stops[0].LowestPrice)
+	assert.Equal(suite.T(), 15, result.Stops[0].TotalRemaining)
+	assert.Equal(suite.T(), eventDate, *result.Stops[0].EventDate)
+	assert.Equal(suite.T(), "Grand Hall", result.Stops[0].Venue.Name)
+	assert.Len(suite.T(), result.Stops[0].Tiers, 3)
+	assert.True(suite.T(), result.Stops[1].IsSold)
+	assert.Empty(suite.T(), result.Stops[1].LowestPrice)
+	assert.Nil(suite.T(), result.Stops[1].EventDate)
+	assert.Equal(suite.T(), int64(2), metaData.TotalPage)
+	assert.Equal(suite.T(), int64(2), metaData.Count)
+	suite.mockVenueUsecaseQuery.AssertNumberOfCalls(suite.T(), "FindVenue", 1)
+	suite.mockRedis.AssertCalled(suite.T(), "Set", mock.Anything, "TOUR:tour-2024:1:10", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindTourErrNotFound() {
+	// Arrange
+	tourPages := []entity.TourPage{{}}
+	suite.mockTourRepositoryQuery.On("FindTourStops", mock.Anything, "unknown", int64(1), int64(10)).
+		Return(mockChannel(helpers.Result{Data: &tourPages}))
+
+	// Act
+	result, metaData, err := suite.usecase.FindTour(suite.ctx, request.TourReq{Tag: "unknown"})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Nil(suite.T(), metaData)
+	assert.Equal(suite.T(), errors.NotFound("tour not found"), err)
+	suite.mockRedis.AssertNotCalled(suite.T(), "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func getMockTicket(ticketType string, price int, remaining int, venueId string) ticketEntity.Ticket {
+	return ticketEntity.Ticket{
+		TicketId:       "ticket-" + ticketType,
+		TicketType:     ticketType,
+		TicketPrice:    price,
+		TotalQuota:     100,
+		TotalRemaining: remaining,
+		ContinentName:  "Asia",
+		ContinentCode:  "AS",
+		Country:        ticketEntity.Country{Name: "Indonesia", Code: "ID"},
+		VenueId:        venueId,
+		Tag:            "tour-2024",
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
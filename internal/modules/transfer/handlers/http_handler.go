@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/transfer"
+	"ticket-service/internal/modules/transfer/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type TransferHttpHandler struct {
+	TransferUsecaseCommand transfer.UsecaseCommand
+	TransferUsecaseQuery   transfer.UsecaseQuery
+	Logger                 log.Logger
+	Validator              *validator.Validate
+}
+
+func InitTransferHttpHandler(app *fiber.App, tuc transfer.UsecaseCommand, tuq transfer.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &TransferHttpHandler{
+		TransferUsecaseCommand: tuc,
+		TransferUsecaseQuery:   tuq,
+		Logger:                 log,
+		Validator:              validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/transfers")
+
+	route.Post("/v1", middlewares.VerifyBearer(), handler.CreateTransfer)
+	route.Get("/v1/incoming", middlewares.VerifyBearer(), handler.GetIncomingTransfers)
+	route.Post("/v1/:id/accept", middlewares.VerifyBearer(), handler.AcceptTransfer)
+	route.Post("/v1/:id/cancel", middlewares.VerifyBearer(), handler.CancelTransfer)
+	route.Get("/v1/history/:issuedTicketId", middlewares.VerifyBearer(), handler.GetOwnershipHistory)
+	route.Get("/v1/rules/:eventId", middlewares.VerifyBearer(), handler.GetTransferRule)
+	route.Put("/v1/rules", middlewares.VerifyBearer(), adminRole, handler.UpsertTransferRule)
+}
+
+func (t TransferHttpHandler) CreateTransfer(c *fiber.Ctx) error {
+	req := new(request.TransferReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := t.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, t.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	resp, err := t.TransferUsecaseCommand.CreateTransfer(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespSuccess(c, t.Logger, resp, "Create transfer success")
+}
+
+func (t TransferHttpHandler) GetIncomingTransfers(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, t.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	resp, err := t.TransferUsecaseQuery.FindIncomingTransfers(c.Context(), userId)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespSuccess(c, t.Logger, resp, "Get incoming transfer success")
+}
+
+func (t TransferHttpHandler) AcceptTransfer(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, t.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.TransferActionReq{
+		UserId:     userId,
+		TransferId: c.Params("id"),
+	}
+	resp, err := t.TransferUsecaseCommand.AcceptTransfer(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespSuccess(c, t.Logger, resp, "Accept transfer success")
+}
+
+func (t TransferHttpHandler) CancelTransfer(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, t.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.TransferActionReq{
+		UserId:     userId,
+		TransferId: c.Params("id"),
+	}
+	resp, err := t.TransferUsecaseCommand.CancelTransfer(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespSuccess(c, t.Logger, resp, "Cancel transfer success")
+}
+
+func (t TransferHttpHandler) GetOwnershipHistory(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, t.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.OwnershipHistoryReq{
+		UserId:         userId,
+		IssuedTicketId: c.Params("issuedTicketId"),
+	}
+	resp, err := t.TransferUsecaseQuery.FindOwnershipHistory(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespSuccess(c, t.Logger, resp, "Get ownership history success")
+}
+
+func (t TransferHttpHandler) GetTransferRule(c *fiber.Ctx) error {
+	eventId := c.Params("eventId")
+	if eventId == "" {
+		return helpers.RespError(c, t.Logger, errors.BadRequest("eventId is required"))
+	}
+	resp, err := t.TransferUsecaseQuery.FindTransferRule(c.Context(), eventId)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespSuccess(c, t.Logger, resp, "Get transfer rule success")
+}
+
+func (t TransferHttpHandler) UpsertTransferRule(c *fiber.Ctx) error {
+	req := new(request.TransferRuleReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := t.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, t.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := t.TransferUsecaseCommand.UpsertTransferRule(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, t.Logger, err)
+	}
+	return helpers.RespSuccess(c, t.Logger, resp, "Update transfer rule success")
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+type Transfer struct {
+	TransferId     string    `json:"transferId" bson:"transferId"`
+	IssuedTicketId string    `json:"issuedTicketId" bson:"issuedTicketId"`
+	EventId        string    `json:"eventId" bson:"eventId"`
+	TicketType     string    `json:"ticketType" bson:"ticketType"`
+	QrVersion      int       `json:"qrVersion" bson:"qrVersion"`
+	FromUserId     string    `json:"fromUserId" bson:"fromUserId"`
+	ToUserId       string    `json:"toUserId" bson:"toUserId"`
+	ToEmail        string    `json:"toEmail" bson:"toEmail"`
+	Status         string    `json:"status" bson:"status"`
+	ExpiredAt      time.Time `json:"expiredAt" bson:"expiredAt"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// TransferRule is configured per event, without a rule transfers are allowed at any time
+type TransferRule struct {
+	EventId     string    `json:"eventId" bson:"eventId"`
+	Enabled     bool      `json:"enabled" bson:"enabled"`
+	CutoffHours int       `json:"cutoffHours" bson:"cutoffHours"`
+	DoorsOpenAt time.Time `json:"doorsOpenAt" bson:"doorsOpenAt"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Deadline is the last moment a transfer can be requested or accepted, zero when there is no cutoff
+func (r TransferRule) Deadline() time.Time {
+	if r.DoorsOpenAt.IsZero() {
+		return time.Time{}
+	}
+	return r.DoorsOpenAt.Add(-time.Duration(r.CutoffHours) * time.Hour)
+}
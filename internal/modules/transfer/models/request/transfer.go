@@ -0,0 +1,26 @@
+package request
+
+import "time"
+
+type TransferReq struct {
+	UserId         string `json:"-"`
+	IssuedTicketId string `json:"issuedTicketId" validate:"required"`
+	RecipientEmail string `json:"recipientEmail" validate:"required,email"`
+}
+
+type TransferActionReq struct {
+	UserId     string `json:"-"`
+	TransferId string `json:"-"`
+}
+
+type OwnershipHistoryReq struct {
+	UserId         string `json:"-"`
+	IssuedTicketId string `json:"-"`
+}
+
+type TransferRuleReq struct {
+	EventId     string    `json:"eventId" validate:"required"`
+	Enabled     bool      `json:"enabled"`
+	CutoffHours int       `json:"cutoffHours" validate:"min=0"`
+	DoorsOpenAt time.Time `json:"doorsOpenAt"`
+}
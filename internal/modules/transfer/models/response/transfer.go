@@ -0,0 +1,28 @@
+package response
+
+import "time"
+
+type Transfer struct {
+	TransferId     string    `json:"transferId"`
+	IssuedTicketId string    `json:"issuedTicketId"`
+	EventId        string    `json:"eventId"`
+	TicketType     string    `json:"ticketType"`
+	RecipientEmail string    `json:"recipientEmail"`
+	Status         string    `json:"status"`
+	ExpiredAt      time.Time `json:"expiredAt"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type Ownership struct {
+	UserId      string    `json:"userId"`
+	Via         string    `json:"via"`
+	ReferenceId string    `json:"referenceId"`
+	AcquiredAt  time.Time `json:"acquiredAt"`
+}
+
+type TransferRule struct {
+	EventId     string    `json:"eventId"`
+	Enabled     bool      `json:"enabled"`
+	CutoffHours int       `json:"cutoffHours"`
+	DoorsOpenAt time.Time `json:"doorsOpenAt"`
+}
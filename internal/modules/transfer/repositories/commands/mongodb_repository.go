@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/transfer"
+	"ticket-service/internal/modules/transfer/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) transfer.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) InsertOneTransfer(ctx context.Context, transfer entity.Transfer) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "ticket-transfers",
+			Document:       transfer,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateTransferStatus moves the transfer only when it is still in fromStatus, Data is nil when it is not
+func (c commandMongodbRepository) UpdateTransferStatus(ctx context.Context, transferId string, fromStatus string, toStatus string) <-chan wrapper.Result {
+	var transfer entity.Transfer
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &transfer,
+			CollectionName: "ticket-transfers",
+			Filter: bson.M{
+				"transferId": transferId,
+				"status":     fromStatus,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    toStatus,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpsertTransferRule(ctx context.Context, rule entity.TransferRule) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "transfer-rules",
+			Filter: bson.M{
+				"eventId": rule.EventId,
+			},
+			Document: rule,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
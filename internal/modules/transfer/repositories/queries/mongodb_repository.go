@@ -0,0 +1,111 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/transfer"
+	"ticket-service/internal/modules/transfer/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) transfer.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindTransferById(ctx context.Context, transferId string) <-chan wrapper.Result {
+	var transfer entity.Transfer
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &transfer,
+			CollectionName: "ticket-transfers",
+			Filter: bson.M{
+				"transferId": transferId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindPendingTransferByIssuedTicketId ignores pending transfers whose accept window already lapsed
+func (q queryMongodbRepository) FindPendingTransferByIssuedTicketId(ctx context.Context, issuedTicketId string) <-chan wrapper.Result {
+	var transfer entity.Transfer
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &transfer,
+			CollectionName: "ticket-transfers",
+			Filter: bson.M{
+				"issuedTicketId": issuedTicketId,
+				"status":         constants.TransferStatusPending,
+				"expiredAt":      bson.M{"$gt": time.Now()},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindIncomingTransfers(ctx context.Context, userId string) <-chan wrapper.Result {
+	var transfers []entity.Transfer
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &transfers,
+			CollectionName: "ticket-transfers",
+			Filter: bson.M{
+				"toUserId":  userId,
+				"status":    constants.TransferStatusPending,
+				"expiredAt": bson.M{"$gt": time.Now()},
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "createdAt",
+				By:        mongodb.SortDescending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindTransferRuleByEventId(ctx context.Context, eventId string) <-chan wrapper.Result {
+	var rule entity.TransferRule
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &rule,
+			CollectionName: "transfer-rules",
+			Filter: bson.M{
+				"eventId": eventId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
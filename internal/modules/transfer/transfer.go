@@ -0,0 +1,35 @@
+package transfer
+
+import (
+	"context"
+	"ticket-service/internal/modules/transfer/models/entity"
+	"ticket-service/internal/modules/transfer/models/request"
+	"ticket-service/internal/modules/transfer/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseCommand interface {
+	CreateTransfer(origCtx context.Context, payload request.TransferReq) (*response.Transfer, error)
+	AcceptTransfer(origCtx context.Context, payload request.TransferActionReq) (*response.Transfer, error)
+	CancelTransfer(origCtx context.Context, payload request.TransferActionReq) (*response.Transfer, error)
+	UpsertTransferRule(origCtx context.Context, payload request.TransferRuleReq) (*response.TransferRule, error)
+}
+
+type UsecaseQuery interface {
+	FindIncomingTransfers(origCtx context.Context, userId string) ([]response.Transfer, error)
+	FindOwnershipHistory(origCtx context.Context, payload request.OwnershipHistoryReq) ([]response.Ownership, error)
+	FindTransferRule(origCtx context.Context, eventId string) (*response.TransferRule, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindTransferById(ctx context.Context, transferId string) <-chan wrapper.Result
+	FindPendingTransferByIssuedTicketId(ctx context.Context, issuedTicketId string) <-chan wrapper.Result
+	FindIncomingTransfers(ctx context.Context, userId string) <-chan wrapper.Result
+	FindTransferRuleByEventId(ctx context.Context, eventId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	InsertOneTransfer(ctx context.Context, transfer entity.Transfer) <-chan wrapper.Result
+	UpdateTransferStatus(ctx context.Context, transferId string, fromStatus string, toStatus string) <-chan wrapper.Result
+	UpsertTransferRule(ctx context.Context, rule entity.TransferRule) <-chan wrapper.Result
+}
@@ -0,0 +1,393 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ticket-service/internal/modules/access"
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/transfer"
+	"ticket-service/internal/modules/transfer/models/entity"
+	"ticket-service/internal/modules/transfer/models/request"
+	"ticket-service/internal/modules/transfer/models/response"
+	"ticket-service/internal/modules/user"
+	userEntity "ticket-service/internal/modules/user/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	transferRepositoryQuery   transfer.MongodbRepositoryQuery
+	transferRepositoryCommand transfer.MongodbRepositoryCommand
+	eticketRepositoryQuery    eticket.MongodbRepositoryQuery
+	eticketRepositoryCommand  eticket.MongodbRepositoryCommand
+	userRepositoryQuery       user.MongodbRepositoryQuery
+	accessUsecaseCommand      access.UsecaseCommand
+	kafkaProducer             kafkaConfluent.Producer
+	logger                    log.Logger
+}
+
+func NewCommandUsecase(tmq transfer.MongodbRepositoryQuery, tmc transfer.MongodbRepositoryCommand, emq eticket.MongodbRepositoryQuery,
+	emc eticket.MongodbRepositoryCommand, umq user.MongodbRepositoryQuery, auc access.UsecaseCommand, kp kafkaConfluent.Producer,
+	log log.Logger) transfer.UsecaseCommand {
+	return commandUsecase{
+		transferRepositoryQuery:   tmq,
+		transferRepositoryCommand: tmc,
+		eticketRepositoryQuery:    emq,
+		eticketRepositoryCommand:  emc,
+		userRepositoryQuery:       umq,
+		accessUsecaseCommand:      auc,
+		kafkaProducer:             kp,
+		logger:                    log,
+	}
+}
+
+func (c commandUsecase) CreateTransfer(origCtx context.Context, payload request.TransferReq) (*response.Transfer, error) {
+	domain := "transferUsecase-CreateTransfer"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	issuedTicket, err := c.findIssuedTicket(ctx, payload.IssuedTicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuedTicket.UserId != payload.UserId {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	if issuedTicket.Status != constants.IssuedTicketStatusActive {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("ticket is %s and cannot be transferred", issuedTicket.Status))
+	}
+
+	rule, err := c.findTransferRule(ctx, issuedTicket.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := checkTransferRule(*rule, now); err != nil {
+		return nil, err
+	}
+
+	recipientData := <-c.userRepositoryQuery.FindOneUserByEmail(ctx, payload.RecipientEmail)
+	if recipientData.Error != nil {
+		msg := "Error query recipient"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", recipientData.Error))
+		return nil, recipientData.Error
+	}
+
+	if recipientData.Data == nil {
+		return nil, errors.NotFound("recipient not found")
+	}
+
+	recipient, ok := recipientData.Data.(*userEntity.User)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if recipient.UserId == payload.UserId {
+		return nil, errors.BadRequest("cannot transfer ticket to yourself")
+	}
+
+	pendingData := <-c.transferRepositoryQuery.FindPendingTransferByIssuedTicketId(ctx, issuedTicket.IssuedTicketId)
+	if pendingData.Error != nil {
+		msg := "Error query pending transfer"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", pendingData.Error))
+		return nil, pendingData.Error
+	}
+
+	if pendingData.Data != nil {
+		return nil, errors.Conflict("ticket already has a pending transfer")
+	}
+
+	expiredAt := now.Add(constants.TransferAcceptWindow)
+	if deadline := rule.Deadline(); !deadline.IsZero() && deadline.Before(expiredAt) {
+		expiredAt = deadline
+	}
+
+	transferData := entity.Transfer{
+		TransferId:     uuid.NewString(),
+		IssuedTicketId: issuedTicket.IssuedTicketId,
+		EventId:        issuedTicket.EventId,
+		TicketType:     issuedTicket.TicketType,
+		QrVersion:      issuedTicket.QrVersion,
+		FromUserId:     payload.UserId,
+		ToUserId:       recipient.UserId,
+		ToEmail:        recipient.Email,
+		Status:         constants.TransferStatusPending,
+		ExpiredAt:      expiredAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	insert := <-c.transferRepositoryCommand.InsertOneTransfer(ctx, transferData)
+	if insert.Error != nil {
+		msg := "Error insert transfer"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		return nil, insert.Error
+	}
+
+	c.publishTransfer(ctx, transferData)
+
+	return mapTransfer(transferData), nil
+}
+
+// AcceptTransfer claims the transfer first and only then moves the ticket, so a concurrent cancel
+// and accept can never both succeed
+func (c commandUsecase) AcceptTransfer(origCtx context.Context, payload request.TransferActionReq) (*response.Transfer, error) {
+	domain := "transferUsecase-AcceptTransfer"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	transferData, err := c.findTransfer(ctx, payload.TransferId)
+	if err != nil {
+		return nil, err
+	}
+
+	if transferData.ToUserId != payload.UserId {
+		return nil, errors.NotFound("transfer not found")
+	}
+
+	if transferData.Status != constants.TransferStatusPending {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("transfer is already %s", transferData.Status))
+	}
+
+	now := time.Now()
+	if now.After(transferData.ExpiredAt) {
+		<-c.transferRepositoryCommand.UpdateTransferStatus(ctx, transferData.TransferId, constants.TransferStatusPending,
+			constants.TransferStatusExpired)
+		return nil, errors.UnprocessableEntity("transfer has expired")
+	}
+
+	// the rule may have been tightened after the transfer was requested
+	rule, err := c.findTransferRule(ctx, transferData.EventId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkTransferRule(*rule, now); err != nil {
+		return nil, err
+	}
+
+	accepted := <-c.transferRepositoryCommand.UpdateTransferStatus(ctx, transferData.TransferId, constants.TransferStatusPending,
+		constants.TransferStatusAccepted)
+	if accepted.Error != nil {
+		msg := "Error accept transfer"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", accepted.Error))
+		return nil, accepted.Error
+	}
+
+	if accepted.Data == nil {
+		return nil, errors.Conflict("transfer is no longer pending")
+	}
+
+	owner := <-c.eticketRepositoryCommand.UpdateIssuedTicketOwner(ctx, eticketEntity.IssuedTicket{
+		IssuedTicketId: transferData.IssuedTicketId,
+		UserId:         transferData.FromUserId,
+		QrVersion:      transferData.QrVersion,
+	}, eticketEntity.Ownership{
+		UserId:      transferData.ToUserId,
+		Via:         constants.OwnershipViaTransfer,
+		ReferenceId: transferData.TransferId,
+		AcquiredAt:  now,
+	})
+	if owner.Error != nil || owner.Data == nil {
+		<-c.transferRepositoryCommand.UpdateTransferStatus(ctx, transferData.TransferId, constants.TransferStatusAccepted,
+			constants.TransferStatusFailed)
+		if owner.Error != nil {
+			msg := "Error update ticket owner"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", owner.Error))
+			return nil, owner.Error
+		}
+		return nil, errors.Conflict("ticket has changed since the transfer was requested")
+	}
+
+	// the recipient gets a pass signed for the bumped qr version, the sender's one stops working
+	if transferData.TicketType == constants.TicketTypeOnline {
+		if _, err := c.accessUsecaseCommand.IssuePass(ctx, transferData.IssuedTicketId); err != nil {
+			msg := "Error issue access pass"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", err))
+		}
+	}
+
+	transferData.Status = constants.TransferStatusAccepted
+	transferData.UpdatedAt = now
+	c.publishTransfer(ctx, *transferData)
+
+	return mapTransfer(*transferData), nil
+}
+
+func (c commandUsecase) CancelTransfer(origCtx context.Context, payload request.TransferActionReq) (*response.Transfer, error) {
+	domain := "transferUsecase-CancelTransfer"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	transferData, err := c.findTransfer(ctx, payload.TransferId)
+	if err != nil {
+		return nil, err
+	}
+
+	if transferData.FromUserId != payload.UserId {
+		return nil, errors.NotFound("transfer not found")
+	}
+
+	cancelled := <-c.transferRepositoryCommand.UpdateTransferStatus(ctx, transferData.TransferId, constants.TransferStatusPending,
+		constants.TransferStatusCancelled)
+	if cancelled.Error != nil {
+		msg := "Error cancel transfer"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", cancelled.Error))
+		return nil, cancelled.Error
+	}
+
+	if cancelled.Data == nil {
+		return nil, errors.UnprocessableEntity("transfer is no longer pending")
+	}
+
+	transferData.Status = constants.TransferStatusCancelled
+	c.publishTransfer(ctx, *transferData)
+
+	return mapTransfer(*transferData), nil
+}
+
+func (c commandUsecase) UpsertTransferRule(origCtx context.Context, payload request.TransferRuleReq) (*response.TransferRule, error) {
+	domain := "transferUsecase-UpsertTransferRule"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if payload.CutoffHours > 0 && payload.DoorsOpenAt.IsZero() {
+		return nil, errors.BadRequest("doorsOpenAt is required when cutoffHours is set")
+	}
+
+	rule := entity.TransferRule{
+		EventId:     payload.EventId,
+		Enabled:     payload.Enabled,
+		CutoffHours: payload.CutoffHours,
+		DoorsOpenAt: payload.DoorsOpenAt,
+		UpdatedAt:   time.Now(),
+	}
+	resp := <-c.transferRepositoryCommand.UpsertTransferRule(ctx, rule)
+	if resp.Error != nil {
+		msg := "Error upsert transfer rule"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	return mapTransferRule(rule), nil
+}
+
+func (c commandUsecase) findIssuedTicket(ctx context.Context, issuedTicketId string) (*eticketEntity.IssuedTicket, error) {
+	resp := <-c.eticketRepositoryQuery.FindIssuedTicketById(ctx, issuedTicketId)
+	if resp.Error != nil {
+		msg := "Error query issued ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	issuedTicket, ok := resp.Data.(*eticketEntity.IssuedTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return issuedTicket, nil
+}
+
+func (c commandUsecase) findTransfer(ctx context.Context, transferId string) (*entity.Transfer, error) {
+	resp := <-c.transferRepositoryQuery.FindTransferById(ctx, transferId)
+	if resp.Error != nil {
+		msg := "Error query transfer"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("transfer not found")
+	}
+
+	transferData, ok := resp.Data.(*entity.Transfer)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return transferData, nil
+}
+
+func (c commandUsecase) findTransferRule(ctx context.Context, eventId string) (*entity.TransferRule, error) {
+	resp := <-c.transferRepositoryQuery.FindTransferRuleByEventId(ctx, eventId)
+	if resp.Error != nil {
+		msg := "Error query transfer rule"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	// events without configuration allow transfers at any time
+	if resp.Data == nil {
+		return &entity.TransferRule{EventId: eventId, Enabled: true}, nil
+	}
+
+	rule, ok := resp.Data.(*entity.TransferRule)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return rule, nil
+}
+
+func (c commandUsecase) publishTransfer(ctx context.Context, transferData entity.Transfer) {
+	marshaledKafkaData, _ := json.Marshal(transferData)
+	topic := "concert-ticket-transfer"
+	c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+	c.logger.Info(ctx, fmt.Sprintf("Send kafka ticket transfer, transfer : %s", transferData.TransferId), fmt.Sprintf("%+v", transferData))
+}
+
+func checkTransferRule(rule entity.TransferRule, now time.Time) error {
+	if !rule.Enabled {
+		return errors.UnprocessableEntity("transfer is disabled for this event")
+	}
+	if deadline := rule.Deadline(); !deadline.IsZero() && now.After(deadline) {
+		return errors.UnprocessableEntity(fmt.Sprintf("transfer is closed %d hours before doors open", rule.CutoffHours))
+	}
+	return nil
+}
+
+func mapTransfer(transferData entity.Transfer) *response.Transfer {
+	return &response.Transfer{
+		TransferId:     transferData.TransferId,
+		IssuedTicketId: transferData.IssuedTicketId,
+		EventId:        transferData.EventId,
+		TicketType:     transferData.TicketType,
+		RecipientEmail: transferData.ToEmail,
+		Status:         transferData.Status,
+		ExpiredAt:      transferData.ExpiredAt,
+		CreatedAt:      transferData.CreatedAt,
+	}
+}
+
+func mapTransferRule(rule entity.TransferRule) *response.TransferRule {
+	return &response.TransferRule{
+		EventId:     rule.EventId,
+		Enabled:     rule.Enabled,
+		CutoffHours: rule.CutoffHours,
+		DoorsOpenAt: rule.DoorsOpenAt,
+	}
+}
@@ -0,0 +1,330 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/transfer"
+	"ticket-service/internal/modules/transfer/models/entity"
+	"ticket-service/internal/modules/transfer/models/request"
+	uc "ticket-service/internal/modules/transfer/usecases"
+	userEntity "ticket-service/internal/modules/user/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockaccess "ticket-service/mocks/modules/access"
+	mocketicket "ticket-service/mocks/modules/eticket"
+	mocktransfer "ticket-service/mocks/modules/transfer"
+	mockuser "ticket-service/mocks/modules/user"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockTransferRepositoryQuery   *mocktransfer.MongodbRepositoryQuery
+	mockTransferRepositoryCommand *mocktransfer.MongodbRepositoryCommand
+	mockEticketRepositoryQuery    *mocketicket.MongodbRepositoryQuery
+	mockEticketRepositoryCommand  *mocketicket.MongodbRepositoryCommand
+	mockUserRepositoryQuery       *mockuser.MongodbRepositoryQuery
+	mockAccessUsecaseCommand      *mockaccess.UsecaseCommand
+	mockKafkaProducer             *mockkafka.Producer
+	mockLogger                    *mocklog.Logger
+	usecase                       transfer.UsecaseCommand
+	ctx                           context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockTransferRepositoryQuery = &mocktransfer.MongodbRepositoryQuery{}
+	suite.mockTransferRepositoryCommand = &mocktransfer.MongodbRepositoryCommand{}
+	suite.mockEticketRepositoryQuery = &mocketicket.MongodbRepositoryQuery{}
+	suite.mockEticketRepositoryCommand = &mocketicket.MongodbRepositoryCommand{}
+	suite.mockUserRepositoryQuery = &mockuser.MongodbRepositoryQuery{}
+	suite.mockAccessUsecaseCommand = &mockaccess.UsecaseCommand{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockTransferRepositoryQuery,
+		suite.mockTransferRepositoryCommand,
+		suite.mockEticketRepositoryQuery,
+		suite.mockEticketRepositoryCommand,
+		suite.mockUserRepositoryQuery,
+		suite.mockAccessUsecaseCommand,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+	suite.mockKafkaProducer.On("Publish", "concert-ticket-transfer", mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateTransfer() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("owner-id")}))
+	suite.mockTransferRepositoryQuery.On("FindTransferRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockUserRepositoryQuery.On("FindOneUserByEmail", mock.Anything, "friend@mail.com").
+		Return(mockChannel(helpers.Result{Data: &userEntity.User{UserId: "friend-id", Email: "friend@mail.com"}}))
+	suite.mockTransferRepositoryQuery.On("FindPendingTransferByIssuedTicketId", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockTransferRepositoryCommand.On("InsertOneTransfer", mock.Anything, mock.MatchedBy(func(t entity.Transfer) bool {
+		return t.FromUserId == "owner-id" && t.ToUserId == "friend-id" && t.QrVersion == 1 && t.Status == constants.TransferStatusPending
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateTransfer(suite.ctx, getTransferReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.TransferStatusPending, result.Status)
+	assert.WithinDuration(suite.T(), time.Now().Add(constants.TransferAcceptWindow), result.ExpiredAt, time.Minute)
+	suite.mockKafkaProducer.AssertCalled(suite.T(), "Publish", "concert-ticket-transfer", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateTransferDeadlineCappedByCutoff() {
+	// Arrange
+	doorsOpenAt := time.Now().Add(30 * time.Hour)
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("owner-id")}))
+	suite.mockTransferRepositoryQuery.On("FindTransferRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: &entity.TransferRule{EventId: "event-id", Enabled: true, CutoffHours: 24, DoorsOpenAt: doorsOpenAt}}))
+	suite.mockUserRepositoryQuery.On("FindOneUserByEmail", mock.Anything, "friend@mail.com").
+		Return(mockChannel(helpers.Result{Data: &userEntity.User{UserId: "friend-id", Email: "friend@mail.com"}}))
+	suite.mockTransferRepositoryQuery.On("FindPendingTransferByIssuedTicketId", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockTransferRepositoryCommand.On("InsertOneTransfer", mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.CreateTransfer(suite.ctx, getTransferReq())
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), doorsOpenAt.Add(-24*time.Hour), result.ExpiredAt)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateTransferErrDisabled() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("owner-id")}))
+	suite.mockTransferRepositoryQuery.On("FindTransferRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: &entity.TransferRule{EventId: "event-id", Enabled: false}}))
+
+	// Act
+	_, err := suite.usecase.CreateTransfer(suite.ctx, getTransferReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("transfer is disabled for this event"), err)
+	suite.mockTransferRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneTransfer", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateTransferErrAfterCutoff() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("owner-id")}))
+	suite.mockTransferRepositoryQuery.On("FindTransferRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: &entity.TransferRule{EventId: "event-id", Enabled: true, CutoffHours: 24,
+			DoorsOpenAt: time.Now().Add(2 * time.Hour)}}))
+
+	// Act
+	_, err := suite.usecase.CreateTransfer(suite.ctx, getTransferReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("transfer is closed 24 hours before doors open"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateTransferErrNotOwner() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("other-id")}))
+
+	// Act
+	_, err := suite.usecase.CreateTransfer(suite.ctx, getTransferReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.NotFound("ticket not found"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCreateTransferErrPending() {
+	// Arrange
+	suite.mockEticketRepositoryQuery.On("FindIssuedTicketById", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("owner-id")}))
+	suite.mockTransferRepositoryQuery.On("FindTransferRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockUserRepositoryQuery.On("FindOneUserByEmail", mock.Anything, "friend@mail.com").
+		Return(mockChannel(helpers.Result{Data: &userEntity.User{UserId: "friend-id", Email: "friend@mail.com"}}))
+	suite.mockTransferRepositoryQuery.On("FindPendingTransferByIssuedTicketId", mock.Anything, "issued-id").
+		Return(mockChannel(helpers.Result{Data: getMockTransfer()}))
+
+	// Act
+	_, err := suite.usecase.CreateTransfer(suite.ctx, getTransferReq())
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("ticket already has a pending transfer"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcceptTransfer() {
+	// Arrange
+	suite.mockTransferRepositoryQuery.On("FindTransferById", mock.Anything, "transfer-id").
+		Return(mockChannel(helpers.Result{Data: getMockTransfer()}))
+	suite.mockTransferRepositoryQuery.On("FindTransferRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockTransferRepositoryCommand.On("UpdateTransferStatus", mock.Anything, "transfer-id", constants.TransferStatusPending,
+		constants.TransferStatusAccepted).Return(mockChannel(helpers.Result{Data: getMockTransfer()}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketOwner", mock.Anything, mock.MatchedBy(func(t eticketEntity.IssuedTicket) bool {
+		return t.UserId == "owner-id" && t.QrVersion == 1
+	}), mock.MatchedBy(func(o eticketEntity.Ownership) bool {
+		return o.UserId == "friend-id" && o.Via == constants.OwnershipViaTransfer && o.ReferenceId == "transfer-id"
+	})).Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("friend-id")}))
+
+	// Act
+	result, err := suite.usecase.AcceptTransfer(suite.ctx, request.TransferActionReq{UserId: "friend-id", TransferId: "transfer-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.TransferStatusAccepted, result.Status)
+	suite.mockAccessUsecaseCommand.AssertNotCalled(suite.T(), "IssuePass", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcceptTransferOnlineSignsPass() {
+	// Arrange
+	transferData := getMockTransfer()
+	transferData.TicketType = constants.TicketTypeOnline
+	suite.mockTransferRepositoryQuery.On("FindTransferById", mock.Anything, "transfer-id").
+		Return(mockChannel(helpers.Result{Data: transferData}))
+	suite.mockTransferRepositoryQuery.On("FindTransferRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockTransferRepositoryCommand.On("UpdateTransferStatus", mock.Anything, "transfer-id", constants.TransferStatusPending,
+		constants.TransferStatusAccepted).Return(mockChannel(helpers.Result{Data: transferData}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketOwner", mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: getMockIssuedTicket("friend-id")}))
+	suite.mockAccessUsecaseCommand.On("IssuePass", mock.Anything, "issued-id").Return(nil, nil)
+
+	// Act
+	_, err := suite.usecase.AcceptTransfer(suite.ctx, request.TransferActionReq{UserId: "friend-id", TransferId: "transfer-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockAccessUsecaseCommand.AssertCalled(suite.T(), "IssuePass", mock.Anything, "issued-id")
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcceptTransferErrTicketChanged() {
+	// Arrange
+	suite.mockTransferRepositoryQuery.On("FindTransferById", mock.Anything, "transfer-id").
+		Return(mockChannel(helpers.Result{Data: getMockTransfer()}))
+	suite.mockTransferRepositoryQuery.On("FindTransferRuleByEventId", mock.Anything, "event-id").
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockTransferRepositoryCommand.On("UpdateTransferStatus", mock.Anything, "transfer-id", constants.TransferStatusPending,
+		constants.TransferStatusAccepted).Return(mockChannel(helpers.Result{Data: getMockTransfer()}))
+	suite.mockEticketRepositoryCommand.On("UpdateIssuedTicketOwner", mock.Anything, mock.Anything, mock.Anything).
+		Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockTransferRepositoryCommand.On("UpdateTransferStatus", mock.Anything, "transfer-id", constants.TransferStatusAccepted,
+		constants.TransferStatusFailed).Return(mockChannel(helpers.Result{Data: getMockTransfer()}))
+
+	// Act
+	_, err := suite.usecase.AcceptTransfer(suite.ctx, request.TransferActionReq{UserId: "friend-id", TransferId: "transfer-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("ticket has changed since the transfer was requested"), err)
+	suite.mockTransferRepositoryCommand.AssertCalled(suite.T(), "UpdateTransferStatus", mock.Anything, "transfer-id",
+		constants.TransferStatusAccepted, constants.TransferStatusFailed)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcceptTransferErrExpired() {
+	// Arrange
+	expired := getMockTransfer()
+	expired.ExpiredAt = time.Now().Add(-time.Minute)
+	suite.mockTransferRepositoryQuery.On("FindTransferById", mock.Anything, "transfer-id").
+		Return(mockChannel(helpers.Result{Data: expired}))
+	suite.mockTransferRepositoryCommand.On("UpdateTransferStatus", mock.Anything, "transfer-id", constants.TransferStatusPending,
+		constants.TransferStatusExpired).Return(mockChannel(helpers.Result{Data: expired}))
+
+	// Act
+	_, err := suite.usecase.AcceptTransfer(suite.ctx, request.TransferActionReq{UserId: "friend-id", TransferId: "transfer-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("transfer has expired"), err)
+	suite.mockEticketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateIssuedTicketOwner", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestAcceptTransferErrNotRecipient() {
+	// Arrange
+	suite.mockTransferRepositoryQuery.On("FindTransferById", mock.Anything, "transfer-id").
+		Return(mockChannel(helpers.Result{Data: getMockTransfer()}))
+
+	// Act
+	_, err := suite.usecase.AcceptTransfer(suite.ctx, request.TransferActionReq{UserId: "owner-id", TransferId: "transfer-id"})
+
+	// Assert
+	assert.Equal(suite.T(), errors.NotFound("transfer not found"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestCancelTransfer() {
+	// Arrange
+	suite.mockTransferRepositoryQuery.On("FindTransferById", mock.Anything, "transfer-id").
+		Return(mockChannel(helpers.Result{Data: getMockTransfer()}))
+	suite.mockTransferRepositoryCommand.On("UpdateTransferStatus", mock.Anything, "transfer-id", constants.TransferStatusPending,
+		constants.TransferStatusCancelled).Return(mockChannel(helpers.Result{Data: getMockTransfer()}))
+
+	// Act
+	result, err := suite.usecase.CancelTransfer(suite.ctx, request.TransferActionReq{UserId: "owner-id", TransferId: "transfer-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.TransferStatusCancelled, result.Status)
+}
+
+func getTransferReq() request.TransferReq {
+	return request.TransferReq{
+		UserId:         "owner-id",
+		IssuedTicketId: "issued-id",
+		RecipientEmail: "friend@mail.com",
+	}
+}
+
+func getMockIssuedTicket(userId string) *eticketEntity.IssuedTicket {
+	return &eticketEntity.IssuedTicket{
+		IssuedTicketId: "issued-id",
+		UserId:         userId,
+		EventId:        "event-id",
+		TicketType:     "Gold",
+		Status:         constants.IssuedTicketStatusActive,
+		QrVersion:      1,
+	}
+}
+
+func getMockTransfer() *entity.Transfer {
+	return &entity.Transfer{
+		TransferId:     "transfer-id",
+		IssuedTicketId: "issued-id",
+		EventId:        "event-id",
+		QrVersion:      1,
+		FromUserId:     "owner-id",
+		ToUserId:       "friend-id",
+		ToEmail:        "friend@mail.com",
+		Status:         constants.TransferStatusPending,
+		ExpiredAt:      time.Now().Add(time.Hour),
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
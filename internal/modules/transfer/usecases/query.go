@@ -0,0 +1,129 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/eticket"
+	eticketEntity "ticket-service/internal/modules/eticket/models/entity"
+	"ticket-service/internal/modules/transfer"
+	"ticket-service/internal/modules/transfer/models/entity"
+	"ticket-service/internal/modules/transfer/models/request"
+	"ticket-service/internal/modules/transfer/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	transferRepositoryQuery transfer.MongodbRepositoryQuery
+	eticketRepositoryQuery  eticket.MongodbRepositoryQuery
+	logger                  log.Logger
+}
+
+func NewQueryUsecase(tmq transfer.MongodbRepositoryQuery, emq eticket.MongodbRepositoryQuery, log log.Logger) transfer.UsecaseQuery {
+	return queryUsecase{
+		transferRepositoryQuery: tmq,
+		eticketRepositoryQuery:  emq,
+		logger:                  log,
+	}
+}
+
+func (q queryUsecase) FindIncomingTransfers(origCtx context.Context, userId string) ([]response.Transfer, error) {
+	domain := "transferUsecase-FindIncomingTransfers"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.transferRepositoryQuery.FindIncomingTransfers(ctx, userId)
+	if resp.Error != nil {
+		msg := "Error query incoming transfer"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	var collectionData = make([]response.Transfer, 0)
+	if resp.Data == nil {
+		return collectionData, nil
+	}
+
+	transfers, ok := resp.Data.(*[]entity.Transfer)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *transfers {
+		collectionData = append(collectionData, *mapTransfer(value))
+	}
+	return collectionData, nil
+}
+
+func (q queryUsecase) FindOwnershipHistory(origCtx context.Context, payload request.OwnershipHistoryReq) ([]response.Ownership, error) {
+	domain := "transferUsecase-FindOwnershipHistory"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.eticketRepositoryQuery.FindIssuedTicketById(ctx, payload.IssuedTicketId)
+	if resp.Error != nil {
+		msg := "Error query issued ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	issuedTicket, ok := resp.Data.(*eticketEntity.IssuedTicket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if issuedTicket.UserId != payload.UserId {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	var collectionData = make([]response.Ownership, 0)
+	for _, value := range issuedTicket.OwnershipHistory {
+		collectionData = append(collectionData, response.Ownership{
+			UserId:      value.UserId,
+			Via:         value.Via,
+			ReferenceId: value.ReferenceId,
+			AcquiredAt:  value.AcquiredAt,
+		})
+	}
+	return collectionData, nil
+}
+
+func (q queryUsecase) FindTransferRule(origCtx context.Context, eventId string) (*response.TransferRule, error) {
+	domain := "transferUsecase-FindTransferRule"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.transferRepositoryQuery.FindTransferRuleByEventId(ctx, eventId)
+	if resp.Error != nil {
+		msg := "Error query transfer rule"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return &response.TransferRule{EventId: eventId, Enabled: true}, nil
+	}
+
+	rule, ok := resp.Data.(*entity.TransferRule)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	return mapTransferRule(*rule), nil
+}
@@ -41,3 +41,22 @@ func (q queryMongodbRepository) FindOneUserId(ctx context.Context, userId string
 
 	return output
 }
+
+func (q queryMongodbRepository) FindOneUserByEmail(ctx context.Context, email string) <-chan wrapper.Result {
+	var user userEntity.User
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &user,
+			CollectionName: "users",
+			Filter: bson.M{
+				"email": email,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
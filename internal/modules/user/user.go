@@ -7,4 +7,5 @@ import (
 
 type MongodbRepositoryQuery interface {
 	FindOneUserId(ctx context.Context, userId string) <-chan wrapper.Result
+	FindOneUserByEmail(ctx context.Context, email string) <-chan wrapper.Result
 }
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/venue"
+	"ticket-service/internal/modules/venue/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type VenueHttpHandler struct {
+	VenueUsecaseCommand venue.UsecaseCommand
+	VenueUsecaseQuery   venue.UsecaseQuery
+	Logger              log.Logger
+	Validator           *validator.Validate
+}
+
+func InitVenueHttpHandler(app *fiber.App, vuc venue.UsecaseCommand, vuq venue.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &VenueHttpHandler{
+		VenueUsecaseCommand: vuc,
+		VenueUsecaseQuery:   vuq,
+		Logger:              log,
+		Validator:           validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/reference")
+
+	route.Get("/v1/continents", middlewares.VerifyBearer(), handler.GetContinents)
+	route.Get("/v1/countries", middlewares.VerifyBearer(), handler.GetCountries)
+	route.Get("/v1/cities", middlewares.VerifyBearer(), handler.GetCities)
+	route.Get("/v1/venues", middlewares.VerifyBearer(), handler.GetVenues)
+	route.Get("/v1/venues/:id", middlewares.VerifyBearer(), handler.GetVenue)
+	route.Post("/v1/import", middlewares.VerifyBearer(), adminRole, handler.ImportReference)
+	route.Put("/v1/venues/:id", middlewares.VerifyBearer(), adminRole, handler.UpsertVenue)
+	route.Put("/v1/venues/:id/tickets", middlewares.VerifyBearer(), adminRole, handler.LinkTickets)
+}
+
+func (v VenueHttpHandler) GetContinents(c *fiber.Ctx) error {
+	resp, err := v.VenueUsecaseQuery.FindContinents(c.Context())
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Get continent success")
+}
+
+func (v VenueHttpHandler) GetCountries(c *fiber.Ctx) error {
+	req := new(request.CountryListReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest("bad request"))
+	}
+
+	resp, err := v.VenueUsecaseQuery.FindCountries(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Get country success")
+}
+
+func (v VenueHttpHandler) GetCities(c *fiber.Ctx) error {
+	req := new(request.CityListReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := v.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := v.VenueUsecaseQuery.FindCities(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Get city success")
+}
+
+func (v VenueHttpHandler) GetVenues(c *fiber.Ctx) error {
+	req := new(request.VenueListReq)
+	if err := c.QueryParser(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest("bad request"))
+	}
+
+	resp, err := v.VenueUsecaseQuery.FindVenues(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Get venue success")
+}
+
+func (v VenueHttpHandler) GetVenue(c *fiber.Ctx) error {
+	resp, err := v.VenueUsecaseQuery.FindVenue(c.Context(), c.Params("id"))
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Get venue success")
+}
+
+func (v VenueHttpHandler) ImportReference(c *fiber.Ctx) error {
+	req := new(request.ReferenceReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := v.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := v.VenueUsecaseCommand.ImportReference(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Import reference data success")
+}
+
+func (v VenueHttpHandler) UpsertVenue(c *fiber.Ctx) error {
+	req := new(request.VenueReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.VenueId = c.Params("id")
+	if err := v.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := v.VenueUsecaseCommand.UpsertVenue(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Update venue success")
+}
+
+func (v VenueHttpHandler) LinkTickets(c *fiber.Ctx) error {
+	req := new(request.LinkTicketsReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest("bad request"))
+	}
+
+	req.VenueId = c.Params("id")
+	if err := v.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := v.VenueUsecaseCommand.LinkTickets(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Link ticket venue success")
+}
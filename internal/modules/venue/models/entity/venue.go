@@ -0,0 +1,44 @@
+package entity
+
+import "time"
+
+type Continent struct {
+	Code      string    `json:"code" bson:"code"`
+	Name      string    `json:"name" bson:"name"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+type Country struct {
+	Code          string    `json:"code" bson:"code"`
+	Name          string    `json:"name" bson:"name"`
+	FullName      string    `json:"fullName" bson:"fullName"`
+	ContinentCode string    `json:"continentCode" bson:"continentCode"`
+	Latitude      float64   `json:"latitude" bson:"latitude"`
+	Longitude     float64   `json:"longitude" bson:"longitude"`
+	UpdatedAt     time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+type City struct {
+	CityId      string    `json:"cityId" bson:"cityId"`
+	Name        string    `json:"name" bson:"name"`
+	CountryCode string    `json:"countryCode" bson:"countryCode"`
+	Timezone    string    `json:"timezone" bson:"timezone"`
+	Latitude    float64   `json:"latitude" bson:"latitude"`
+	Longitude   float64   `json:"longitude" bson:"longitude"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Venue is referenced by VenueId from ticket-detail rows, its name and city are resolved when tickets are read
+type Venue struct {
+	VenueId     string    `json:"venueId" bson:"venueId"`
+	Name        string    `json:"name" bson:"name"`
+	Address     string    `json:"address" bson:"address"`
+	CityId      string    `json:"cityId" bson:"cityId"`
+	CountryCode string    `json:"countryCode" bson:"countryCode"`
+	Capacity    int       `json:"capacity" bson:"capacity"`
+	Timezone    string    `json:"timezone" bson:"timezone"`
+	Latitude    float64   `json:"latitude" bson:"latitude"`
+	Longitude   float64   `json:"longitude" bson:"longitude"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
@@ -0,0 +1,60 @@
+package request
+
+type ReferenceReq struct {
+	Continents []ContinentReq `json:"continents" validate:"max=20,dive"`
+	Countries  []CountryReq   `json:"countries" validate:"max=300,dive"`
+	Cities     []CityReq      `json:"cities" validate:"max=5000,dive"`
+}
+
+type ContinentReq struct {
+	Code string `json:"code" validate:"required,len=2"`
+	Name string `json:"name" validate:"required"`
+}
+
+type CountryReq struct {
+	Code          string  `json:"code" validate:"required,len=2"`
+	Name          string  `json:"name" validate:"required"`
+	FullName      string  `json:"fullName"`
+	ContinentCode string  `json:"continentCode" validate:"required,len=2"`
+	Latitude      float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude     float64 `json:"longitude" validate:"min=-180,max=180"`
+}
+
+type CityReq struct {
+	CityId      string  `json:"cityId" validate:"required"`
+	Name        string  `json:"name" validate:"required"`
+	CountryCode string  `json:"countryCode" validate:"required,len=2"`
+	Timezone    string  `json:"timezone" validate:"required"`
+	Latitude    float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude   float64 `json:"longitude" validate:"min=-180,max=180"`
+}
+
+type VenueReq struct {
+	VenueId   string  `json:"venueId" validate:"required"`
+	Name      string  `json:"name" validate:"required"`
+	Address   string  `json:"address"`
+	CityId    string  `json:"cityId" validate:"required"`
+	Capacity  int     `json:"capacity" validate:"required,min=1"`
+	Timezone  string  `json:"timezone"`
+	Latitude  float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" validate:"min=-180,max=180"`
+}
+
+type LinkTicketsReq struct {
+	EventId     string `json:"eventId" validate:"required"`
+	CountryCode string `json:"countryCode" validate:"required"`
+	VenueId     string `json:"venueId" validate:"required"`
+}
+
+type CountryListReq struct {
+	ContinentCode string `json:"continentCode"`
+}
+
+type CityListReq struct {
+	CountryCode string `json:"countryCode" validate:"required"`
+}
+
+type VenueListReq struct {
+	CountryCode string `json:"countryCode"`
+	CityId      string `json:"cityId"`
+}
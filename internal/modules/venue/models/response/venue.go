@@ -0,0 +1,50 @@
+package response
+
+type Continent struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type Country struct {
+	Code          string  `json:"code"`
+	Name          string  `json:"name"`
+	FullName      string  `json:"fullName"`
+	ContinentCode string  `json:"continentCode"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+}
+
+type City struct {
+	CityId      string  `json:"cityId"`
+	Name        string  `json:"name"`
+	CountryCode string  `json:"countryCode"`
+	Timezone    string  `json:"timezone"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+type Venue struct {
+	VenueId     string  `json:"venueId"`
+	Name        string  `json:"name"`
+	Address     string  `json:"address"`
+	CityId      string  `json:"cityId"`
+	CityName    string  `json:"cityName"`
+	CountryCode string  `json:"countryCode"`
+	Capacity    int     `json:"capacity"`
+	Timezone    string  `json:"timezone"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+}
+
+type ReferenceImport struct {
+	Continents int `json:"continents"`
+	Countries  int `json:"countries"`
+	Cities     int `json:"cities"`
+}
+
+type LinkedTickets struct {
+	EventId     string `json:"eventId"`
+	CountryCode string `json:"countryCode"`
+	VenueId     string `json:"venueId"`
+	Tickets     int    `json:"tickets"`
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/venue"
+	"ticket-service/internal/modules/venue/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) venue.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (c commandMongodbRepository) UpsertContinent(ctx context.Context, continent entity.Continent) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "continents",
+			Filter: bson.M{
+				"code": continent.Code,
+			},
+			Document: continent,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpsertCountry(ctx context.Context, country entity.Country) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "countries",
+			Filter: bson.M{
+				"code": country.Code,
+			},
+			Document: country,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpsertCity(ctx context.Context, city entity.City) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "cities",
+			Filter: bson.M{
+				"cityId": city.CityId,
+			},
+			Document: city,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) UpsertVenue(ctx context.Context, venue entity.Venue) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.UpsertOne(mongodb.UpdateOne{
+			CollectionName: "venues",
+			Filter: bson.M{
+				"venueId": venue.VenueId,
+			},
+			Document: venue,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,182 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/venue"
+	"ticket-service/internal/modules/venue/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) venue.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindContinents(ctx context.Context) <-chan wrapper.Result {
+	var continents []entity.Continent
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &continents,
+			CollectionName: "continents",
+			Filter:         bson.M{},
+			Sort: &mongodb.Sort{
+				FieldName: "name",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindCountries lists the countries of a continent, every country when continentCode is empty
+func (q queryMongodbRepository) FindCountries(ctx context.Context, continentCode string) <-chan wrapper.Result {
+	var countries []entity.Country
+	output := make(chan wrapper.Result)
+
+	filter := bson.M{}
+	if continentCode != "" {
+		filter["continentCode"] = continentCode
+	}
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &countries,
+			CollectionName: "countries",
+			Filter:         filter,
+			Sort: &mongodb.Sort{
+				FieldName: "name",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindCountryByCode(ctx context.Context, code string) <-chan wrapper.Result {
+	var country entity.Country
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &country,
+			CollectionName: "countries",
+			Filter: bson.M{
+				"code": code,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindCities(ctx context.Context, countryCode string) <-chan wrapper.Result {
+	var cities []entity.City
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &cities,
+			CollectionName: "cities",
+			Filter: bson.M{
+				"countryCode": countryCode,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "name",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindCityById(ctx context.Context, cityId string) <-chan wrapper.Result {
+	var city entity.City
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &city,
+			CollectionName: "cities",
+			Filter: bson.M{
+				"cityId": cityId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindVenues lists venues narrowed by country and city, an empty value does not narrow
+func (q queryMongodbRepository) FindVenues(ctx context.Context, countryCode string, cityId string) <-chan wrapper.Result {
+	var venues []entity.Venue
+	output := make(chan wrapper.Result)
+
+	filter := bson.M{}
+	if countryCode != "" {
+		filter["countryCode"] = countryCode
+	}
+	if cityId != "" {
+		filter["cityId"] = cityId
+	}
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &venues,
+			CollectionName: "venues",
+			Filter:         filter,
+			Sort: &mongodb.Sort{
+				FieldName: "name",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindVenueById(ctx context.Context, venueId string) <-chan wrapper.Result {
+	var venue entity.Venue
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &venue,
+			CollectionName: "venues",
+			Filter: bson.M{
+				"venueId": venueId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
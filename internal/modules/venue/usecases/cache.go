@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"fmt"
+	"ticket-service/internal/modules/venue/models/entity"
+	"ticket-service/internal/modules/venue/models/response"
+	"ticket-service/internal/pkg/constants"
+)
+
+func countriesKey(continentCode string) string {
+	return fmt.Sprintf("%s:%s", constants.RedisKeyReferenceCountries, continentCode)
+}
+
+func citiesKey(countryCode string) string {
+	return fmt.Sprintf("%s:%s", constants.RedisKeyReferenceCities, countryCode)
+}
+
+func venuesKey(countryCode string, cityId string) string {
+	return fmt.Sprintf("%s:%s:%s", constants.RedisKeyReferenceVenues, countryCode, cityId)
+}
+
+func venueKey(venueId string) string {
+	return fmt.Sprintf("%s:%s", constants.RedisKeyReferenceVenue, venueId)
+}
+
+func mapVenue(value entity.Venue, cityName string) response.Venue {
+	return response.Venue{
+		VenueId:     value.VenueId,
+		Name:        value.Name,
+		Address:     value.Address,
+		CityId:      value.CityId,
+		CityName:    cityName,
+		CountryCode: value.CountryCode,
+		Capacity:    value.Capacity,
+		Timezone:    value.Timezone,
+		Latitude:    value.Latitude,
+		Longitude:   value.Longitude,
+	}
+}
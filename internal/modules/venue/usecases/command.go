@@ -0,0 +1,351 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/ticket"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/modules/venue"
+	"ticket-service/internal/modules/venue/models/entity"
+	"ticket-service/internal/modules/venue/models/request"
+	"ticket-service/internal/modules/venue/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	venueRepositoryQuery    venue.MongodbRepositoryQuery
+	venueRepositoryCommand  venue.MongodbRepositoryCommand
+	ticketRepositoryQuery   ticket.MongodbRepositoryQuery
+	ticketRepositoryCommand ticket.MongodbRepositoryCommand
+	redisClient             redis.Collections
+	logger                  log.Logger
+}
+
+func NewCommandUsecase(vmq venue.MongodbRepositoryQuery, vmc venue.MongodbRepositoryCommand, tmq ticket.MongodbRepositoryQuery,
+	tmc ticket.MongodbRepositoryCommand, redisClient redis.Collections, log log.Logger) venue.UsecaseCommand {
+	return commandUsecase{
+		venueRepositoryQuery:    vmq,
+		venueRepositoryCommand:  vmc,
+		ticketRepositoryQuery:   tmq,
+		ticketRepositoryCommand: tmc,
+		redisClient:             redisClient,
+		logger:                  log,
+	}
+}
+
+// ImportReference upserts continents, countries and cities by their codes. A country must belong to a continent and
+// a city to a country that is in the import or already stored
+func (c commandUsecase) ImportReference(origCtx context.Context, payload request.ReferenceReq) (*response.ReferenceImport, error) {
+	domain := "venueUsecase-ImportReference"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	continentCodes := make(map[string]bool)
+	for _, value := range payload.Continents {
+		continentCodes[value.Code] = true
+	}
+	for _, value := range payload.Countries {
+		if continentCodes[value.ContinentCode] {
+			continue
+		}
+		exist, err := c.findContinent(ctx, value.ContinentCode)
+		if err != nil {
+			return nil, err
+		}
+		if !exist {
+			return nil, errors.UnprocessableEntity(fmt.Sprintf("continent %s of country %s not found", value.ContinentCode, value.Code))
+		}
+		continentCodes[value.ContinentCode] = true
+	}
+
+	countryCodes := make(map[string]bool)
+	for _, value := range payload.Countries {
+		countryCodes[value.Code] = true
+	}
+	for _, value := range payload.Cities {
+		if _, err := time.LoadLocation(value.Timezone); err != nil {
+			return nil, errors.BadRequest(fmt.Sprintf("unknown timezone %s of city %s", value.Timezone, value.CityId))
+		}
+		if countryCodes[value.CountryCode] {
+			continue
+		}
+		resp := <-c.venueRepositoryQuery.FindCountryByCode(ctx, value.CountryCode)
+		if resp.Error != nil {
+			msg := "Error query country"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+			return nil, resp.Error
+		}
+		if resp.Data == nil {
+			return nil, errors.UnprocessableEntity(fmt.Sprintf("country %s of city %s not found", value.CountryCode, value.CityId))
+		}
+		countryCodes[value.CountryCode] = true
+	}
+
+	now := time.Now()
+	for _, value := range payload.Continents {
+		resp := <-c.venueRepositoryCommand.UpsertContinent(ctx, entity.Continent{
+			Code:      value.Code,
+			Name:      value.Name,
+			UpdatedAt: now,
+		})
+		if resp.Error != nil {
+			msg := "Error upsert continent"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+			return nil, resp.Error
+		}
+	}
+	for _, value := range payload.Countries {
+		resp := <-c.venueRepositoryCommand.UpsertCountry(ctx, entity.Country{
+			Code:          value.Code,
+			Name:          value.Name,
+			FullName:      value.FullName,
+			ContinentCode: value.ContinentCode,
+			Latitude:      value.Latitude,
+			Longitude:     value.Longitude,
+			UpdatedAt:     now,
+		})
+		if resp.Error != nil {
+			msg := "Error upsert country"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+			return nil, resp.Error
+		}
+	}
+	for _, value := range payload.Cities {
+		resp := <-c.venueRepositoryCommand.UpsertCity(ctx, entity.City{
+			CityId:      value.CityId,
+			Name:        value.Name,
+			CountryCode: value.CountryCode,
+			Timezone:    value.Timezone,
+			Latitude:    value.Latitude,
+			Longitude:   value.Longitude,
+			UpdatedAt:   now,
+		})
+		if resp.Error != nil {
+			msg := "Error upsert city"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+			return nil, resp.Error
+		}
+	}
+
+	// a country moving to another continent leaves the old continent list stale until the cache expires
+	keys := []string{constants.RedisKeyReferenceContinents, countriesKey("")}
+	for code := range continentCodes {
+		keys = append(keys, countriesKey(code))
+	}
+	for _, value := range payload.Cities {
+		keys = append(keys, citiesKey(value.CountryCode))
+	}
+	c.redisClient.Del(ctx, keys...)
+
+	return &response.ReferenceImport{
+		Continents: len(payload.Continents),
+		Countries:  len(payload.Countries),
+		Cities:     len(payload.Cities),
+	}, nil
+}
+
+// UpsertVenue creates or renames a venue. The timezone of its city applies when none is given
+func (c commandUsecase) UpsertVenue(origCtx context.Context, payload request.VenueReq) (*response.Venue, error) {
+	domain := "venueUsecase-UpsertVenue"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.venueRepositoryQuery.FindCityById(ctx, payload.CityId)
+	if resp.Error != nil {
+		msg := "Error query city"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("city not found")
+	}
+
+	city, ok := resp.Data.(*entity.City)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	timezone := payload.Timezone
+	if timezone == "" {
+		timezone = city.Timezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return nil, errors.BadRequest(fmt.Sprintf("unknown timezone %s", timezone))
+	}
+
+	existing, err := c.findVenue(ctx, payload.VenueId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	venueData := entity.Venue{
+		VenueId:     payload.VenueId,
+		Name:        payload.Name,
+		Address:     payload.Address,
+		CityId:      city.CityId,
+		CountryCode: city.CountryCode,
+		Capacity:    payload.Capacity,
+		Timezone:    timezone,
+		Latitude:    payload.Latitude,
+		Longitude:   payload.Longitude,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if existing != nil {
+		venueData.CreatedAt = existing.CreatedAt
+	}
+
+	upserted := <-c.venueRepositoryCommand.UpsertVenue(ctx, venueData)
+	if upserted.Error != nil {
+		msg := "Error upsert venue"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", upserted.Error))
+		return nil, upserted.Error
+	}
+
+	keys := []string{venueKey(venueData.VenueId), venuesKey("", ""), venuesKey(venueData.CountryCode, ""),
+		venuesKey(venueData.CountryCode, venueData.CityId), venuesKey("", venueData.CityId)}
+	if existing != nil && existing.CityId != venueData.CityId {
+		keys = append(keys, venuesKey(existing.CountryCode, ""), venuesKey(existing.CountryCode, existing.CityId),
+			venuesKey("", existing.CityId))
+	}
+	c.redisClient.Del(ctx, keys...)
+
+	result := mapVenue(venueData, city.Name)
+	return &result, nil
+}
+
+// LinkTickets points every tier of the event in the country at the venue, a venue rename then shows on all of them
+func (c commandUsecase) LinkTickets(origCtx context.Context, payload request.LinkTicketsReq) (*response.LinkedTickets, error) {
+	domain := "venueUsecase-LinkTickets"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	venueDetail, err := c.findVenue(ctx, payload.VenueId)
+	if err != nil {
+		return nil, err
+	}
+
+	if venueDetail == nil {
+		return nil, errors.NotFound("venue not found")
+	}
+
+	if venueDetail.CountryCode != payload.CountryCode {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("venue %s is not in country %s", payload.VenueId, payload.CountryCode))
+	}
+
+	resp := <-c.ticketRepositoryQuery.FindTicketsByEventCountry(ctx, payload.EventId, payload.CountryCode)
+	if resp.Error != nil {
+		msg := "Error query ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	tickets, ok := resp.Data.(*[]ticketEntity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if len(*tickets) == 0 {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	cityName, err := c.findCityName(ctx, venueDetail.CityId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range *tickets {
+		linked := <-c.ticketRepositoryCommand.UpdateVenue(ctx, value.TicketId, payload.VenueId, venueDetail.Name, cityName)
+		if linked.Error != nil {
+			msg := "Error link ticket venue"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", linked.Error))
+			return nil, linked.Error
+		}
+	}
+
+	return &response.LinkedTickets{
+		EventId:     payload.EventId,
+		CountryCode: payload.CountryCode,
+		VenueId:     payload.VenueId,
+		Tickets:     len(*tickets),
+	}, nil
+}
+
+func (c commandUsecase) findContinent(ctx context.Context, code string) (bool, error) {
+	resp := <-c.venueRepositoryQuery.FindContinents(ctx)
+	if resp.Error != nil {
+		msg := "Error query continent"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return false, resp.Error
+	}
+
+	continents, ok := resp.Data.(*[]entity.Continent)
+	if !ok {
+		return false, nil
+	}
+	for _, value := range *continents {
+		if value.Code == code {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (c commandUsecase) findCityName(ctx context.Context, cityId string) (string, error) {
+	resp := <-c.venueRepositoryQuery.FindCityById(ctx, cityId)
+	if resp.Error != nil {
+		msg := "Error query city"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return "", resp.Error
+	}
+
+	if resp.Data == nil {
+		return "", errors.NotFound("city not found")
+	}
+
+	city, ok := resp.Data.(*entity.City)
+	if !ok {
+		return "", errors.InternalServerError("cannot parsing data")
+	}
+	return city.Name, nil
+}
+
+func (c commandUsecase) findVenue(ctx context.Context, venueId string) (*entity.Venue, error) {
+	resp := <-c.venueRepositoryQuery.FindVenueById(ctx, venueId)
+	if resp.Error != nil {
+		msg := "Error query venue"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	venueDetail, ok := resp.Data.(*entity.Venue)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return venueDetail, nil
+}
@@ -0,0 +1,156 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	"ticket-service/internal/modules/venue"
+	"ticket-service/internal/modules/venue/models/entity"
+	"ticket-service/internal/modules/venue/models/request"
+	uc "ticket-service/internal/modules/venue/usecases"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockticket "ticket-service/mocks/modules/ticket"
+	mockvenue "ticket-service/mocks/modules/venue"
+	mocklog "ticket-service/mocks/pkg/log"
+	mockredis "ticket-service/mocks/pkg/redis"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockVenueRepositoryQuery    *mockvenue.MongodbRepositoryQuery
+	mockVenueRepositoryCommand  *mockvenue.MongodbRepositoryCommand
+	mockTicketRepositoryQuery   *mockticket.MongodbRepositoryQuery
+	mockTicketRepositoryCommand *mockticket.MongodbRepositoryCommand
+	mockRedis                   *mockredis.Collections
+	mockLogger                  *mocklog.Logger
+	usecase                     venue.UsecaseCommand
+	ctx                         context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockVenueRepositoryQuery = &mockvenue.MongodbRepositoryQuery{}
+	suite.mockVenueRepositoryCommand = &mockvenue.MongodbRepositoryCommand{}
+	suite.mockTicketRepositoryQuery = &mockticket.MongodbRepositoryQuery{}
+	suite.mockTicketRepositoryCommand = &mockticket.MongodbRepositoryCommand{}
+	suite.mockRedis = &mockredis.Collections{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockVenueRepositoryQuery,
+		suite.mockVenueRepositoryCommand,
+		suite.mockTicketRepositoryQuery,
+		suite.mockTicketRepositoryCommand,
+		suite.mockRedis,
+		suite.mockLogger,
+	)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpsertVenueRename() {
+	// Arrange
+	suite.mockVenueRepositoryQuery.On("FindCityById", mock.Anything, "city-id").
+		Return(mockChannel(helpers.Result{Data: &entity.City{CityId: "city-id", Name: "Jakarta", CountryCode: "ID", Timezone: "Asia/Jakarta"}}))
+	suite.mockVenueRepositoryQuery.On("FindVenueById", mock.Anything, "venue-id").
+		Return(mockChannel(helpers.Result{Data: &entity.Venue{VenueId: "venue-id", Name: "Old Hall", CityId: "city-id", CountryCode: "ID"}}))
+	suite.mockVenueRepositoryCommand.On("UpsertVenue", mock.Anything, mock.MatchedBy(func(value entity.Venue) bool {
+		return value.Name == "Grand Hall" && value.Timezone == "Asia/Jakarta" && value.CountryCode == "ID"
+	})).Return(mockChannel(helpers.Result{}))
+	suite.mockRedis.On("Del", mock.Anything, "REFERENCE-VENUE:venue-id", "REFERENCE-VENUES::", "REFERENCE-VENUES:ID:",
+		"REFERENCE-VENUES:ID:city-id", "REFERENCE-VENUES::city-id").Return(redis.NewIntResult(5, nil))
+
+	// Act
+	result, err := suite.usecase.UpsertVenue(suite.ctx, request.VenueReq{
+		VenueId:  "venue-id",
+		Name:     "Grand Hall",
+		CityId:   "city-id",
+		Capacity: 5000,
+	})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Grand Hall", result.Name)
+	assert.Equal(suite.T(), "Jakarta", result.CityName)
+	suite.mockRedis.AssertExpectations(suite.T())
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpsertVenueErrTimezone() {
+	// Arrange
+	suite.mockVenueRepositoryQuery.On("FindCityById", mock.Anything, "city-id").
+		Return(mockChannel(helpers.Result{Data: &entity.City{CityId: "city-id", CountryCode: "ID", Timezone: "Asia/Jakarta"}}))
+
+	// Act
+	result, err := suite.usecase.UpsertVenue(suite.ctx, request.VenueReq{
+		VenueId:  "venue-id",
+		Name:     "Grand Hall",
+		CityId:   "city-id",
+		Capacity: 5000,
+		Timezone: "Mars/Olympus",
+	})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.BadRequest("unknown timezone Mars/Olympus"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestLinkTickets() {
+	// Arrange
+	suite.mockVenueRepositoryQuery.On("FindVenueById", mock.Anything, "venue-id").
+		Return(mockChannel(helpers.Result{Data: &entity.Venue{VenueId: "venue-id", Name: "Grand Hall", CityId: "city-id", CountryCode: "ID"}}))
+	suite.mockTicketRepositoryQuery.On("FindTicketsByEventCountry", mock.Anything, "event-id", "ID").
+		Return(mockChannel(helpers.Result{Data: &[]ticketEntity.Ticket{{TicketId: "ticket-1"}, {TicketId: "ticket-2"}}}))
+	suite.mockVenueRepositoryQuery.On("FindCityById", mock.Anything, "city-id").
+		Return(mockChannel(helpers.Result{Data: &entity.City{CityId: "city-id", Name: "Jakarta"}}))
+	suite.mockTicketRepositoryCommand.On("UpdateVenue", mock.Anything, mock.Anything, "venue-id", "Grand Hall", "Jakarta").
+		Return(func(context.Context, string, string, string, string) <-chan helpers.Result {
+			return mockChannel(helpers.Result{Data: &ticketEntity.Ticket{}})
+		})
+
+	// Act
+	result, err := suite.usecase.LinkTickets(suite.ctx, request.LinkTicketsReq{EventId: "event-id", CountryCode: "ID", VenueId: "venue-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.Tickets)
+	suite.mockTicketRepositoryCommand.AssertNumberOfCalls(suite.T(), "UpdateVenue", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestLinkTicketsErrCountry() {
+	// Arrange
+	suite.mockVenueRepositoryQuery.On("FindVenueById", mock.Anything, "venue-id").
+		Return(mockChannel(helpers.Result{Data: &entity.Venue{VenueId: "venue-id", CountryCode: "SG"}}))
+
+	// Act
+	result, err := suite.usecase.LinkTickets(suite.ctx, request.LinkTicketsReq{EventId: "event-id", CountryCode: "ID", VenueId: "venue-id"})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.UnprocessableEntity("venue venue-id is not in country ID"), err)
+	suite.mockTicketRepositoryCommand.AssertNotCalled(suite.T(), "UpdateVenue", mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestImportReferenceErrContinent() {
+	// Arrange
+	suite.mockVenueRepositoryQuery.On("FindContinents", mock.Anything).
+		Return(mockChannel(helpers.Result{Data: &[]entity.Continent{{Code: "EU"}}}))
+
+	// Act
+	result, err := suite.usecase.ImportReference(suite.ctx, request.ReferenceReq{
+		Countries: []request.CountryReq{{Code: "ID", Name: "Indonesia", ContinentCode: "AS"}},
+	})
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.UnprocessableEntity("continent AS of country ID not found"), err)
+	suite.mockVenueRepositoryCommand.AssertNotCalled(suite.T(), "UpsertCountry", mock.Anything, mock.Anything)
+}
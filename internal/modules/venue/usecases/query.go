@@ -0,0 +1,238 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/venue"
+	"ticket-service/internal/modules/venue/models/entity"
+	"ticket-service/internal/modules/venue/models/request"
+	"ticket-service/internal/modules/venue/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	venueRepositoryQuery venue.MongodbRepositoryQuery
+	redisClient          redis.Collections
+	logger               log.Logger
+}
+
+func NewQueryUsecase(vmq venue.MongodbRepositoryQuery, redisClient redis.Collections, log log.Logger) venue.UsecaseQuery {
+	return queryUsecase{
+		venueRepositoryQuery: vmq,
+		redisClient:          redisClient,
+		logger:               log,
+	}
+}
+
+func (q queryUsecase) FindContinents(origCtx context.Context) ([]response.Continent, error) {
+	domain := "venueUsecase-FindContinents"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	result := make([]response.Continent, 0)
+	if redis.GetCache(ctx, q.redisClient, constants.RedisKeyReferenceContinents, &result) {
+		return result, nil
+	}
+
+	resp := <-q.venueRepositoryQuery.FindContinents(ctx)
+	if resp.Error != nil {
+		msg := "Error query continent"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data != nil {
+		continents, ok := resp.Data.(*[]entity.Continent)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		for _, value := range *continents {
+			result = append(result, response.Continent{
+				Code: value.Code,
+				Name: value.Name,
+			})
+		}
+	}
+	redis.SetCache(ctx, q.redisClient, constants.RedisKeyReferenceContinents, result, constants.ReferenceCacheTTL)
+	return result, nil
+}
+
+func (q queryUsecase) FindCountries(origCtx context.Context, payload request.CountryListReq) ([]response.Country, error) {
+	domain := "venueUsecase-FindCountries"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	key := countriesKey(payload.ContinentCode)
+	result := make([]response.Country, 0)
+	if redis.GetCache(ctx, q.redisClient, key, &result) {
+		return result, nil
+	}
+
+	resp := <-q.venueRepositoryQuery.FindCountries(ctx, payload.ContinentCode)
+	if resp.Error != nil {
+		msg := "Error query country"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data != nil {
+		countries, ok := resp.Data.(*[]entity.Country)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		for _, value := range *countries {
+			result = append(result, response.Country{
+				Code:          value.Code,
+				Name:          value.Name,
+				FullName:      value.FullName,
+				ContinentCode: value.ContinentCode,
+				Latitude:      value.Latitude,
+				Longitude:     value.Longitude,
+			})
+		}
+	}
+	redis.SetCache(ctx, q.redisClient, key, result, constants.ReferenceCacheTTL)
+	return result, nil
+}
+
+func (q queryUsecase) FindCities(origCtx context.Context, payload request.CityListReq) ([]response.City, error) {
+	domain := "venueUsecase-FindCities"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	key := citiesKey(payload.CountryCode)
+	result := make([]response.City, 0)
+	if redis.GetCache(ctx, q.redisClient, key, &result) {
+		return result, nil
+	}
+
+	resp := <-q.venueRepositoryQuery.FindCities(ctx, payload.CountryCode)
+	if resp.Error != nil {
+		msg := "Error query city"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data != nil {
+		cities, ok := resp.Data.(*[]entity.City)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		for _, value := range *cities {
+			result = append(result, response.City{
+				CityId:      value.CityId,
+				Name:        value.Name,
+				CountryCode: value.CountryCode,
+				Timezone:    value.Timezone,
+				Latitude:    value.Latitude,
+				Longitude:   value.Longitude,
+			})
+		}
+	}
+	redis.SetCache(ctx, q.redisClient, key, result, constants.ReferenceCacheTTL)
+	return result, nil
+}
+
+func (q queryUsecase) FindVenues(origCtx context.Context, payload request.VenueListReq) ([]response.Venue, error) {
+	domain := "venueUsecase-FindVenues"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	key := venuesKey(payload.CountryCode, payload.CityId)
+	result := make([]response.Venue, 0)
+	if redis.GetCache(ctx, q.redisClient, key, &result) {
+		return result, nil
+	}
+
+	resp := <-q.venueRepositoryQuery.FindVenues(ctx, payload.CountryCode, payload.CityId)
+	if resp.Error != nil {
+		msg := "Error query venue"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data != nil {
+		venues, ok := resp.Data.(*[]entity.Venue)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		cityNames := make(map[string]string)
+		for _, value := range *venues {
+			if _, ok := cityNames[value.CityId]; !ok {
+				cityNames[value.CityId] = q.findCityName(ctx, value.CityId)
+			}
+			result = append(result, mapVenue(value, cityNames[value.CityId]))
+		}
+	}
+	redis.SetCache(ctx, q.redisClient, key, result, constants.ReferenceCacheTTL)
+	return result, nil
+}
+
+func (q queryUsecase) FindVenue(origCtx context.Context, venueId string) (*response.Venue, error) {
+	domain := "venueUsecase-FindVenue"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	key := venueKey(venueId)
+	var result response.Venue
+	if redis.GetCache(ctx, q.redisClient, key, &result) {
+		return &result, nil
+	}
+
+	resp := <-q.venueRepositoryQuery.FindVenueById(ctx, venueId)
+	if resp.Error != nil {
+		msg := "Error query venue"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("venue not found")
+	}
+
+	venueDetail, ok := resp.Data.(*entity.Venue)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	result = mapVenue(*venueDetail, q.findCityName(ctx, venueDetail.CityId))
+	redis.SetCache(ctx, q.redisClient, key, result, constants.ReferenceCacheTTL)
+	return &result, nil
+}
+
+// findCityName leaves the name empty rather than failing the read when the city is missing
+func (q queryUsecase) findCityName(ctx context.Context, cityId string) string {
+	resp := <-q.venueRepositoryQuery.FindCityById(ctx, cityId)
+	if resp.Error != nil {
+		msg := "Error query city"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return ""
+	}
+
+	city, ok := resp.Data.(*entity.City)
+	if !ok {
+		return ""
+	}
+	return city.Name
+}
@@ -0,0 +1,104 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"ticket-service/internal/modules/venue"
+	"ticket-service/internal/modules/venue/models/entity"
+	uc "ticket-service/internal/modules/venue/usecases"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockvenue "ticket-service/mocks/modules/venue"
+	mocklog "ticket-service/mocks/pkg/log"
+	mockredis "ticket-service/mocks/pkg/redis"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryUsecaseTestSuite struct {
+	suite.Suite
+	mockVenueRepositoryQuery *mockvenue.MongodbRepositoryQuery
+	mockRedis                *mockredis.Collections
+	mockLogger               *mocklog.Logger
+	usecase                  venue.UsecaseQuery
+	ctx                      context.Context
+}
+
+func (suite *QueryUsecaseTestSuite) SetupTest() {
+	suite.mockVenueRepositoryQuery = &mockvenue.MongodbRepositoryQuery{}
+	suite.mockRedis = &mockredis.Collections{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewQueryUsecase(
+		suite.mockVenueRepositoryQuery,
+		suite.mockRedis,
+		suite.mockLogger,
+	)
+}
+
+func TestQueryUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryUsecaseTestSuite))
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindVenueCached() {
+	// Arrange
+	suite.mockRedis.On("Get", mock.Anything, "REFERENCE-VENUE:venue-id").
+		Return(redis.NewStringResult(`{"venueId":"venue-id","name":"Grand Hall","cityName":"Jakarta"}`, nil))
+
+	// Act
+	result, err := suite.usecase.FindVenue(suite.ctx, "venue-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Grand Hall", result.Name)
+	suite.mockVenueRepositoryQuery.AssertNotCalled(suite.T(), "FindVenueById", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindVenueCacheMiss() {
+	// Arrange
+	suite.mockRedis.On("Get", mock.Anything, "REFERENCE-VENUE:venue-id").Return(redis.NewStringResult("", redis.Nil))
+	suite.mockRedis.On("Set", mock.Anything, "REFERENCE-VENUE:venue-id", mock.Anything, mock.Anything).
+		Return(redis.NewStatusResult("OK", nil))
+	suite.mockVenueRepositoryQuery.On("FindVenueById", mock.Anything, "venue-id").
+		Return(mockChannel(helpers.Result{Data: &entity.Venue{VenueId: "venue-id", Name: "Grand Hall", CityId: "city-id", Capacity: 5000}}))
+	suite.mockVenueRepositoryQuery.On("FindCityById", mock.Anything, "city-id").
+		Return(mockChannel(helpers.Result{Data: &entity.City{CityId: "city-id", Name: "Jakarta"}}))
+
+	// Act
+	result, err := suite.usecase.FindVenue(suite.ctx, "venue-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Jakarta", result.CityName)
+	assert.Equal(suite.T(), 5000, result.Capacity)
+	suite.mockRedis.AssertCalled(suite.T(), "Set", mock.Anything, "REFERENCE-VENUE:venue-id", mock.Anything, mock.Anything)
+}
+
+func (suite *QueryUsecaseTestSuite) TestFindVenueErrNotFound() {
+	// Arrange
+	suite.mockRedis.On("Get", mock.Anything, mock.Anything).Return(redis.NewStringResult("", redis.Nil))
+	suite.mockVenueRepositoryQuery.On("FindVenueById", mock.Anything, "venue-id").Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	result, err := suite.usecase.FindVenue(suite.ctx, "venue-id")
+
+	// Assert
+	assert.Nil(suite.T(), result)
+	assert.Equal(suite.T(), errors.NotFound("venue not found"), err)
+	suite.mockRedis.AssertNotCalled(suite.T(), "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
@@ -0,0 +1,40 @@
+package venue
+
+import (
+	"context"
+	"ticket-service/internal/modules/venue/models/entity"
+	"ticket-service/internal/modules/venue/models/request"
+	"ticket-service/internal/modules/venue/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseCommand interface {
+	ImportReference(origCtx context.Context, payload request.ReferenceReq) (*response.ReferenceImport, error)
+	UpsertVenue(origCtx context.Context, payload request.VenueReq) (*response.Venue, error)
+	LinkTickets(origCtx context.Context, payload request.LinkTicketsReq) (*response.LinkedTickets, error)
+}
+
+type UsecaseQuery interface {
+	FindContinents(origCtx context.Context) ([]response.Continent, error)
+	FindCountries(origCtx context.Context, payload request.CountryListReq) ([]response.Country, error)
+	FindCities(origCtx context.Context, payload request.CityListReq) ([]response.City, error)
+	FindVenues(origCtx context.Context, payload request.VenueListReq) ([]response.Venue, error)
+	FindVenue(origCtx context.Context, venueId string) (*response.Venue, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindContinents(ctx context.Context) <-chan wrapper.Result
+	FindCountries(ctx context.Context, continentCode string) <-chan wrapper.Result
+	FindCountryByCode(ctx context.Context, code string) <-chan wrapper.Result
+	FindCities(ctx context.Context, countryCode string) <-chan wrapper.Result
+	FindCityById(ctx context.Context, cityId string) <-chan wrapper.Result
+	FindVenues(ctx context.Context, countryCode string, cityId string) <-chan wrapper.Result
+	FindVenueById(ctx context.Context, venueId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertContinent(ctx context.Context, continent entity.Continent) <-chan wrapper.Result
+	UpsertCountry(ctx context.Context, country entity.Country) <-chan wrapper.Result
+	UpsertCity(ctx context.Context, city entity.City) <-chan wrapper.Result
+	UpsertVenue(ctx context.Context, venue entity.Venue) <-chan wrapper.Result
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/voucher"
+	"ticket-service/internal/modules/voucher/models/request"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type VoucherHttpHandler struct {
+	VoucherUsecaseCommand voucher.UsecaseCommand
+	VoucherUsecaseQuery   voucher.UsecaseQuery
+	Logger                log.Logger
+	Validator             *validator.Validate
+}
+
+func InitVoucherHttpHandler(app *fiber.App, vuc voucher.UsecaseCommand, vuq voucher.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &VoucherHttpHandler{
+		VoucherUsecaseCommand: vuc,
+		VoucherUsecaseQuery:   vuq,
+		Logger:                log,
+		Validator:             validator.New(),
+	}
+	adminRole := middlewares.AllowedRoles(constants.RoleAdmin)
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/vouchers")
+
+	route.Post("/v1/validate", middlewares.VerifyBearer(), handler.ValidateVouchers)
+	route.Put("/v1", middlewares.VerifyBearer(), adminRole, handler.UpsertVoucher)
+	route.Get("/v1/:code", middlewares.VerifyBearer(), adminRole, handler.GetVoucher)
+}
+
+func (v VoucherHttpHandler) ValidateVouchers(c *fiber.Ctx) error {
+	req := new(request.ValidateReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := v.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, v.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	resp, err := v.VoucherUsecaseQuery.ValidateVouchers(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Validate voucher success")
+}
+
+func (v VoucherHttpHandler) UpsertVoucher(c *fiber.Ctx) error {
+	req := new(request.VoucherReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := v.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, v.Logger, errors.BadRequest(err.Error()))
+	}
+	resp, err := v.VoucherUsecaseCommand.UpsertVoucher(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Update voucher success")
+}
+
+func (v VoucherHttpHandler) GetVoucher(c *fiber.Ctx) error {
+	resp, err := v.VoucherUsecaseQuery.FindVoucher(c.Context(), c.Params("code"))
+	if err != nil {
+		return helpers.RespCustomError(c, v.Logger, err)
+	}
+	return helpers.RespSuccess(c, v.Logger, resp, "Get voucher success")
+}
@@ -0,0 +1,24 @@
+package dto
+
+// Target is what a set of vouchers is applied to
+type Target struct {
+	UserId      string
+	EventId     string
+	Tag         string
+	TicketType  string
+	CountryCode string
+	TicketPrice int
+	Quantity    int
+}
+
+type AppliedVoucher struct {
+	Code     string
+	Discount int
+}
+
+type Quote struct {
+	Subtotal      int
+	TotalDiscount int
+	Total         int
+	Vouchers      []AppliedVoucher
+}
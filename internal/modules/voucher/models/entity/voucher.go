@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"ticket-service/internal/pkg/constants"
+	"time"
+)
+
+// Voucher is a promo code, an empty applicability list matches everything and a zero cap is not enforced.
+// Value is a percentage for PERCENTAGE vouchers and an amount for FIXED ones, MaxDiscount caps a percentage
+type Voucher struct {
+	Code           string    `json:"code" bson:"code"`
+	Description    string    `json:"description" bson:"description"`
+	Type           string    `json:"type" bson:"type"`
+	Value          int       `json:"value" bson:"value"`
+	MaxDiscount    int       `json:"maxDiscount" bson:"maxDiscount"`
+	MinSpend       int       `json:"minSpend" bson:"minSpend"`
+	EventIds       []string  `json:"eventIds" bson:"eventIds"`
+	Tags           []string  `json:"tags" bson:"tags"`
+	TicketTypes    []string  `json:"ticketTypes" bson:"ticketTypes"`
+	CountryCodes   []string  `json:"countryCodes" bson:"countryCodes"`
+	ValidFrom      time.Time `json:"validFrom" bson:"validFrom"`
+	ValidUntil     time.Time `json:"validUntil" bson:"validUntil"`
+	MaxRedemptions int       `json:"maxRedemptions" bson:"maxRedemptions"`
+	MaxPerUser     int       `json:"maxPerUser" bson:"maxPerUser"`
+	TotalRedeemed  int       `json:"totalRedeemed" bson:"totalRedeemed"`
+	Stackable      bool      `json:"stackable" bson:"stackable"`
+	Active         bool      `json:"active" bson:"active"`
+	CreatedAt      time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// Discount is what the voucher takes off amount, it never exceeds amount
+func (v Voucher) Discount(amount int) int {
+	discount := v.Value
+	if v.Type == constants.VoucherTypePercentage {
+		discount = amount * v.Value / 100
+		if v.MaxDiscount > 0 && discount > v.MaxDiscount {
+			discount = v.MaxDiscount
+		}
+	}
+	if discount > amount {
+		return amount
+	}
+	return discount
+}
+
+// VoucherUserCounter keeps how often a user redeemed one voucher
+type VoucherUserCounter struct {
+	Code      string    `json:"code" bson:"code"`
+	UserId    string    `json:"userId" bson:"userId"`
+	Total     int       `json:"total" bson:"total"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+type Redemption struct {
+	RedemptionId string    `json:"redemptionId" bson:"redemptionId"`
+	Code         string    `json:"code" bson:"code"`
+	UserId       string    `json:"userId" bson:"userId"`
+	OrderId      string    `json:"orderId" bson:"orderId"`
+	Discount     int       `json:"discount" bson:"discount"`
+	Status       string    `json:"status" bson:"status"`
+	CreatedAt    time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt" bson:"updatedAt"`
+}
@@ -0,0 +1,40 @@
+package request
+
+import (
+	"ticket-service/internal/modules/voucher/models/dto"
+	"time"
+)
+
+type VoucherReq struct {
+	Code           string    `json:"code" validate:"required,alphanum,max=32"`
+	Description    string    `json:"description"`
+	Type           string    `json:"type" validate:"required,oneof=PERCENTAGE FIXED"`
+	Value          int       `json:"value" validate:"required,min=1"`
+	MaxDiscount    int       `json:"maxDiscount" validate:"min=0"`
+	MinSpend       int       `json:"minSpend" validate:"min=0"`
+	EventIds       []string  `json:"eventIds"`
+	Tags           []string  `json:"tags"`
+	TicketTypes    []string  `json:"ticketTypes"`
+	CountryCodes   []string  `json:"countryCodes"`
+	ValidFrom      time.Time `json:"validFrom" validate:"required"`
+	ValidUntil     time.Time `json:"validUntil" validate:"required,gtfield=ValidFrom"`
+	MaxRedemptions int       `json:"maxRedemptions" validate:"min=0"`
+	MaxPerUser     int       `json:"maxPerUser" validate:"min=0"`
+	Stackable      bool      `json:"stackable"`
+	Active         bool      `json:"active"`
+}
+
+type ValidateReq struct {
+	UserId      string   `json:"-"`
+	EventId     string   `json:"eventId" validate:"required"`
+	CountryCode string   `json:"countryCode" validate:"required"`
+	TicketType  string   `json:"ticketType" validate:"required"`
+	Quantity    int      `json:"quantity" validate:"required,min=1"`
+	Codes       []string `json:"codes" validate:"required,min=1,max=3,dive,required"`
+}
+
+type RedeemReq struct {
+	OrderId string
+	Codes   []string
+	Target  dto.Target
+}
@@ -0,0 +1,40 @@
+package response
+
+import "time"
+
+type Voucher struct {
+	Code           string    `json:"code"`
+	Description    string    `json:"description"`
+	Type           string    `json:"type"`
+	Value          int       `json:"value"`
+	MaxDiscount    string    `json:"maxDiscount"`
+	MinSpend       string    `json:"minSpend"`
+	EventIds       []string  `json:"eventIds"`
+	Tags           []string  `json:"tags"`
+	TicketTypes    []string  `json:"ticketTypes"`
+	CountryCodes   []string  `json:"countryCodes"`
+	ValidFrom      time.Time `json:"validFrom"`
+	ValidUntil     time.Time `json:"validUntil"`
+	MaxRedemptions int       `json:"maxRedemptions"`
+	MaxPerUser     int       `json:"maxPerUser"`
+	TotalRedeemed  int       `json:"totalRedeemed"`
+	Stackable      bool      `json:"stackable"`
+	Active         bool      `json:"active"`
+}
+
+type Quote struct {
+	EventId       string           `json:"eventId"`
+	TicketType    string           `json:"ticketType"`
+	CountryCode   string           `json:"countryCode"`
+	Quantity      int              `json:"quantity"`
+	TicketPrice   string           `json:"ticketPrice"`
+	Subtotal      string           `json:"subtotal"`
+	Vouchers      []AppliedVoucher `json:"vouchers"`
+	TotalDiscount string           `json:"totalDiscount"`
+	Total         string           `json:"total"`
+}
+
+type AppliedVoucher struct {
+	Code     string `json:"code"`
+	Discount string `json:"discount"`
+}
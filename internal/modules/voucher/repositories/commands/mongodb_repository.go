@@ -0,0 +1,284 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/voucher"
+	"ticket-service/internal/modules/voucher/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) voucher.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// UpsertVoucher writes the configuration of a voucher and keeps its redemption count
+func (c commandMongodbRepository) UpsertVoucher(ctx context.Context, voucher entity.Voucher) <-chan wrapper.Result {
+	var updated entity.Voucher
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &updated,
+			CollectionName: "vouchers",
+			Filter: bson.M{
+				"code": voucher.Code,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"description":    voucher.Description,
+					"type":           voucher.Type,
+					"value":          voucher.Value,
+					"maxDiscount":    voucher.MaxDiscount,
+					"minSpend":       voucher.MinSpend,
+					"eventIds":       voucher.EventIds,
+					"tags":           voucher.Tags,
+					"ticketTypes":    voucher.TicketTypes,
+					"countryCodes":   voucher.CountryCodes,
+					"validFrom":      voucher.ValidFrom,
+					"validUntil":     voucher.ValidUntil,
+					"maxRedemptions": voucher.MaxRedemptions,
+					"maxPerUser":     voucher.MaxPerUser,
+					"stackable":      voucher.Stackable,
+					"active":         voucher.Active,
+					"updatedAt":      voucher.UpdatedAt,
+				},
+				"$setOnInsert": bson.M{
+					"totalRedeemed": 0,
+					"createdAt":     voucher.UpdatedAt,
+				},
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// IncreaseVoucherRedeemed counts one redemption as long as the global cap has room, Data is nil when it has not
+func (c commandMongodbRepository) IncreaseVoucherRedeemed(ctx context.Context, code string) <-chan wrapper.Result {
+	var voucher entity.Voucher
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &voucher,
+			CollectionName: "vouchers",
+			Filter: bson.M{
+				"code": code,
+				"$expr": bson.M{
+					"$or": bson.A{
+						bson.M{"$lte": bson.A{"$maxRedemptions", 0}},
+						bson.M{"$lt": bson.A{"$totalRedeemed", "$maxRedemptions"}},
+					},
+				},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"totalRedeemed": 1},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) DecreaseVoucherRedeemed(ctx context.Context, code string) <-chan wrapper.Result {
+	var voucher entity.Voucher
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &voucher,
+			CollectionName: "vouchers",
+			Filter: bson.M{
+				"code":          code,
+				"totalRedeemed": bson.M{"$gt": 0},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"totalRedeemed": -1},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// InitVoucherUserCounter makes sure the counter document exists before it is conditionally incremented
+func (c commandMongodbRepository) InitVoucherUserCounter(ctx context.Context, code string, userId string) <-chan wrapper.Result {
+	var counter entity.VoucherUserCounter
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &counter,
+			CollectionName: "voucher-user-counters",
+			Filter: bson.M{
+				"code":   code,
+				"userId": userId,
+			},
+			Update: bson.M{
+				"$setOnInsert": bson.M{
+					"total":     0,
+					"createdAt": time.Now(),
+					"updatedAt": time.Now(),
+				},
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// IncreaseVoucherUserCounter counts one redemption for the user unless maxPerUser is reached, Data is nil when it is
+func (c commandMongodbRepository) IncreaseVoucherUserCounter(ctx context.Context, code string, userId string, maxPerUser int) <-chan wrapper.Result {
+	var counter entity.VoucherUserCounter
+	output := make(chan wrapper.Result)
+
+	go func() {
+		filter := bson.M{
+			"code":   code,
+			"userId": userId,
+		}
+		if maxPerUser > 0 {
+			filter["total"] = bson.M{"$lt": maxPerUser}
+		}
+
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &counter,
+			CollectionName: "voucher-user-counters",
+			Filter:         filter,
+			Update: bson.M{
+				"$inc": bson.M{"total": 1},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) DecreaseVoucherUserCounter(ctx context.Context, code string, userId string) <-chan wrapper.Result {
+	var counter entity.VoucherUserCounter
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &counter,
+			CollectionName: "voucher-user-counters",
+			Filter: bson.M{
+				"code":   code,
+				"userId": userId,
+				"total":  bson.M{"$gt": 0},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"total": -1},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) InsertOneRedemption(ctx context.Context, redemption entity.Redemption) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "voucher-redemptions",
+			Document:       redemption,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateRedemptionReleased gives a redemption back once, Data is nil when it was released already
+func (c commandMongodbRepository) UpdateRedemptionReleased(ctx context.Context, redemptionId string) <-chan wrapper.Result {
+	var redemption entity.Redemption
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &redemption,
+			CollectionName: "voucher-redemptions",
+			Filter: bson.M{
+				"redemptionId": redemptionId,
+				"status":       constants.RedemptionStatusRedeemed,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.RedemptionStatusReleased,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CreateUniqueIndexes keeps a single voucher per code and a single usage counter per code and user,
+// concurrent UpsertVoucher and InitVoucherUserCounter upserts would create duplicates without it
+func (c commandMongodbRepository) CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		for _, index := range []mongodb.CreateIndex{
+			{
+				CollectionName: "vouchers",
+				Keys:           bson.D{{Key: "code", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+			{
+				CollectionName: "voucher-user-counters",
+				Keys:           bson.D{{Key: "code", Value: 1}, {Key: "userId", Value: 1}},
+				Options:        options.Index().SetUnique(true),
+			},
+		} {
+			resp := <-c.mongoDb.CreateIndex(index, ctx)
+			if resp.Error != nil {
+				output <- resp
+				return
+			}
+		}
+		output <- wrapper.Result{Data: "Success create index"}
+	}()
+
+	return output
+}
@@ -0,0 +1,82 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/voucher"
+	"ticket-service/internal/modules/voucher/models/entity"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) voucher.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindVoucherByCode(ctx context.Context, code string) <-chan wrapper.Result {
+	var voucher entity.Voucher
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &voucher,
+			CollectionName: "vouchers",
+			Filter: bson.M{
+				"code": code,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindVoucherUserCounter(ctx context.Context, code string, userId string) <-chan wrapper.Result {
+	var counter entity.VoucherUserCounter
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &counter,
+			CollectionName: "voucher-user-counters",
+			Filter: bson.M{
+				"code":   code,
+				"userId": userId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindRedemptionsByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result {
+	var redemptions []entity.Redemption
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &redemptions,
+			CollectionName: "voucher-redemptions",
+			Filter: bson.M{
+				"orderId": orderId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,360 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"ticket-service/internal/modules/voucher"
+	"ticket-service/internal/modules/voucher/models/dto"
+	"ticket-service/internal/modules/voucher/models/entity"
+	"ticket-service/internal/modules/voucher/models/request"
+	"ticket-service/internal/modules/voucher/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	voucherRepositoryQuery   voucher.MongodbRepositoryQuery
+	voucherRepositoryCommand voucher.MongodbRepositoryCommand
+	logger                   log.Logger
+}
+
+func NewCommandUsecase(vmq voucher.MongodbRepositoryQuery, vmc voucher.MongodbRepositoryCommand, log log.Logger) voucher.UsecaseCommand {
+	return commandUsecase{
+		voucherRepositoryQuery:   vmq,
+		voucherRepositoryCommand: vmc,
+		logger:                   log,
+	}
+}
+
+func (c commandUsecase) UpsertVoucher(origCtx context.Context, payload request.VoucherReq) (*response.Voucher, error) {
+	domain := "voucherUsecase-UpsertVoucher"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	if payload.Type == constants.VoucherTypePercentage && payload.Value > 100 {
+		return nil, errors.BadRequest("percentage value cannot be more than 100")
+	}
+
+	voucherData := entity.Voucher{
+		Code:           normalizeCode(payload.Code),
+		Description:    payload.Description,
+		Type:           payload.Type,
+		Value:          payload.Value,
+		MaxDiscount:    payload.MaxDiscount,
+		MinSpend:       payload.MinSpend,
+		EventIds:       payload.EventIds,
+		Tags:           payload.Tags,
+		TicketTypes:    payload.TicketTypes,
+		CountryCodes:   payload.CountryCodes,
+		ValidFrom:      payload.ValidFrom,
+		ValidUntil:     payload.ValidUntil,
+		MaxRedemptions: payload.MaxRedemptions,
+		MaxPerUser:     payload.MaxPerUser,
+		Stackable:      payload.Stackable,
+		Active:         payload.Active,
+		UpdatedAt:      time.Now(),
+	}
+	resp := <-c.voucherRepositoryCommand.UpsertVoucher(ctx, voucherData)
+	if resp.Error != nil {
+		msg := "Error upsert voucher"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if updated, ok := resp.Data.(*entity.Voucher); ok {
+		voucherData = *updated
+	}
+
+	return mapVoucher(voucherData), nil
+}
+
+// RedeemVouchers applies the codes to an order and counts them against the global and per-user caps,
+// either every code is redeemed or none is
+func (c commandUsecase) RedeemVouchers(origCtx context.Context, payload request.RedeemReq) (*dto.Quote, error) {
+	domain := "voucherUsecase-RedeemVouchers"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	vouchers, err := findVouchers(ctx, c.voucherRepositoryQuery, c.logger, payload.Codes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, value := range vouchers {
+		if err := checkVoucher(value, payload.Target, now); err != nil {
+			return nil, err
+		}
+	}
+
+	redeemed := make([]entity.Voucher, 0)
+	for _, value := range vouchers {
+		if err := c.countRedemption(ctx, value, payload.Target.UserId); err != nil {
+			c.rollbackRedemptions(ctx, redeemed, payload.Target.UserId)
+			return nil, err
+		}
+		redeemed = append(redeemed, value)
+	}
+
+	quote := applyVouchers(vouchers, payload.Target.TicketPrice*payload.Target.Quantity)
+	inserted := make([]string, 0)
+	for _, value := range quote.Vouchers {
+		redemption := entity.Redemption{
+			RedemptionId: uuid.NewString(),
+			Code:         value.Code,
+			UserId:       payload.Target.UserId,
+			OrderId:      payload.OrderId,
+			Discount:     value.Discount,
+			Status:       constants.RedemptionStatusRedeemed,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+		resp := <-c.voucherRepositoryCommand.InsertOneRedemption(ctx, redemption)
+		if resp.Error != nil {
+			msg := "Error insert redemption"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+			for _, redemptionId := range inserted {
+				<-c.voucherRepositoryCommand.UpdateRedemptionReleased(ctx, redemptionId)
+			}
+			c.rollbackRedemptions(ctx, redeemed, payload.Target.UserId)
+			return nil, resp.Error
+		}
+		inserted = append(inserted, redemption.RedemptionId)
+	}
+
+	return &quote, nil
+}
+
+// ReleaseVouchers gives the redemptions of an order that did not go through back to their caps, it is safe to call more than once
+func (c commandUsecase) ReleaseVouchers(origCtx context.Context, orderId string) error {
+	domain := "voucherUsecase-ReleaseVouchers"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.voucherRepositoryQuery.FindRedemptionsByOrderId(ctx, orderId)
+	if resp.Error != nil {
+		msg := "Error query redemption"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil
+	}
+
+	redemptions, ok := resp.Data.(*[]entity.Redemption)
+	if !ok {
+		return errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *redemptions {
+		if value.Status != constants.RedemptionStatusRedeemed {
+			continue
+		}
+		released := <-c.voucherRepositoryCommand.UpdateRedemptionReleased(ctx, value.RedemptionId)
+		if released.Error != nil {
+			msg := "Error release redemption"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", released.Error))
+			return released.Error
+		}
+		if released.Data == nil {
+			continue
+		}
+		c.rollbackRedemptions(ctx, []entity.Voucher{{Code: value.Code}}, value.UserId)
+	}
+	return nil
+}
+
+func (c commandUsecase) countRedemption(ctx context.Context, voucherData entity.Voucher, userId string) error {
+	resp := <-c.voucherRepositoryCommand.IncreaseVoucherRedeemed(ctx, voucherData.Code)
+	if resp.Error != nil {
+		msg := "Error increase voucher redeemed"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return resp.Error
+	}
+
+	if resp.Data == nil {
+		return errors.UnprocessableEntity(fmt.Sprintf("voucher %s has been fully redeemed", voucherData.Code))
+	}
+
+	initCounter := <-c.voucherRepositoryCommand.InitVoucherUserCounter(ctx, voucherData.Code, userId)
+	if initCounter.Error != nil {
+		msg := "Error init voucher user counter"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", initCounter.Error))
+		<-c.voucherRepositoryCommand.DecreaseVoucherRedeemed(ctx, voucherData.Code)
+		return initCounter.Error
+	}
+
+	counter := <-c.voucherRepositoryCommand.IncreaseVoucherUserCounter(ctx, voucherData.Code, userId, voucherData.MaxPerUser)
+	if counter.Error != nil || counter.Data == nil {
+		<-c.voucherRepositoryCommand.DecreaseVoucherRedeemed(ctx, voucherData.Code)
+		if counter.Error != nil {
+			msg := "Error increase voucher user counter"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", counter.Error))
+			return counter.Error
+		}
+		return errors.UnprocessableEntity(fmt.Sprintf("you have already used voucher %s", voucherData.Code))
+	}
+	return nil
+}
+
+func (c commandUsecase) rollbackRedemptions(ctx context.Context, vouchers []entity.Voucher, userId string) {
+	for _, value := range vouchers {
+		redeemed := <-c.voucherRepositoryCommand.DecreaseVoucherRedeemed(ctx, value.Code)
+		if redeemed.Error != nil {
+			msg := "Error decrease voucher redeemed"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", value.Code))
+		}
+		counter := <-c.voucherRepositoryCommand.DecreaseVoucherUserCounter(ctx, value.Code, userId)
+		if counter.Error != nil {
+			msg := "Error decrease voucher user counter"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", value.Code))
+		}
+	}
+}
+
+// findVouchers loads the codes and enforces the stacking rules: a code is used once,
+// at most MaxVouchersPerOrder codes and only stackable vouchers are combined
+func findVouchers(ctx context.Context, repository voucher.MongodbRepositoryQuery, logger log.Logger, codes []string) ([]entity.Voucher, error) {
+	if len(codes) > constants.MaxVouchersPerOrder {
+		return nil, errors.BadRequest(fmt.Sprintf("maximum %d vouchers per order", constants.MaxVouchersPerOrder))
+	}
+
+	seen := make(map[string]bool)
+	vouchers := make([]entity.Voucher, 0)
+	for _, value := range codes {
+		code := normalizeCode(value)
+		if seen[code] {
+			return nil, errors.BadRequest(fmt.Sprintf("voucher %s is used more than once", code))
+		}
+		seen[code] = true
+
+		resp := <-repository.FindVoucherByCode(ctx, code)
+		if resp.Error != nil {
+			msg := "Error query voucher"
+			logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+			return nil, resp.Error
+		}
+
+		if resp.Data == nil {
+			return nil, errors.NotFound(fmt.Sprintf("voucher %s not found", code))
+		}
+
+		voucherData, ok := resp.Data.(*entity.Voucher)
+		if !ok {
+			return nil, errors.InternalServerError("cannot parsing data")
+		}
+		vouchers = append(vouchers, *voucherData)
+	}
+
+	if len(vouchers) > 1 {
+		for _, value := range vouchers {
+			if !value.Stackable {
+				return nil, errors.UnprocessableEntity(fmt.Sprintf("voucher %s cannot be combined with other vouchers", value.Code))
+			}
+		}
+	}
+	return vouchers, nil
+}
+
+// checkVoucher tells why a voucher does not apply to target, the per-user cap is left to the caller
+func checkVoucher(voucherData entity.Voucher, target dto.Target, now time.Time) error {
+	if !voucherData.Active || now.Before(voucherData.ValidFrom) || now.After(voucherData.ValidUntil) {
+		return errors.UnprocessableEntity(fmt.Sprintf("voucher %s is not valid at this time", voucherData.Code))
+	}
+	if !allowed(voucherData.EventIds, target.EventId) || !allowed(voucherData.Tags, target.Tag) {
+		return errors.UnprocessableEntity(fmt.Sprintf("voucher %s does not apply to this event", voucherData.Code))
+	}
+	if !allowed(voucherData.TicketTypes, target.TicketType) {
+		return errors.UnprocessableEntity(fmt.Sprintf("voucher %s does not apply to %s tickets", voucherData.Code, target.TicketType))
+	}
+	if !allowed(voucherData.CountryCodes, target.CountryCode) {
+		return errors.UnprocessableEntity(fmt.Sprintf("voucher %s is not available in %s", voucherData.Code, target.CountryCode))
+	}
+	if target.TicketPrice*target.Quantity < voucherData.MinSpend {
+		return errors.UnprocessableEntity(fmt.Sprintf("voucher %s needs a minimum spend of $%d", voucherData.Code, voucherData.MinSpend))
+	}
+	if voucherData.MaxRedemptions > 0 && voucherData.TotalRedeemed >= voucherData.MaxRedemptions {
+		return errors.UnprocessableEntity(fmt.Sprintf("voucher %s has been fully redeemed", voucherData.Code))
+	}
+	return nil
+}
+
+// applyVouchers takes the percentages off first and the fixed amounts off what is left,
+// so the order the codes were entered in does not change the total
+func applyVouchers(vouchers []entity.Voucher, subtotal int) dto.Quote {
+	sorted := make([]entity.Voucher, len(vouchers))
+	copy(sorted, vouchers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Type == constants.VoucherTypePercentage && sorted[j].Type != constants.VoucherTypePercentage
+	})
+
+	remaining := subtotal
+	applied := make([]dto.AppliedVoucher, 0)
+	for _, value := range sorted {
+		discount := value.Discount(remaining)
+		remaining -= discount
+		applied = append(applied, dto.AppliedVoucher{Code: value.Code, Discount: discount})
+	}
+
+	return dto.Quote{
+		Subtotal:      subtotal,
+		TotalDiscount: subtotal - remaining,
+		Total:         remaining,
+		Vouchers:      applied,
+	}
+}
+
+func allowed(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func mapVoucher(voucherData entity.Voucher) *response.Voucher {
+	return &response.Voucher{
+		Code:           voucherData.Code,
+		Description:    voucherData.Description,
+		Type:           voucherData.Type,
+		Value:          voucherData.Value,
+		MaxDiscount:    fmt.Sprintf("$%d", voucherData.MaxDiscount),
+		MinSpend:       fmt.Sprintf("$%d", voucherData.MinSpend),
+		EventIds:       voucherData.EventIds,
+		Tags:           voucherData.Tags,
+		TicketTypes:    voucherData.TicketTypes,
+		CountryCodes:   voucherData.CountryCodes,
+		ValidFrom:      voucherData.ValidFrom,
+		ValidUntil:     voucherData.ValidUntil,
+		MaxRedemptions: voucherData.MaxRedemptions,
+		MaxPerUser:     voucherData.MaxPerUser,
+		TotalRedeemed:  voucherData.TotalRedeemed,
+		Stackable:      voucherData.Stackable,
+		Active:         voucherData.Active,
+	}
+}
@@ -0,0 +1,227 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ticket-service/internal/modules/voucher"
+	"ticket-service/internal/modules/voucher/models/dto"
+	"ticket-service/internal/modules/voucher/models/entity"
+	"ticket-service/internal/modules/voucher/models/request"
+	uc "ticket-service/internal/modules/voucher/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockvoucher "ticket-service/mocks/modules/voucher"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockVoucherRepositoryQuery   *mockvoucher.MongodbRepositoryQuery
+	mockVoucherRepositoryCommand *mockvoucher.MongodbRepositoryCommand
+	mockLogger                   *mocklog.Logger
+	usecase                      voucher.UsecaseCommand
+	ctx                          context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockVoucherRepositoryQuery = &mockvoucher.MongodbRepositoryQuery{}
+	suite.mockVoucherRepositoryCommand = &mockvoucher.MongodbRepositoryCommand{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockVoucherRepositoryQuery,
+		suite.mockVoucherRepositoryCommand,
+		suite.mockLogger,
+	)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestUpsertVoucherErrPercentage() {
+	// Arrange
+	payload := request.VoucherReq{Code: "promo", Type: constants.VoucherTypePercentage, Value: 120}
+
+	// Act
+	_, err := suite.usecase.UpsertVoucher(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.BadRequest("percentage value cannot be more than 100"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRedeemVouchersStacked() {
+	// Arrange
+	percentage := getMockVoucher("PERCENT10", constants.VoucherTypePercentage, 10)
+	fixed := getMockVoucher("FIXED30", constants.VoucherTypeFixed, 30)
+	suite.mockVoucherRepositoryQuery.On("FindVoucherByCode", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: &fixed}))
+	suite.mockVoucherRepositoryQuery.On("FindVoucherByCode", mock.Anything, "PERCENT10").Return(mockChannel(helpers.Result{Data: &percentage}))
+	suite.mockCounters("FIXED30")
+	suite.mockCounters("PERCENT10")
+	suite.mockVoucherRepositoryCommand.On("InsertOneRedemption", mock.Anything, mock.Anything).
+		Return(func(context.Context, entity.Redemption) <-chan helpers.Result {
+			return mockChannel(helpers.Result{Data: "Success insert data"})
+		})
+
+	// Act
+	result, err := suite.usecase.RedeemVouchers(suite.ctx, getRedeemReq("fixed30", "percent10"))
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 200, result.Subtotal)
+	assert.Equal(suite.T(), 50, result.TotalDiscount)
+	assert.Equal(suite.T(), 150, result.Total)
+	assert.Equal(suite.T(), []dto.AppliedVoucher{{Code: "PERCENT10", Discount: 20}, {Code: "FIXED30", Discount: 30}}, result.Vouchers)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRedeemVouchersErrNotStackable() {
+	// Arrange
+	percentage := getMockVoucher("PERCENT10", constants.VoucherTypePercentage, 10)
+	fixed := getMockVoucher("FIXED30", constants.VoucherTypeFixed, 30)
+	fixed.Stackable = false
+	suite.mockVoucherRepositoryQuery.On("FindVoucherByCode", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: &fixed}))
+	suite.mockVoucherRepositoryQuery.On("FindVoucherByCode", mock.Anything, "PERCENT10").Return(mockChannel(helpers.Result{Data: &percentage}))
+
+	// Act
+	_, err := suite.usecase.RedeemVouchers(suite.ctx, getRedeemReq("FIXED30", "PERCENT10"))
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("voucher FIXED30 cannot be combined with other vouchers"), err)
+	suite.mockVoucherRepositoryCommand.AssertNotCalled(suite.T(), "IncreaseVoucherRedeemed", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRedeemVouchersErrDuplicate() {
+	// Arrange
+	fixed := getMockVoucher("FIXED30", constants.VoucherTypeFixed, 30)
+	suite.mockVoucherRepositoryQuery.On("FindVoucherByCode", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: &fixed}))
+
+	// Act
+	_, err := suite.usecase.RedeemVouchers(suite.ctx, getRedeemReq("FIXED30", "fixed30"))
+
+	// Assert
+	assert.Equal(suite.T(), errors.BadRequest("voucher FIXED30 is used more than once"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRedeemVouchersErrFullyRedeemed() {
+	// Arrange
+	percentage := getMockVoucher("PERCENT10", constants.VoucherTypePercentage, 10)
+	fixed := getMockVoucher("FIXED30", constants.VoucherTypeFixed, 30)
+	suite.mockVoucherRepositoryQuery.On("FindVoucherByCode", mock.Anything, "PERCENT10").Return(mockChannel(helpers.Result{Data: &percentage}))
+	suite.mockVoucherRepositoryQuery.On("FindVoucherByCode", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: &fixed}))
+	suite.mockCounters("PERCENT10")
+	suite.mockVoucherRepositoryCommand.On("IncreaseVoucherRedeemed", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockVoucherRepositoryCommand.On("DecreaseVoucherRedeemed", mock.Anything, "PERCENT10").Return(mockChannel(helpers.Result{Data: &percentage}))
+	suite.mockVoucherRepositoryCommand.On("DecreaseVoucherUserCounter", mock.Anything, "PERCENT10", "user-id").Return(mockChannel(helpers.Result{Data: &entity.VoucherUserCounter{}}))
+
+	// Act
+	_, err := suite.usecase.RedeemVouchers(suite.ctx, getRedeemReq("PERCENT10", "FIXED30"))
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("voucher FIXED30 has been fully redeemed"), err)
+	suite.mockVoucherRepositoryCommand.AssertCalled(suite.T(), "DecreaseVoucherRedeemed", mock.Anything, "PERCENT10")
+	suite.mockVoucherRepositoryCommand.AssertCalled(suite.T(), "DecreaseVoucherUserCounter", mock.Anything, "PERCENT10", "user-id")
+	suite.mockVoucherRepositoryCommand.AssertNotCalled(suite.T(), "InsertOneRedemption", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestRedeemVouchersErrPerUser() {
+	// Arrange
+	fixed := getMockVoucher("FIXED30", constants.VoucherTypeFixed, 30)
+	suite.mockVoucherRepositoryQuery.On("FindVoucherByCode", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: &fixed}))
+	suite.mockVoucherRepositoryCommand.On("IncreaseVoucherRedeemed", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: &fixed}))
+	suite.mockVoucherRepositoryCommand.On("InitVoucherUserCounter", mock.Anything, "FIXED30", "user-id").Return(mockChannel(helpers.Result{Data: &entity.VoucherUserCounter{}}))
+	suite.mockVoucherRepositoryCommand.On("IncreaseVoucherUserCounter", mock.Anything, "FIXED30", "user-id", 1).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockVoucherRepositoryCommand.On("DecreaseVoucherRedeemed", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: &fixed}))
+
+	// Act
+	_, err := suite.usecase.RedeemVouchers(suite.ctx, getRedeemReq("FIXED30"))
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("you have already used voucher FIXED30"), err)
+	suite.mockVoucherRepositoryCommand.AssertCalled(suite.T(), "DecreaseVoucherRedeemed", mock.Anything, "FIXED30")
+}
+
+func (suite *CommandUsecaseTestSuite) TestRedeemVouchersErrMinSpend() {
+	// Arrange
+	fixed := getMockVoucher("FIXED30", constants.VoucherTypeFixed, 30)
+	fixed.MinSpend = 500
+	suite.mockVoucherRepositoryQuery.On("FindVoucherByCode", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: &fixed}))
+
+	// Act
+	_, err := suite.usecase.RedeemVouchers(suite.ctx, getRedeemReq("FIXED30"))
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("voucher FIXED30 needs a minimum spend of $500"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestReleaseVouchers() {
+	// Arrange
+	redemptions := []entity.Redemption{
+		{RedemptionId: "redemption-1", Code: "FIXED30", UserId: "user-id", Status: constants.RedemptionStatusRedeemed},
+		{RedemptionId: "redemption-2", Code: "PERCENT10", UserId: "user-id", Status: constants.RedemptionStatusReleased},
+	}
+	suite.mockVoucherRepositoryQuery.On("FindRedemptionsByOrderId", mock.Anything, "order-id").Return(mockChannel(helpers.Result{Data: &redemptions}))
+	suite.mockVoucherRepositoryCommand.On("UpdateRedemptionReleased", mock.Anything, "redemption-1").Return(mockChannel(helpers.Result{Data: &redemptions[0]}))
+	suite.mockVoucherRepositoryCommand.On("DecreaseVoucherRedeemed", mock.Anything, "FIXED30").Return(mockChannel(helpers.Result{Data: &entity.Voucher{}}))
+	suite.mockVoucherRepositoryCommand.On("DecreaseVoucherUserCounter", mock.Anything, "FIXED30", "user-id").Return(mockChannel(helpers.Result{Data: &entity.VoucherUserCounter{}}))
+
+	// Act
+	err := suite.usecase.ReleaseVouchers(suite.ctx, "order-id")
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockVoucherRepositoryCommand.AssertNotCalled(suite.T(), "UpdateRedemptionReleased", mock.Anything, "redemption-2")
+	suite.mockVoucherRepositoryCommand.AssertNotCalled(suite.T(), "DecreaseVoucherRedeemed", mock.Anything, "PERCENT10")
+}
+
+func (suite *CommandUsecaseTestSuite) mockCounters(code string) {
+	suite.mockVoucherRepositoryCommand.On("IncreaseVoucherRedeemed", mock.Anything, code).Return(mockChannel(helpers.Result{Data: &entity.Voucher{Code: code}}))
+	suite.mockVoucherRepositoryCommand.On("InitVoucherUserCounter", mock.Anything, code, "user-id").Return(mockChannel(helpers.Result{Data: &entity.VoucherUserCounter{}}))
+	suite.mockVoucherRepositoryCommand.On("IncreaseVoucherUserCounter", mock.Anything, code, "user-id", 1).Return(mockChannel(helpers.Result{Data: &entity.VoucherUserCounter{Total: 1}}))
+}
+
+func getMockVoucher(code string, voucherType string, value int) entity.Voucher {
+	return entity.Voucher{
+		Code:           code,
+		Type:           voucherType,
+		Value:          value,
+		ValidFrom:      time.Now().Add(-time.Hour),
+		ValidUntil:     time.Now().Add(time.Hour),
+		MaxRedemptions: 100,
+		MaxPerUser:     1,
+		Stackable:      true,
+		Active:         true,
+	}
+}
+
+func getRedeemReq(codes ...string) request.RedeemReq {
+	return request.RedeemReq{
+		OrderId: "order-id",
+		Codes:   codes,
+		Target: dto.Target{
+			UserId:      "user-id",
+			EventId:     "event-id",
+			TicketType:  "GOLD",
+			CountryCode: "ID",
+			TicketPrice: 100,
+			Quantity:    2,
+		},
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
@@ -0,0 +1,175 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/ticket"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	ticketRequest "ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/modules/voucher"
+	"ticket-service/internal/modules/voucher/models/dto"
+	"ticket-service/internal/modules/voucher/models/entity"
+	"ticket-service/internal/modules/voucher/models/request"
+	"ticket-service/internal/modules/voucher/models/response"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	voucherRepositoryQuery voucher.MongodbRepositoryQuery
+	ticketRepositoryQuery  ticket.MongodbRepositoryQuery
+	logger                 log.Logger
+}
+
+func NewQueryUsecase(vmq voucher.MongodbRepositoryQuery, tmq ticket.MongodbRepositoryQuery, log log.Logger) voucher.UsecaseQuery {
+	return queryUsecase{
+		voucherRepositoryQuery: vmq,
+		ticketRepositoryQuery:  tmq,
+		logger:                 log,
+	}
+}
+
+// ValidateVouchers previews the total of an order with the codes applied, nothing is redeemed
+func (q queryUsecase) ValidateVouchers(origCtx context.Context, payload request.ValidateReq) (*response.Quote, error) {
+	domain := "voucherUsecase-ValidateVouchers"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	ticketData := <-q.ticketRepositoryQuery.FindTicketByType(ctx, ticketRequest.TicketTypeReq{
+		EventId:     payload.EventId,
+		CountryCode: payload.CountryCode,
+		TicketType:  payload.TicketType,
+	})
+	if ticketData.Error != nil {
+		msg := "Error query ticket"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", ticketData.Error))
+		return nil, ticketData.Error
+	}
+
+	if ticketData.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	ticketDetail, ok := ticketData.Data.(*ticketEntity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	target := dto.Target{
+		UserId:      payload.UserId,
+		EventId:     ticketDetail.EventId,
+		Tag:         ticketDetail.Tag,
+		TicketType:  ticketDetail.TicketType,
+		CountryCode: ticketDetail.Country.Code,
+		TicketPrice: ticketDetail.PriceAt(time.Now(), ticketDetail.Sold()).Price,
+		Quantity:    payload.Quantity,
+	}
+	quote, err := q.previewVouchers(ctx, payload.Codes, target)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]response.AppliedVoucher, 0)
+	for _, value := range quote.Vouchers {
+		applied = append(applied, response.AppliedVoucher{
+			Code:     value.Code,
+			Discount: fmt.Sprintf("$%d", value.Discount),
+		})
+	}
+
+	return &response.Quote{
+		EventId:       target.EventId,
+		TicketType:    target.TicketType,
+		CountryCode:   target.CountryCode,
+		Quantity:      target.Quantity,
+		TicketPrice:   fmt.Sprintf("$%d", target.TicketPrice),
+		Subtotal:      fmt.Sprintf("$%d", quote.Subtotal),
+		Vouchers:      applied,
+		TotalDiscount: fmt.Sprintf("$%d", quote.TotalDiscount),
+		Total:         fmt.Sprintf("$%d", quote.Total),
+	}, nil
+}
+
+// PreviewVouchers applies the codes to target the way an order would, nothing is redeemed
+func (q queryUsecase) PreviewVouchers(origCtx context.Context, codes []string, target dto.Target) (*dto.Quote, error) {
+	domain := "voucherUsecase-PreviewVouchers"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	return q.previewVouchers(ctx, codes, target)
+}
+
+func (q queryUsecase) previewVouchers(ctx context.Context, codes []string, target dto.Target) (*dto.Quote, error) {
+	vouchers, err := findVouchers(ctx, q.voucherRepositoryQuery, q.logger, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, value := range vouchers {
+		if err := checkVoucher(value, target, now); err != nil {
+			return nil, err
+		}
+		if err := q.checkUserCap(ctx, value, target.UserId); err != nil {
+			return nil, err
+		}
+	}
+
+	quote := applyVouchers(vouchers, target.TicketPrice*target.Quantity)
+	return &quote, nil
+}
+
+func (q queryUsecase) FindVoucher(origCtx context.Context, code string) (*response.Voucher, error) {
+	domain := "voucherUsecase-FindVoucher"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.voucherRepositoryQuery.FindVoucherByCode(ctx, normalizeCode(code))
+	if resp.Error != nil {
+		msg := "Error query voucher"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("voucher not found")
+	}
+
+	voucherData, ok := resp.Data.(*entity.Voucher)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	return mapVoucher(*voucherData), nil
+}
+
+func (q queryUsecase) checkUserCap(ctx context.Context, voucherData entity.Voucher, userId string) error {
+	if voucherData.MaxPerUser == 0 {
+		return nil
+	}
+
+	resp := <-q.voucherRepositoryQuery.FindVoucherUserCounter(ctx, voucherData.Code, userId)
+	if resp.Error != nil {
+		msg := "Error query voucher user counter"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return resp.Error
+	}
+
+	counter, ok := resp.Data.(*entity.VoucherUserCounter)
+	if ok && counter.Total >= voucherData.MaxPerUser {
+		return errors.UnprocessableEntity(fmt.Sprintf("you have already used voucher %s", voucherData.Code))
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package voucher
+
+import (
+	"context"
+	"ticket-service/internal/modules/voucher/models/dto"
+	"ticket-service/internal/modules/voucher/models/entity"
+	"ticket-service/internal/modules/voucher/models/request"
+	"ticket-service/internal/modules/voucher/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+)
+
+type UsecaseCommand interface {
+	UpsertVoucher(origCtx context.Context, payload request.VoucherReq) (*response.Voucher, error)
+	RedeemVouchers(origCtx context.Context, payload request.RedeemReq) (*dto.Quote, error)
+	ReleaseVouchers(origCtx context.Context, orderId string) error
+}
+
+type UsecaseQuery interface {
+	ValidateVouchers(origCtx context.Context, payload request.ValidateReq) (*response.Quote, error)
+	PreviewVouchers(origCtx context.Context, codes []string, target dto.Target) (*dto.Quote, error)
+	FindVoucher(origCtx context.Context, code string) (*response.Voucher, error)
+}
+
+type MongodbRepositoryQuery interface {
+	FindVoucherByCode(ctx context.Context, code string) <-chan wrapper.Result
+	FindVoucherUserCounter(ctx context.Context, code string, userId string) <-chan wrapper.Result
+	FindRedemptionsByOrderId(ctx context.Context, orderId string) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	UpsertVoucher(ctx context.Context, voucher entity.Voucher) <-chan wrapper.Result
+	IncreaseVoucherRedeemed(ctx context.Context, code string) <-chan wrapper.Result
+	DecreaseVoucherRedeemed(ctx context.Context, code string) <-chan wrapper.Result
+	InitVoucherUserCounter(ctx context.Context, code string, userId string) <-chan wrapper.Result
+	IncreaseVoucherUserCounter(ctx context.Context, code string, userId string, maxPerUser int) <-chan wrapper.Result
+	DecreaseVoucherUserCounter(ctx context.Context, code string, userId string) <-chan wrapper.Result
+	InsertOneRedemption(ctx context.Context, redemption entity.Redemption) <-chan wrapper.Result
+	UpdateRedemptionReleased(ctx context.Context, redemptionId string) <-chan wrapper.Result
+	CreateUniqueIndexes(ctx context.Context) <-chan wrapper.Result
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"ticket-service/internal/modules/waitlist"
+	"ticket-service/internal/modules/waitlist/models/request"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"ticket-service/internal/pkg/redis"
+
+	middlewares "ticket-service/configs/middleware"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WaitlistHttpHandler struct {
+	WaitlistUsecaseCommand waitlist.UsecaseCommand
+	WaitlistUsecaseQuery   waitlist.UsecaseQuery
+	Logger                 log.Logger
+	Validator              *validator.Validate
+}
+
+func InitWaitlistHttpHandler(app *fiber.App, wuc waitlist.UsecaseCommand, wuq waitlist.UsecaseQuery, log log.Logger, redisClient redis.Collections) {
+	handler := &WaitlistHttpHandler{
+		WaitlistUsecaseCommand: wuc,
+		WaitlistUsecaseQuery:   wuq,
+		Logger:                 log,
+		Validator:              validator.New(),
+	}
+	middlewares := middlewares.NewMiddlewares(redisClient)
+	route := app.Group("/api/waitlists")
+
+	route.Post("/v1", middlewares.VerifyBearer(), handler.JoinWaitlist)
+	route.Get("/v1/me", middlewares.VerifyBearer(), handler.GetMyEntries)
+	route.Post("/v1/:id/leave", middlewares.VerifyBearer(), handler.LeaveWaitlist)
+	route.Post("/v1/offer", middlewares.VerifyBasicAuth(), handler.OfferReleasedTickets)
+}
+
+func (w WaitlistHttpHandler) JoinWaitlist(c *fiber.Ctx) error {
+	req := new(request.JoinReq)
+	if err := c.BodyParser(req); err != nil {
+		return helpers.RespError(c, w.Logger, errors.BadRequest("bad request"))
+	}
+
+	if err := w.Validator.Struct(req); err != nil {
+		return helpers.RespError(c, w.Logger, errors.BadRequest(err.Error()))
+	}
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, w.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req.UserId = userId
+	resp, err := w.WaitlistUsecaseCommand.JoinWaitlist(c.Context(), *req)
+	if err != nil {
+		return helpers.RespCustomError(c, w.Logger, err)
+	}
+	return helpers.RespSuccess(c, w.Logger, resp, "Join waitlist success")
+}
+
+func (w WaitlistHttpHandler) GetMyEntries(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, w.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	resp, err := w.WaitlistUsecaseQuery.FindMyEntries(c.Context(), userId)
+	if err != nil {
+		return helpers.RespCustomError(c, w.Logger, err)
+	}
+	return helpers.RespSuccess(c, w.Logger, resp, "Get waitlist entries success")
+}
+
+func (w WaitlistHttpHandler) LeaveWaitlist(c *fiber.Ctx) error {
+	userId, ok := c.Locals("userId").(string)
+	if !ok {
+		return helpers.RespError(c, w.Logger, errors.UnauthorizedError("invalid user"))
+	}
+	req := request.LeaveReq{
+		UserId:  userId,
+		EntryId: c.Params("id"),
+	}
+	resp, err := w.WaitlistUsecaseCommand.LeaveWaitlist(c.Context(), req)
+	if err != nil {
+		return helpers.RespCustomError(c, w.Logger, err)
+	}
+	return helpers.RespSuccess(c, w.Logger, resp, "Leave waitlist success")
+}
+
+func (w WaitlistHttpHandler) OfferReleasedTickets(c *fiber.Ctx) error {
+	resp, err := w.WaitlistUsecaseCommand.OfferReleasedTickets(c.Context())
+	if err != nil {
+		return helpers.RespCustomError(c, w.Logger, err)
+	}
+	return helpers.RespSuccess(c, w.Logger, resp, "Offer released tickets success")
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// Waitlist is kept per ticket, LastPosition numbers the entries in join order
+// and Waiting counts the entries that are still waiting for an offer
+type Waitlist struct {
+	TicketId     string    `json:"ticketId" bson:"ticketId"`
+	EventId      string    `json:"eventId" bson:"eventId"`
+	CountryCode  string    `json:"countryCode" bson:"countryCode"`
+	TicketType   string    `json:"ticketType" bson:"ticketType"`
+	LastPosition int       `json:"lastPosition" bson:"lastPosition"`
+	Waiting      int       `json:"waiting" bson:"waiting"`
+	CreatedAt    time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+// WaitlistEntry gets an OrderId once it is offered, the order is the hold the user has to pay before HoldUntil
+type WaitlistEntry struct {
+	EntryId     string    `json:"entryId" bson:"entryId"`
+	TicketId    string    `json:"ticketId" bson:"ticketId"`
+	EventId     string    `json:"eventId" bson:"eventId"`
+	CountryCode string    `json:"countryCode" bson:"countryCode"`
+	TicketType  string    `json:"ticketType" bson:"ticketType"`
+	UserId      string    `json:"userId" bson:"userId"`
+	Quantity    int       `json:"quantity" bson:"quantity"`
+	Position    int       `json:"position" bson:"position"`
+	Status      string    `json:"status" bson:"status"`
+	OrderId     string    `json:"orderId,omitempty" bson:"orderId,omitempty"`
+	HoldUntil   time.Time `json:"holdUntil,omitempty" bson:"holdUntil,omitempty"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
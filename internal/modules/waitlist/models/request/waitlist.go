@@ -0,0 +1,14 @@
+package request
+
+type JoinReq struct {
+	UserId      string `json:"-"`
+	EventId     string `json:"eventId" validate:"required"`
+	CountryCode string `json:"countryCode" validate:"required"`
+	TicketType  string `json:"ticketType" validate:"required"`
+	Quantity    int    `json:"quantity" validate:"required,min=1"`
+}
+
+type LeaveReq struct {
+	UserId  string `json:"-"`
+	EntryId string `json:"-"`
+}
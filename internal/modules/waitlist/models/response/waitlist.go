@@ -0,0 +1,21 @@
+package response
+
+import "time"
+
+// Entry has the Position of a waiting entry in its queue, 1 is next in line
+type Entry struct {
+	EntryId     string    `json:"entryId"`
+	EventId     string    `json:"eventId"`
+	CountryCode string    `json:"countryCode"`
+	TicketType  string    `json:"ticketType"`
+	Quantity    int       `json:"quantity"`
+	Status      string    `json:"status"`
+	Position    int       `json:"position,omitempty"`
+	OrderId     string    `json:"orderId,omitempty"`
+	HoldUntil   time.Time `json:"holdUntil,omitempty"`
+}
+
+type Offers struct {
+	Offered  int `json:"offered"`
+	Resolved int `json:"resolved"`
+}
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"context"
+	"ticket-service/internal/modules/waitlist"
+	"ticket-service/internal/modules/waitlist/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type commandMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewCommandMongodbRepository(mongodb mongodb.Collections, log log.Logger) waitlist.MongodbRepositoryCommand {
+	return &commandMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+// InitWaitlist creates the waitlist of a ticket the first time someone joins it and leaves an existing one untouched
+func (c commandMongodbRepository) InitWaitlist(ctx context.Context, waitlist entity.Waitlist) <-chan wrapper.Result {
+	var updated entity.Waitlist
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &updated,
+			CollectionName: "waitlists",
+			Filter: bson.M{
+				"ticketId": waitlist.TicketId,
+			},
+			Update: bson.M{
+				"$setOnInsert": bson.M{
+					"eventId":      waitlist.EventId,
+					"countryCode":  waitlist.CountryCode,
+					"ticketType":   waitlist.TicketType,
+					"lastPosition": 0,
+					"waiting":      0,
+					"createdAt":    waitlist.CreatedAt,
+					"updatedAt":    waitlist.CreatedAt,
+				},
+			},
+			Upsert: true,
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// IncreaseWaiting hands out the next position of a waitlist, it is taken from LastPosition of the returned waitlist
+func (c commandMongodbRepository) IncreaseWaiting(ctx context.Context, ticketId string) <-chan wrapper.Result {
+	var waitlist entity.Waitlist
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &waitlist,
+			CollectionName: "waitlists",
+			Filter: bson.M{
+				"ticketId": ticketId,
+			},
+			Update: bson.M{
+				"$inc": bson.M{
+					"lastPosition": 1,
+					"waiting":      1,
+				},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) DecreaseWaiting(ctx context.Context, ticketId string) <-chan wrapper.Result {
+	var waitlist entity.Waitlist
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &waitlist,
+			CollectionName: "waitlists",
+			Filter: bson.M{
+				"ticketId": ticketId,
+				"waiting":  bson.M{"$gt": 0},
+			},
+			Update: bson.M{
+				"$inc": bson.M{"waiting": -1},
+				"$set": bson.M{"updatedAt": time.Now()},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (c commandMongodbRepository) InsertOneEntry(ctx context.Context, entry entity.WaitlistEntry) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.InsertOne(mongodb.InsertOne{
+			CollectionName: "waitlist-entries",
+			Document:       entry,
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateEntryStatus moves an entry from one status to another, Data is nil when it is not in from anymore
+func (c commandMongodbRepository) UpdateEntryStatus(ctx context.Context, entryId string, from string, to string) <-chan wrapper.Result {
+	var entry entity.WaitlistEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &entry,
+			CollectionName: "waitlist-entries",
+			Filter: bson.M{
+				"entryId": entryId,
+				"status":  from,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    to,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// UpdateEntryOffered gives a waiting entry its hold, Data is nil when the entry left or was offered by another run
+func (c commandMongodbRepository) UpdateEntryOffered(ctx context.Context, entryId string, orderId string, holdUntil time.Time) <-chan wrapper.Result {
+	var entry entity.WaitlistEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-c.mongoDb.FindOneAndUpdate(mongodb.FindOneAndUpdate{
+			Result:         &entry,
+			CollectionName: "waitlist-entries",
+			Filter: bson.M{
+				"entryId": entryId,
+				"status":  constants.WaitlistStatusWaiting,
+			},
+			Update: bson.M{
+				"$set": bson.M{
+					"status":    constants.WaitlistStatusOffered,
+					"orderId":   orderId,
+					"holdUntil": holdUntil,
+					"updatedAt": time.Now(),
+				},
+			},
+		}, options.After, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
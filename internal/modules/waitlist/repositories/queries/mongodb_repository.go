@@ -0,0 +1,215 @@
+package queries
+
+import (
+	"context"
+	"ticket-service/internal/modules/waitlist"
+	"ticket-service/internal/modules/waitlist/models/entity"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/databases/mongodb"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"ticket-service/internal/pkg/log"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type queryMongodbRepository struct {
+	mongoDb mongodb.Collections
+	logger  log.Logger
+}
+
+func NewQueryMongodbRepository(mongodb mongodb.Collections, log log.Logger) waitlist.MongodbRepositoryQuery {
+	return &queryMongodbRepository{
+		mongoDb: mongodb,
+		logger:  log,
+	}
+}
+
+func (q queryMongodbRepository) FindWaitlist(ctx context.Context, ticketId string) <-chan wrapper.Result {
+	var waitlist entity.Waitlist
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &waitlist,
+			CollectionName: "waitlists",
+			Filter: bson.M{
+				"ticketId": ticketId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindWaitingWaitlists(ctx context.Context) <-chan wrapper.Result {
+	var waitlists []entity.Waitlist
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &waitlists,
+			CollectionName: "waitlists",
+			Filter: bson.M{
+				"waiting": bson.M{"$gt": 0},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// FindActiveEntry finds the entry of a user that is still waiting or holding an offer for a ticket
+func (q queryMongodbRepository) FindActiveEntry(ctx context.Context, ticketId string, userId string) <-chan wrapper.Result {
+	var entry entity.WaitlistEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &entry,
+			CollectionName: "waitlist-entries",
+			Filter: bson.M{
+				"ticketId": ticketId,
+				"userId":   userId,
+				"status":   bson.M{"$in": []string{constants.WaitlistStatusWaiting, constants.WaitlistStatusOffered}},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindEntryById(ctx context.Context, entryId string) <-chan wrapper.Result {
+	var entry entity.WaitlistEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindOne(mongodb.FindOne{
+			Result:         &entry,
+			CollectionName: "waitlist-entries",
+			Filter: bson.M{
+				"entryId": entryId,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindEntriesByStatus(ctx context.Context, ticketId string, status string) <-chan wrapper.Result {
+	var entries []entity.WaitlistEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &entries,
+			CollectionName: "waitlist-entries",
+			Filter: bson.M{
+				"ticketId": ticketId,
+				"status":   status,
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "position",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindOfferedEntries(ctx context.Context) <-chan wrapper.Result {
+	var entries []entity.WaitlistEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &entries,
+			CollectionName: "waitlist-entries",
+			Filter: bson.M{
+				"status": constants.WaitlistStatusOffered,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+func (q queryMongodbRepository) FindActiveEntriesByUserId(ctx context.Context, userId string) <-chan wrapper.Result {
+	var entries []entity.WaitlistEntry
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.FindMany(mongodb.FindMany{
+			Result:         &entries,
+			CollectionName: "waitlist-entries",
+			Filter: bson.M{
+				"userId": userId,
+				"status": bson.M{"$in": []string{constants.WaitlistStatusWaiting, constants.WaitlistStatusOffered}},
+			},
+			Sort: &mongodb.Sort{
+				FieldName: "createdAt",
+				By:        mongodb.SortAscending,
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CountWaitingAhead counts the waiting entries that joined the queue of a ticket before position, the result is in Count
+func (q queryMongodbRepository) CountWaitingAhead(ctx context.Context, ticketId string, position int) <-chan wrapper.Result {
+	var count int64
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.CountData(mongodb.CountData{
+			Result:         &count,
+			CollectionName: "waitlist-entries",
+			Filter: bson.M{
+				"ticketId": ticketId,
+				"status":   constants.WaitlistStatusWaiting,
+				"position": bson.M{"$lt": position},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
+
+// CountServableEntries counts the waiting entries of a ticket asking for at most quantity tickets, the result is in Count
+func (q queryMongodbRepository) CountServableEntries(ctx context.Context, ticketId string, quantity int) <-chan wrapper.Result {
+	var count int64
+	output := make(chan wrapper.Result)
+
+	go func() {
+		resp := <-q.mongoDb.CountData(mongodb.CountData{
+			Result:         &count,
+			CollectionName: "waitlist-entries",
+			Filter: bson.M{
+				"ticketId": ticketId,
+				"status":   constants.WaitlistStatusWaiting,
+				"quantity": bson.M{"$lte": quantity},
+			},
+		}, ctx)
+		output <- resp
+		close(output)
+	}()
+
+	return output
+}
@@ -0,0 +1,471 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"ticket-service/internal/modules/order"
+	orderDto "ticket-service/internal/modules/order/models/dto"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	"ticket-service/internal/modules/ticket"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	ticketRequest "ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/modules/waitlist"
+	"ticket-service/internal/modules/waitlist/models/entity"
+	"ticket-service/internal/modules/waitlist/models/request"
+	"ticket-service/internal/modules/waitlist/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	kafkaConfluent "ticket-service/internal/pkg/kafka/confluent"
+
+	"github.com/google/uuid"
+	"go.elastic.co/apm"
+)
+
+type commandUsecase struct {
+	waitlistRepositoryQuery   waitlist.MongodbRepositoryQuery
+	waitlistRepositoryCommand waitlist.MongodbRepositoryCommand
+	ticketRepositoryQuery     ticket.MongodbRepositoryQuery
+	orderRepositoryQuery      order.MongodbRepositoryQuery
+	orderUsecaseCommand       order.UsecaseCommand
+	kafkaProducer             kafkaConfluent.Producer
+	logger                    log.Logger
+}
+
+func NewCommandUsecase(wmq waitlist.MongodbRepositoryQuery, wmc waitlist.MongodbRepositoryCommand, tmq ticket.MongodbRepositoryQuery,
+	omq order.MongodbRepositoryQuery, ouc order.UsecaseCommand, kp kafkaConfluent.Producer, log log.Logger) waitlist.UsecaseCommand {
+	return commandUsecase{
+		waitlistRepositoryQuery:   wmq,
+		waitlistRepositoryCommand: wmc,
+		ticketRepositoryQuery:     tmq,
+		orderRepositoryQuery:      omq,
+		orderUsecaseCommand:       ouc,
+		kafkaProducer:             kp,
+		logger:                    log,
+	}
+}
+
+func (c commandUsecase) JoinWaitlist(origCtx context.Context, payload request.JoinReq) (*response.Entry, error) {
+	domain := "waitlistUsecase-JoinWaitlist"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	ticketDetail, err := c.findTicket(ctx, payload.EventId, payload.CountryCode, payload.TicketType)
+	if err != nil {
+		return nil, err
+	}
+
+	// an entry the tier can never fill would wait forever
+	if payload.Quantity > ticketDetail.TotalQuota {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("this ticket only has %d tickets in total", ticketDetail.TotalQuota))
+	}
+
+	if ticketDetail.TotalRemaining >= payload.Quantity {
+		held, err := heldForWaitlist(ctx, c.waitlistRepositoryQuery, c.logger, ticketDetail.TicketId, ticketDetail.TotalRemaining)
+		if err != nil {
+			return nil, err
+		}
+		if !held {
+			return nil, errors.UnprocessableEntity("tickets are still available")
+		}
+	}
+
+	waitlistData, err := findWaitlist(ctx, c.waitlistRepositoryQuery, c.logger, ticketDetail.TicketId)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := <-c.waitlistRepositoryQuery.FindActiveEntry(ctx, ticketDetail.TicketId, payload.UserId)
+	if existing.Error != nil {
+		msg := "Error query waitlist entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", existing.Error))
+		return nil, existing.Error
+	}
+
+	if existing.Data != nil {
+		return nil, errors.Conflict("you are already on the waitlist of this ticket")
+	}
+
+	now := time.Now()
+	if waitlistData == nil {
+		initiated := <-c.waitlistRepositoryCommand.InitWaitlist(ctx, entity.Waitlist{
+			TicketId:    ticketDetail.TicketId,
+			EventId:     payload.EventId,
+			CountryCode: payload.CountryCode,
+			TicketType:  payload.TicketType,
+			CreatedAt:   now,
+		})
+		if initiated.Error != nil {
+			msg := "Error init waitlist"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", initiated.Error))
+			return nil, initiated.Error
+		}
+	}
+
+	increased := <-c.waitlistRepositoryCommand.IncreaseWaiting(ctx, ticketDetail.TicketId)
+	if increased.Error != nil {
+		msg := "Error increase waitlist"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", increased.Error))
+		return nil, increased.Error
+	}
+
+	waitlistData, ok := increased.Data.(*entity.Waitlist)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	entry := entity.WaitlistEntry{
+		EntryId:     uuid.NewString(),
+		TicketId:    ticketDetail.TicketId,
+		EventId:     payload.EventId,
+		CountryCode: payload.CountryCode,
+		TicketType:  payload.TicketType,
+		UserId:      payload.UserId,
+		Quantity:    payload.Quantity,
+		Position:    waitlistData.LastPosition,
+		Status:      constants.WaitlistStatusWaiting,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	insert := <-c.waitlistRepositoryCommand.InsertOneEntry(ctx, entry)
+	if insert.Error != nil {
+		msg := "Error insert waitlist entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", insert.Error))
+		c.decreaseWaiting(ctx, ticketDetail.TicketId)
+		return nil, insert.Error
+	}
+
+	// everyone still waiting joined before this entry
+	return mapEntry(entry, waitlistData.Waiting), nil
+}
+
+func (c commandUsecase) LeaveWaitlist(origCtx context.Context, payload request.LeaveReq) (*response.Entry, error) {
+	domain := "waitlistUsecase-LeaveWaitlist"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-c.waitlistRepositoryQuery.FindEntryById(ctx, payload.EntryId)
+	if resp.Error != nil {
+		msg := "Error query waitlist entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("waitlist entry not found")
+	}
+
+	entry, ok := resp.Data.(*entity.WaitlistEntry)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	if entry.UserId != payload.UserId {
+		return nil, errors.NotFound("waitlist entry not found")
+	}
+
+	if entry.Status == constants.WaitlistStatusOffered {
+		return nil, errors.UnprocessableEntity(fmt.Sprintf("your entry holds order %s, cancel the reservation instead", entry.OrderId))
+	}
+
+	left := <-c.waitlistRepositoryCommand.UpdateEntryStatus(ctx, entry.EntryId, constants.WaitlistStatusWaiting, constants.WaitlistStatusLeft)
+	if left.Error != nil {
+		msg := "Error leave waitlist"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", left.Error))
+		return nil, left.Error
+	}
+
+	if left.Data == nil {
+		return nil, errors.Conflict("waitlist entry is no longer waiting")
+	}
+	c.decreaseWaiting(ctx, entry.TicketId)
+
+	entry.Status = constants.WaitlistStatusLeft
+	return mapEntry(*entry, 0), nil
+}
+
+// OfferReleasedTickets hands tickets given back by refunds, expired holds or a bigger quota to the waitlist in join order,
+// each offer is a reservation held for WaitlistHoldDuration. Every pod calls it on WaitlistOfferInterval, an entry is only
+// moved out of WAITING once so pods running it together do not offer it twice
+func (c commandUsecase) OfferReleasedTickets(origCtx context.Context) (*response.Offers, error) {
+	domain := "waitlistUsecase-OfferReleasedTickets"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	result := response.Offers{}
+	resolved, err := c.resolveOffers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.Resolved = resolved
+
+	resp := <-c.waitlistRepositoryQuery.FindWaitingWaitlists(ctx)
+	if resp.Error != nil {
+		msg := "Error query waitlist"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return &result, nil
+	}
+
+	waitlists, ok := resp.Data.(*[]entity.Waitlist)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *waitlists {
+		offered, err := c.offerWaitlist(ctx, value)
+		if err != nil {
+			continue
+		}
+		result.Offered += offered
+	}
+
+	return &result, nil
+}
+
+// offerWaitlist walks the waiting entries of one ticket, an entry wanting more than is left is skipped
+// so smaller entries behind it are not held up
+func (c commandUsecase) offerWaitlist(ctx context.Context, waitlistData entity.Waitlist) (int, error) {
+	ticketDetail, err := c.findTicket(ctx, waitlistData.EventId, waitlistData.CountryCode, waitlistData.TicketType)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := ticketDetail.TotalRemaining
+	if remaining <= 0 {
+		return 0, nil
+	}
+
+	resp := <-c.waitlistRepositoryQuery.FindEntriesByStatus(ctx, waitlistData.TicketId, constants.WaitlistStatusWaiting)
+	if resp.Error != nil {
+		msg := "Error query waitlist entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return 0, resp.Error
+	}
+
+	if resp.Data == nil {
+		return 0, nil
+	}
+
+	entries, ok := resp.Data.(*[]entity.WaitlistEntry)
+	if !ok {
+		return 0, errors.InternalServerError("cannot parsing data")
+	}
+
+	offered := 0
+	for _, value := range *entries {
+		if value.Quantity > remaining {
+			continue
+		}
+
+		orderId := uuid.NewString()
+		holdUntil := time.Now().Add(constants.WaitlistHoldDuration)
+		marked := <-c.waitlistRepositoryCommand.UpdateEntryOffered(ctx, value.EntryId, orderId, holdUntil)
+		if marked.Error != nil {
+			msg := "Error offer waitlist entry"
+			c.logger.Error(ctx, msg, fmt.Sprintf("%+v", marked.Error))
+			continue
+		}
+
+		if marked.Data == nil {
+			continue
+		}
+
+		reservation, err := c.orderUsecaseCommand.CreateReservation(ctx, orderDto.ReservationReq{
+			UserId:          value.UserId,
+			OrderId:         orderId,
+			WaitlistEntryId: value.EntryId,
+			HoldDuration:    constants.WaitlistHoldDuration,
+			EventId:         value.EventId,
+			CountryCode:     value.CountryCode,
+			TicketType:      value.TicketType,
+			Quantity:        value.Quantity,
+		})
+		if err != nil {
+			if c.hasRemaining(ctx, waitlistData, value.Quantity) {
+				// the tickets are there so the reservation failed on the user, e.g. a purchase limit, and the turn passes on
+				c.logger.Error(ctx, "Error reserve waitlist offer", fmt.Sprintf("%+v", err))
+				c.updateEntryStatus(ctx, value.EntryId, constants.WaitlistStatusOffered, constants.WaitlistStatusExpired)
+				c.decreaseWaiting(ctx, value.TicketId)
+				continue
+			}
+			c.updateEntryStatus(ctx, value.EntryId, constants.WaitlistStatusOffered, constants.WaitlistStatusWaiting)
+			return offered, nil
+		}
+
+		c.decreaseWaiting(ctx, value.TicketId)
+		remaining -= value.Quantity
+		offered++
+
+		offerEvent := map[string]interface{}{
+			"entryId":     value.EntryId,
+			"userId":      value.UserId,
+			"eventId":     value.EventId,
+			"countryCode": value.CountryCode,
+			"ticketType":  value.TicketType,
+			"quantity":    value.Quantity,
+			"orderId":     reservation.OrderId,
+			"holdUntil":   reservation.ExpiredAt,
+		}
+		marshaledKafkaData, _ := json.Marshal(offerEvent)
+		topic := "concert-waitlist-offered"
+		c.kafkaProducer.Publish(topic, marshaledKafkaData, nil)
+		c.logger.Info(ctx, fmt.Sprintf("Send kafka waitlist offered, entry : %s", value.EntryId), fmt.Sprintf("%+v", offerEvent))
+	}
+
+	return offered, nil
+}
+
+// resolveOffers closes offers whose hold has ended, paid holds are claimed and the rest expired
+func (c commandUsecase) resolveOffers(ctx context.Context) (int, error) {
+	resp := <-c.waitlistRepositoryQuery.FindOfferedEntries(ctx)
+	if resp.Error != nil {
+		msg := "Error query waitlist entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return 0, resp.Error
+	}
+
+	if resp.Data == nil {
+		return 0, nil
+	}
+
+	entries, ok := resp.Data.(*[]entity.WaitlistEntry)
+	if !ok {
+		return 0, errors.InternalServerError("cannot parsing data")
+	}
+
+	resolved := 0
+	for _, value := range *entries {
+		orderData := <-c.orderRepositoryQuery.FindOrderById(ctx, value.OrderId)
+		if orderData.Error != nil || orderData.Data == nil {
+			continue
+		}
+
+		orderDetail, ok := orderData.Data.(*orderEntity.Order)
+		if !ok || orderDetail.Status == constants.OrderStatusPending {
+			continue
+		}
+
+		toStatus := constants.WaitlistStatusExpired
+		if orderDetail.Status == constants.OrderStatusPaid {
+			toStatus = constants.WaitlistStatusClaimed
+		}
+		if c.updateEntryStatus(ctx, value.EntryId, constants.WaitlistStatusOffered, toStatus) {
+			resolved++
+		}
+	}
+
+	return resolved, nil
+}
+
+func (c commandUsecase) hasRemaining(ctx context.Context, waitlistData entity.Waitlist, quantity int) bool {
+	ticketDetail, err := c.findTicket(ctx, waitlistData.EventId, waitlistData.CountryCode, waitlistData.TicketType)
+	if err != nil {
+		return false
+	}
+	return ticketDetail.TotalRemaining >= quantity
+}
+
+func (c commandUsecase) updateEntryStatus(ctx context.Context, entryId string, from string, to string) bool {
+	updated := <-c.waitlistRepositoryCommand.UpdateEntryStatus(ctx, entryId, from, to)
+	if updated.Error != nil {
+		msg := "Error update waitlist entry"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", updated.Error))
+		return false
+	}
+	return updated.Data != nil
+}
+
+func (c commandUsecase) decreaseWaiting(ctx context.Context, ticketId string) {
+	decreased := <-c.waitlistRepositoryCommand.DecreaseWaiting(ctx, ticketId)
+	if decreased.Error != nil {
+		msg := "Error decrease waitlist"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", decreased.Error))
+	}
+}
+
+func (c commandUsecase) findTicket(ctx context.Context, eventId string, countryCode string, ticketType string) (*ticketEntity.Ticket, error) {
+	resp := <-c.ticketRepositoryQuery.FindTicketByType(ctx, ticketRequest.TicketTypeReq{
+		EventId:     eventId,
+		CountryCode: countryCode,
+		TicketType:  ticketType,
+	})
+	if resp.Error != nil {
+		msg := "Error query ticket"
+		c.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, errors.NotFound("ticket not found")
+	}
+
+	ticketDetail, ok := resp.Data.(*ticketEntity.Ticket)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return ticketDetail, nil
+}
+
+func findWaitlist(ctx context.Context, repository waitlist.MongodbRepositoryQuery, logger log.Logger, ticketId string) (*entity.Waitlist, error) {
+	resp := <-repository.FindWaitlist(ctx, ticketId)
+	if resp.Error != nil {
+		msg := "Error query waitlist"
+		logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	waitlistData, ok := resp.Data.(*entity.Waitlist)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+	return waitlistData, nil
+}
+
+// heldForWaitlist tells whether the remaining tickets are held back for a waiting entry they are enough for
+func heldForWaitlist(ctx context.Context, repository waitlist.MongodbRepositoryQuery, logger log.Logger, ticketId string, remaining int) (bool, error) {
+	if remaining <= 0 {
+		return false, nil
+	}
+
+	resp := <-repository.CountServableEntries(ctx, ticketId, remaining)
+	if resp.Error != nil {
+		msg := "Error count waitlist entry"
+		logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return false, resp.Error
+	}
+	return resp.Count > 0, nil
+}
+
+func mapEntry(entry entity.WaitlistEntry, position int) *response.Entry {
+	return &response.Entry{
+		EntryId:     entry.EntryId,
+		EventId:     entry.EventId,
+		CountryCode: entry.CountryCode,
+		TicketType:  entry.TicketType,
+		Quantity:    entry.Quantity,
+		Status:      entry.Status,
+		Position:    position,
+		OrderId:     entry.OrderId,
+		HoldUntil:   entry.HoldUntil,
+	}
+}
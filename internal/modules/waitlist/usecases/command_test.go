@@ -0,0 +1,302 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	orderDto "ticket-service/internal/modules/order/models/dto"
+	orderEntity "ticket-service/internal/modules/order/models/entity"
+	orderResponse "ticket-service/internal/modules/order/models/response"
+	ticketEntity "ticket-service/internal/modules/ticket/models/entity"
+	ticketRequest "ticket-service/internal/modules/ticket/models/request"
+	"ticket-service/internal/modules/waitlist"
+	"ticket-service/internal/modules/waitlist/models/entity"
+	"ticket-service/internal/modules/waitlist/models/request"
+	uc "ticket-service/internal/modules/waitlist/usecases"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockorder "ticket-service/mocks/modules/order"
+	mockticket "ticket-service/mocks/modules/ticket"
+	mockwaitlist "ticket-service/mocks/modules/waitlist"
+	mockkafka "ticket-service/mocks/pkg/kafka"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommandUsecaseTestSuite struct {
+	suite.Suite
+	mockWaitlistRepositoryQuery   *mockwaitlist.MongodbRepositoryQuery
+	mockWaitlistRepositoryCommand *mockwaitlist.MongodbRepositoryCommand
+	mockTicketRepositoryQuery     *mockticket.MongodbRepositoryQuery
+	mockOrderRepositoryQuery      *mockorder.MongodbRepositoryQuery
+	mockOrderUsecaseCommand       *mockorder.UsecaseCommand
+	mockKafkaProducer             *mockkafka.Producer
+	mockLogger                    *mocklog.Logger
+	usecase                       waitlist.UsecaseCommand
+	ctx                           context.Context
+}
+
+func (suite *CommandUsecaseTestSuite) SetupTest() {
+	suite.mockWaitlistRepositoryQuery = &mockwaitlist.MongodbRepositoryQuery{}
+	suite.mockWaitlistRepositoryCommand = &mockwaitlist.MongodbRepositoryCommand{}
+	suite.mockTicketRepositoryQuery = &mockticket.MongodbRepositoryQuery{}
+	suite.mockOrderRepositoryQuery = &mockorder.MongodbRepositoryQuery{}
+	suite.mockOrderUsecaseCommand = &mockorder.UsecaseCommand{}
+	suite.mockKafkaProducer = &mockkafka.Producer{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewCommandUsecase(
+		suite.mockWaitlistRepositoryQuery,
+		suite.mockWaitlistRepositoryCommand,
+		suite.mockTicketRepositoryQuery,
+		suite.mockOrderRepositoryQuery,
+		suite.mockOrderUsecaseCommand,
+		suite.mockKafkaProducer,
+		suite.mockLogger,
+	)
+}
+
+func TestCommandUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CommandUsecaseTestSuite))
+}
+
+func (suite *CommandUsecaseTestSuite) TestJoinWaitlistSuccess() {
+	// Arrange
+	payload := getJoinReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(0)))
+	suite.mockWaitlistRepositoryQuery.On("FindWaitlist", mock.Anything, "ticket-id").Return(mockChannel(helpers.Result{Data: &entity.Waitlist{TicketId: "ticket-id", LastPosition: 7, Waiting: 3}}))
+	suite.mockWaitlistRepositoryQuery.On("FindActiveEntry", mock.Anything, "ticket-id", "user-id").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockWaitlistRepositoryCommand.On("IncreaseWaiting", mock.Anything, "ticket-id").Return(mockChannel(helpers.Result{Data: &entity.Waitlist{TicketId: "ticket-id", LastPosition: 8, Waiting: 4}}))
+	suite.mockWaitlistRepositoryCommand.On("InsertOneEntry", mock.Anything, mock.MatchedBy(func(e entity.WaitlistEntry) bool {
+		return e.Position == 8 && e.Status == constants.WaitlistStatusWaiting
+	})).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.JoinWaitlist(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 4, result.Position)
+	suite.mockWaitlistRepositoryCommand.AssertNotCalled(suite.T(), "InitWaitlist", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestJoinWaitlistErrStillAvailable() {
+	// Arrange
+	payload := getJoinReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(5)))
+	suite.mockWaitlistRepositoryQuery.On("CountServableEntries", mock.Anything, "ticket-id", 5).Return(mockChannel(helpers.Result{Count: 0}))
+
+	// Act
+	_, err := suite.usecase.JoinWaitlist(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("tickets are still available"), err)
+	suite.mockWaitlistRepositoryCommand.AssertNotCalled(suite.T(), "IncreaseWaiting", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestJoinWaitlistBehindServableEntry() {
+	// Arrange
+	payload := getJoinReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(5)))
+	suite.mockWaitlistRepositoryQuery.On("CountServableEntries", mock.Anything, "ticket-id", 5).Return(mockChannel(helpers.Result{Count: 1}))
+	suite.mockWaitlistRepositoryQuery.On("FindWaitlist", mock.Anything, "ticket-id").Return(mockChannel(helpers.Result{Data: &entity.Waitlist{TicketId: "ticket-id", LastPosition: 1, Waiting: 1}}))
+	suite.mockWaitlistRepositoryQuery.On("FindActiveEntry", mock.Anything, "ticket-id", "user-id").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockWaitlistRepositoryCommand.On("IncreaseWaiting", mock.Anything, "ticket-id").Return(mockChannel(helpers.Result{Data: &entity.Waitlist{TicketId: "ticket-id", LastPosition: 2, Waiting: 2}}))
+	suite.mockWaitlistRepositoryCommand.On("InsertOneEntry", mock.Anything, mock.Anything).Return(mockChannel(helpers.Result{Data: "Success insert data"}))
+
+	// Act
+	result, err := suite.usecase.JoinWaitlist(suite.ctx, payload)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.Position)
+}
+
+func (suite *CommandUsecaseTestSuite) TestJoinWaitlistErrOverQuota() {
+	// Arrange
+	payload := getJoinReq(101)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(0)))
+
+	// Act
+	_, err := suite.usecase.JoinWaitlist(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("this ticket only has 100 tickets in total"), err)
+	suite.mockWaitlistRepositoryCommand.AssertNotCalled(suite.T(), "IncreaseWaiting", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestJoinWaitlistErrDuplicate() {
+	// Arrange
+	payload := getJoinReq(2)
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(mockChannel(getMockTicket(0)))
+	suite.mockWaitlistRepositoryQuery.On("FindWaitlist", mock.Anything, "ticket-id").Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockWaitlistRepositoryQuery.On("FindActiveEntry", mock.Anything, "ticket-id", "user-id").Return(mockChannel(helpers.Result{Data: &entity.WaitlistEntry{EntryId: "entry-id"}}))
+
+	// Act
+	_, err := suite.usecase.JoinWaitlist(suite.ctx, payload)
+
+	// Assert
+	assert.Equal(suite.T(), errors.Conflict("you are already on the waitlist of this ticket"), err)
+}
+
+func (suite *CommandUsecaseTestSuite) TestLeaveWaitlist() {
+	// Arrange
+	entry := getMockEntry("entry-id", 1, 2)
+	suite.mockWaitlistRepositoryQuery.On("FindEntryById", mock.Anything, "entry-id").Return(mockChannel(helpers.Result{Data: &entry}))
+	suite.mockWaitlistRepositoryCommand.On("UpdateEntryStatus", mock.Anything, "entry-id", constants.WaitlistStatusWaiting,
+		constants.WaitlistStatusLeft).Return(mockChannel(helpers.Result{Data: &entry}))
+	suite.mockWaitlistRepositoryCommand.On("DecreaseWaiting", mock.Anything, "ticket-id").Return(mockChannel(helpers.Result{Data: &entity.Waitlist{}}))
+
+	// Act
+	result, err := suite.usecase.LeaveWaitlist(suite.ctx, request.LeaveReq{UserId: "user-id", EntryId: "entry-id"})
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), constants.WaitlistStatusLeft, result.Status)
+	suite.mockWaitlistRepositoryCommand.AssertCalled(suite.T(), "DecreaseWaiting", mock.Anything, "ticket-id")
+}
+
+func (suite *CommandUsecaseTestSuite) TestOfferReleasedTicketsInJoinOrder() {
+	// Arrange
+	entries := []entity.WaitlistEntry{
+		getMockEntry("entry-1", 1, 2),
+		getMockEntry("entry-2", 2, 3),
+		getMockEntry("entry-3", 3, 1),
+	}
+	suite.arrangeOffer(entries, 3)
+	suite.mockOrderUsecaseCommand.On("CreateReservation", mock.Anything, mock.Anything).Return(&orderResponse.Reservation{OrderId: "order-id"}, nil)
+
+	// Act
+	result, err := suite.usecase.OfferReleasedTickets(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 2, result.Offered)
+	suite.mockWaitlistRepositoryCommand.AssertCalled(suite.T(), "UpdateEntryOffered", mock.Anything, "entry-1", mock.Anything, mock.Anything)
+	suite.mockWaitlistRepositoryCommand.AssertNotCalled(suite.T(), "UpdateEntryOffered", mock.Anything, "entry-2", mock.Anything, mock.Anything)
+	suite.mockWaitlistRepositoryCommand.AssertCalled(suite.T(), "UpdateEntryOffered", mock.Anything, "entry-3", mock.Anything, mock.Anything)
+	suite.mockOrderUsecaseCommand.AssertCalled(suite.T(), "CreateReservation", mock.Anything, mock.MatchedBy(func(req orderDto.ReservationReq) bool {
+		return req.WaitlistEntryId == "entry-1" && req.HoldDuration == constants.WaitlistHoldDuration
+	}))
+	suite.mockKafkaProducer.AssertNumberOfCalls(suite.T(), "Publish", 2)
+}
+
+func (suite *CommandUsecaseTestSuite) TestOfferReleasedTicketsUserFailurePassesTurn() {
+	// Arrange
+	entries := []entity.WaitlistEntry{
+		getMockEntry("entry-1", 1, 2),
+		getMockEntry("entry-2", 2, 2),
+	}
+	suite.arrangeOffer(entries, 2)
+	suite.mockOrderUsecaseCommand.On("CreateReservation", mock.Anything, mock.MatchedBy(func(req orderDto.ReservationReq) bool {
+		return req.WaitlistEntryId == "entry-1"
+	})).Return(nil, errors.UnprocessableEntity("maximum 4 tickets per user"))
+	suite.mockOrderUsecaseCommand.On("CreateReservation", mock.Anything, mock.Anything).Return(&orderResponse.Reservation{OrderId: "order-id"}, nil)
+
+	// Act
+	result, err := suite.usecase.OfferReleasedTickets(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Offered)
+	suite.mockWaitlistRepositoryCommand.AssertCalled(suite.T(), "UpdateEntryStatus", mock.Anything, "entry-1",
+		constants.WaitlistStatusOffered, constants.WaitlistStatusExpired)
+	suite.mockWaitlistRepositoryCommand.AssertCalled(suite.T(), "UpdateEntryOffered", mock.Anything, "entry-2", mock.Anything, mock.Anything)
+}
+
+func (suite *CommandUsecaseTestSuite) TestOfferReleasedTicketsResolvesPaidOffer() {
+	// Arrange
+	offered := getMockEntry("entry-1", 1, 2)
+	offered.Status = constants.WaitlistStatusOffered
+	offered.OrderId = "order-id"
+	suite.mockWaitlistRepositoryQuery.On("FindOfferedEntries", mock.Anything).Return(mockChannel(helpers.Result{Data: &[]entity.WaitlistEntry{offered}}))
+	suite.mockOrderRepositoryQuery.On("FindOrderById", mock.Anything, "order-id").Return(mockChannel(helpers.Result{Data: &orderEntity.Order{
+		OrderId: "order-id",
+		Status:  constants.OrderStatusPaid,
+	}}))
+	suite.mockWaitlistRepositoryCommand.On("UpdateEntryStatus", mock.Anything, "entry-1", constants.WaitlistStatusOffered,
+		constants.WaitlistStatusClaimed).Return(mockChannel(helpers.Result{Data: &offered}))
+	suite.mockWaitlistRepositoryQuery.On("FindWaitingWaitlists", mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+
+	// Act
+	result, err := suite.usecase.OfferReleasedTickets(suite.ctx)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 1, result.Resolved)
+	assert.Equal(suite.T(), 0, result.Offered)
+}
+
+func (suite *CommandUsecaseTestSuite) arrangeOffer(entries []entity.WaitlistEntry, remaining int) {
+	waitlists := []entity.Waitlist{{TicketId: "ticket-id", EventId: "event-id", CountryCode: "ID", TicketType: "Gold", Waiting: len(entries)}}
+	suite.mockWaitlistRepositoryQuery.On("FindOfferedEntries", mock.Anything).Return(mockChannel(helpers.Result{Data: nil}))
+	suite.mockWaitlistRepositoryQuery.On("FindWaitingWaitlists", mock.Anything).Return(mockChannel(helpers.Result{Data: &waitlists}))
+	suite.mockTicketRepositoryQuery.On("FindTicketByType", mock.Anything, mock.Anything).Return(func(context.Context, ticketRequest.TicketTypeReq) <-chan helpers.Result {
+		return mockChannel(getMockTicket(remaining))
+	})
+	suite.mockWaitlistRepositoryQuery.On("FindEntriesByStatus", mock.Anything, "ticket-id", constants.WaitlistStatusWaiting).Return(mockChannel(helpers.Result{Data: &entries}))
+	suite.mockWaitlistRepositoryCommand.On("UpdateEntryOffered", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(func(context.Context, string, string, time.Time) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &entity.WaitlistEntry{}})
+	})
+	suite.mockWaitlistRepositoryCommand.On("UpdateEntryStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(func(context.Context, string, string, string) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &entity.WaitlistEntry{}})
+	})
+	suite.mockWaitlistRepositoryCommand.On("DecreaseWaiting", mock.Anything, "ticket-id").Return(func(context.Context, string) <-chan helpers.Result {
+		return mockChannel(helpers.Result{Data: &entity.Waitlist{}})
+	})
+	suite.mockKafkaProducer.On("Publish", "concert-waitlist-offered", mock.Anything, mock.Anything)
+	suite.mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockLogger.On("Error", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func getJoinReq(quantity int) request.JoinReq {
+	return request.JoinReq{
+		UserId:      "user-id",
+		EventId:     "event-id",
+		CountryCode: "ID",
+		TicketType:  "Gold",
+		Quantity:    quantity,
+	}
+}
+
+func getMockEntry(entryId string, position int, quantity int) entity.WaitlistEntry {
+	return entity.WaitlistEntry{
+		EntryId:     entryId,
+		TicketId:    "ticket-id",
+		EventId:     "event-id",
+		CountryCode: "ID",
+		TicketType:  "Gold",
+		UserId:      "user-id",
+		Quantity:    quantity,
+		Position:    position,
+		Status:      constants.WaitlistStatusWaiting,
+	}
+}
+
+func getMockTicket(remaining int) helpers.Result {
+	return helpers.Result{
+		Data: &ticketEntity.Ticket{
+			TicketId:       "ticket-id",
+			EventId:        "event-id",
+			TicketType:     "Gold",
+			TotalQuota:     100,
+			TotalRemaining: remaining,
+		},
+	}
+}
+
+func mockChannel(result helpers.Result) <-chan helpers.Result {
+	responseChan := make(chan helpers.Result)
+
+	go func() {
+		responseChan <- result
+		close(responseChan)
+	}()
+
+	return responseChan
+}
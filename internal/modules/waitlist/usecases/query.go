@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"ticket-service/internal/modules/waitlist"
+	"ticket-service/internal/modules/waitlist/models/entity"
+	"ticket-service/internal/modules/waitlist/models/response"
+	"ticket-service/internal/pkg/constants"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/log"
+	"time"
+
+	"go.elastic.co/apm"
+)
+
+type queryUsecase struct {
+	waitlistRepositoryQuery waitlist.MongodbRepositoryQuery
+	logger                  log.Logger
+}
+
+func NewQueryUsecase(wmq waitlist.MongodbRepositoryQuery, log log.Logger) waitlist.UsecaseQuery {
+	return queryUsecase{
+		waitlistRepositoryQuery: wmq,
+		logger:                  log,
+	}
+}
+
+// FindMyEntries returns the entries of a user that are waiting or holding an offer, waiting ones with their position
+func (q queryUsecase) FindMyEntries(origCtx context.Context, userId string) ([]response.Entry, error) {
+	domain := "waitlistUsecase-FindMyEntries"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	resp := <-q.waitlistRepositoryQuery.FindActiveEntriesByUserId(ctx, userId)
+	if resp.Error != nil {
+		msg := "Error query waitlist entry"
+		q.logger.Error(ctx, msg, fmt.Sprintf("%+v", resp.Error))
+		return nil, resp.Error
+	}
+
+	result := make([]response.Entry, 0)
+	if resp.Data == nil {
+		return result, nil
+	}
+
+	entries, ok := resp.Data.(*[]entity.WaitlistEntry)
+	if !ok {
+		return nil, errors.InternalServerError("cannot parsing data")
+	}
+
+	for _, value := range *entries {
+		position := 0
+		if value.Status == constants.WaitlistStatusWaiting {
+			ahead := <-q.waitlistRepositoryQuery.CountWaitingAhead(ctx, value.TicketId, value.Position)
+			if ahead.Error != nil {
+				msg := "Error count waitlist entry"
+				q.logger.Error(ctx, msg, fmt.Sprintf("%+v", ahead.Error))
+				return nil, ahead.Error
+			}
+			position = int(ahead.Count) + 1
+		}
+		result = append(result, *mapEntry(value, position))
+	}
+
+	return result, nil
+}
+
+// CheckPublicSale refuses a normal purchase of a ticket while a waiting entry can be served from the remaining tickets,
+// entries asking for more than is left wait for further releases without holding up the public sale
+func (q queryUsecase) CheckPublicSale(origCtx context.Context, ticketId string, remaining int) error {
+	domain := "waitlistUsecase-CheckPublicSale"
+	span, ctx := apm.StartSpanOptions(origCtx, domain, "function", apm.SpanOptions{
+		Start:  time.Now(),
+		Parent: apm.TraceContext{},
+	})
+	defer span.End()
+
+	held, err := heldForWaitlist(ctx, q.waitlistRepositoryQuery, q.logger, ticketId, remaining)
+	if err != nil {
+		return err
+	}
+
+	if !held {
+		return nil
+	}
+
+	return errors.UnprocessableEntity("released tickets of this tier are held for the waitlist")
+}
@@ -0,0 +1,70 @@
+package usecases_test
+
+import (
+	"context"
+	"testing"
+
+	"ticket-service/internal/modules/waitlist"
+	uc "ticket-service/internal/modules/waitlist/usecases"
+	"ticket-service/internal/pkg/errors"
+	"ticket-service/internal/pkg/helpers"
+	mockwaitlist "ticket-service/mocks/modules/waitlist"
+	mocklog "ticket-service/mocks/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryUsecaseTestSuite struct {
+	suite.Suite
+	mockWaitlistRepositoryQuery *mockwaitlist.MongodbRepositoryQuery
+	mockLogger                  *mocklog.Logger
+	usecase                     waitlist.UsecaseQuery
+	ctx                         context.Context
+}
+
+func (suite *QueryUsecaseTestSuite) SetupTest() {
+	suite.mockWaitlistRepositoryQuery = &mockwaitlist.MongodbRepositoryQuery{}
+	suite.mockLogger = &mocklog.Logger{}
+	suite.ctx = context.Background()
+	suite.usecase = uc.NewQueryUsecase(
+		suite.mockWaitlistRepositoryQuery,
+		suite.mockLogger,
+	)
+}
+
+func TestQueryUsecaseTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryUsecaseTestSuite))
+}
+
+func (suite *QueryUsecaseTestSuite) TestCheckPublicSaleErrHeldForWaitlist() {
+	// Arrange
+	suite.mockWaitlistRepositoryQuery.On("CountServableEntries", mock.Anything, "ticket-id", 2).Return(mockChannel(helpers.Result{Count: 1}))
+
+	// Act
+	err := suite.usecase.CheckPublicSale(suite.ctx, "ticket-id", 2)
+
+	// Assert
+	assert.Equal(suite.T(), errors.UnprocessableEntity("released tickets of this tier are held for the waitlist"), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestCheckPublicSaleOnlyOversizedEntriesWaiting() {
+	// Arrange
+	suite.mockWaitlistRepositoryQuery.On("CountServableEntries", mock.Anything, "ticket-id", 2).Return(mockChannel(helpers.Result{Count: 0}))
+
+	// Act
+	err := suite.usecase.CheckPublicSale(suite.ctx, "ticket-id", 2)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *QueryUsecaseTestSuite) TestCheckPublicSaleSoldOut() {
+	// Act
+	err := suite.usecase.CheckPublicSale(suite.ctx, "ticket-id", 0)
+
+	// Assert
+	assert.NoError(suite.T(), err)
+	suite.mockWaitlistRepositoryQuery.AssertNotCalled(suite.T(), "CountServableEntries", mock.Anything, mock.Anything, mock.Anything)
+}
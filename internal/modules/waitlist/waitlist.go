@@ -0,0 +1,42 @@
+package waitlist
+
+import (
+	"context"
+	"ticket-service/internal/modules/waitlist/models/entity"
+	"ticket-service/internal/modules/waitlist/models/request"
+	"ticket-service/internal/modules/waitlist/models/response"
+	wrapper "ticket-service/internal/pkg/helpers"
+	"time"
+)
+
+type UsecaseCommand interface {
+	JoinWaitlist(origCtx context.Context, payload request.JoinReq) (*response.Entry, error)
+	LeaveWaitlist(origCtx context.Context, payload request.LeaveReq) (*response.Entry, error)
+	OfferReleasedTickets(origCtx context.Context) (*response.Offers, error)
+}
+
+type UsecaseQuery interface {
+	FindMyEntries(origCtx context.Context, userId string) ([]response.Entry, error)
+	CheckPublicSale(origCtx context.Context, ticketId string, remaining int) error
+}
+
+type MongodbRepositoryQuery interface {
+	FindWaitlist(ctx context.Context, ticketId string) <-chan wrapper.Result
+	FindWaitingWaitlists(ctx context.Context) <-chan wrapper.Result
+	FindActiveEntry(ctx context.Context, ticketId string, userId string) <-chan wrapper.Result
+	FindEntryById(ctx context.Context, entryId string) <-chan wrapper.Result
+	FindEntriesByStatus(ctx context.Context, ticketId string, status string) <-chan wrapper.Result
+	FindOfferedEntries(ctx context.Context) <-chan wrapper.Result
+	FindActiveEntriesByUserId(ctx context.Context, userId string) <-chan wrapper.Result
+	CountWaitingAhead(ctx context.Context, ticketId string, position int) <-chan wrapper.Result
+	CountServableEntries(ctx context.Context, ticketId string, quantity int) <-chan wrapper.Result
+}
+
+type MongodbRepositoryCommand interface {
+	InitWaitlist(ctx context.Context, waitlist entity.Waitlist) <-chan wrapper.Result
+	IncreaseWaiting(ctx context.Context, ticketId string) <-chan wrapper.Result
+	DecreaseWaiting(ctx context.Context, ticketId string) <-chan wrapper.Result
+	InsertOneEntry(ctx context.Context, entry entity.WaitlistEntry) <-chan wrapper.Result
+	UpdateEntryStatus(ctx context.Context, entryId string, from string, to string) <-chan wrapper.Result
+	UpdateEntryOffered(ctx context.Context, entryId string, orderId string, holdUntil time.Time) <-chan wrapper.Result
+}
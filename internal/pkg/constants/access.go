@@ -0,0 +1,15 @@
+package constants
+
+import "time"
+
+// online access pass, a pass opens AccessPassOpenBefore the event starts and stays valid AccessPassCloseAfter it
+// for the replay. A viewer keeps a session alive by validating again before AccessSessionTTL runs out
+const (
+	TicketTypeOnline        = `Online`
+	AccessPassPurposeStream = `STREAM`
+	AccessPassOpenBefore    = 2 * time.Hour
+	AccessPassCloseAfter    = 24 * time.Hour
+	AccessPassMaxDevices    = 3
+	AccessPassMaxSessions   = 1
+	AccessSessionTTL        = 90 * time.Second
+)
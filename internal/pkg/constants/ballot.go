@@ -0,0 +1,16 @@
+package constants
+
+// ballot status
+const (
+	BallotStatusOpen   = `OPEN`
+	BallotStatusClosed = `CLOSED`
+)
+
+// ballot entry status, an ENTERED entry takes part in every draw until it wins or the ballot is closed
+const (
+	BallotEntryStatusEntered = `ENTERED`
+	BallotEntryStatusWon     = `WON`
+	BallotEntryStatusClaimed = `CLAIMED`
+	BallotEntryStatusExpired = `EXPIRED`
+	BallotEntryStatusLost    = `LOST`
+)
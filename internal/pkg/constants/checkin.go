@@ -0,0 +1,9 @@
+package constants
+
+// gate scan result
+const (
+	ScanResultAccepted  = `ACCEPTED`
+	ScanResultDuplicate = `DUPLICATE`
+	ScanResultConflict  = `CONFLICT`
+	ScanResultRejected  = `REJECTED`
+)
@@ -0,0 +1,43 @@
+package constants
+
+// NativeCurrency is what every event is priced and settled in, other currencies are only shown
+const NativeCurrency = `USD`
+
+// where an exchange-rate snapshot was loaded from
+const (
+	RateSourceFile  = `FILE`
+	RateSourceAdmin = `ADMIN`
+)
+
+// CountryCurrencies picks the currency shown to a user from the country of their profile
+var CountryCurrencies = map[string]string{
+	"AU": "AUD",
+	"CN": "CNY",
+	"DE": "EUR",
+	"ES": "EUR",
+	"FR": "EUR",
+	"GB": "GBP",
+	"HK": "HKD",
+	"ID": "IDR",
+	"IN": "INR",
+	"IT": "EUR",
+	"JP": "JPY",
+	"KR": "KRW",
+	"MY": "MYR",
+	"NL": "EUR",
+	"PH": "PHP",
+	"SG": "SGD",
+	"TH": "THB",
+	"TW": "TWD",
+	"US": "USD",
+	"VN": "VND",
+}
+
+// ZeroDecimalCurrencies are shown without minor units
+var ZeroDecimalCurrencies = map[string]bool{
+	"IDR": true,
+	"JPY": true,
+	"KRW": true,
+	"TWD": true,
+	"VND": true,
+}
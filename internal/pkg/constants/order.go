@@ -0,0 +1,15 @@
+package constants
+
+import "time"
+
+// order status
+const (
+	OrderStatusPending   = `PENDING`
+	OrderStatusPaid      = `PAID`
+	OrderStatusExpired   = `EXPIRED`
+	OrderStatusCancelled = `CANCELLED`
+	OrderStatusRefunded  = `REFUNDED`
+)
+
+// OrderHoldDuration is how long a reservation keeps its inventory before it expires
+const OrderHoldDuration = 15 * time.Minute
@@ -0,0 +1,30 @@
+package constants
+
+import "time"
+
+// payment status
+const (
+	PaymentStatusPending    = `PENDING`
+	PaymentStatusAuthorized = `AUTHORIZED`
+	PaymentStatusCaptured   = `CAPTURED`
+	PaymentStatusFailed     = `FAILED`
+	PaymentStatusVoided     = `VOIDED`
+	PaymentStatusRefunded   = `REFUNDED`
+)
+
+// payment provider webhook event type
+const (
+	PaymentEventChargeAuthorized = `charge.authorized`
+	PaymentEventChargeFailed     = `charge.failed`
+	PaymentEventChargeRefunded   = `charge.refunded`
+)
+
+// processing status of a received webhook event
+const (
+	WebhookStatusReceived  = `RECEIVED`
+	WebhookStatusProcessed = `PROCESSED`
+	WebhookStatusFailed    = `FAILED`
+)
+
+// HoldExpireInterval is how often every pod expires the reservations that were not paid in time
+const HoldExpireInterval = time.Minute
@@ -0,0 +1,10 @@
+package constants
+
+// presale access code usage
+const (
+	AccessCodeUsageSingle = `SINGLE`
+	AccessCodeUsageMulti  = `MULTI`
+)
+
+// MaxAccessCodesPerImport is how many access codes one bulk import takes
+const MaxAccessCodesPerImport = 5000
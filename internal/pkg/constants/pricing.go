@@ -0,0 +1,33 @@
+package constants
+
+import "time"
+
+// signals a dynamic pricing adjustment can react to
+const (
+	// PricingFactorSellThrough is the share of the quota sold, in percent
+	PricingFactorSellThrough = `SELL_THROUGH`
+	// PricingFactorPace compares the sell-through with the share of the sales window that passed, 100 is on pace
+	PricingFactorPace = `PACE`
+	// PricingFactorHoursToShow counts the whole hours left until the show
+	PricingFactorHoursToShow = `HOURS_TO_SHOW`
+	// PricingFactorWaitlist is how many entries are waiting on the waitlist of the tier
+	PricingFactorWaitlist = `WAITLIST`
+)
+
+// how an adjustment compares its signal with the threshold
+const (
+	PricingOperatorAbove = `ABOVE`
+	PricingOperatorBelow = `BELOW`
+)
+
+// why a price change was logged
+const (
+	PriceChangeReasonRecalculated = `RECALCULATED`
+	PriceChangeReasonDisabled     = `DISABLED`
+)
+
+// PricePhaseDynamic is the phase shown while a pricing rule sets the price of a tier
+const PricePhaseDynamic = `DYNAMIC`
+
+// PricingRecalculateInterval is how often every pod recalculates the dynamic prices
+const PricingRecalculateInterval = 5 * time.Minute
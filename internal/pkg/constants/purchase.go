@@ -0,0 +1,54 @@
+package constants
+
+import "time"
+
+// purchase saga status
+const (
+	SagaStatusRunning      = `RUNNING`
+	SagaStatusCompensating = `COMPENSATING`
+	SagaStatusCompleted    = `COMPLETED`
+	SagaStatusCompensated  = `COMPENSATED`
+	SagaStatusFailed       = `FAILED`
+)
+
+// purchase saga steps, in the order they run
+const (
+	SagaStepReserve      = `RESERVE`
+	SagaStepCharge       = `CHARGE`
+	SagaStepAwaitPayment = `AWAIT_PAYMENT`
+	SagaStepIssue        = `ISSUE`
+	SagaStepNotify       = `NOTIFY`
+)
+
+// status of a single saga step
+const (
+	SagaStepStatusRunning     = `RUNNING`
+	SagaStepStatusDone        = `DONE`
+	SagaStepStatusFailed      = `FAILED`
+	SagaStepStatusCompensated = `COMPENSATED`
+)
+
+// SagaSteps is the forward order of the purchase saga, compensation walks it backwards
+var SagaSteps = []string{SagaStepReserve, SagaStepCharge, SagaStepAwaitPayment, SagaStepIssue, SagaStepNotify}
+
+// SagaStepTimeouts bounds how long a step may keep retrying or waiting before the saga is compensated
+var SagaStepTimeouts = map[string]time.Duration{
+	SagaStepReserve:      30 * time.Second,
+	SagaStepCharge:       30 * time.Second,
+	SagaStepAwaitPayment: OrderHoldDuration,
+	SagaStepIssue:        2 * time.Minute,
+	SagaStepNotify:       time.Minute,
+}
+
+const (
+	// SagaLeaseDuration is how long a worker owns a saga, a saga of a crashed pod is picked up once its lease lapses
+	SagaLeaseDuration = time.Minute
+	// SagaRetryDelay is the pause before a failed or waiting step is tried again
+	SagaRetryDelay = 10 * time.Second
+	// SagaMaxAttempts is how often a step or compensation is retried, a compensation that keeps failing marks the saga FAILED
+	SagaMaxAttempts = 5
+	// SagaResumeInterval is how often every pod looks for sagas to continue
+	SagaResumeInterval = 15 * time.Second
+	// SagaStuckAfter is the default age after which an unfinished saga is reported as stuck
+	SagaStuckAfter = 30 * time.Minute
+)
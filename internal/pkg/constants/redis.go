@@ -9,4 +9,17 @@ const (
 	RedisKeyLoginAttempt        = `LOGIN-ATTEMPT`
 	RedisKeyOtpRegister         = `OTP-REGISTER`
 	RedisKeyOtpLogin            = `OTP-LOGIN`
+	RedisKeyReferenceContinents = `REFERENCE-CONTINENTS`
+	RedisKeyReferenceCountries  = `REFERENCE-COUNTRIES`
+	RedisKeyReferenceCities     = `REFERENCE-CITIES`
+	RedisKeyReferenceVenues     = `REFERENCE-VENUES`
+	RedisKeyReferenceVenue      = `REFERENCE-VENUE`
+	RedisKeyTour                = `TOUR`
+	RedisKeyAccessDevice        = `ACCESS-DEVICE`
+	RedisKeyAccessSession       = `ACCESS-SESSION`
+)
+
+// channel redis pub/sub
+const (
+	RedisChannelTicketAvailability = `TICKET-AVAILABILITY`
 )
@@ -0,0 +1,21 @@
+package constants
+
+// refund policy type of an event
+const (
+	RefundPolicyFull    = `FULL`
+	RefundPolicyPartial = `PARTIAL`
+	RefundPolicyNone    = `NONE`
+)
+
+// refund status
+const (
+	RefundStatusPendingApproval = `PENDING_APPROVAL`
+	RefundStatusApproved        = `APPROVED`
+	RefundStatusRejected        = `REJECTED`
+)
+
+// who asked for the refund
+const (
+	RefundInitiatorUser      = `USER`
+	RefundInitiatorOrganiser = `ORGANISER`
+)
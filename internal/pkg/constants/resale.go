@@ -0,0 +1,14 @@
+package constants
+
+// resale listing status, a RESERVED listing is held for the buyer until their order is paid or expires
+const (
+	ListingStatusListed    = `LISTED`
+	ListingStatusReserved  = `RESERVED`
+	ListingStatusSold      = `SOLD`
+	ListingStatusCancelled = `CANCELLED`
+)
+
+// resale payout status, payouts are executed outside this service
+const (
+	PayoutStatusPending = `PENDING`
+)
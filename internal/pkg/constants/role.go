@@ -0,0 +1,8 @@
+package constants
+
+// user role
+const (
+	RoleAdmin = `admin`
+	RoleUser  = `user`
+	RoleGate  = `gate`
+)
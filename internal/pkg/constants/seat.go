@@ -0,0 +1,13 @@
+package constants
+
+// seat status, a HELD seat is back on sale once its hold expires even when nobody released it.
+// REMOVED seats were dropped from the seat map and are never sold
+const (
+	SeatStatusAvailable = `AVAILABLE`
+	SeatStatusHeld      = `HELD`
+	SeatStatusSold      = `SOLD`
+	SeatStatusRemoved   = `REMOVED`
+)
+
+// SeatHoldAttempts is how many times best-available selection picks again when another order took its seats first
+const SeatHoldAttempts = 3
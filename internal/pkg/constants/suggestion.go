@@ -0,0 +1,12 @@
+package constants
+
+// alternative countries suggested when a country sells out, travel time is estimated by road up to
+// GroundTravelMaxKm and by air beyond it
+const (
+	SuggestionLimit     = 3
+	SuggestionDiscount  = 20
+	GroundTravelMaxKm   = 500
+	GroundSpeedKmh      = 80
+	FlightSpeedKmh      = 800
+	FlightOverheadHours = 3
+)
@@ -0,0 +1,52 @@
+package constants
+
+import "time"
+
+// issued ticket status
+const (
+	IssuedTicketStatusActive  = `ACTIVE`
+	IssuedTicketStatusUsed    = `USED`
+	IssuedTicketStatusRevoked = `REVOKED`
+	IssuedTicketStatusListed  = `LISTED`
+)
+
+// availability summary sort fields and page size
+const (
+	AvailabilitySortCountry     = `country`
+	AvailabilitySortRemaining   = `remaining`
+	AvailabilitySortSellThrough = `sellThrough`
+	AvailabilityPageSize        = 20
+)
+
+// live availability stream, a client that falls StreamBufferSize deltas behind skips to the newest ones
+const (
+	StreamHeartbeatInterval     = 15 * time.Second
+	StreamMaxConnections        = 5000
+	StreamMaxConnectionsPerUser = 3
+	StreamBufferSize            = 16
+)
+
+// ticket list sort fields, price sorts by the price on sale now
+const (
+	TicketSortPrice      = `price`
+	TicketSortRemaining  = `remaining`
+	TicketSortTicketType = `ticketType`
+	TicketPageSize       = 20
+)
+
+// ticket search sort fields, a search reads at most SearchMaxGroups event and country groups before paging
+const (
+	SearchSortRelevance = `relevance`
+	SearchSortDate      = `date`
+	SearchSortPrice     = `price`
+	SearchPageSize      = 20
+	SearchMaxGroups     = 500
+	SearchIndexName     = `ticket_search_text`
+)
+
+// how an issued ticket owner got the ticket
+const (
+	OwnershipViaPurchase = `PURCHASE`
+	OwnershipViaTransfer = `TRANSFER`
+	OwnershipViaResale   = `RESALE`
+)
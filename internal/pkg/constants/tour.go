@@ -0,0 +1,9 @@
+package constants
+
+import "time"
+
+// a cached tour page trails the live availability by at most TourCacheTTL
+const (
+	TourPageSize = 10
+	TourCacheTTL = 30 * time.Second
+)
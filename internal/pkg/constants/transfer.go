@@ -0,0 +1,15 @@
+package constants
+
+import "time"
+
+// transfer status of an issued ticket
+const (
+	TransferStatusPending   = `PENDING`
+	TransferStatusAccepted  = `ACCEPTED`
+	TransferStatusCancelled = `CANCELLED`
+	TransferStatusExpired   = `EXPIRED`
+	TransferStatusFailed    = `FAILED`
+)
+
+// TransferAcceptWindow is how long the recipient has to accept before the transfer lapses
+const TransferAcceptWindow = 48 * time.Hour
@@ -0,0 +1,7 @@
+package constants
+
+import "time"
+
+// ReferenceCacheTTL bounds how long a cached list still shows a city or country renamed by an import,
+// venue writes clear the venue keys right away
+const ReferenceCacheTTL = 1 * time.Hour
@@ -0,0 +1,16 @@
+package constants
+
+// voucher discount type
+const (
+	VoucherTypePercentage = `PERCENTAGE`
+	VoucherTypeFixed      = `FIXED`
+)
+
+// voucher redemption status
+const (
+	RedemptionStatusRedeemed = `REDEEMED`
+	RedemptionStatusReleased = `RELEASED`
+)
+
+// MaxVouchersPerOrder is how many codes can be stacked on one order
+const MaxVouchersPerOrder = 3
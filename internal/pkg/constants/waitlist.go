@@ -0,0 +1,18 @@
+package constants
+
+import "time"
+
+// waitlist entry status, a WAITING entry is offered a hold once enough tickets of its tier are released
+const (
+	WaitlistStatusWaiting = `WAITING`
+	WaitlistStatusOffered = `OFFERED`
+	WaitlistStatusClaimed = `CLAIMED`
+	WaitlistStatusExpired = `EXPIRED`
+	WaitlistStatusLeft    = `LEFT`
+)
+
+// WaitlistHoldDuration is how long a waitlisted user has to pay the hold they were offered
+const WaitlistHoldDuration = 2 * time.Hour
+
+// WaitlistOfferInterval is how often every pod offers released tickets to the waitlist
+const WaitlistOfferInterval = time.Minute
@@ -419,6 +419,41 @@ func (m MongoDBLogger) InsertOne(payload InsertOne, ctx context.Context) <-chan
 	return output
 }
 
+type CreateIndex struct {
+	CollectionName string
+	Keys           interface{}
+	Options        *options.IndexOptions
+}
+
+// CreateIndex is a no-op when the same index already exists, an index with the same name but other keys is an error
+func (m MongoDBLogger) CreateIndex(payload CreateIndex, ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+
+		collection := m.mongoClient.Database(m.dbName).Collection(payload.CollectionName)
+		name, err := collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    payload.Keys,
+			Options: payload.Options,
+		})
+		if err != nil {
+			msg := fmt.Sprintf("Error Mongodb Connection : %s", err.Error())
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+			output <- wrapper.Result{
+				Error: errors.InternalServerError("Error mongodb connection"),
+			}
+			return
+		}
+
+		output <- wrapper.Result{
+			Data: name,
+		}
+	}()
+
+	return output
+}
+
 func (m MongoDBLogger) UpdateOne(payload UpdateOne, ctx context.Context) <-chan wrapper.Result {
 	output := make(chan wrapper.Result)
 
@@ -474,6 +509,53 @@ func (m MongoDBLogger) UpdateOne(payload UpdateOne, ctx context.Context) <-chan
 	return output
 }
 
+// UpdateMany sets Document on every row matching Filter, Data is the number of rows it modified
+func (m MongoDBLogger) UpdateMany(payload UpdateOne, ctx context.Context) <-chan wrapper.Result {
+	output := make(chan wrapper.Result)
+
+	go func() {
+		defer close(output)
+		start := time.Now()
+
+		collection := m.mongoClient.Database(m.dbName).Collection(payload.CollectionName)
+
+		update, err := MarshalUpdate(payload.Document)
+		if err != nil {
+			msg := fmt.Sprintf("Error Mongodb: %s", err.Error())
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+			output <- wrapper.Result{
+				Error: errors.InternalServerError("Error mongodb"),
+			}
+			return
+		}
+
+		doc := bson.D{{Key: "$set", Value: update}}
+		res, err := collection.UpdateMany(ctx, payload.Filter, doc)
+		if err != nil {
+			msg := fmt.Sprintf("Error Mongodb Connection : %s", err.Error())
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+			output <- wrapper.Result{
+				Error: errors.InternalServerError("Error mongodb connection"),
+			}
+			return
+		}
+
+		finish := time.Now()
+
+		if finish.Sub(start).Seconds() > 10 {
+			j, _ := json.Marshal(payload.Filter)
+			msg := fmt.Sprintf("slow query: %v second, query: %s", finish.Sub(start).Seconds(), string(j))
+			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
+		}
+
+		output <- wrapper.Result{
+			Data: res.ModifiedCount,
+		}
+	}()
+
+	return output
+}
+
 type Aggregate struct {
 	Result         interface{}
 	CollectionName string
@@ -529,11 +611,26 @@ type FindOneAndUpdate struct {
 	Upsert         bool
 }
 
+// MarshalUpdate converts an update to the document FindOneAndUpdate sends, an aggregation pipeline is refused
+func MarshalUpdate(update interface{}) (bson.M, error) {
+	pByte, err := bson.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bson.M
+	if err := bson.Unmarshal(pByte, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
 // FindOneAndUpdate executes a findAndModify command to update at most one document in the collection and returns the document BEFORE or AFTER updating.
 func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.ReturnDocument, ctx context.Context) <-chan wrapper.Result {
 	output := make(chan wrapper.Result)
 
 	go func() {
+		defer close(output)
 		start := time.Now()
 
 		wc := writeconcern.Majority()
@@ -541,23 +638,14 @@ func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.Ret
 		txnOpts := options.Transaction().SetWriteConcern(wc).SetReadConcern(rc)
 
 		collection := m.mongoClient.Database(m.dbName).Collection(payload.CollectionName, options.Collection().SetReadPreference(readpref.Primary()))
-		pByte, err := bson.Marshal(payload.Update)
-		if err != nil {
-			msg := fmt.Sprintf("Error Mongodb: %s", err.Error())
-			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
-			output <- wrapper.Result{
-				Error: errors.InternalServerError("Error mongodb connection"),
-			}
-		}
-
-		var update bson.M
-		err = bson.Unmarshal(pByte, &update)
+		update, err := MarshalUpdate(payload.Update)
 		if err != nil {
 			msg := fmt.Sprintf("Error Mongodb: %s", err.Error())
 			m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
 			output <- wrapper.Result{
 				Error: errors.InternalServerError("Error mongodb connection"),
 			}
+			return
 		}
 
 		callback := func(sessCtx mongo.SessionContext) (interface{}, error) {
@@ -565,8 +653,11 @@ func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.Ret
 			// transaction.
 			opts := options.FindOneAndUpdate().SetUpsert(payload.Upsert).SetReturnDocument(rd)
 			res := collection.FindOneAndUpdate(ctx, payload.Filter, update, opts)
+			if res.Err() == mongo.ErrNoDocuments {
+				return nil, nil
+			}
 			if res.Err() != nil {
-				msg := fmt.Sprintf("Error Mongodb: %s", err.Error())
+				msg := fmt.Sprintf("Error Mongodb: %s", res.Err().Error())
 				m.logger.Error(ctx, msg, fmt.Sprintf("%+v", payload))
 				return nil, errors.InternalServerError("Error mongodb connection")
 			}
@@ -586,6 +677,7 @@ func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.Ret
 			output <- wrapper.Result{
 				Error: errors.InternalServerError("Error mongodb session"),
 			}
+			return
 		}
 		defer session.EndSession(context.Background())
 
@@ -596,13 +688,20 @@ func (m MongoDBLogger) FindOneAndUpdate(payload FindOneAndUpdate, rd options.Ret
 			output <- wrapper.Result{
 				Error: errors.InternalServerError("Error mongodb transaction"),
 			}
+			return
 		}
 		rs, _ := json.Marshal(result)
 		fmt.Println("-----Result FindOneAndUpdate------")
 		fmt.Println(string(rs))
 
-		output <- wrapper.Result{
-			Data: payload.Result,
+		if result == nil {
+			output <- wrapper.Result{
+				Data: nil,
+			}
+		} else {
+			output <- wrapper.Result{
+				Data: payload.Result,
+			}
 		}
 
 		finish := time.Now()
@@ -627,6 +726,8 @@ type Collections interface {
 	UpsertOne(payload UpdateOne, ctx context.Context) <-chan wrapper.Result
 	InsertOne(payload InsertOne, ctx context.Context) <-chan wrapper.Result
 	UpdateOne(payload UpdateOne, ctx context.Context) <-chan wrapper.Result
+	UpdateMany(payload UpdateOne, ctx context.Context) <-chan wrapper.Result
 	Aggregate(payload Aggregate, ctx context.Context) <-chan wrapper.Result
+	CreateIndex(payload CreateIndex, ctx context.Context) <-chan wrapper.Result
 	Close(ctx context.Context) error
 }
@@ -0,0 +1,40 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	QrFormatPng = `png`
+	QrFormatSvg = `svg`
+)
+
+func GenerateQrPng(content string, size int) ([]byte, error) {
+	return qrcode.Encode(content, qrcode.Medium, size)
+}
+
+// GenerateQrSvg draws one rect per dark module, the viewBox keeps it sharp at any size
+func GenerateQrSvg(content string) ([]byte, error) {
+	qr, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return nil, err
+	}
+
+	bitmap := qr.Bitmap()
+	var svg strings.Builder
+	svg.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, len(bitmap), len(bitmap)))
+	svg.WriteString(`<rect width="100%" height="100%" fill="#fff"/>`)
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				svg.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="1" height="1"/>`, x, y))
+			}
+		}
+	}
+	svg.WriteString(`</svg>`)
+
+	return []byte(svg.String()), nil
+}
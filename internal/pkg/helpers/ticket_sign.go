@@ -0,0 +1,119 @@
+package helpers
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"ticket-service/internal/pkg/errors"
+)
+
+// ticket keys are kept apart from the jwt keys so a leaked QR key can't mint access tokens
+var (
+	ticketSignKey   ed25519.PrivateKey
+	ticketVerifyKey ed25519.PublicKey
+)
+
+type TicketSignImpl struct{}
+
+// TicketPayload is a gate QR code when Purpose is empty, an online access pass also carries its unix validity window
+type TicketPayload struct {
+	TicketId   string `json:"tid"`
+	EventId    string `json:"eid"`
+	TicketType string `json:"tt"`
+	UserId     string `json:"uid"`
+	Version    int    `json:"v"`
+	Purpose    string `json:"p,omitempty"`
+	NotBefore  int64  `json:"nbf,omitempty"`
+	ExpiresAt  int64  `json:"exp,omitempty"`
+}
+
+func (t *TicketSignImpl) InitConfig(privateKeyConf string, publicKeyConf string) {
+	privateKeyByte, err := base64.StdEncoding.DecodeString(privateKeyConf)
+	if err != nil {
+		panic("Error reading ticket private key file")
+	}
+
+	privateBlock, _ := pem.Decode([]byte(strings.ReplaceAll(string(privateKeyByte), "\\n", "\n")))
+	if privateBlock == nil {
+		panic("Ticket Private Key cannot Verify")
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(privateBlock.Bytes)
+	if err != nil {
+		panic("Ticket Private Key cannot Verify")
+	}
+	signKey, ok := privateKey.(ed25519.PrivateKey)
+	if !ok {
+		panic("Ticket Private Key must be ed25519")
+	}
+
+	publicKeyByte, err := base64.StdEncoding.DecodeString(publicKeyConf)
+	if err != nil {
+		panic("Error reading ticket public key file")
+	}
+
+	publicBlock, _ := pem.Decode([]byte(strings.ReplaceAll(string(publicKeyByte), "\\n", "\n")))
+	if publicBlock == nil {
+		panic("Ticket Public Key cannot Verify")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(publicBlock.Bytes)
+	if err != nil {
+		panic("Ticket Public Key cannot Verify")
+	}
+	verifyKey, ok := publicKey.(ed25519.PublicKey)
+	if !ok {
+		panic("Ticket Public Key must be ed25519")
+	}
+
+	ticketSignKey = signKey
+	ticketVerifyKey = verifyKey
+}
+
+// SignTicket returns base64url(payload).base64url(signature), short enough to keep the QR code scannable
+func (t *TicketSignImpl) SignTicket(payload TicketPayload) (string, error) {
+	if ticketSignKey == nil {
+		return "", errors.InternalServerError("ticket sign key is not initialized")
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", errors.InternalServerError(err.Error())
+	}
+	encodedBody := base64.RawURLEncoding.EncodeToString(body)
+	signature := ed25519.Sign(ticketSignKey, []byte(encodedBody))
+
+	return encodedBody + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (t *TicketSignImpl) VerifyTicket(token string) (*TicketPayload, error) {
+	if ticketVerifyKey == nil {
+		return nil, errors.InternalServerError("ticket verify key is not initialized")
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, errors.BadRequest("Invalid ticket format")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !ed25519.Verify(ticketVerifyKey, []byte(parts[0]), signature) {
+		return nil, errors.ForbiddenError("Invalid ticket signature")
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.BadRequest("Invalid ticket format")
+	}
+
+	var payload TicketPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, errors.BadRequest("Invalid ticket format")
+	}
+
+	return &payload, nil
+}
+
+type TicketSigner interface {
+	SignTicket(payload TicketPayload) (string, error)
+	VerifyTicket(token string) (*TicketPayload, error)
+}
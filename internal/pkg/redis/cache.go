@@ -0,0 +1,25 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// GetCache reads a value cached as json, a miss or an unreadable value returns false so the caller reads the source
+func GetCache(ctx context.Context, client Collections, key string, result interface{}) bool {
+	cached, err := client.Get(ctx, key).Result()
+	if err != nil || cached == "" {
+		return false
+	}
+	return json.Unmarshal([]byte(cached), result) == nil
+}
+
+// SetCache caches a value as json, a failed write only costs the next reader a trip to the source
+func SetCache(ctx context.Context, client Collections, key string, value interface{}, expiration time.Duration) {
+	marshaled, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	client.Set(ctx, key, marshaled, expiration)
+}
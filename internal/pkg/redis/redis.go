@@ -75,10 +75,13 @@ func InitConnection(redisDB, redisHost, redisPort, redisPassword string, appConf
 type Collections interface {
 	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
 	EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd
+	ScriptLoad(ctx context.Context, script string) *redis.StringCmd
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
 	Conn(ctx context.Context) *redis.Conn
 	Get(ctx context.Context, key string) *redis.StringCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd
+	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
 
 	Close() error
 }
@@ -91,6 +94,10 @@ func (r *RedisClient) EvalSha(ctx context.Context, sha1 string, keys []string, a
 	return r.Client.(*redis.Client).EvalSha(ctx, sha1, keys, args...)
 }
 
+func (r *RedisClient) ScriptLoad(ctx context.Context, script string) *redis.StringCmd {
+	return r.Client.(*redis.Client).ScriptLoad(ctx, script)
+}
+
 func (r *RedisClient) Del(ctx context.Context, keys ...string) *redis.IntCmd {
 	return r.Client.(*redis.Client).Del(ctx, keys...)
 }
@@ -107,6 +114,14 @@ func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, ex
 	return r.Client.(*redis.Client).Set(ctx, key, value, expiration)
 }
 
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) *redis.IntCmd {
+	return r.Client.(*redis.Client).Publish(ctx, channel, message)
+}
+
+func (r *RedisClient) Subscribe(ctx context.Context, channels ...string) *redis.PubSub {
+	return r.Client.(*redis.Client).Subscribe(ctx, channels...)
+}
+
 func (r *RedisClient) Close() error {
 	switch c := r.Client.(type) {
 	case *redis.Client:
@@ -0,0 +1,70 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/access/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// CreateUniqueIndexes provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryCommand) CreateUniqueIndexes(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUniqueIndexes")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertPass provides a mock function with given fields: ctx, pass
+func (_m *MongodbRepositoryCommand) UpsertPass(ctx context.Context, pass entity.Pass) <-chan helpers.Result {
+	ret := _m.Called(ctx, pass)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPass")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Pass) <-chan helpers.Result); ok {
+		r0 = rf(ctx, pass)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,109 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/access/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/access/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// IssuePass provides a mock function with given fields: origCtx, issuedTicketId
+func (_m *UsecaseCommand) IssuePass(origCtx context.Context, issuedTicketId string) (*response.Pass, error) {
+	ret := _m.Called(origCtx, issuedTicketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssuePass")
+	}
+
+	var r0 *response.Pass
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.Pass, error)); ok {
+		return rf(origCtx, issuedTicketId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Pass); ok {
+		r0 = rf(origCtx, issuedTicketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Pass)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, issuedTicketId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokePass provides a mock function with given fields: origCtx, issuedTicketId
+func (_m *UsecaseCommand) RevokePass(origCtx context.Context, issuedTicketId string) error {
+	ret := _m.Called(origCtx, issuedTicketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokePass")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(origCtx, issuedTicketId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ValidatePass provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ValidatePass(origCtx context.Context, payload request.ValidateReq) (*response.Validation, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidatePass")
+	}
+
+	var r0 *response.Validation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ValidateReq) (*response.Validation, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ValidateReq) *response.Validation); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Validation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ValidateReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
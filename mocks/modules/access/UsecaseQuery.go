@@ -0,0 +1,61 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/access/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/access/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindPass provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindPass(origCtx context.Context, payload request.PassReq) (*response.Pass, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPass")
+	}
+
+	var r0 *response.Pass
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.PassReq) (*response.Pass, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.PassReq) *response.Pass); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Pass)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.PassReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,212 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/ballot/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// CreateUniqueIndexes provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryCommand) CreateUniqueIndexes(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUniqueIndexes")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// IncreaseBallotRound provides a mock function with given fields: ctx, ballotId, round
+func (_m *MongodbRepositoryCommand) IncreaseBallotRound(ctx context.Context, ballotId string, round int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ballotId, round)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseBallotRound")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ballotId, round)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOneDraw provides a mock function with given fields: ctx, draw
+func (_m *MongodbRepositoryCommand) InsertOneDraw(ctx context.Context, draw entity.BallotDraw) <-chan helpers.Result {
+	ret := _m.Called(ctx, draw)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneDraw")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BallotDraw) <-chan helpers.Result); ok {
+		r0 = rf(ctx, draw)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOneEntry provides a mock function with given fields: ctx, entry
+func (_m *MongodbRepositoryCommand) InsertOneEntry(ctx context.Context, entry entity.BallotEntry) <-chan helpers.Result {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneEntry")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.BallotEntry) <-chan helpers.Result); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateBallotClosed provides a mock function with given fields: ctx, ballotId
+func (_m *MongodbRepositoryCommand) UpdateBallotClosed(ctx context.Context, ballotId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ballotId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBallotClosed")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ballotId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateEntryClaimed provides a mock function with given fields: ctx, entryId, orderId, now
+func (_m *MongodbRepositoryCommand) UpdateEntryClaimed(ctx context.Context, entryId string, orderId string, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, entryId, orderId, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEntryClaimed")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, entryId, orderId, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateEntryStatus provides a mock function with given fields: ctx, entryId, from, to
+func (_m *MongodbRepositoryCommand) UpdateEntryStatus(ctx context.Context, entryId string, from string, to string) <-chan helpers.Result {
+	ret := _m.Called(ctx, entryId, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEntryStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, entryId, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateEntryWon provides a mock function with given fields: ctx, entryId, round, claimBy
+func (_m *MongodbRepositoryCommand) UpdateEntryWon(ctx context.Context, entryId string, round int, claimBy time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, entryId, round, claimBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEntryWon")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, entryId, round, claimBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertBallot provides a mock function with given fields: ctx, _a1
+func (_m *MongodbRepositoryCommand) UpsertBallot(ctx context.Context, _a1 entity.Ballot) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertBallot")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Ballot) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// FindActiveBallotByEventId provides a mock function with given fields: ctx, eventId, now
+func (_m *MongodbRepositoryQuery) FindActiveBallotByEventId(ctx context.Context, eventId string, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveBallotByEventId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindBallotById provides a mock function with given fields: ctx, ballotId
+func (_m *MongodbRepositoryQuery) FindBallotById(ctx context.Context, ballotId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ballotId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBallotById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ballotId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindDraw provides a mock function with given fields: ctx, ballotId, round
+func (_m *MongodbRepositoryQuery) FindDraw(ctx context.Context, ballotId string, round int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ballotId, round)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDraw")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ballotId, round)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEntriesByStatus provides a mock function with given fields: ctx, ballotId, status
+func (_m *MongodbRepositoryQuery) FindEntriesByStatus(ctx context.Context, ballotId string, status string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ballotId, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEntriesByStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ballotId, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEntry provides a mock function with given fields: ctx, ballotId, userId
+func (_m *MongodbRepositoryQuery) FindEntry(ctx context.Context, ballotId string, userId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ballotId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEntry")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ballotId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
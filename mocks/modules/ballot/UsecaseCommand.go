@@ -0,0 +1,183 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	modelsresponse "ticket-service/internal/modules/ballot/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/ballot/models/request"
+
+	response "ticket-service/internal/modules/order/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// ClaimEntry provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ClaimEntry(origCtx context.Context, payload request.MyEntryReq) (*response.Reservation, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimEntry")
+	}
+
+	var r0 *response.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.MyEntryReq) (*response.Reservation, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.MyEntryReq) *response.Reservation); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.MyEntryReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseBallot provides a mock function with given fields: origCtx, ballotId
+func (_m *UsecaseCommand) CloseBallot(origCtx context.Context, ballotId string) (*modelsresponse.Ballot, error) {
+	ret := _m.Called(origCtx, ballotId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseBallot")
+	}
+
+	var r0 *modelsresponse.Ballot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*modelsresponse.Ballot, error)); ok {
+		return rf(origCtx, ballotId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *modelsresponse.Ballot); ok {
+		r0 = rf(origCtx, ballotId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*modelsresponse.Ballot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, ballotId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DrawBallot provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) DrawBallot(origCtx context.Context, payload request.DrawReq) (*modelsresponse.Draw, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DrawBallot")
+	}
+
+	var r0 *modelsresponse.Draw
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.DrawReq) (*modelsresponse.Draw, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.DrawReq) *modelsresponse.Draw); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*modelsresponse.Draw)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.DrawReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnterBallot provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) EnterBallot(origCtx context.Context, payload request.EntryReq) (*modelsresponse.Entry, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnterBallot")
+	}
+
+	var r0 *modelsresponse.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.EntryReq) (*modelsresponse.Entry, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.EntryReq) *modelsresponse.Entry); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*modelsresponse.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.EntryReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertBallot provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertBallot(origCtx context.Context, payload request.BallotReq) (*modelsresponse.Ballot, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertBallot")
+	}
+
+	var r0 *modelsresponse.Ballot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.BallotReq) (*modelsresponse.Ballot, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.BallotReq) *modelsresponse.Ballot); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*modelsresponse.Ballot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.BallotReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
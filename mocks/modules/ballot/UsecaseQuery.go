@@ -0,0 +1,109 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/ballot/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/ballot/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// CheckDirectSale provides a mock function with given fields: origCtx, eventId
+func (_m *UsecaseQuery) CheckDirectSale(origCtx context.Context, eventId string) error {
+	ret := _m.Called(origCtx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckDirectSale")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(origCtx, eventId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindDraw provides a mock function with given fields: origCtx, ballotId, round
+func (_m *UsecaseQuery) FindDraw(origCtx context.Context, ballotId string, round int) (*response.Draw, error) {
+	ret := _m.Called(origCtx, ballotId, round)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDraw")
+	}
+
+	var r0 *response.Draw
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*response.Draw, error)); ok {
+		return rf(origCtx, ballotId, round)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *response.Draw); ok {
+		r0 = rf(origCtx, ballotId, round)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Draw)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(origCtx, ballotId, round)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMyEntry provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindMyEntry(origCtx context.Context, payload request.MyEntryReq) (*response.Entry, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMyEntry")
+	}
+
+	var r0 *response.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.MyEntryReq) (*response.Entry, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.MyEntryReq) *response.Entry); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.MyEntryReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,50 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/checkin/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// InsertOneScan provides a mock function with given fields: ctx, scan
+func (_m *MongodbRepositoryCommand) InsertOneScan(ctx context.Context, scan entity.Scan) <-chan helpers.Result {
+	ret := _m.Called(ctx, scan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneScan")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Scan) <-chan helpers.Result); ok {
+		r0 = rf(ctx, scan)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
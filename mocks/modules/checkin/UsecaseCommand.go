@@ -0,0 +1,91 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/checkin/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/checkin/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// ScanTicket provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ScanTicket(origCtx context.Context, payload request.ScanReq) (*response.Scan, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanTicket")
+	}
+
+	var r0 *response.Scan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ScanReq) (*response.Scan, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ScanReq) *response.Scan); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Scan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ScanReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SyncScans provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) SyncScans(origCtx context.Context, payload request.SyncReq) (*response.Sync, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncScans")
+	}
+
+	var r0 *response.Sync
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.SyncReq) (*response.Sync, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.SyncReq) *response.Sync); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Sync)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.SyncReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
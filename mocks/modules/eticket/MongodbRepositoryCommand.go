@@ -0,0 +1,171 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/eticket/models/entity"
+
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// CreateUniqueIndexes provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryCommand) CreateUniqueIndexes(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUniqueIndexes")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InitIssuedTicket provides a mock function with given fields: ctx, issuedTicket
+func (_m *MongodbRepositoryCommand) InitIssuedTicket(ctx context.Context, issuedTicket entity.IssuedTicket) <-chan helpers.Result {
+	ret := _m.Called(ctx, issuedTicket)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitIssuedTicket")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.IssuedTicket) <-chan helpers.Result); ok {
+		r0 = rf(ctx, issuedTicket)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateIssuedTicketOwner provides a mock function with given fields: ctx, payload, ownership
+func (_m *MongodbRepositoryCommand) UpdateIssuedTicketOwner(ctx context.Context, payload entity.IssuedTicket, ownership entity.Ownership) <-chan helpers.Result {
+	ret := _m.Called(ctx, payload, ownership)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIssuedTicketOwner")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.IssuedTicket, entity.Ownership) <-chan helpers.Result); ok {
+		r0 = rf(ctx, payload, ownership)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateIssuedTicketResold provides a mock function with given fields: ctx, payload, ownership
+func (_m *MongodbRepositoryCommand) UpdateIssuedTicketResold(ctx context.Context, payload entity.IssuedTicket, ownership entity.Ownership) <-chan helpers.Result {
+	ret := _m.Called(ctx, payload, ownership)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIssuedTicketResold")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.IssuedTicket, entity.Ownership) <-chan helpers.Result); ok {
+		r0 = rf(ctx, payload, ownership)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateIssuedTicketRevoked provides a mock function with given fields: ctx, issuedTicketId
+func (_m *MongodbRepositoryCommand) UpdateIssuedTicketRevoked(ctx context.Context, issuedTicketId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, issuedTicketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIssuedTicketRevoked")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, issuedTicketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateIssuedTicketStatus provides a mock function with given fields: ctx, payload, fromStatus
+func (_m *MongodbRepositoryCommand) UpdateIssuedTicketStatus(ctx context.Context, payload entity.IssuedTicket, fromStatus string) <-chan helpers.Result {
+	ret := _m.Called(ctx, payload, fromStatus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIssuedTicketStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.IssuedTicket, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, payload, fromStatus)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateIssuedTicketUsed provides a mock function with given fields: ctx, payload
+func (_m *MongodbRepositoryCommand) UpdateIssuedTicketUsed(ctx context.Context, payload entity.IssuedTicket) <-chan helpers.Result {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIssuedTicketUsed")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.IssuedTicket) <-chan helpers.Result); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
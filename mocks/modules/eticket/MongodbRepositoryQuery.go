@@ -0,0 +1,90 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// FindIssuedTicketById provides a mock function with given fields: ctx, issuedTicketId
+func (_m *MongodbRepositoryQuery) FindIssuedTicketById(ctx context.Context, issuedTicketId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, issuedTicketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIssuedTicketById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, issuedTicketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindIssuedTicketsByOrderId provides a mock function with given fields: ctx, orderId
+func (_m *MongodbRepositoryQuery) FindIssuedTicketsByOrderId(ctx context.Context, orderId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIssuedTicketsByOrderId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindIssuedTicketsByUserId provides a mock function with given fields: ctx, userId
+func (_m *MongodbRepositoryQuery) FindIssuedTicketsByUserId(ctx context.Context, userId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIssuedTicketsByUserId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
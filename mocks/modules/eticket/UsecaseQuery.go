@@ -0,0 +1,92 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/eticket/models/request"
+
+	response "ticket-service/internal/modules/eticket/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindMyTicketQr provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindMyTicketQr(origCtx context.Context, payload request.TicketQrReq) (*response.TicketQr, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMyTicketQr")
+	}
+
+	var r0 *response.TicketQr
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.TicketQrReq) (*response.TicketQr, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.TicketQrReq) *response.TicketQr); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.TicketQr)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.TicketQrReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMyTickets provides a mock function with given fields: origCtx, userId
+func (_m *UsecaseQuery) FindMyTickets(origCtx context.Context, userId string) ([]response.IssuedTicket, error) {
+	ret := _m.Called(origCtx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMyTickets")
+	}
+
+	var r0 []response.IssuedTicket
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]response.IssuedTicket, error)); ok {
+		return rf(origCtx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.IssuedTicket); ok {
+		r0 = rf(origCtx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.IssuedTicket)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
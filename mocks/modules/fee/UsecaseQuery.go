@@ -0,0 +1,59 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/fee/models/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// CalculateBreakdown provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) CalculateBreakdown(origCtx context.Context, payload dto.BreakdownReq) (*dto.Breakdown, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CalculateBreakdown")
+	}
+
+	var r0 *dto.Breakdown
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.BreakdownReq) (*dto.Breakdown, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.BreakdownReq) *dto.Breakdown); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Breakdown)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.BreakdownReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
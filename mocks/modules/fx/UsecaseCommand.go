@@ -0,0 +1,92 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/fx/models/request"
+
+	response "ticket-service/internal/modules/fx/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// ImportRates provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ImportRates(origCtx context.Context, payload request.RatesReq) (*response.Snapshot, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportRates")
+	}
+
+	var r0 *response.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.RatesReq) (*response.Snapshot, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.RatesReq) *response.Snapshot); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.RatesReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadRatesFile provides a mock function with given fields: origCtx, path
+func (_m *UsecaseCommand) LoadRatesFile(origCtx context.Context, path string) (*response.Snapshot, error) {
+	ret := _m.Called(origCtx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadRatesFile")
+	}
+
+	var r0 *response.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.Snapshot, error)); ok {
+		return rf(origCtx, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Snapshot); ok {
+		r0 = rf(origCtx, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/fx/models/dto"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/fx/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindDisplayRate provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindDisplayRate(origCtx context.Context, payload dto.DisplayReq) (*dto.Rate, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDisplayRate")
+	}
+
+	var r0 *dto.Rate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.DisplayReq) (*dto.Rate, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.DisplayReq) *dto.Rate); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Rate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.DisplayReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLatestRates provides a mock function with given fields: origCtx
+func (_m *UsecaseQuery) FindLatestRates(origCtx context.Context) (*response.Snapshot, error) {
+	ret := _m.Called(origCtx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLatestRates")
+	}
+
+	var r0 *response.Snapshot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*response.Snapshot, error)); ok {
+		return rf(origCtx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *response.Snapshot); ok {
+		r0 = rf(origCtx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Snapshot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(origCtx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,172 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/order/models/dto"
+	entity "ticket-service/internal/modules/order/models/entity"
+
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// CreateUniqueIndexes provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryCommand) CreateUniqueIndexes(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUniqueIndexes")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// DecreasePurchaseCounter provides a mock function with given fields: ctx, payload
+func (_m *MongodbRepositoryCommand) DecreasePurchaseCounter(ctx context.Context, payload dto.PurchaseCounter) <-chan helpers.Result {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecreasePurchaseCounter")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PurchaseCounter) <-chan helpers.Result); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// IncreasePurchaseCounter provides a mock function with given fields: ctx, payload, limit
+func (_m *MongodbRepositoryCommand) IncreasePurchaseCounter(ctx context.Context, payload dto.PurchaseCounter, limit entity.PurchaseLimit) <-chan helpers.Result {
+	ret := _m.Called(ctx, payload, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreasePurchaseCounter")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PurchaseCounter, entity.PurchaseLimit) <-chan helpers.Result); ok {
+		r0 = rf(ctx, payload, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InitPurchaseCounter provides a mock function with given fields: ctx, userId, eventId
+func (_m *MongodbRepositoryCommand) InitPurchaseCounter(ctx context.Context, userId string, eventId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, userId, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitPurchaseCounter")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, userId, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOneOrder provides a mock function with given fields: ctx, _a1
+func (_m *MongodbRepositoryCommand) InsertOneOrder(ctx context.Context, _a1 entity.Order) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneOrder")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Order) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateOrderStatus provides a mock function with given fields: ctx, orderId, fromStatus, toStatus
+func (_m *MongodbRepositoryCommand) UpdateOrderStatus(ctx context.Context, orderId string, fromStatus string, toStatus string) <-chan helpers.Result {
+	ret := _m.Called(ctx, orderId, fromStatus, toStatus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, orderId, fromStatus, toStatus)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertPurchaseLimit provides a mock function with given fields: ctx, limit
+func (_m *MongodbRepositoryCommand) UpsertPurchaseLimit(ctx context.Context, limit entity.PurchaseLimit) <-chan helpers.Result {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPurchaseLimit")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PurchaseLimit) <-chan helpers.Result); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// FindExpiredPendingOrders provides a mock function with given fields: ctx, expiredBefore
+func (_m *MongodbRepositoryQuery) FindExpiredPendingOrders(ctx context.Context, expiredBefore time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, expiredBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExpiredPendingOrders")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, expiredBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindOrderById provides a mock function with given fields: ctx, orderId
+func (_m *MongodbRepositoryQuery) FindOrderById(ctx context.Context, orderId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrderById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindOrdersByEventId provides a mock function with given fields: ctx, eventId, status
+func (_m *MongodbRepositoryQuery) FindOrdersByEventId(ctx context.Context, eventId string, status string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrdersByEventId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindPurchaseCounter provides a mock function with given fields: ctx, userId, eventId
+func (_m *MongodbRepositoryQuery) FindPurchaseCounter(ctx context.Context, userId string, eventId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, userId, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPurchaseCounter")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, userId, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindPurchaseLimitByEventId provides a mock function with given fields: ctx, eventId
+func (_m *MongodbRepositoryQuery) FindPurchaseLimitByEventId(ctx context.Context, eventId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPurchaseLimitByEventId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
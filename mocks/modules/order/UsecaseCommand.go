@@ -0,0 +1,159 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/order/models/dto"
+	entity "ticket-service/internal/modules/order/models/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/order/models/request"
+
+	response "ticket-service/internal/modules/order/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// CancelReservation provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) CancelReservation(origCtx context.Context, payload request.CancelReservationReq) (*response.Reservation, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelReservation")
+	}
+
+	var r0 *response.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.CancelReservationReq) (*response.Reservation, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.CancelReservationReq) *response.Reservation); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.CancelReservationReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateReservation provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) CreateReservation(origCtx context.Context, payload dto.ReservationReq) (*response.Reservation, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReservation")
+	}
+
+	var r0 *response.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ReservationReq) (*response.Reservation, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ReservationReq) *response.Reservation); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ReservationReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseHold provides a mock function with given fields: origCtx, orderDetail
+func (_m *UsecaseCommand) ReleaseHold(origCtx context.Context, orderDetail entity.Order) {
+	_m.Called(origCtx, orderDetail)
+}
+
+// ReleaseOrder provides a mock function with given fields: origCtx, orderId
+func (_m *UsecaseCommand) ReleaseOrder(origCtx context.Context, orderId string) (*response.Reservation, error) {
+	ret := _m.Called(origCtx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseOrder")
+	}
+
+	var r0 *response.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.Reservation, error)); ok {
+		return rf(origCtx, orderId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Reservation); ok {
+		r0 = rf(origCtx, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Reservation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, orderId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertPurchaseLimit provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertPurchaseLimit(origCtx context.Context, payload request.PurchaseLimitReq) (*response.PurchaseLimit, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPurchaseLimit")
+	}
+
+	var r0 *response.PurchaseLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.PurchaseLimitReq) (*response.PurchaseLimit, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.PurchaseLimitReq) *response.PurchaseLimit); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.PurchaseLimit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.PurchaseLimitReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/order/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindPurchaseLimit provides a mock function with given fields: origCtx, eventId
+func (_m *UsecaseQuery) FindPurchaseLimit(origCtx context.Context, eventId string) (*response.PurchaseLimit, error) {
+	ret := _m.Called(origCtx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPurchaseLimit")
+	}
+
+	var r0 *response.PurchaseLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.PurchaseLimit, error)); ok {
+		return rf(origCtx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.PurchaseLimit); ok {
+		r0 = rf(origCtx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.PurchaseLimit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,150 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/payment/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// CreateUniqueIndexes provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryCommand) CreateUniqueIndexes(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUniqueIndexes")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InitWebhookEvent provides a mock function with given fields: ctx, event
+func (_m *MongodbRepositoryCommand) InitWebhookEvent(ctx context.Context, event entity.WebhookEvent) <-chan helpers.Result {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitWebhookEvent")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WebhookEvent) <-chan helpers.Result); ok {
+		r0 = rf(ctx, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOnePayment provides a mock function with given fields: ctx, _a1
+func (_m *MongodbRepositoryCommand) InsertOnePayment(ctx context.Context, _a1 entity.Payment) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOnePayment")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Payment) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdatePaymentRefunded provides a mock function with given fields: ctx, paymentId, amount
+func (_m *MongodbRepositoryCommand) UpdatePaymentRefunded(ctx context.Context, paymentId string, amount int) <-chan helpers.Result {
+	ret := _m.Called(ctx, paymentId, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePaymentRefunded")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, paymentId, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdatePaymentStatus provides a mock function with given fields: ctx, paymentId, fromStatus, toStatus
+func (_m *MongodbRepositoryCommand) UpdatePaymentStatus(ctx context.Context, paymentId string, fromStatus string, toStatus string) <-chan helpers.Result {
+	ret := _m.Called(ctx, paymentId, fromStatus, toStatus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePaymentStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, paymentId, fromStatus, toStatus)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateWebhookEventStatus provides a mock function with given fields: ctx, provider, eventId, status
+func (_m *MongodbRepositoryCommand) UpdateWebhookEventStatus(ctx context.Context, provider string, eventId string, status string) <-chan helpers.Result {
+	ret := _m.Called(ctx, provider, eventId, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookEventStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, provider, eventId, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
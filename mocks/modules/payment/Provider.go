@@ -0,0 +1,197 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/payment/models/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Provider is an autogenerated mock type for the Provider type
+type Provider struct {
+	mock.Mock
+}
+
+// CaptureCharge provides a mock function with given fields: ctx, chargeId
+func (_m *Provider) CaptureCharge(ctx context.Context, chargeId string) (*dto.Charge, error) {
+	ret := _m.Called(ctx, chargeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CaptureCharge")
+	}
+
+	var r0 *dto.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*dto.Charge, error)); ok {
+		return rf(ctx, chargeId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *dto.Charge); ok {
+		r0 = rf(ctx, chargeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chargeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCharge provides a mock function with given fields: ctx, payload
+func (_m *Provider) CreateCharge(ctx context.Context, payload dto.ChargeReq) (*dto.Charge, error) {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCharge")
+	}
+
+	var r0 *dto.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ChargeReq) (*dto.Charge, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ChargeReq) *dto.Charge); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ChargeReq) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with given fields:
+func (_m *Provider) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ParseWebhook provides a mock function with given fields: payload, signature
+func (_m *Provider) ParseWebhook(payload []byte, signature string) (*dto.WebhookEvent, error) {
+	ret := _m.Called(payload, signature)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ParseWebhook")
+	}
+
+	var r0 *dto.WebhookEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]byte, string) (*dto.WebhookEvent, error)); ok {
+		return rf(payload, signature)
+	}
+	if rf, ok := ret.Get(0).(func([]byte, string) *dto.WebhookEvent); ok {
+		r0 = rf(payload, signature)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.WebhookEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]byte, string) error); ok {
+		r1 = rf(payload, signature)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundCharge provides a mock function with given fields: ctx, chargeId, amount
+func (_m *Provider) RefundCharge(ctx context.Context, chargeId string, amount int) (*dto.Charge, error) {
+	ret := _m.Called(ctx, chargeId, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundCharge")
+	}
+
+	var r0 *dto.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*dto.Charge, error)); ok {
+		return rf(ctx, chargeId, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *dto.Charge); ok {
+		r0 = rf(ctx, chargeId, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, chargeId, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VoidCharge provides a mock function with given fields: ctx, chargeId
+func (_m *Provider) VoidCharge(ctx context.Context, chargeId string) (*dto.Charge, error) {
+	ret := _m.Called(ctx, chargeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VoidCharge")
+	}
+
+	var r0 *dto.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*dto.Charge, error)); ok {
+		return rf(ctx, chargeId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *dto.Charge); ok {
+		r0 = rf(ctx, chargeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, chargeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProvider creates a new instance of Provider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Provider {
+	mock := &Provider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
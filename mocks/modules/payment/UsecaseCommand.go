@@ -0,0 +1,182 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/payment/models/request"
+
+	response "ticket-service/internal/modules/payment/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// CancelOrderPayments provides a mock function with given fields: origCtx, orderId
+func (_m *UsecaseCommand) CancelOrderPayments(origCtx context.Context, orderId string) (*response.CancelledPayments, error) {
+	ret := _m.Called(origCtx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelOrderPayments")
+	}
+
+	var r0 *response.CancelledPayments
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.CancelledPayments, error)); ok {
+		return rf(origCtx, orderId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.CancelledPayments); ok {
+		r0 = rf(origCtx, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.CancelledPayments)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, orderId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateCharge provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) CreateCharge(origCtx context.Context, payload request.ChargeReq) (*response.Payment, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCharge")
+	}
+
+	var r0 *response.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ChargeReq) (*response.Payment, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ChargeReq) *response.Payment); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ChargeReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireHolds provides a mock function with given fields: origCtx
+func (_m *UsecaseCommand) ExpireHolds(origCtx context.Context) (*response.ExpiredHolds, error) {
+	ret := _m.Called(origCtx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireHolds")
+	}
+
+	var r0 *response.ExpiredHolds
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*response.ExpiredHolds, error)); ok {
+		return rf(origCtx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *response.ExpiredHolds); ok {
+		r0 = rf(origCtx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ExpiredHolds)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(origCtx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HandleWebhook provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) HandleWebhook(origCtx context.Context, payload request.WebhookReq) (*response.Webhook, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HandleWebhook")
+	}
+
+	var r0 *response.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.WebhookReq) (*response.Webhook, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.WebhookReq) *response.Webhook); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.WebhookReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RefundOrder provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) RefundOrder(origCtx context.Context, payload request.RefundOrderReq) (*response.Payment, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefundOrder")
+	}
+
+	var r0 *response.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundOrderReq) (*response.Payment, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundOrderReq) *response.Payment); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.RefundOrderReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
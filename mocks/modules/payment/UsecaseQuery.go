@@ -0,0 +1,60 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/payment/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindOrderPayments provides a mock function with given fields: origCtx, userId, orderId
+func (_m *UsecaseQuery) FindOrderPayments(origCtx context.Context, userId string, orderId string) ([]response.Payment, error) {
+	ret := _m.Called(origCtx, userId, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOrderPayments")
+	}
+
+	var r0 []response.Payment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]response.Payment, error)); ok {
+		return rf(origCtx, userId, orderId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []response.Payment); ok {
+		r0 = rf(origCtx, userId, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Payment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(origCtx, userId, orderId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,210 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/presale/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// CreateUniqueIndexes provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryCommand) CreateUniqueIndexes(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUniqueIndexes")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// DecreaseAccessCodeUsed provides a mock function with given fields: ctx, presaleId, code
+func (_m *MongodbRepositoryCommand) DecreaseAccessCodeUsed(ctx context.Context, presaleId string, code string) <-chan helpers.Result {
+	ret := _m.Called(ctx, presaleId, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecreaseAccessCodeUsed")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, presaleId, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// DecreaseAllocation provides a mock function with given fields: ctx, presaleId, ticketId, quantity
+func (_m *MongodbRepositoryCommand) DecreaseAllocation(ctx context.Context, presaleId string, ticketId string, quantity int) <-chan helpers.Result {
+	ret := _m.Called(ctx, presaleId, ticketId, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecreaseAllocation")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, presaleId, ticketId, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// IncreaseAccessCodeUsed provides a mock function with given fields: ctx, presaleId, code
+func (_m *MongodbRepositoryCommand) IncreaseAccessCodeUsed(ctx context.Context, presaleId string, code string) <-chan helpers.Result {
+	ret := _m.Called(ctx, presaleId, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseAccessCodeUsed")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, presaleId, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// IncreaseAllocation provides a mock function with given fields: ctx, presaleId, ticketId, quantity, allocation
+func (_m *MongodbRepositoryCommand) IncreaseAllocation(ctx context.Context, presaleId string, ticketId string, quantity int, allocation int) <-chan helpers.Result {
+	ret := _m.Called(ctx, presaleId, ticketId, quantity, allocation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseAllocation")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, presaleId, ticketId, quantity, allocation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InitAllocation provides a mock function with given fields: ctx, presaleId, ticketId
+func (_m *MongodbRepositoryCommand) InitAllocation(ctx context.Context, presaleId string, ticketId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, presaleId, ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitAllocation")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, presaleId, ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOneGrant provides a mock function with given fields: ctx, grant
+func (_m *MongodbRepositoryCommand) InsertOneGrant(ctx context.Context, grant entity.PresaleGrant) <-chan helpers.Result {
+	ret := _m.Called(ctx, grant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneGrant")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PresaleGrant) <-chan helpers.Result); ok {
+		r0 = rf(ctx, grant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertAccessCode provides a mock function with given fields: ctx, accessCode
+func (_m *MongodbRepositoryCommand) UpsertAccessCode(ctx context.Context, accessCode entity.AccessCode) <-chan helpers.Result {
+	ret := _m.Called(ctx, accessCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertAccessCode")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AccessCode) <-chan helpers.Result); ok {
+		r0 = rf(ctx, accessCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertPresale provides a mock function with given fields: ctx, _a1
+func (_m *MongodbRepositoryCommand) UpsertPresale(ctx context.Context, _a1 entity.Presale) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPresale")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Presale) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
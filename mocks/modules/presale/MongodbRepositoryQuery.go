@@ -0,0 +1,151 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// FindAccessCode provides a mock function with given fields: ctx, presaleId, code
+func (_m *MongodbRepositoryQuery) FindAccessCode(ctx context.Context, presaleId string, code string) <-chan helpers.Result {
+	ret := _m.Called(ctx, presaleId, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAccessCode")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, presaleId, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindActivePresale provides a mock function with given fields: ctx, eventId, now
+func (_m *MongodbRepositoryQuery) FindActivePresale(ctx context.Context, eventId string, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActivePresale")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindAllocationsByPresaleId provides a mock function with given fields: ctx, presaleId
+func (_m *MongodbRepositoryQuery) FindAllocationsByPresaleId(ctx context.Context, presaleId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, presaleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAllocationsByPresaleId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, presaleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindGrant provides a mock function with given fields: ctx, presaleId, userId
+func (_m *MongodbRepositoryQuery) FindGrant(ctx context.Context, presaleId string, userId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, presaleId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindGrant")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, presaleId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindPresaleById provides a mock function with given fields: ctx, presaleId
+func (_m *MongodbRepositoryQuery) FindPresaleById(ctx context.Context, presaleId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, presaleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPresaleById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, presaleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindPresalesByEventId provides a mock function with given fields: ctx, eventId
+func (_m *MongodbRepositoryQuery) FindPresalesByEventId(ctx context.Context, eventId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPresalesByEventId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
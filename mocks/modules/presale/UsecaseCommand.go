@@ -0,0 +1,169 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/presale/models/dto"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/presale/models/request"
+
+	response "ticket-service/internal/modules/presale/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// HoldAllocation provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) HoldAllocation(origCtx context.Context, payload dto.AllocationReq) (string, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HoldAllocation")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AllocationReq) (string, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AllocationReq) string); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.AllocationReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportAccessCodes provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ImportAccessCodes(origCtx context.Context, payload request.ImportCodesReq) (*response.ImportedCodes, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportAccessCodes")
+	}
+
+	var r0 *response.ImportedCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ImportCodesReq) (*response.ImportedCodes, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ImportCodesReq) *response.ImportedCodes); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ImportedCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ImportCodesReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseAllocation provides a mock function with given fields: origCtx, presaleId, ticketId, quantity
+func (_m *UsecaseCommand) ReleaseAllocation(origCtx context.Context, presaleId string, ticketId string, quantity int) error {
+	ret := _m.Called(origCtx, presaleId, ticketId, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseAllocation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = rf(origCtx, presaleId, ticketId, quantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlockPresale provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UnlockPresale(origCtx context.Context, payload request.UnlockReq) (*response.PresaleAccess, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockPresale")
+	}
+
+	var r0 *response.PresaleAccess
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.UnlockReq) (*response.PresaleAccess, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.UnlockReq) *response.PresaleAccess); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.PresaleAccess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.UnlockReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertPresale provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertPresale(origCtx context.Context, payload request.PresaleReq) (*response.Presale, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPresale")
+	}
+
+	var r0 *response.Presale
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.PresaleReq) (*response.Presale, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.PresaleReq) *response.Presale); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Presale)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.PresaleReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
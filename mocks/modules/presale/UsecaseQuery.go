@@ -0,0 +1,123 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/presale/models/dto"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/presale/models/request"
+
+	response "ticket-service/internal/modules/presale/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// CheckPresaleAccess provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) CheckPresaleAccess(origCtx context.Context, payload dto.AccessReq) (*dto.Access, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckPresaleAccess")
+	}
+
+	var r0 *dto.Access
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AccessReq) (*dto.Access, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.AccessReq) *dto.Access); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Access)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.AccessReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMyPresaleAccess provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindMyPresaleAccess(origCtx context.Context, payload request.PresaleAccessReq) (*response.PresaleAccess, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMyPresaleAccess")
+	}
+
+	var r0 *response.PresaleAccess
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.PresaleAccessReq) (*response.PresaleAccess, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.PresaleAccessReq) *response.PresaleAccess); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.PresaleAccess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.PresaleAccessReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPresales provides a mock function with given fields: origCtx, eventId
+func (_m *UsecaseQuery) FindPresales(origCtx context.Context, eventId string) ([]response.Presale, error) {
+	ret := _m.Called(origCtx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPresales")
+	}
+
+	var r0 []response.Presale
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]response.Presale, error)); ok {
+		return rf(origCtx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.Presale); ok {
+		r0 = rf(origCtx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Presale)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
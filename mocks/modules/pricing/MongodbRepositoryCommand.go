@@ -0,0 +1,92 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/pricing/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// InsertOnePriceChange provides a mock function with given fields: ctx, change
+func (_m *MongodbRepositoryCommand) InsertOnePriceChange(ctx context.Context, change entity.PriceChange) <-chan helpers.Result {
+	ret := _m.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOnePriceChange")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PriceChange) <-chan helpers.Result); ok {
+		r0 = rf(ctx, change)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateCurrentPrice provides a mock function with given fields: ctx, ticketId, fromPrice, toPrice, calculatedAt
+func (_m *MongodbRepositoryCommand) UpdateCurrentPrice(ctx context.Context, ticketId string, fromPrice int, toPrice int, calculatedAt time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, fromPrice, toPrice, calculatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCurrentPrice")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, fromPrice, toPrice, calculatedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertPricingRule provides a mock function with given fields: ctx, rule
+func (_m *MongodbRepositoryCommand) UpsertPricingRule(ctx context.Context, rule entity.PricingRule) <-chan helpers.Result {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPricingRule")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PricingRule) <-chan helpers.Result); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
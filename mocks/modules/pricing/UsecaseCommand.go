@@ -0,0 +1,92 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/pricing/models/request"
+
+	response "ticket-service/internal/modules/pricing/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// RecalculatePrices provides a mock function with given fields: origCtx
+func (_m *UsecaseCommand) RecalculatePrices(origCtx context.Context) (*response.Recalculation, error) {
+	ret := _m.Called(origCtx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecalculatePrices")
+	}
+
+	var r0 *response.Recalculation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*response.Recalculation, error)); ok {
+		return rf(origCtx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *response.Recalculation); ok {
+		r0 = rf(origCtx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Recalculation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(origCtx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertPricingRule provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertPricingRule(origCtx context.Context, payload request.PricingRuleReq) (*response.PricingRule, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertPricingRule")
+	}
+
+	var r0 *response.PricingRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.PricingRuleReq) (*response.PricingRule, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.PricingRuleReq) *response.PricingRule); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.PricingRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.PricingRuleReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
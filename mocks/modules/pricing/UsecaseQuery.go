@@ -0,0 +1,60 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/pricing/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindPriceChanges provides a mock function with given fields: origCtx, ticketId
+func (_m *UsecaseQuery) FindPriceChanges(origCtx context.Context, ticketId string) ([]response.PriceChange, error) {
+	ret := _m.Called(origCtx, ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPriceChanges")
+	}
+
+	var r0 []response.PriceChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]response.PriceChange, error)); ok {
+		return rf(origCtx, ticketId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.PriceChange); ok {
+		r0 = rf(origCtx, ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.PriceChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, ticketId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,112 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/purchase/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// ClaimSaga provides a mock function with given fields: ctx, sagaId, owner, now
+func (_m *MongodbRepositoryCommand) ClaimSaga(ctx context.Context, sagaId string, owner string, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, sagaId, owner, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimSaga")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, sagaId, owner, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOneSaga provides a mock function with given fields: ctx, saga
+func (_m *MongodbRepositoryCommand) InsertOneSaga(ctx context.Context, saga entity.Saga) <-chan helpers.Result {
+	ret := _m.Called(ctx, saga)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneSaga")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Saga) <-chan helpers.Result); ok {
+		r0 = rf(ctx, saga)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// ReopenFailedSaga provides a mock function with given fields: ctx, sagaId, owner
+func (_m *MongodbRepositoryCommand) ReopenFailedSaga(ctx context.Context, sagaId string, owner string) <-chan helpers.Result {
+	ret := _m.Called(ctx, sagaId, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReopenFailedSaga")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, sagaId, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// SaveSaga provides a mock function with given fields: ctx, saga, owner
+func (_m *MongodbRepositoryCommand) SaveSaga(ctx context.Context, saga entity.Saga, owner string) <-chan helpers.Result {
+	ret := _m.Called(ctx, saga, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSaga")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Saga, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, saga, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
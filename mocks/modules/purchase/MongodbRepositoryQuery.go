@@ -0,0 +1,91 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// FindResumableSagas provides a mock function with given fields: ctx, now
+func (_m *MongodbRepositoryQuery) FindResumableSagas(ctx context.Context, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindResumableSagas")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindSagaById provides a mock function with given fields: ctx, sagaId
+func (_m *MongodbRepositoryQuery) FindSagaById(ctx context.Context, sagaId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, sagaId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSagaById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, sagaId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindStuckSagas provides a mock function with given fields: ctx, updatedBefore
+func (_m *MongodbRepositoryQuery) FindStuckSagas(ctx context.Context, updatedBefore time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, updatedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStuckSagas")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, updatedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,122 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/purchase/models/request"
+
+	response "ticket-service/internal/modules/purchase/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// ResumeSagas provides a mock function with given fields: origCtx
+func (_m *UsecaseCommand) ResumeSagas(origCtx context.Context) (*response.ResumedSagas, error) {
+	ret := _m.Called(origCtx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeSagas")
+	}
+
+	var r0 *response.ResumedSagas
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*response.ResumedSagas, error)); ok {
+		return rf(origCtx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *response.ResumedSagas); ok {
+		r0 = rf(origCtx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ResumedSagas)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(origCtx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetrySaga provides a mock function with given fields: origCtx, sagaId
+func (_m *UsecaseCommand) RetrySaga(origCtx context.Context, sagaId string) (*response.Saga, error) {
+	ret := _m.Called(origCtx, sagaId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrySaga")
+	}
+
+	var r0 *response.Saga
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.Saga, error)); ok {
+		return rf(origCtx, sagaId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Saga); ok {
+		r0 = rf(origCtx, sagaId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Saga)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, sagaId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartPurchase provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) StartPurchase(origCtx context.Context, payload request.PurchaseReq) (*response.Saga, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartPurchase")
+	}
+
+	var r0 *response.Saga
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.PurchaseReq) (*response.Saga, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.PurchaseReq) *response.Saga); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Saga)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.PurchaseReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,92 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/purchase/models/request"
+
+	response "ticket-service/internal/modules/purchase/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindMyPurchase provides a mock function with given fields: origCtx, userId, sagaId
+func (_m *UsecaseQuery) FindMyPurchase(origCtx context.Context, userId string, sagaId string) (*response.Saga, error) {
+	ret := _m.Called(origCtx, userId, sagaId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMyPurchase")
+	}
+
+	var r0 *response.Saga
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*response.Saga, error)); ok {
+		return rf(origCtx, userId, sagaId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *response.Saga); ok {
+		r0 = rf(origCtx, userId, sagaId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Saga)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(origCtx, userId, sagaId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindStuckSagas provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindStuckSagas(origCtx context.Context, payload request.StuckSagaReq) ([]response.Saga, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStuckSagas")
+	}
+
+	var r0 []response.Saga
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.StuckSagaReq) ([]response.Saga, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.StuckSagaReq) []response.Saga); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Saga)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.StuckSagaReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
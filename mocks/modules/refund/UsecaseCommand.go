@@ -0,0 +1,182 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/refund/models/request"
+
+	response "ticket-service/internal/modules/refund/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// ApproveRefund provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ApproveRefund(origCtx context.Context, payload request.RefundReviewReq) (*response.Refund, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveRefund")
+	}
+
+	var r0 *response.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundReviewReq) (*response.Refund, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundReviewReq) *response.Refund); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.RefundReviewReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelEvent provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) CancelEvent(origCtx context.Context, payload request.CancelEventReq) (*response.EventCancellation, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelEvent")
+	}
+
+	var r0 *response.EventCancellation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.CancelEventReq) (*response.EventCancellation, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.CancelEventReq) *response.EventCancellation); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.EventCancellation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.CancelEventReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectRefund provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) RejectRefund(origCtx context.Context, payload request.RefundReviewReq) (*response.Refund, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectRefund")
+	}
+
+	var r0 *response.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundReviewReq) (*response.Refund, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundReviewReq) *response.Refund); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.RefundReviewReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestRefund provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) RequestRefund(origCtx context.Context, payload request.RefundReq) (*response.Refund, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestRefund")
+	}
+
+	var r0 *response.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundReq) (*response.Refund, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundReq) *response.Refund); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.RefundReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertRefundPolicy provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertRefundPolicy(origCtx context.Context, payload request.RefundPolicyReq) (*response.RefundPolicy, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertRefundPolicy")
+	}
+
+	var r0 *response.RefundPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundPolicyReq) (*response.RefundPolicy, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.RefundPolicyReq) *response.RefundPolicy); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.RefundPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.RefundPolicyReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
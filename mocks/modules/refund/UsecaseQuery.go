@@ -0,0 +1,120 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/refund/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindMyRefunds provides a mock function with given fields: origCtx, userId
+func (_m *UsecaseQuery) FindMyRefunds(origCtx context.Context, userId string) ([]response.Refund, error) {
+	ret := _m.Called(origCtx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMyRefunds")
+	}
+
+	var r0 []response.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]response.Refund, error)); ok {
+		return rf(origCtx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.Refund); ok {
+		r0 = rf(origCtx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPendingApprovalRefunds provides a mock function with given fields: origCtx
+func (_m *UsecaseQuery) FindPendingApprovalRefunds(origCtx context.Context) ([]response.Refund, error) {
+	ret := _m.Called(origCtx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPendingApprovalRefunds")
+	}
+
+	var r0 []response.Refund
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]response.Refund, error)); ok {
+		return rf(origCtx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Refund); ok {
+		r0 = rf(origCtx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Refund)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(origCtx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRefundPolicy provides a mock function with given fields: origCtx, eventId
+func (_m *UsecaseQuery) FindRefundPolicy(origCtx context.Context, eventId string) (*response.RefundPolicy, error) {
+	ret := _m.Called(origCtx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRefundPolicy")
+	}
+
+	var r0 *response.RefundPolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.RefundPolicy, error)); ok {
+		return rf(origCtx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.RefundPolicy); ok {
+		r0 = rf(origCtx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.RefundPolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
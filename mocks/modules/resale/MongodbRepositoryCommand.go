@@ -0,0 +1,172 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/resale/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// InitSale provides a mock function with given fields: ctx, sale
+func (_m *MongodbRepositoryCommand) InitSale(ctx context.Context, sale entity.Sale) <-chan helpers.Result {
+	ret := _m.Called(ctx, sale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitSale")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Sale) <-chan helpers.Result); ok {
+		r0 = rf(ctx, sale)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOneListing provides a mock function with given fields: ctx, listing
+func (_m *MongodbRepositoryCommand) InsertOneListing(ctx context.Context, listing entity.Listing) <-chan helpers.Result {
+	ret := _m.Called(ctx, listing)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneListing")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Listing) <-chan helpers.Result); ok {
+		r0 = rf(ctx, listing)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateListingReleased provides a mock function with given fields: ctx, orderId
+func (_m *MongodbRepositoryCommand) UpdateListingReleased(ctx context.Context, orderId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateListingReleased")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateListingReserved provides a mock function with given fields: ctx, listingId, buyerId, orderId, now
+func (_m *MongodbRepositoryCommand) UpdateListingReserved(ctx context.Context, listingId string, buyerId string, orderId string, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, listingId, buyerId, orderId, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateListingReserved")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, listingId, buyerId, orderId, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateListingSold provides a mock function with given fields: ctx, orderId
+func (_m *MongodbRepositoryCommand) UpdateListingSold(ctx context.Context, orderId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateListingSold")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateListingStatus provides a mock function with given fields: ctx, listingId, fromStatus, toStatus
+func (_m *MongodbRepositoryCommand) UpdateListingStatus(ctx context.Context, listingId string, fromStatus string, toStatus string) <-chan helpers.Result {
+	ret := _m.Called(ctx, listingId, fromStatus, toStatus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateListingStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, listingId, fromStatus, toStatus)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertResaleRule provides a mock function with given fields: ctx, rule
+func (_m *MongodbRepositoryCommand) UpsertResaleRule(ctx context.Context, rule entity.ResaleRule) <-chan helpers.Result {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertResaleRule")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ResaleRule) <-chan helpers.Result); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// FindListingById provides a mock function with given fields: ctx, listingId
+func (_m *MongodbRepositoryQuery) FindListingById(ctx context.Context, listingId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, listingId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindListingById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, listingId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindListingByOrderId provides a mock function with given fields: ctx, orderId
+func (_m *MongodbRepositoryQuery) FindListingByOrderId(ctx context.Context, orderId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindListingByOrderId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindListingsBySellerId provides a mock function with given fields: ctx, sellerId
+func (_m *MongodbRepositoryQuery) FindListingsBySellerId(ctx context.Context, sellerId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, sellerId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindListingsBySellerId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, sellerId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindOpenListingsByEventId provides a mock function with given fields: ctx, eventId, now
+func (_m *MongodbRepositoryQuery) FindOpenListingsByEventId(ctx context.Context, eventId string, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOpenListingsByEventId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindResaleRuleByEventId provides a mock function with given fields: ctx, eventId
+func (_m *MongodbRepositoryQuery) FindResaleRuleByEventId(ctx context.Context, eventId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindResaleRuleByEventId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
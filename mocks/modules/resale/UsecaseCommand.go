@@ -0,0 +1,187 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/resale/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/resale/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// BuyListing provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) BuyListing(origCtx context.Context, payload request.ListingActionReq) (*response.Purchase, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BuyListing")
+	}
+
+	var r0 *response.Purchase
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ListingActionReq) (*response.Purchase, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ListingActionReq) *response.Purchase); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Purchase)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ListingActionReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelListing provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) CancelListing(origCtx context.Context, payload request.ListingActionReq) (*response.Listing, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelListing")
+	}
+
+	var r0 *response.Listing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ListingActionReq) (*response.Listing, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ListingActionReq) *response.Listing); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Listing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ListingActionReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteSale provides a mock function with given fields: origCtx, orderId
+func (_m *UsecaseCommand) CompleteSale(origCtx context.Context, orderId string) error {
+	ret := _m.Called(origCtx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteSale")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(origCtx, orderId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateListing provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) CreateListing(origCtx context.Context, payload request.ListingReq) (*response.Listing, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateListing")
+	}
+
+	var r0 *response.Listing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ListingReq) (*response.Listing, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ListingReq) *response.Listing); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Listing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ListingReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseListing provides a mock function with given fields: origCtx, orderId
+func (_m *UsecaseCommand) ReleaseListing(origCtx context.Context, orderId string) error {
+	ret := _m.Called(origCtx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseListing")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(origCtx, orderId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertResaleRule provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertResaleRule(origCtx context.Context, payload request.ResaleRuleReq) (*response.ResaleRule, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertResaleRule")
+	}
+
+	var r0 *response.ResaleRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ResaleRuleReq) (*response.ResaleRule, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ResaleRuleReq) *response.ResaleRule); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ResaleRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ResaleRuleReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
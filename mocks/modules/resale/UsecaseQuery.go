@@ -0,0 +1,90 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/resale/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindListings provides a mock function with given fields: origCtx, eventId
+func (_m *UsecaseQuery) FindListings(origCtx context.Context, eventId string) ([]response.Listing, error) {
+	ret := _m.Called(origCtx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindListings")
+	}
+
+	var r0 []response.Listing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]response.Listing, error)); ok {
+		return rf(origCtx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.Listing); ok {
+		r0 = rf(origCtx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Listing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMyListings provides a mock function with given fields: origCtx, userId
+func (_m *UsecaseQuery) FindMyListings(origCtx context.Context, userId string) ([]response.Listing, error) {
+	ret := _m.Called(origCtx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMyListings")
+	}
+
+	var r0 []response.Listing
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]response.Listing, error)); ok {
+		return rf(origCtx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.Listing); ok {
+		r0 = rf(origCtx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Listing)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,172 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/seat/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// CreateUniqueIndexes provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryCommand) CreateUniqueIndexes(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUniqueIndexes")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateSeatHeld provides a mock function with given fields: ctx, seatId, orderId, heldUntil, now
+func (_m *MongodbRepositoryCommand) UpdateSeatHeld(ctx context.Context, seatId string, orderId string, heldUntil time.Time, now time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, seatId, orderId, heldUntil, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSeatHeld")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, seatId, orderId, heldUntil, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateSeatReleased provides a mock function with given fields: ctx, seatId, orderId
+func (_m *MongodbRepositoryCommand) UpdateSeatReleased(ctx context.Context, seatId string, orderId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, seatId, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSeatReleased")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, seatId, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateSeatSold provides a mock function with given fields: ctx, seatId, orderId
+func (_m *MongodbRepositoryCommand) UpdateSeatSold(ctx context.Context, seatId string, orderId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, seatId, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSeatSold")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, seatId, orderId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateSeatStatus provides a mock function with given fields: ctx, seatId, fromStatus, toStatus
+func (_m *MongodbRepositoryCommand) UpdateSeatStatus(ctx context.Context, seatId string, fromStatus string, toStatus string) <-chan helpers.Result {
+	ret := _m.Called(ctx, seatId, fromStatus, toStatus)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSeatStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, seatId, fromStatus, toStatus)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertSeat provides a mock function with given fields: ctx, _a1
+func (_m *MongodbRepositoryCommand) UpsertSeat(ctx context.Context, _a1 entity.Seat) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertSeat")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Seat) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertSeatMap provides a mock function with given fields: ctx, seatMap
+func (_m *MongodbRepositoryCommand) UpsertSeatMap(ctx context.Context, seatMap entity.SeatMap) <-chan helpers.Result {
+	ret := _m.Called(ctx, seatMap)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertSeatMap")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SeatMap) <-chan helpers.Result); ok {
+		r0 = rf(ctx, seatMap)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,129 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/seat/models/dto"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/seat/models/request"
+
+	response "ticket-service/internal/modules/seat/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// HoldSeats provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) HoldSeats(origCtx context.Context, payload dto.HoldReq) ([]dto.Seat, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HoldSeats")
+	}
+
+	var r0 []dto.Seat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.HoldReq) ([]dto.Seat, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.HoldReq) []dto.Seat); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.Seat)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.HoldReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseSeats provides a mock function with given fields: origCtx, orderId
+func (_m *UsecaseCommand) ReleaseSeats(origCtx context.Context, orderId string) error {
+	ret := _m.Called(origCtx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseSeats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(origCtx, orderId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SellSeats provides a mock function with given fields: origCtx, orderId
+func (_m *UsecaseCommand) SellSeats(origCtx context.Context, orderId string) error {
+	ret := _m.Called(origCtx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SellSeats")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(origCtx, orderId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertSeatMap provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertSeatMap(origCtx context.Context, payload request.SeatMapReq) (*response.SeatMap, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertSeatMap")
+	}
+
+	var r0 *response.SeatMap
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.SeatMapReq) (*response.SeatMap, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.SeatMapReq) *response.SeatMap); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.SeatMap)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.SeatMapReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,149 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// CancelEventTickets provides a mock function with given fields: ctx, eventId
+func (_m *MongodbRepositoryCommand) CancelEventTickets(ctx context.Context, eventId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelEventTickets")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// CreateSearchIndex provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryCommand) CreateSearchIndex(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSearchIndex")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// DecreaseTotalRemaining provides a mock function with given fields: ctx, ticketId, quantity
+func (_m *MongodbRepositoryCommand) DecreaseTotalRemaining(ctx context.Context, ticketId string, quantity int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecreaseTotalRemaining")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// IncreaseTotalRemaining provides a mock function with given fields: ctx, ticketId, quantity
+func (_m *MongodbRepositoryCommand) IncreaseTotalRemaining(ctx context.Context, ticketId string, quantity int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseTotalRemaining")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateDynamicPrice provides a mock function with given fields: ctx, ticketId, price
+func (_m *MongodbRepositoryCommand) UpdateDynamicPrice(ctx context.Context, ticketId string, price int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, price)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDynamicPrice")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, price)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateVenue provides a mock function with given fields: ctx, ticketId, venueId, place, city
+func (_m *MongodbRepositoryCommand) UpdateVenue(ctx context.Context, ticketId string, venueId string, place string, city string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, venueId, place, city)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVenue")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, venueId, place, city)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
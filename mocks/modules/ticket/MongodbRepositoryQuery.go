@@ -56,6 +56,26 @@ func (_m *MongodbRepositoryQuery) FindOfflineTicketByCountryCode(ctx context.Con
 	return r0
 }
 
+// FindOfflineTicketsByTag provides a mock function with given fields: ctx, tag
+func (_m *MongodbRepositoryQuery) FindOfflineTicketsByTag(ctx context.Context, tag string) <-chan helpers.Result {
+	ret := _m.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOfflineTicketsByTag")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // FindOnlineTicketByCountry provides a mock function with given fields: ctx, payload
 func (_m *MongodbRepositoryQuery) FindOnlineTicketByCountry(ctx context.Context, payload request.TicketReq) <-chan helpers.Result {
 	ret := _m.Called(ctx, payload)
@@ -96,6 +116,86 @@ func (_m *MongodbRepositoryQuery) FindTicketByLowestPrice(ctx context.Context, t
 	return r0
 }
 
+// FindTicketByType provides a mock function with given fields: ctx, payload
+func (_m *MongodbRepositoryQuery) FindTicketByType(ctx context.Context, payload request.TicketTypeReq) <-chan helpers.Result {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTicketByType")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, request.TicketTypeReq) <-chan helpers.Result); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindTicketsByEventCountry provides a mock function with given fields: ctx, eventId, countryCode
+func (_m *MongodbRepositoryQuery) FindTicketsByEventCountry(ctx context.Context, eventId string, countryCode string) <-chan helpers.Result {
+	ret := _m.Called(ctx, eventId, countryCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTicketsByEventCountry")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, eventId, countryCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindTotalAvailableTicket provides a mock function with given fields: ctx, payload
+func (_m *MongodbRepositoryQuery) FindTotalAvailableTicket(ctx context.Context, payload request.AvailabilityReq) <-chan helpers.Result {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTotalAvailableTicket")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, request.AvailabilityReq) <-chan helpers.Result); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// SearchTickets provides a mock function with given fields: ctx, payload
+func (_m *MongodbRepositoryQuery) SearchTickets(ctx context.Context, payload request.SearchReq) <-chan helpers.Result {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTickets")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, request.SearchReq) <-chan helpers.Result); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMongodbRepositoryQuery(t interface {
@@ -4,10 +4,12 @@ package mocks
 
 import (
 	context "context"
-	request "ticket-service/internal/modules/ticket/models/request"
+	constants "ticket-service/internal/pkg/constants"
 
 	mock "github.com/stretchr/testify/mock"
 
+	request "ticket-service/internal/modules/ticket/models/request"
+
 	response "ticket-service/internal/modules/ticket/models/response"
 )
 
@@ -16,6 +18,45 @@ type UsecaseQuery struct {
 	mock.Mock
 }
 
+// FindAvailableTicket provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindAvailableTicket(origCtx context.Context, payload request.AvailabilityReq) ([]response.TicketCountry, *constants.MetaData, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAvailableTicket")
+	}
+
+	var r0 []response.TicketCountry
+	var r1 *constants.MetaData
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.AvailabilityReq) ([]response.TicketCountry, *constants.MetaData, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.AvailabilityReq) []response.TicketCountry); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.TicketCountry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.AvailabilityReq) *constants.MetaData); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*constants.MetaData)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, request.AvailabilityReq) error); ok {
+		r2 = rf(origCtx, payload)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // FindOnlineTicket provides a mock function with given fields: origCtx, payload
 func (_m *UsecaseQuery) FindOnlineTicket(origCtx context.Context, payload request.TicketReq) (*response.Ticket, error) {
 	ret := _m.Called(origCtx, payload)
@@ -47,7 +88,7 @@ func (_m *UsecaseQuery) FindOnlineTicket(origCtx context.Context, payload reques
 }
 
 // FindTickets provides a mock function with given fields: origCtx, payload
-func (_m *UsecaseQuery) FindTickets(origCtx context.Context, payload request.TicketReq) (*response.TicketResp, error) {
+func (_m *UsecaseQuery) FindTickets(origCtx context.Context, payload request.TicketReq) (*response.TicketResp, *constants.MetaData, error) {
 	ret := _m.Called(origCtx, payload)
 
 	if len(ret) == 0 {
@@ -55,8 +96,9 @@ func (_m *UsecaseQuery) FindTickets(origCtx context.Context, payload request.Tic
 	}
 
 	var r0 *response.TicketResp
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, request.TicketReq) (*response.TicketResp, error)); ok {
+	var r1 *constants.MetaData
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.TicketReq) (*response.TicketResp, *constants.MetaData, error)); ok {
 		return rf(origCtx, payload)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, request.TicketReq) *response.TicketResp); ok {
@@ -67,7 +109,45 @@ func (_m *UsecaseQuery) FindTickets(origCtx context.Context, payload request.Tic
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, request.TicketReq) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, request.TicketReq) *constants.MetaData); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*constants.MetaData)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, request.TicketReq) error); ok {
+		r2 = rf(origCtx, payload)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// QuoteTicket provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) QuoteTicket(origCtx context.Context, payload request.QuoteReq) (*response.Quote, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuoteTicket")
+	}
+
+	var r0 *response.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.QuoteReq) (*response.Quote, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.QuoteReq) *response.Quote); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.QuoteReq) error); ok {
 		r1 = rf(origCtx, payload)
 	} else {
 		r1 = ret.Error(1)
@@ -76,6 +156,45 @@ func (_m *UsecaseQuery) FindTickets(origCtx context.Context, payload request.Tic
 	return r0, r1
 }
 
+// SearchTickets provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) SearchTickets(origCtx context.Context, payload request.SearchReq) ([]response.SearchResult, *constants.MetaData, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTickets")
+	}
+
+	var r0 []response.SearchResult
+	var r1 *constants.MetaData
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.SearchReq) ([]response.SearchResult, *constants.MetaData, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.SearchReq) []response.SearchResult); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.SearchReq) *constants.MetaData); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*constants.MetaData)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, request.SearchReq) error); ok {
+		r2 = rf(origCtx, payload)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUsecaseQuery(t interface {
@@ -0,0 +1,68 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/ticket/models/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/ticket/models/request"
+
+	response "ticket-service/internal/modules/ticket/models/response"
+)
+
+// UsecaseStream is an autogenerated mock type for the UsecaseStream type
+type UsecaseStream struct {
+	mock.Mock
+}
+
+// PublishAvailability provides a mock function with given fields: ctx, _a1
+func (_m *UsecaseStream) PublishAvailability(ctx context.Context, _a1 entity.Ticket) {
+	_m.Called(ctx, _a1)
+}
+
+// Subscribe provides a mock function with given fields: ctx, payload
+func (_m *UsecaseStream) Subscribe(ctx context.Context, payload request.StreamReq) (<-chan response.AvailabilityDelta, error) {
+	ret := _m.Called(ctx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan response.AvailabilityDelta
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.StreamReq) (<-chan response.AvailabilityDelta, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.StreamReq) <-chan response.AvailabilityDelta); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan response.AvailabilityDelta)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.StreamReq) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseStream creates a new instance of UsecaseStream. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseStream(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseStream {
+	mock := &UsecaseStream{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
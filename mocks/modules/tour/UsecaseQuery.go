@@ -0,0 +1,72 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	constants "ticket-service/internal/pkg/constants"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/tour/models/request"
+
+	response "ticket-service/internal/modules/tour/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindTour provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindTour(origCtx context.Context, payload request.TourReq) (*response.Tour, *constants.MetaData, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTour")
+	}
+
+	var r0 *response.Tour
+	var r1 *constants.MetaData
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.TourReq) (*response.Tour, *constants.MetaData, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.TourReq) *response.Tour); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Tour)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.TourReq) *constants.MetaData); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*constants.MetaData)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, request.TourReq) error); ok {
+		r2 = rf(origCtx, payload)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,151 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/transfer/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/transfer/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// AcceptTransfer provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) AcceptTransfer(origCtx context.Context, payload request.TransferActionReq) (*response.Transfer, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptTransfer")
+	}
+
+	var r0 *response.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.TransferActionReq) (*response.Transfer, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.TransferActionReq) *response.Transfer); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.TransferActionReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelTransfer provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) CancelTransfer(origCtx context.Context, payload request.TransferActionReq) (*response.Transfer, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelTransfer")
+	}
+
+	var r0 *response.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.TransferActionReq) (*response.Transfer, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.TransferActionReq) *response.Transfer); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.TransferActionReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTransfer provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) CreateTransfer(origCtx context.Context, payload request.TransferReq) (*response.Transfer, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTransfer")
+	}
+
+	var r0 *response.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.TransferReq) (*response.Transfer, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.TransferReq) *response.Transfer); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.TransferReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertTransferRule provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertTransferRule(origCtx context.Context, payload request.TransferRuleReq) (*response.TransferRule, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertTransferRule")
+	}
+
+	var r0 *response.TransferRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.TransferRuleReq) (*response.TransferRule, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.TransferRuleReq) *response.TransferRule); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.TransferRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.TransferRuleReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,121 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/transfer/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/transfer/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindIncomingTransfers provides a mock function with given fields: origCtx, userId
+func (_m *UsecaseQuery) FindIncomingTransfers(origCtx context.Context, userId string) ([]response.Transfer, error) {
+	ret := _m.Called(origCtx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIncomingTransfers")
+	}
+
+	var r0 []response.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]response.Transfer, error)); ok {
+		return rf(origCtx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.Transfer); ok {
+		r0 = rf(origCtx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindOwnershipHistory provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindOwnershipHistory(origCtx context.Context, payload request.OwnershipHistoryReq) ([]response.Ownership, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOwnershipHistory")
+	}
+
+	var r0 []response.Ownership
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.OwnershipHistoryReq) ([]response.Ownership, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.OwnershipHistoryReq) []response.Ownership); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Ownership)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.OwnershipHistoryReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTransferRule provides a mock function with given fields: origCtx, eventId
+func (_m *UsecaseQuery) FindTransferRule(origCtx context.Context, eventId string) (*response.TransferRule, error) {
+	ret := _m.Called(origCtx, eventId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTransferRule")
+	}
+
+	var r0 *response.TransferRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.TransferRule, error)); ok {
+		return rf(origCtx, eventId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.TransferRule); ok {
+		r0 = rf(origCtx, eventId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.TransferRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, eventId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -14,6 +14,26 @@ type MongodbRepositoryQuery struct {
 	mock.Mock
 }
 
+// FindOneUserByEmail provides a mock function with given fields: ctx, email
+func (_m *MongodbRepositoryQuery) FindOneUserByEmail(ctx context.Context, email string) <-chan helpers.Result {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOneUserByEmail")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // FindOneUserId provides a mock function with given fields: ctx, userId
 func (_m *MongodbRepositoryQuery) FindOneUserId(ctx context.Context, userId string) <-chan helpers.Result {
 	ret := _m.Called(ctx, userId)
@@ -0,0 +1,110 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/venue/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// UpsertCity provides a mock function with given fields: ctx, city
+func (_m *MongodbRepositoryCommand) UpsertCity(ctx context.Context, city entity.City) <-chan helpers.Result {
+	ret := _m.Called(ctx, city)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCity")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.City) <-chan helpers.Result); ok {
+		r0 = rf(ctx, city)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertContinent provides a mock function with given fields: ctx, continent
+func (_m *MongodbRepositoryCommand) UpsertContinent(ctx context.Context, continent entity.Continent) <-chan helpers.Result {
+	ret := _m.Called(ctx, continent)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertContinent")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Continent) <-chan helpers.Result); ok {
+		r0 = rf(ctx, continent)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertCountry provides a mock function with given fields: ctx, country
+func (_m *MongodbRepositoryCommand) UpsertCountry(ctx context.Context, country entity.Country) <-chan helpers.Result {
+	ret := _m.Called(ctx, country)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCountry")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Country) <-chan helpers.Result); ok {
+		r0 = rf(ctx, country)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertVenue provides a mock function with given fields: ctx, _a1
+func (_m *MongodbRepositoryCommand) UpsertVenue(ctx context.Context, _a1 entity.Venue) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertVenue")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Venue) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
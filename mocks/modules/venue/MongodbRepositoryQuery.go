@@ -0,0 +1,169 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// FindCities provides a mock function with given fields: ctx, countryCode
+func (_m *MongodbRepositoryQuery) FindCities(ctx context.Context, countryCode string) <-chan helpers.Result {
+	ret := _m.Called(ctx, countryCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCities")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, countryCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindCityById provides a mock function with given fields: ctx, cityId
+func (_m *MongodbRepositoryQuery) FindCityById(ctx context.Context, cityId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, cityId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCityById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, cityId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindContinents provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryQuery) FindContinents(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindContinents")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindCountries provides a mock function with given fields: ctx, continentCode
+func (_m *MongodbRepositoryQuery) FindCountries(ctx context.Context, continentCode string) <-chan helpers.Result {
+	ret := _m.Called(ctx, continentCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCountries")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, continentCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindCountryByCode provides a mock function with given fields: ctx, code
+func (_m *MongodbRepositoryQuery) FindCountryByCode(ctx context.Context, code string) <-chan helpers.Result {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCountryByCode")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindVenueById provides a mock function with given fields: ctx, venueId
+func (_m *MongodbRepositoryQuery) FindVenueById(ctx context.Context, venueId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, venueId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindVenueById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, venueId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindVenues provides a mock function with given fields: ctx, countryCode, cityId
+func (_m *MongodbRepositoryQuery) FindVenues(ctx context.Context, countryCode string, cityId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, countryCode, cityId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindVenues")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, countryCode, cityId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
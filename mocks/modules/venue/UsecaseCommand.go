@@ -0,0 +1,121 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/venue/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/venue/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// ImportReference provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) ImportReference(origCtx context.Context, payload request.ReferenceReq) (*response.ReferenceImport, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportReference")
+	}
+
+	var r0 *response.ReferenceImport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ReferenceReq) (*response.ReferenceImport, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ReferenceReq) *response.ReferenceImport); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.ReferenceImport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ReferenceReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LinkTickets provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) LinkTickets(origCtx context.Context, payload request.LinkTicketsReq) (*response.LinkedTickets, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkTickets")
+	}
+
+	var r0 *response.LinkedTickets
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.LinkTicketsReq) (*response.LinkedTickets, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.LinkTicketsReq) *response.LinkedTickets); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.LinkedTickets)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.LinkTicketsReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertVenue provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertVenue(origCtx context.Context, payload request.VenueReq) (*response.Venue, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertVenue")
+	}
+
+	var r0 *response.Venue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.VenueReq) (*response.Venue, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.VenueReq) *response.Venue); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Venue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.VenueReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
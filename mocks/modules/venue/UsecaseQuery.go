@@ -0,0 +1,181 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/venue/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/venue/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindCities provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindCities(origCtx context.Context, payload request.CityListReq) ([]response.City, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCities")
+	}
+
+	var r0 []response.City
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.CityListReq) ([]response.City, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.CityListReq) []response.City); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.City)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.CityListReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindContinents provides a mock function with given fields: origCtx
+func (_m *UsecaseQuery) FindContinents(origCtx context.Context) ([]response.Continent, error) {
+	ret := _m.Called(origCtx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindContinents")
+	}
+
+	var r0 []response.Continent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]response.Continent, error)); ok {
+		return rf(origCtx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []response.Continent); ok {
+		r0 = rf(origCtx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Continent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(origCtx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindCountries provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindCountries(origCtx context.Context, payload request.CountryListReq) ([]response.Country, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCountries")
+	}
+
+	var r0 []response.Country
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.CountryListReq) ([]response.Country, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.CountryListReq) []response.Country); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Country)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.CountryListReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindVenue provides a mock function with given fields: origCtx, venueId
+func (_m *UsecaseQuery) FindVenue(origCtx context.Context, venueId string) (*response.Venue, error) {
+	ret := _m.Called(origCtx, venueId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindVenue")
+	}
+
+	var r0 *response.Venue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.Venue, error)); ok {
+		return rf(origCtx, venueId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Venue); ok {
+		r0 = rf(origCtx, venueId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Venue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, venueId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindVenues provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) FindVenues(origCtx context.Context, payload request.VenueListReq) ([]response.Venue, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindVenues")
+	}
+
+	var r0 []response.Venue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.VenueListReq) ([]response.Venue, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.VenueListReq) []response.Venue); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Venue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.VenueListReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,210 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/voucher/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// CreateUniqueIndexes provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryCommand) CreateUniqueIndexes(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUniqueIndexes")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// DecreaseVoucherRedeemed provides a mock function with given fields: ctx, code
+func (_m *MongodbRepositoryCommand) DecreaseVoucherRedeemed(ctx context.Context, code string) <-chan helpers.Result {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecreaseVoucherRedeemed")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// DecreaseVoucherUserCounter provides a mock function with given fields: ctx, code, userId
+func (_m *MongodbRepositoryCommand) DecreaseVoucherUserCounter(ctx context.Context, code string, userId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, code, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecreaseVoucherUserCounter")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, code, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// IncreaseVoucherRedeemed provides a mock function with given fields: ctx, code
+func (_m *MongodbRepositoryCommand) IncreaseVoucherRedeemed(ctx context.Context, code string) <-chan helpers.Result {
+	ret := _m.Called(ctx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseVoucherRedeemed")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// IncreaseVoucherUserCounter provides a mock function with given fields: ctx, code, userId, maxPerUser
+func (_m *MongodbRepositoryCommand) IncreaseVoucherUserCounter(ctx context.Context, code string, userId string, maxPerUser int) <-chan helpers.Result {
+	ret := _m.Called(ctx, code, userId, maxPerUser)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseVoucherUserCounter")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, code, userId, maxPerUser)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InitVoucherUserCounter provides a mock function with given fields: ctx, code, userId
+func (_m *MongodbRepositoryCommand) InitVoucherUserCounter(ctx context.Context, code string, userId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, code, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitVoucherUserCounter")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, code, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOneRedemption provides a mock function with given fields: ctx, redemption
+func (_m *MongodbRepositoryCommand) InsertOneRedemption(ctx context.Context, redemption entity.Redemption) <-chan helpers.Result {
+	ret := _m.Called(ctx, redemption)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneRedemption")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Redemption) <-chan helpers.Result); ok {
+		r0 = rf(ctx, redemption)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateRedemptionReleased provides a mock function with given fields: ctx, redemptionId
+func (_m *MongodbRepositoryCommand) UpdateRedemptionReleased(ctx context.Context, redemptionId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, redemptionId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRedemptionReleased")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, redemptionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpsertVoucher provides a mock function with given fields: ctx, _a1
+func (_m *MongodbRepositoryCommand) UpsertVoucher(ctx context.Context, _a1 entity.Voucher) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertVoucher")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Voucher) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,111 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/voucher/models/dto"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/voucher/models/request"
+
+	response "ticket-service/internal/modules/voucher/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// RedeemVouchers provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) RedeemVouchers(origCtx context.Context, payload request.RedeemReq) (*dto.Quote, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeemVouchers")
+	}
+
+	var r0 *dto.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.RedeemReq) (*dto.Quote, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.RedeemReq) *dto.Quote); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.RedeemReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseVouchers provides a mock function with given fields: origCtx, orderId
+func (_m *UsecaseCommand) ReleaseVouchers(origCtx context.Context, orderId string) error {
+	ret := _m.Called(origCtx, orderId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseVouchers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(origCtx, orderId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertVoucher provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) UpsertVoucher(origCtx context.Context, payload request.VoucherReq) (*response.Voucher, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertVoucher")
+	}
+
+	var r0 *response.Voucher
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.VoucherReq) (*response.Voucher, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.VoucherReq) *response.Voucher); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Voucher)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.VoucherReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
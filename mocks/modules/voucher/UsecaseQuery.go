@@ -0,0 +1,123 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "ticket-service/internal/modules/voucher/models/dto"
+
+	mock "github.com/stretchr/testify/mock"
+
+	request "ticket-service/internal/modules/voucher/models/request"
+
+	response "ticket-service/internal/modules/voucher/models/response"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// FindVoucher provides a mock function with given fields: origCtx, code
+func (_m *UsecaseQuery) FindVoucher(origCtx context.Context, code string) (*response.Voucher, error) {
+	ret := _m.Called(origCtx, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindVoucher")
+	}
+
+	var r0 *response.Voucher
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.Voucher, error)); ok {
+		return rf(origCtx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.Voucher); ok {
+		r0 = rf(origCtx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Voucher)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PreviewVouchers provides a mock function with given fields: origCtx, codes, target
+func (_m *UsecaseQuery) PreviewVouchers(origCtx context.Context, codes []string, target dto.Target) (*dto.Quote, error) {
+	ret := _m.Called(origCtx, codes, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PreviewVouchers")
+	}
+
+	var r0 *dto.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, dto.Target) (*dto.Quote, error)); ok {
+		return rf(origCtx, codes, target)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, dto.Target) *dto.Quote); ok {
+		r0 = rf(origCtx, codes, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, dto.Target) error); ok {
+		r1 = rf(origCtx, codes, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateVouchers provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseQuery) ValidateVouchers(origCtx context.Context, payload request.ValidateReq) (*response.Quote, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateVouchers")
+	}
+
+	var r0 *response.Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.ValidateReq) (*response.Quote, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.ValidateReq) *response.Quote); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Quote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.ValidateReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
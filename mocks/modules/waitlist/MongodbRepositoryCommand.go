@@ -0,0 +1,152 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	entity "ticket-service/internal/modules/waitlist/models/entity"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MongodbRepositoryCommand is an autogenerated mock type for the MongodbRepositoryCommand type
+type MongodbRepositoryCommand struct {
+	mock.Mock
+}
+
+// DecreaseWaiting provides a mock function with given fields: ctx, ticketId
+func (_m *MongodbRepositoryCommand) DecreaseWaiting(ctx context.Context, ticketId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DecreaseWaiting")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// IncreaseWaiting provides a mock function with given fields: ctx, ticketId
+func (_m *MongodbRepositoryCommand) IncreaseWaiting(ctx context.Context, ticketId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncreaseWaiting")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InitWaitlist provides a mock function with given fields: ctx, _a1
+func (_m *MongodbRepositoryCommand) InitWaitlist(ctx context.Context, _a1 entity.Waitlist) <-chan helpers.Result {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InitWaitlist")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.Waitlist) <-chan helpers.Result); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// InsertOneEntry provides a mock function with given fields: ctx, entry
+func (_m *MongodbRepositoryCommand) InsertOneEntry(ctx context.Context, entry entity.WaitlistEntry) <-chan helpers.Result {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertOneEntry")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, entity.WaitlistEntry) <-chan helpers.Result); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateEntryOffered provides a mock function with given fields: ctx, entryId, orderId, holdUntil
+func (_m *MongodbRepositoryCommand) UpdateEntryOffered(ctx context.Context, entryId string, orderId string, holdUntil time.Time) <-chan helpers.Result {
+	ret := _m.Called(ctx, entryId, orderId, holdUntil)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEntryOffered")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) <-chan helpers.Result); ok {
+		r0 = rf(ctx, entryId, orderId, holdUntil)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// UpdateEntryStatus provides a mock function with given fields: ctx, entryId, from, to
+func (_m *MongodbRepositoryCommand) UpdateEntryStatus(ctx context.Context, entryId string, from string, to string) <-chan helpers.Result {
+	ret := _m.Called(ctx, entryId, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEntryStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, entryId, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryCommand creates a new instance of MongodbRepositoryCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryCommand {
+	mock := &MongodbRepositoryCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
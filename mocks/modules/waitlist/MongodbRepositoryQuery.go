@@ -0,0 +1,209 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MongodbRepositoryQuery is an autogenerated mock type for the MongodbRepositoryQuery type
+type MongodbRepositoryQuery struct {
+	mock.Mock
+}
+
+// CountServableEntries provides a mock function with given fields: ctx, ticketId, quantity
+func (_m *MongodbRepositoryQuery) CountServableEntries(ctx context.Context, ticketId string, quantity int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountServableEntries")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// CountWaitingAhead provides a mock function with given fields: ctx, ticketId, position
+func (_m *MongodbRepositoryQuery) CountWaitingAhead(ctx context.Context, ticketId string, position int) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, position)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountWaitingAhead")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, position)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindActiveEntriesByUserId provides a mock function with given fields: ctx, userId
+func (_m *MongodbRepositoryQuery) FindActiveEntriesByUserId(ctx context.Context, userId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveEntriesByUserId")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindActiveEntry provides a mock function with given fields: ctx, ticketId, userId
+func (_m *MongodbRepositoryQuery) FindActiveEntry(ctx context.Context, ticketId string, userId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindActiveEntry")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEntriesByStatus provides a mock function with given fields: ctx, ticketId, status
+func (_m *MongodbRepositoryQuery) FindEntriesByStatus(ctx context.Context, ticketId string, status string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEntriesByStatus")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindEntryById provides a mock function with given fields: ctx, entryId
+func (_m *MongodbRepositoryQuery) FindEntryById(ctx context.Context, entryId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, entryId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEntryById")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, entryId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindOfferedEntries provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryQuery) FindOfferedEntries(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOfferedEntries")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindWaitingWaitlists provides a mock function with given fields: ctx
+func (_m *MongodbRepositoryQuery) FindWaitingWaitlists(ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWaitingWaitlists")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context) <-chan helpers.Result); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// FindWaitlist provides a mock function with given fields: ctx, ticketId
+func (_m *MongodbRepositoryQuery) FindWaitlist(ctx context.Context, ticketId string) <-chan helpers.Result {
+	ret := _m.Called(ctx, ticketId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindWaitlist")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan helpers.Result); ok {
+		r0 = rf(ctx, ticketId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
+// NewMongodbRepositoryQuery creates a new instance of MongodbRepositoryQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMongodbRepositoryQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MongodbRepositoryQuery {
+	mock := &MongodbRepositoryQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,121 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	request "ticket-service/internal/modules/waitlist/models/request"
+
+	mock "github.com/stretchr/testify/mock"
+
+	response "ticket-service/internal/modules/waitlist/models/response"
+)
+
+// UsecaseCommand is an autogenerated mock type for the UsecaseCommand type
+type UsecaseCommand struct {
+	mock.Mock
+}
+
+// JoinWaitlist provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) JoinWaitlist(origCtx context.Context, payload request.JoinReq) (*response.Entry, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JoinWaitlist")
+	}
+
+	var r0 *response.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.JoinReq) (*response.Entry, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.JoinReq) *response.Entry); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.JoinReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LeaveWaitlist provides a mock function with given fields: origCtx, payload
+func (_m *UsecaseCommand) LeaveWaitlist(origCtx context.Context, payload request.LeaveReq) (*response.Entry, error) {
+	ret := _m.Called(origCtx, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LeaveWaitlist")
+	}
+
+	var r0 *response.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.LeaveReq) (*response.Entry, error)); ok {
+		return rf(origCtx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.LeaveReq) *response.Entry); ok {
+		r0 = rf(origCtx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.LeaveReq) error); ok {
+		r1 = rf(origCtx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OfferReleasedTickets provides a mock function with given fields: origCtx
+func (_m *UsecaseCommand) OfferReleasedTickets(origCtx context.Context) (*response.Offers, error) {
+	ret := _m.Called(origCtx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OfferReleasedTickets")
+	}
+
+	var r0 *response.Offers
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*response.Offers, error)); ok {
+		return rf(origCtx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *response.Offers); ok {
+		r0 = rf(origCtx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.Offers)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(origCtx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseCommand creates a new instance of UsecaseCommand. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseCommand(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseCommand {
+	mock := &UsecaseCommand{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	response "ticket-service/internal/modules/waitlist/models/response"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UsecaseQuery is an autogenerated mock type for the UsecaseQuery type
+type UsecaseQuery struct {
+	mock.Mock
+}
+
+// CheckPublicSale provides a mock function with given fields: origCtx, ticketId, remaining
+func (_m *UsecaseQuery) CheckPublicSale(origCtx context.Context, ticketId string, remaining int) error {
+	ret := _m.Called(origCtx, ticketId, remaining)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckPublicSale")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = rf(origCtx, ticketId, remaining)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindMyEntries provides a mock function with given fields: origCtx, userId
+func (_m *UsecaseQuery) FindMyEntries(origCtx context.Context, userId string) ([]response.Entry, error) {
+	ret := _m.Called(origCtx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindMyEntries")
+	}
+
+	var r0 []response.Entry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]response.Entry, error)); ok {
+		return rf(origCtx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []response.Entry); ok {
+		r0 = rf(origCtx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]response.Entry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(origCtx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewUsecaseQuery creates a new instance of UsecaseQuery. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUsecaseQuery(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UsecaseQuery {
+	mock := &UsecaseQuery{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -76,6 +76,26 @@ func (_m *Collections) CountData(payload mongodb.CountData, ctx context.Context)
 	return r0
 }
 
+// CreateIndex provides a mock function with given fields: payload, ctx
+func (_m *Collections) CreateIndex(payload mongodb.CreateIndex, ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(payload, ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIndex")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(mongodb.CreateIndex, context.Context) <-chan helpers.Result); ok {
+		r0 = rf(payload, ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // FindAllData provides a mock function with given fields: payload, ctx
 func (_m *Collections) FindAllData(payload mongodb.FindAllData, ctx context.Context) <-chan helpers.Result {
 	ret := _m.Called(payload, ctx)
@@ -176,6 +196,26 @@ func (_m *Collections) InsertOne(payload mongodb.InsertOne, ctx context.Context)
 	return r0
 }
 
+// UpdateMany provides a mock function with given fields: payload, ctx
+func (_m *Collections) UpdateMany(payload mongodb.UpdateOne, ctx context.Context) <-chan helpers.Result {
+	ret := _m.Called(payload, ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMany")
+	}
+
+	var r0 <-chan helpers.Result
+	if rf, ok := ret.Get(0).(func(mongodb.UpdateOne, context.Context) <-chan helpers.Result); ok {
+		r0 = rf(payload, ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan helpers.Result)
+		}
+	}
+
+	return r0
+}
+
 // UpdateOne provides a mock function with given fields: payload, ctx
 func (_m *Collections) UpdateOne(payload mongodb.UpdateOne, ctx context.Context) <-chan helpers.Result {
 	ret := _m.Called(payload, ctx)
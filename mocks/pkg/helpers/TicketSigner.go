@@ -0,0 +1,86 @@
+// Code generated by mockery v2.40.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	helpers "ticket-service/internal/pkg/helpers"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TicketSigner is an autogenerated mock type for the TicketSigner type
+type TicketSigner struct {
+	mock.Mock
+}
+
+// SignTicket provides a mock function with given fields: payload
+func (_m *TicketSigner) SignTicket(payload helpers.TicketPayload) (string, error) {
+	ret := _m.Called(payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignTicket")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(helpers.TicketPayload) (string, error)); ok {
+		return rf(payload)
+	}
+	if rf, ok := ret.Get(0).(func(helpers.TicketPayload) string); ok {
+		r0 = rf(payload)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(helpers.TicketPayload) error); ok {
+		r1 = rf(payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyTicket provides a mock function with given fields: token
+func (_m *TicketSigner) VerifyTicket(token string) (*helpers.TicketPayload, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyTicket")
+	}
+
+	var r0 *helpers.TicketPayload
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*helpers.TicketPayload, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *helpers.TicketPayload); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*helpers.TicketPayload)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTicketSigner creates a new instance of TicketSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTicketSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TicketSigner {
+	mock := &TicketSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.39.1. DO NOT EDIT.
+// Code generated by mockery v2.40.1. DO NOT EDIT.
 
 package mocks
 
@@ -125,6 +125,46 @@ func (_m *Collections) Get(ctx context.Context, key string) *v8.StringCmd {
 	return r0
 }
 
+// Publish provides a mock function with given fields: ctx, channel, message
+func (_m *Collections) Publish(ctx context.Context, channel string, message interface{}) *v8.IntCmd {
+	ret := _m.Called(ctx, channel, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 *v8.IntCmd
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) *v8.IntCmd); ok {
+		r0 = rf(ctx, channel, message)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v8.IntCmd)
+		}
+	}
+
+	return r0
+}
+
+// ScriptLoad provides a mock function with given fields: ctx, script
+func (_m *Collections) ScriptLoad(ctx context.Context, script string) *v8.StringCmd {
+	ret := _m.Called(ctx, script)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScriptLoad")
+	}
+
+	var r0 *v8.StringCmd
+	if rf, ok := ret.Get(0).(func(context.Context, string) *v8.StringCmd); ok {
+		r0 = rf(ctx, script)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v8.StringCmd)
+		}
+	}
+
+	return r0
+}
+
 // Set provides a mock function with given fields: ctx, key, value, expiration
 func (_m *Collections) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *v8.StatusCmd {
 	ret := _m.Called(ctx, key, value, expiration)
@@ -165,6 +205,33 @@ func (_m *Collections) SetNX(ctx context.Context, key string, value interface{},
 	return r0
 }
 
+// Subscribe provides a mock function with given fields: ctx, channels
+func (_m *Collections) Subscribe(ctx context.Context, channels ...string) *v8.PubSub {
+	_va := make([]interface{}, len(channels))
+	for _i := range channels {
+		_va[_i] = channels[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 *v8.PubSub
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) *v8.PubSub); ok {
+		r0 = rf(ctx, channels...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v8.PubSub)
+		}
+	}
+
+	return r0
+}
+
 // NewCollections creates a new instance of Collections. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCollections(t interface {